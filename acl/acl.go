@@ -0,0 +1,526 @@
+// Package acl replays access-control statements (CREATE USER/ROLE, GRANT, REVOKE, DROP USER/ROLE)
+// and answers whether a user holds the privileges a parsed statement needs.
+//
+// The model follows ClickHouse: users and roles share one namespace, a grant on `db.*` or `*.*`
+// covers every table below it, a REVOKE narrower than an existing grant is kept as a partial
+// revoke, and a grantee inherits everything granted to its roles. Every granted role counts as
+// active, SET ROLE and DEFAULT ROLE are not taken into account.
+package acl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	parser "github.com/carmel/go-sql-parser"
+)
+
+// Any matches every database or table in an Object.
+const Any = "*"
+
+// DefaultDatabase is the database unqualified table names resolve to until a USE statement is replayed.
+const DefaultDatabase = "default"
+
+// Object is the target of a privilege, Database and Table may be Any.
+type Object struct {
+	Database string
+	Table    string
+}
+
+func (o Object) String() string {
+	return o.Database + "." + o.Table
+}
+
+func (o Object) covers(other Object) bool {
+	return (o.Database == Any || o.Database == other.Database) &&
+		(o.Table == Any || o.Table == other.Table)
+}
+
+// Requirement is a privilege a statement needs on an object.
+type Requirement struct {
+	Privilege string
+	Object    Object
+	// Columns restricts the requirement to some columns, nil means the whole table.
+	Columns []string
+	// GrantOption requires the privilege to be held WITH GRANT OPTION, e.g. to run a GRANT.
+	GrantOption bool
+	// Role is set for granting or revoking a role, which needs the role WITH ADMIN OPTION
+	// or the privilege, ROLE ADMIN, on every object.
+	Role string
+}
+
+func (r Requirement) String() string {
+	if r.Role != "" {
+		return r.Privilege + " OR ADMIN OPTION FOR " + r.Role
+	}
+	var builder strings.Builder
+	builder.WriteString(r.Privilege)
+	if len(r.Columns) > 0 {
+		builder.WriteString("(" + strings.Join(r.Columns, ", ") + ")")
+	}
+	builder.WriteString(" ON ")
+	builder.WriteString(r.Object.String())
+	if r.GrantOption {
+		builder.WriteString(" WITH GRANT OPTION")
+	}
+	return builder.String()
+}
+
+// DeniedError is returned by Check when a user lacks some of the privileges a statement needs.
+type DeniedError struct {
+	User    string
+	Missing []Requirement
+}
+
+func (e *DeniedError) Error() string {
+	missing := make([]string, 0, len(e.Missing))
+	for _, requirement := range e.Missing {
+		missing = append(missing, requirement.String())
+	}
+	return fmt.Sprintf("acl: %s is missing %s", e.User, strings.Join(missing, ", "))
+}
+
+// grant is one privilege given to, or revoked from, a grantee.
+type grant struct {
+	privilege string
+	object    Object
+	columns   []string
+	// grantOption is WITH GRANT OPTION for a grant and GRANT OPTION FOR for a revoke.
+	grantOption bool
+	revoke      bool
+}
+
+func (g *grant) covers(requirement Requirement) bool {
+	if !coversPrivilege(g.privilege, requirement.Privilege) || !g.object.covers(requirement.Object) {
+		return false
+	}
+	if g.columns == nil {
+		return true
+	}
+	return len(requirement.Columns) > 0 && containsAll(g.columns, requirement.Columns)
+}
+
+// overlaps reports whether g applies to any part of requirement.
+func (g *grant) overlaps(requirement Requirement) bool {
+	if !coversPrivilege(g.privilege, requirement.Privilege) || !g.object.covers(requirement.Object) {
+		return false
+	}
+	if g.columns == nil || len(requirement.Columns) == 0 {
+		return true
+	}
+	for _, column := range requirement.Columns {
+		if contains(g.columns, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// within reports whether g applies to nothing beyond other.
+func (g *grant) within(other *grant) bool {
+	return coversPrivilege(other.privilege, g.privilege) && other.object.covers(g.object) &&
+		(other.columns == nil || (g.columns != nil && containsAll(other.columns, g.columns)))
+}
+
+type grantee struct {
+	name string
+	// grants holds grants and revokes in the order they were applied, later ones take precedence.
+	grants []*grant
+	roles  map[string]bool // granted role -> WITH ADMIN OPTION
+}
+
+func (g *grantee) allows(requirement Requirement) bool {
+	for i := len(g.grants) - 1; i >= 0; i-- {
+		grant := g.grants[i]
+		if grant.revoke {
+			// a revoke of the grant option keeps the privilege itself
+			if grant.overlaps(requirement) && (!grant.grantOption || requirement.GrantOption) {
+				return false
+			}
+			continue
+		}
+		if grant.covers(requirement) && (!requirement.GrantOption || grant.grantOption) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply adds a grant or revoke, dropping the earlier ones it fully replaces.
+func (g *grantee) apply(privilege *grant) {
+	grants := g.grants[:0]
+	for _, grant := range g.grants {
+		replaced := grant.within(privilege)
+		switch {
+		case privilege.revoke && privilege.grantOption:
+			replaced = replaced && grant.revoke && grant.grantOption
+		case !privilege.revoke && !privilege.grantOption:
+			replaced = replaced && !grant.grantOption
+		}
+		if !replaced {
+			grants = append(grants, grant)
+		}
+	}
+	g.grants = append(grants, privilege)
+}
+
+// Policy is the access-control state built by replaying statements.
+type Policy struct {
+	grantees map[string]*grantee
+	database string
+}
+
+// New returns an empty Policy.
+func New() *Policy {
+	return &Policy{
+		grantees: make(map[string]*grantee),
+		database: DefaultDatabase,
+	}
+}
+
+// Replay parses an access-control script and applies its statements to a new Policy.
+func Replay(script string) (*Policy, error) {
+	policy := New()
+	if err := policy.ApplyScript(script); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ApplyScript parses script and applies its statements in order.
+func (p *Policy) ApplyScript(script string) error {
+	stmts, err := parser.NewParser(script).Parse()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		if err := p.Apply(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply updates the policy with one statement. Statements that do not change
+// access control are ignored, USE changes the database unqualified names resolve to.
+func (p *Policy) Apply(stmt parser.Expr) error {
+	switch stmt := stmt.(type) {
+	case *parser.UseStmt:
+		p.database = stmt.Database.Name
+	case *parser.CreateUser:
+		for _, name := range stmt.UserNames {
+			p.create(roleName(name), stmt.OrReplace)
+		}
+	case *parser.CreateRole:
+		for _, name := range stmt.RoleNames {
+			p.create(roleName(name), stmt.OrReplace)
+		}
+	case *parser.AlterUser:
+		p.rename(stmt.RoleRenamePairs)
+	case *parser.AlterRole:
+		p.rename(stmt.RoleRenamePairs)
+	case *parser.DropUserOrRole:
+		switch strings.ToUpper(stmt.Target) {
+		case parser.KeywordUser, parser.KeywordRole:
+			for _, name := range stmt.Names {
+				p.drop(roleName(name))
+			}
+		}
+	case *parser.GrantPrivilegeStmt:
+		return p.applyGrant(stmt)
+	case *parser.RevokeStmt:
+		return p.applyRevoke(stmt)
+	}
+	return nil
+}
+
+func (p *Policy) create(name string, orReplace bool) {
+	if _, ok := p.grantees[name]; ok && !orReplace {
+		return
+	}
+	p.grantees[name] = &grantee{name: name, roles: make(map[string]bool)}
+}
+
+func (p *Policy) drop(name string) {
+	delete(p.grantees, name)
+	for _, grantee := range p.grantees {
+		delete(grantee.roles, name)
+	}
+}
+
+func (p *Policy) rename(pairs []*parser.RoleRenamePair) {
+	for _, pair := range pairs {
+		if pair.NewName == nil {
+			continue
+		}
+		name, newName := roleName(pair.RoleName), roleName(&parser.RoleName{Name: pair.NewName})
+		grantee, ok := p.grantees[name]
+		if !ok {
+			continue
+		}
+		delete(p.grantees, name)
+		grantee.name = newName
+		p.grantees[newName] = grantee
+		for _, other := range p.grantees {
+			if admin, ok := other.roles[name]; ok {
+				delete(other.roles, name)
+				other.roles[newName] = admin
+			}
+		}
+	}
+}
+
+// grantee returns the named user or role, creating it for names defined outside the script,
+// whether it is granted privileges, granted to or granted roles.
+func (p *Policy) grantee(name string) *grantee {
+	if g, ok := p.grantees[name]; ok {
+		return g
+	}
+	p.create(name, false)
+	return p.grantees[name]
+}
+
+func (p *Policy) applyGrant(stmt *parser.GrantPrivilegeStmt) error {
+	withGrant, withAdmin := false, false
+	for _, option := range stmt.WithOptions {
+		switch strings.ToUpper(option) {
+		case parser.KeywordGrant:
+			withGrant = true
+		case parser.KeywordAdmin:
+			withAdmin = true
+		}
+	}
+	for _, to := range stmt.To {
+		name := roleName(to)
+		// CURRENT_USER has no meaning while replaying a script
+		if strings.EqualFold(name, "CURRENT_USER") {
+			continue
+		}
+		grantee := p.grantee(name)
+		for _, role := range stmt.Roles {
+			granted := p.grantee(roleName(role)).name
+			grantee.roles[granted] = grantee.roles[granted] || withAdmin
+		}
+		for _, privilege := range stmt.Privileges {
+			grantee.apply(&grant{
+				privilege:   privilegeName(privilege),
+				object:      p.object(stmt.On),
+				columns:     privilegeColumns(privilege),
+				grantOption: withGrant,
+			})
+		}
+	}
+	return nil
+}
+
+func (p *Policy) applyRevoke(stmt *parser.RevokeStmt) error {
+	for _, grantee := range p.revokeTargets(stmt.From) {
+		for _, role := range stmt.Roles {
+			name := roleName(role)
+			if stmt.AdminOptionFor {
+				if _, ok := grantee.roles[name]; ok {
+					grantee.roles[name] = false
+				}
+				continue
+			}
+			delete(grantee.roles, name)
+		}
+		for _, privilege := range stmt.Privileges {
+			grantee.apply(&grant{
+				privilege:   privilegeName(privilege),
+				object:      p.object(stmt.On),
+				columns:     privilegeColumns(privilege),
+				grantOption: stmt.GrantOptionFor,
+				revoke:      true,
+			})
+		}
+	}
+	return nil
+}
+
+func (p *Policy) revokeTargets(from *parser.RoleList) []*grantee {
+	var targets []*grantee
+	if from.Keyword == parser.KeywordAll {
+		except := make(map[string]bool)
+		for _, name := range from.Except {
+			except[roleName(name)] = true
+		}
+		for _, name := range p.names() {
+			if !except[name] {
+				targets = append(targets, p.grantees[name])
+			}
+		}
+		return targets
+	}
+	for _, name := range from.Roles {
+		// CURRENT_USER has no meaning while replaying a script
+		if n := roleName(name); !strings.EqualFold(n, "CURRENT_USER") {
+			targets = append(targets, p.grantee(n))
+		}
+	}
+	return targets
+}
+
+func (p *Policy) names() []string {
+	names := make([]string, 0, len(p.grantees))
+	for name := range p.grantees {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// object resolves the target of a GRANT or REVOKE, `*` alone is every table of the current database.
+func (p *Policy) object(on *parser.TableIdentifier) Object {
+	if on.Schema == nil {
+		return Object{Database: p.database, Table: on.Table.Name}
+	}
+	return Object{Database: on.Schema.Name, Table: on.Table.Name}
+}
+
+// Database returns the database unqualified names currently resolve to.
+func (p *Policy) Database() string {
+	return p.database
+}
+
+// Exists reports whether a user or role is known to the policy.
+func (p *Policy) Exists(name string) bool {
+	_, ok := p.grantees[name]
+	return ok
+}
+
+// Roles returns every role granted to name, directly or through other roles, in sorted order.
+func (p *Policy) Roles(name string) []string {
+	seen := make(map[string]bool)
+	p.collectRoles(name, seen)
+	delete(seen, name)
+	roles := make([]string, 0, len(seen))
+	for role := range seen {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+func (p *Policy) collectRoles(name string, seen map[string]bool) {
+	grantee, ok := p.grantees[name]
+	if !ok || seen[name] {
+		return
+	}
+	seen[name] = true
+	for role := range grantee.roles {
+		p.collectRoles(role, seen)
+	}
+}
+
+// Allowed reports whether user holds requirement, directly or through its roles.
+// Each column of requirement may be held by a different grant.
+func (p *Policy) Allowed(user string, requirement Requirement) bool {
+	if requirement.Role != "" && p.admins(user, requirement.Role, make(map[string]bool)) {
+		return true
+	}
+	if len(requirement.Columns) > 1 {
+		for _, column := range requirement.Columns {
+			single := requirement
+			single.Columns = []string{column}
+			if !p.allows(user, single, make(map[string]bool)) {
+				return false
+			}
+		}
+		return true
+	}
+	return p.allows(user, requirement, make(map[string]bool))
+}
+
+// admins reports whether name holds role WITH ADMIN OPTION, directly or through its roles.
+func (p *Policy) admins(name string, role string, seen map[string]bool) bool {
+	grantee, ok := p.grantees[name]
+	if !ok || seen[name] {
+		return false
+	}
+	seen[name] = true
+	if grantee.roles[role] {
+		return true
+	}
+	for granted := range grantee.roles {
+		if p.admins(granted, role, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) allows(name string, requirement Requirement, seen map[string]bool) bool {
+	grantee, ok := p.grantees[name]
+	if !ok || seen[name] {
+		return false
+	}
+	seen[name] = true
+	if grantee.allows(requirement) {
+		return true
+	}
+	for role := range grantee.roles {
+		if p.allows(role, requirement, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns a *DeniedError if user lacks any privilege stmt needs.
+// Unqualified table names in stmt resolve against the policy's current database.
+func (p *Policy) Check(user string, stmt parser.Expr) error {
+	requirements, err := Required(stmt, p.database)
+	if err != nil {
+		return err
+	}
+	var missing []Requirement
+	for _, requirement := range requirements {
+		if !p.Allowed(user, requirement) {
+			missing = append(missing, requirement)
+		}
+	}
+	if len(missing) > 0 {
+		return &DeniedError{User: user, Missing: missing}
+	}
+	return nil
+}
+
+// Can reports whether user may run stmt.
+func (p *Policy) Can(user string, stmt parser.Expr) bool {
+	return p.Check(user, stmt) == nil
+}
+
+func roleName(name *parser.RoleName) string {
+	var result string
+	switch n := name.Name.(type) {
+	case *parser.Ident:
+		result = n.Name
+	case *parser.StringLiteral:
+		result = n.Literal
+	default:
+		result = n.String()
+	}
+	if name.Scope != nil {
+		result += "@" + name.Scope.Literal
+	}
+	return result
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAll(items []string, subset []string) bool {
+	for _, item := range subset {
+		if !contains(items, item) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,262 @@
+package acl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	parser "github.com/carmel/go-sql-parser"
+)
+
+const script = `
+CREATE ROLE reader;
+CREATE ROLE writer;
+CREATE ROLE admin;
+CREATE USER alice;
+CREATE USER bob;
+CREATE USER carol;
+GRANT SELECT ON analytics.* TO reader;
+GRANT INSERT(id, name) ON analytics.events TO writer;
+GRANT ALTER COLUMN ON analytics.events TO writer;
+GRANT reader TO writer;
+GRANT writer TO alice;
+GRANT reader TO bob;
+REVOKE SELECT ON analytics.salaries FROM reader;
+GRANT SELECT ON analytics.* TO admin WITH GRANT OPTION;
+GRANT admin TO carol;
+USE analytics;
+GRANT SELECT ON events TO dave;
+CREATE USER 'erin'@'localhost';
+GRANT SELECT(id, name) ON users TO 'erin'@'localhost';
+GRANT auditor TO dave;
+`
+
+func mustParse(t *testing.T, sql string) parser.Expr {
+	t.Helper()
+	stmts, err := parser.NewParser(sql).Parse()
+	if err != nil {
+		t.Fatalf("parse %q: %v", sql, err)
+	}
+	if len(stmts) != 1 {
+		t.Fatalf("parse %q: expected 1 statement, got %d", sql, len(stmts))
+	}
+	return stmts[0]
+}
+
+func TestCan(t *testing.T) {
+	policy, err := Replay(script)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if policy.Database() != "analytics" {
+		t.Fatalf("expected current database analytics, got %s", policy.Database())
+	}
+
+	tests := []struct {
+		user    string
+		sql     string
+		allowed bool
+	}{
+		{"alice", "SELECT * FROM analytics.events", true},
+		{"alice", "SELECT * FROM analytics.salaries", false},
+		{"alice", "SELECT * FROM events e JOIN (SELECT * FROM analytics.users) u ON e.uid = u.id", true},
+		{"alice", "SELECT * FROM analytics.events WHERE id IN (SELECT id FROM other.ids)", false},
+		{"alice", "WITH recent AS (SELECT * FROM analytics.events) SELECT * FROM recent", true},
+		{"alice", "WITH salaries AS (SELECT * FROM salaries) SELECT * FROM salaries", false},
+		{"alice", "SELECT * FROM (WITH salaries AS (SELECT 1) SELECT * FROM salaries) JOIN salaries ON 1", false},
+		{"alice", "SELECT * FROM (WITH salaries AS (SELECT 1) SELECT * FROM salaries)", true},
+		{"alice", "INSERT INTO analytics.events (id, name) VALUES (1, 'a')", true},
+		{"alice", "INSERT INTO analytics.events (id, payload) VALUES (1, 'a')", false},
+		{"alice", "INSERT INTO analytics.events VALUES (1, 'a')", false},
+		{"alice", "INSERT INTO analytics.events (id) SELECT id FROM analytics.users", true},
+		{"alice", "ALTER TABLE analytics.events ADD COLUMN x UInt8", true},
+		{"alice", "ALTER TABLE analytics.events DELETE WHERE id = 1", false},
+		{"bob", "SELECT * FROM analytics.events", true},
+		{"bob", "INSERT INTO analytics.events (id) VALUES (1)", false},
+		{"bob", "EXPLAIN AST SELECT * FROM analytics.events", true},
+		{"bob", "DROP TABLE analytics.events", false},
+		{"dave", "SELECT * FROM analytics.events", true},
+		{"dave", "SELECT * FROM default.events", false},
+		{"erin@localhost", "SELECT id, name FROM users WHERE id = 1", true},
+		{"erin@localhost", "SELECT u.name FROM users u JOIN (SELECT 1 AS id) x USING (id)", true},
+		{"erin@localhost", "SELECT email FROM users", false},
+		{"erin@localhost", "SELECT * FROM users", false},
+		{"erin@localhost", "SELECT count() FROM users", false},
+		{"nobody", "SELECT 1", true},
+		{"nobody", "SELECT * FROM analytics.events", false},
+		{"bob", "GRANT SELECT ON analytics.events TO eve", false},
+		{"carol", "GRANT SELECT ON analytics.events TO eve", true},
+		{"carol", "GRANT SELECT ON analytics.* TO eve", true},
+		{"carol", "GRANT SELECT ON *.* TO eve", false},
+		{"carol", "GRANT INSERT ON analytics.events TO eve", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.user+": "+tt.sql, func(t *testing.T) {
+			stmt := mustParse(t, tt.sql)
+			if allowed := policy.Can(tt.user, stmt); allowed != tt.allowed {
+				t.Fatalf("expected %v, got %v (%v)", tt.allowed, allowed, policy.Check(tt.user, stmt))
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	policy, err := Replay(script)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	err = policy.Check("bob", mustParse(t, "SELECT * FROM analytics.events, analytics.salaries, other.t"))
+	var denied *DeniedError
+	if !errors.As(err, &denied) {
+		t.Fatalf("expected a DeniedError, got %v", err)
+	}
+	expected := "acl: bob is missing SELECT ON analytics.salaries, SELECT ON other.t"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+
+	if _, err := Required(mustParse(t, "SELECT * FROM unknown_function(1)"), DefaultDatabase); err == nil {
+		t.Fatal("expected an error for an unsupported table function")
+	}
+}
+
+func TestRequired(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{"SYSTEM FLUSH LOGS", "SYSTEM FLUSH LOGS ON *.*"},
+		{"SYSTEM FLUSH DISTRIBUTED db.t", "SYSTEM FLUSH DISTRIBUTED ON db.t"},
+		{"SYSTEM RELOAD DICTIONARY db.d", "SYSTEM RELOAD DICTIONARY ON db.d"},
+		{"SYSTEM STOP REPLICATED SENDS", "SYSTEM REPLICATED SENDS ON *.*"},
+		{"SYSTEM DROP DNS CACHE", "SYSTEM DROP DNS CACHE ON *.*"},
+		{"KILL QUERY WHERE query_id = 'q'", "KILL QUERY ON *.*"},
+		{"CHECK TABLE db.t", "SHOW TABLES ON db.t"},
+		{"RENAME TABLE db.a TO db.b", "SELECT ON db.a, DROP TABLE ON db.a, CREATE TABLE ON db.b, INSERT ON db.b"},
+		{"SELECT * FROM remote('host', db.t)", "REMOTE ON *.*"},
+		{"SELECT * FROM numbers(10)", ""},
+		{"INSERT INTO FUNCTION file('out.csv') SELECT 1", "FILE ON *.*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sql, func(t *testing.T) {
+			requirements, err := Required(mustParse(t, tt.sql), DefaultDatabase)
+			if err != nil {
+				t.Fatalf("Required: %v", err)
+			}
+			var actual []string
+			for _, requirement := range requirements {
+				actual = append(actual, requirement.String())
+			}
+			if got := strings.Join(actual, ", "); got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPrivilegeHierarchy(t *testing.T) {
+	policy, err := Replay(`
+GRANT CREATE, ALTER, DROP ON *.* TO mallory;
+GRANT ACCESS MANAGEMENT ON *.* TO admin;
+GRANT SELECT(a) ON db.t TO frank;
+GRANT SELECT(b) ON db.t TO frank;
+GRANT SELECT ON db.u TO frank;
+REVOKE SELECT(b) ON db.u FROM frank;
+GRANT URL ON *.* TO grace;
+GRANT SYSTEM FLUSH ON *.* TO grace;
+`)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	tests := []struct {
+		user    string
+		sql     string
+		allowed bool
+	}{
+		{"mallory", "CREATE TABLE db.t (a UInt8) ENGINE = Memory", true},
+		{"mallory", "CREATE USER eve", false},
+		{"mallory", "CREATE ROLE r", false},
+		{"mallory", "ALTER USER alice RENAME TO eve", false},
+		{"mallory", "DROP USER alice", false},
+		{"mallory", "DROP ROLE r", false},
+		{"admin", "CREATE USER eve", true},
+		{"admin", "DROP ROLE r", true},
+		{"frank", "SELECT a, b FROM db.t", true},
+		{"frank", "SELECT a, b, c FROM db.t", false},
+		{"frank", "SELECT a FROM db.u", true},
+		{"frank", "SELECT a, b FROM db.u", false},
+		{"grace", "SELECT * FROM url('http://example.com/data.csv', CSV)", true},
+		{"grace", "SELECT * FROM file('data.csv', CSV)", false},
+		{"grace", "SELECT * FROM remote('host', db.t)", false},
+		{"grace", "SYSTEM FLUSH LOGS", true},
+		{"grace", "SYSTEM DROP DNS CACHE", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.user+": "+tt.sql, func(t *testing.T) {
+			stmt := mustParse(t, tt.sql)
+			if allowed := policy.Can(tt.user, stmt); allowed != tt.allowed {
+				t.Fatalf("expected %v, got %v (%v)", tt.allowed, allowed, policy.Check(tt.user, stmt))
+			}
+		})
+	}
+}
+
+func TestRoles(t *testing.T) {
+	policy, err := Replay(script + `
+CREATE ROLE lead;
+GRANT writer TO lead WITH ADMIN OPTION;
+GRANT lead TO bob;
+`)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	roles := policy.Roles("bob")
+	if len(roles) != 3 || roles[0] != "lead" || roles[1] != "reader" || roles[2] != "writer" {
+		t.Fatalf("unexpected roles of bob: %v", roles)
+	}
+	// roles defined outside the script are created like users
+	if roles := policy.Roles("dave"); len(roles) != 1 || roles[0] != "auditor" || !policy.Exists("auditor") {
+		t.Fatalf("unexpected roles of dave: %v", roles)
+	}
+	if !policy.Can("bob", mustParse(t, "GRANT writer TO carol")) {
+		t.Fatal("bob should be able to grant writer through lead")
+	}
+	if policy.Can("alice", mustParse(t, "GRANT writer TO carol")) {
+		t.Fatal("alice should not be able to grant writer")
+	}
+
+	for _, sql := range []string{
+		"REVOKE writer FROM alice",
+		"REVOKE GRANT OPTION FOR SELECT ON analytics.* FROM admin",
+		"DROP ROLE lead",
+	} {
+		if err := policy.Apply(mustParse(t, sql)); err != nil {
+			t.Fatalf("Apply %q: %v", sql, err)
+		}
+	}
+	if policy.Can("alice", mustParse(t, "SELECT * FROM analytics.events")) {
+		t.Fatal("alice should have lost reader with writer")
+	}
+	if !policy.Can("carol", mustParse(t, "SELECT * FROM analytics.events")) {
+		t.Fatal("carol should keep SELECT after losing the grant option")
+	}
+	if policy.Can("carol", mustParse(t, "GRANT SELECT ON analytics.events TO eve")) {
+		t.Fatal("carol should have lost the grant option")
+	}
+	if policy.Exists("lead") || policy.Can("bob", mustParse(t, "GRANT writer TO carol")) {
+		t.Fatal("lead should be dropped")
+	}
+
+	if err := policy.ApplyScript("GRANT ALL ON *.* TO root; REVOKE DROP ON analytics.* FROM root"); err != nil {
+		t.Fatalf("ApplyScript: %v", err)
+	}
+	if !policy.Can("root", mustParse(t, "DROP TABLE other.t")) || policy.Can("root", mustParse(t, "DROP TABLE analytics.t")) {
+		t.Fatal("partial revoke of DROP on analytics.* is not applied")
+	}
+	if err := policy.Apply(mustParse(t, "GRANT DROP TABLE ON analytics.t TO root")); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if !policy.Can("root", mustParse(t, "DROP TABLE analytics.t")) {
+		t.Fatal("a later grant should override the partial revoke")
+	}
+}
@@ -0,0 +1,177 @@
+package acl
+
+import (
+	"strings"
+
+	parser "github.com/carmel/go-sql-parser"
+)
+
+// privilegeParents maps a privilege to the one directly above it, privileges missing from it are
+// at the top of the hierarchy and only implied by ALL.
+var privilegeParents = map[string]string{
+	"ALTER ADD COLUMN":             "ALTER COLUMN",
+	"ALTER DROP COLUMN":            "ALTER COLUMN",
+	"ALTER MODIFY COLUMN":          "ALTER COLUMN",
+	"ALTER COMMENT COLUMN":         "ALTER COLUMN",
+	"ALTER CLEAR COLUMN":           "ALTER COLUMN",
+	"ALTER RENAME COLUMN":          "ALTER COLUMN",
+	"ALTER ORDER BY":               "ALTER INDEX",
+	"ALTER SAMPLE BY":              "ALTER INDEX",
+	"ALTER ADD INDEX":              "ALTER INDEX",
+	"ALTER DROP INDEX":             "ALTER INDEX",
+	"ALTER MATERIALIZE INDEX":      "ALTER INDEX",
+	"ALTER CLEAR INDEX":            "ALTER INDEX",
+	"ALTER ADD PROJECTION":         "ALTER PROJECTION",
+	"ALTER DROP PROJECTION":        "ALTER PROJECTION",
+	"ALTER MATERIALIZE PROJECTION": "ALTER PROJECTION",
+	"ALTER CLEAR PROJECTION":       "ALTER PROJECTION",
+	"ALTER ADD CONSTRAINT":         "ALTER CONSTRAINT",
+	"ALTER DROP CONSTRAINT":        "ALTER CONSTRAINT",
+	"ALTER MODIFY TTL":             "ALTER TTL",
+	"ALTER MATERIALIZE TTL":        "ALTER TTL",
+	"ALTER UPDATE":                 "ALTER TABLE",
+	"ALTER DELETE":                 "ALTER TABLE",
+	"ALTER COLUMN":                 "ALTER TABLE",
+	"ALTER INDEX":                  "ALTER TABLE",
+	"ALTER PROJECTION":             "ALTER TABLE",
+	"ALTER CONSTRAINT":             "ALTER TABLE",
+	"ALTER TTL":                    "ALTER TABLE",
+	"ALTER SETTINGS":               "ALTER TABLE",
+	"ALTER MODIFY COMMENT":         "ALTER TABLE",
+	"ALTER MOVE PARTITION":         "ALTER TABLE",
+	"ALTER FETCH PARTITION":        "ALTER TABLE",
+	"ALTER FREEZE PARTITION":       "ALTER TABLE",
+	"ALTER VIEW MODIFY":            "ALTER VIEW",
+	"ALTER VIEW REFRESH":           "ALTER VIEW",
+	"ALTER TABLE":                  "ALTER",
+	"ALTER VIEW":                   "ALTER",
+	"ALTER DATABASE":               "ALTER",
+
+	"CREATE DATABASE":        "CREATE",
+	"CREATE TABLE":           "CREATE",
+	"CREATE TEMPORARY TABLE": "CREATE TABLE",
+	"CREATE VIEW":            "CREATE",
+	"CREATE DICTIONARY":      "CREATE",
+	"CREATE FUNCTION":        "CREATE",
+	"DROP DATABASE":          "DROP",
+	"DROP TABLE":             "DROP",
+	"DROP VIEW":              "DROP",
+	"DROP DICTIONARY":        "DROP",
+	"DROP FUNCTION":          "DROP",
+
+	"SHOW DATABASES":    "SHOW",
+	"SHOW TABLES":       "SHOW",
+	"SHOW COLUMNS":      "SHOW",
+	"SHOW DICTIONARIES": "SHOW",
+
+	// users, roles and the other access entities are only managed with ACCESS MANAGEMENT,
+	// CREATE, ALTER and DROP do not imply them
+	"CREATE USER":             "ACCESS MANAGEMENT",
+	"ALTER USER":              "ACCESS MANAGEMENT",
+	"DROP USER":               "ACCESS MANAGEMENT",
+	"CREATE ROLE":             "ACCESS MANAGEMENT",
+	"ALTER ROLE":              "ACCESS MANAGEMENT",
+	"DROP ROLE":               "ACCESS MANAGEMENT",
+	"ROLE ADMIN":              "ACCESS MANAGEMENT",
+	"CREATE ROW POLICY":       "ACCESS MANAGEMENT",
+	"ALTER ROW POLICY":        "ACCESS MANAGEMENT",
+	"DROP ROW POLICY":         "ACCESS MANAGEMENT",
+	"CREATE QUOTA":            "ACCESS MANAGEMENT",
+	"ALTER QUOTA":             "ACCESS MANAGEMENT",
+	"DROP QUOTA":              "ACCESS MANAGEMENT",
+	"CREATE SETTINGS PROFILE": "ACCESS MANAGEMENT",
+	"ALTER SETTINGS PROFILE":  "ACCESS MANAGEMENT",
+	"DROP SETTINGS PROFILE":   "ACCESS MANAGEMENT",
+	"SHOW ACCESS":             "ACCESS MANAGEMENT",
+
+	"SYSTEM SHUTDOWN":                       "SYSTEM",
+	"SYSTEM DROP CACHE":                     "SYSTEM",
+	"SYSTEM DROP DNS CACHE":                 "SYSTEM DROP CACHE",
+	"SYSTEM DROP MARK CACHE":                "SYSTEM DROP CACHE",
+	"SYSTEM DROP UNCOMPRESSED CACHE":        "SYSTEM DROP CACHE",
+	"SYSTEM DROP FILESYSTEM CACHE":          "SYSTEM DROP CACHE",
+	"SYSTEM DROP QUERY CACHE":               "SYSTEM DROP CACHE",
+	"SYSTEM DROP COMPILED EXPRESSION CACHE": "SYSTEM DROP CACHE",
+	"SYSTEM RELOAD":                         "SYSTEM",
+	"SYSTEM RELOAD CONFIG":                  "SYSTEM RELOAD",
+	"SYSTEM RELOAD DICTIONARY":              "SYSTEM RELOAD",
+	"SYSTEM RELOAD EMBEDDED DICTIONARIES":   "SYSTEM RELOAD",
+	"SYSTEM MERGES":                         "SYSTEM",
+	"SYSTEM TTL MERGES":                     "SYSTEM",
+	"SYSTEM FETCHES":                        "SYSTEM",
+	"SYSTEM MOVES":                          "SYSTEM",
+	"SYSTEM SENDS":                          "SYSTEM",
+	"SYSTEM DISTRIBUTED SENDS":              "SYSTEM SENDS",
+	"SYSTEM REPLICATED SENDS":               "SYSTEM SENDS",
+	"SYSTEM REPLICATION QUEUES":             "SYSTEM",
+	"SYSTEM SYNC REPLICA":                   "SYSTEM",
+	"SYSTEM RESTART REPLICA":                "SYSTEM",
+	"SYSTEM FLUSH":                          "SYSTEM",
+	"SYSTEM FLUSH DISTRIBUTED":              "SYSTEM FLUSH",
+	"SYSTEM FLUSH LOGS":                     "SYSTEM FLUSH",
+
+	"FILE":     "SOURCES",
+	"URL":      "SOURCES",
+	"REMOTE":   "SOURCES",
+	"MYSQL":    "SOURCES",
+	"POSTGRES": "SOURCES",
+	"ODBC":     "SOURCES",
+	"JDBC":     "SOURCES",
+	"HDFS":     "SOURCES",
+	"S3":       "SOURCES",
+	"AZURE":    "SOURCES",
+	"MONGO":    "SOURCES",
+	"REDIS":    "SOURCES",
+	"SQLITE":   "SOURCES",
+}
+
+// parentPrivilege returns the privilege that implies privilege, or "" at the top of the hierarchy.
+func parentPrivilege(privilege string) string {
+	return privilegeParents[privilege]
+}
+
+// coversPrivilege reports whether holding granted implies required.
+func coversPrivilege(granted, required string) bool {
+	if granted == parser.KeywordAll {
+		return true
+	}
+	for privilege := required; privilege != ""; privilege = parentPrivilege(privilege) {
+		if privilege == granted {
+			return true
+		}
+	}
+	return false
+}
+
+func privilegeName(privilege *parser.PrivilegeClause) string {
+	name := strings.ToUpper(strings.Join(privilege.Keywords, " "))
+	// `ALTER ADD INDEX` is kept as `ALTER ADD INDEX CONSTRAINT` by the parser
+	return strings.TrimSuffix(name, " "+parser.KeywordConstraint)
+}
+
+// privilegeColumns returns the columns of `SELECT(a, b)`, nil when the privilege covers the whole table.
+func privilegeColumns(privilege *parser.PrivilegeClause) []string {
+	if privilege.Params == nil || privilege.Params.Items == nil {
+		return nil
+	}
+	columns := make([]string, 0, len(privilege.Params.Items.Items))
+	for _, item := range privilege.Params.Items.Items {
+		columns = append(columns, columnName(item))
+	}
+	return columns
+}
+
+func columnName(expr parser.Expr) string {
+	switch expr := expr.(type) {
+	case *parser.ColumnExpr:
+		return columnName(expr.Expr)
+	case *parser.Ident:
+		return expr.Name
+	case *parser.NestedIdentifier:
+		if expr.DotIdent != nil {
+			return expr.Ident.Name + "." + expr.DotIdent.Name
+		}
+		return expr.Ident.Name
+	}
+	return expr.String()
+}
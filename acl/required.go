@@ -0,0 +1,396 @@
+package acl
+
+import (
+	"fmt"
+	"strings"
+
+	parser "github.com/carmel/go-sql-parser"
+)
+
+// Required returns the privileges needed to run stmt, unqualified table names resolve to database.
+//
+// Every table in the Reads of parser.Classify needs SELECT on the columns of its ReadColumns, or
+// on the whole table if it has none. An unqualified column of a query of several tables is
+// required on each of them, as it is not resolved without their definitions.
+func Required(stmt parser.Expr, database string) ([]Requirement, error) {
+	r := &requirements{database: database}
+	if err := r.statement(stmt); err != nil {
+		return nil, err
+	}
+	return r.list, nil
+}
+
+type requirements struct {
+	database string
+	list     []Requirement
+}
+
+func (r *requirements) add(privilege string, object Object, columns ...string) {
+	for _, requirement := range r.list {
+		if requirement.Privilege == privilege && requirement.Object == object && requirement.Role == "" &&
+			!requirement.GrantOption && strings.Join(requirement.Columns, ",") == strings.Join(columns, ",") {
+			return
+		}
+	}
+	r.list = append(r.list, Requirement{Privilege: privilege, Object: object, Columns: columns})
+}
+
+func (r *requirements) object(table *parser.TableIdentifier) Object {
+	if table.Schema == nil {
+		return Object{Database: r.database, Table: table.Table.Name}
+	}
+	return Object{Database: table.Schema.Name, Table: table.Table.Name}
+}
+
+// allTables is every table of a database.
+func allTables(database string) Object {
+	return Object{Database: database, Table: Any}
+}
+
+func (r *requirements) statement(stmt parser.Expr) error {
+	switch stmt := stmt.(type) {
+	case *parser.SelectQuery, *parser.SetOperation:
+	case *parser.ExplainStmt:
+		return r.statement(stmt.Statement)
+	case *parser.InsertStmt:
+		switch table := stmt.Table.(type) {
+		case *parser.TableIdentifier:
+			var columns []string
+			if stmt.ColumnNames != nil {
+				for _, column := range stmt.ColumnNames.ColumnNames {
+					columns = append(columns, columnName(&column))
+				}
+			}
+			r.add(parser.KeywordInsert, r.object(table), columns...)
+		case *parser.FunctionExpr:
+			var args []parser.Expr
+			if table.Params != nil && table.Params.Items != nil {
+				args = table.Params.Items.Items
+			}
+			if err := r.tableFunction(table.Name.Name, args); err != nil {
+				return err
+			}
+		}
+	case *parser.DeleteClause:
+		r.add("ALTER DELETE", r.object(stmt.Table))
+	case *parser.AlterTable:
+		r.alter(stmt)
+	case *parser.TruncateTable:
+		r.add(parser.KeywordTruncate, r.object(stmt.Name))
+	case *parser.OptimizeStmt:
+		r.add(parser.KeywordOptimize, r.object(stmt.Table))
+	case *parser.CreateTable:
+		if stmt.HasTemporary {
+			r.add("CREATE TEMPORARY TABLE", r.object(stmt.Identifier))
+		} else {
+			r.add("CREATE TABLE", r.object(stmt.Identifier))
+		}
+	case *parser.CreateView:
+		r.add("CREATE VIEW", r.object(stmt.Name))
+	case *parser.CreateMaterializedView:
+		r.add("CREATE VIEW", r.object(stmt.Name))
+	case *parser.CreateLiveView:
+		r.add("CREATE VIEW", r.object(stmt.Name))
+	case *parser.CreateDictionary:
+		r.add("CREATE DICTIONARY", r.object(stmt.Name))
+	case *parser.CreateDatabase:
+		r.add("CREATE DATABASE", allTables(stmt.Name.String()))
+	case *parser.CreateFunction:
+		r.add("CREATE FUNCTION", allTables(Any))
+	case *parser.DropStmt:
+		r.add("DROP "+stmt.DropTarget, r.object(stmt.Name))
+	case *parser.DropDatabase:
+		r.add("DROP DATABASE", allTables(stmt.Name.Name))
+	case *parser.CreateUser:
+		r.add("CREATE USER", allTables(Any))
+	case *parser.AlterUser:
+		r.add("ALTER USER", allTables(Any))
+	case *parser.CreateRole:
+		r.add("CREATE ROLE", allTables(Any))
+	case *parser.AlterRole:
+		r.add("ALTER ROLE", allTables(Any))
+	case *parser.DropUserOrRole:
+		r.add("DROP "+strings.ToUpper(stmt.Target), allTables(Any))
+	case *parser.GrantPrivilegeStmt:
+		r.grant(stmt.Privileges, stmt.On, stmt.Roles)
+	case *parser.RevokeStmt:
+		r.grant(stmt.Privileges, stmt.On, stmt.Roles)
+	case *parser.SystemStmt:
+		r.system(stmt)
+		return nil
+	case *parser.KillStmt:
+		if stmt.Target == parser.KeywordMutation {
+			r.add("ALTER TABLE", allTables(Any))
+		} else {
+			r.add("KILL QUERY", allTables(Any))
+		}
+	case *parser.CheckStmt:
+		r.add("SHOW TABLES", r.object(stmt.Table))
+		return nil
+	case *parser.RenameStmt:
+		r.rename(stmt)
+		return nil
+	case *parser.LockTablesStmt:
+		for _, lock := range stmt.Locks {
+			r.add("LOCK TABLES", r.object(lock.Table))
+			r.add(parser.KeywordSelect, r.object(lock.Table))
+		}
+	case *parser.UseStmt, *parser.SetRoleStmt, *parser.SetStmt, *parser.SetVariableStmt, *parser.SetNamesStmt,
+		*parser.BeginStmt, *parser.SetTransactionStmt, *parser.CommitStmt, *parser.RollbackStmt, *parser.SavepointStmt, *parser.UnlockTablesStmt:
+		return nil
+	default:
+		return fmt.Errorf("acl: unsupported statement %T", stmt)
+	}
+	return r.reads(stmt)
+}
+
+// reads requires SELECT on every table the statement reads from, and the source privilege
+// of every table function it reads from.
+func (r *requirements) reads(stmt parser.Expr) error {
+	info, err := parser.Classify(stmt)
+	if err != nil {
+		return err
+	}
+	for _, table := range info.Reads {
+		r.add(parser.KeywordSelect, r.object(table), info.ReadColumns[table.String()]...)
+	}
+	visitor := &tableFunctionVisitor{depth: []int{0}}
+	_ = stmt.Accept(visitor)
+	for _, function := range visitor.functions {
+		if err := r.tableFunction(function.Name.String(), function.Args.Args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableFunctionSources maps a table function to the source privilege needed to read or write
+// through it, functions mapped to "" generate their rows and need none.
+var tableFunctionSources = map[string]string{
+	"remote":             "REMOTE",
+	"remotesecure":       "REMOTE",
+	"cluster":            "REMOTE",
+	"clusterallreplicas": "REMOTE",
+	"url":                "URL",
+	"urlcluster":         "URL",
+	"file":               "FILE",
+	"filecluster":        "FILE",
+	"mysql":              "MYSQL",
+	"postgresql":         "POSTGRES",
+	"odbc":               "ODBC",
+	"jdbc":               "JDBC",
+	"hdfs":               "HDFS",
+	"hdfscluster":        "HDFS",
+	"s3":                 "S3",
+	"s3cluster":          "S3",
+	"gcs":                "S3",
+	"azureblobstorage":   "AZURE",
+	"mongodb":            "MONGO",
+	"redis":              "REDIS",
+	"sqlite":             "SQLITE",
+	"numbers":            "",
+	"numbers_mt":         "",
+	"zeros":              "",
+	"zeros_mt":           "",
+	"values":             "",
+	"generaterandom":     "",
+	"generate_series":    "",
+	"null":               "",
+	"input":              "",
+	"view":               "",
+}
+
+// tableFunction requires the privilege of a table function, merge reads the tables of a database.
+func (r *requirements) tableFunction(name string, args []parser.Expr) error {
+	name = strings.ToLower(name)
+	if name == "merge" {
+		database := r.database
+		if len(args) > 1 {
+			database = strings.Trim(args[0].String(), "'`\"")
+		}
+		r.add(parser.KeywordSelect, allTables(database))
+		return nil
+	}
+	source, ok := tableFunctionSources[name]
+	if !ok {
+		return fmt.Errorf("acl: unsupported table function %s", name)
+	}
+	if source != "" {
+		r.add(source, allTables(Any))
+	}
+	return nil
+}
+
+// tableFunctionVisitor collects the table functions of FROM clauses, the function calls
+// in their arguments are not table functions.
+type tableFunctionVisitor struct {
+	parser.DefaultASTVisitor
+	// depth is the table function nesting of the queries being visited, innermost last
+	depth     []int
+	functions []*parser.TableFunctionExpr
+}
+
+func (v *tableFunctionVisitor) Enter(expr parser.Expr) {
+	switch expr := expr.(type) {
+	case *parser.SelectQuery:
+		v.depth = append(v.depth, 0)
+	case *parser.TableFunctionExpr:
+		if v.depth[len(v.depth)-1] == 0 {
+			v.functions = append(v.functions, expr)
+		}
+		v.depth[len(v.depth)-1]++
+	}
+}
+
+func (v *tableFunctionVisitor) Leave(expr parser.Expr) {
+	switch expr.(type) {
+	case *parser.SelectQuery:
+		v.depth = v.depth[:len(v.depth)-1]
+	case *parser.TableFunctionExpr:
+		v.depth[len(v.depth)-1]--
+	}
+}
+
+// system requires the SYSTEM privilege of the command, on the table it names if any.
+func (r *requirements) system(stmt *parser.SystemStmt) {
+	switch expr := stmt.Expr.(type) {
+	case *parser.SystemFlushExpr:
+		if expr.Distributed != nil {
+			r.add("SYSTEM FLUSH DISTRIBUTED", r.object(expr.Distributed))
+		} else {
+			r.add("SYSTEM FLUSH LOGS", allTables(Any))
+		}
+	case *parser.SystemReloadExpr:
+		switch {
+		case expr.Dictionary != nil:
+			r.add("SYSTEM RELOAD DICTIONARY", r.object(expr.Dictionary))
+		case expr.Type == parser.KeywordDictionaries:
+			r.add("SYSTEM RELOAD DICTIONARY", allTables(Any))
+		default:
+			r.add("SYSTEM RELOAD "+expr.Type, allTables(Any))
+		}
+	case *parser.SystemSyncExpr:
+		r.add("SYSTEM SYNC REPLICA", r.object(expr.Cluster))
+	case *parser.SystemCtrlExpr:
+		if expr.Cluster != nil {
+			r.add("SYSTEM "+expr.Type, r.object(expr.Cluster))
+		} else {
+			r.add("SYSTEM "+expr.Type, allTables(Any))
+		}
+	case *parser.SystemDropExpr:
+		r.add("SYSTEM DROP "+strings.ToUpper(expr.Type), allTables(Any))
+	default:
+		r.add("SYSTEM", allTables(Any))
+	}
+}
+
+// rename requires dropping each old name and creating each new one, with SELECT and INSERT
+// to move the data of a table.
+func (r *requirements) rename(stmt *parser.RenameStmt) {
+	target := strings.ToUpper(stmt.RenameTarget)
+	for _, pair := range stmt.TargetPairList {
+		if target == parser.KeywordDatabase {
+			r.add("DROP DATABASE", allTables(pair.Old.String()))
+			r.add("CREATE DATABASE", allTables(pair.New.String()))
+			continue
+		}
+		r.add(parser.KeywordSelect, r.object(pair.Old))
+		r.add("DROP "+target, r.object(pair.Old))
+		r.add("CREATE "+target, r.object(pair.New))
+		r.add(parser.KeywordInsert, r.object(pair.New))
+	}
+}
+
+func (r *requirements) grant(privileges []*parser.PrivilegeClause, on *parser.TableIdentifier, roles []*parser.RoleName) {
+	for _, privilege := range privileges {
+		r.list = append(r.list, Requirement{
+			Privilege:   privilegeName(privilege),
+			Object:      r.object(on),
+			Columns:     privilegeColumns(privilege),
+			GrantOption: true,
+		})
+	}
+	for _, role := range roles {
+		r.list = append(r.list, Requirement{
+			Privilege: "ROLE ADMIN",
+			Object:    allTables(Any),
+			Role:      roleName(role),
+		})
+	}
+}
+
+func (r *requirements) alter(stmt *parser.AlterTable) {
+	table := r.object(stmt.TableIdentifier)
+	for _, expr := range stmt.AlterExprs {
+		switch expr := expr.(type) {
+		case *parser.AlterTableAddColumn:
+			r.add("ALTER ADD COLUMN", table)
+		case *parser.AlterTableDropColumn:
+			r.add("ALTER DROP COLUMN", table)
+		case *parser.AlterTableModifyColumn, *parser.AlterTableChangeColumn:
+			r.add("ALTER MODIFY COLUMN", table)
+		case *parser.AlterTableClearColumn:
+			r.add("ALTER CLEAR COLUMN", table)
+		case *parser.AlterTableRenameColumn:
+			r.add("ALTER RENAME COLUMN", table)
+		case *parser.AlterTableAlterColumn:
+			r.add("ALTER MODIFY COLUMN", table)
+		case *parser.AlterTableAddIndex, *parser.AlterTableAddKey:
+			r.add("ALTER ADD INDEX", table)
+		case *parser.AlterTableDropIndex, *parser.AlterTableDropPrimaryKey:
+			r.add("ALTER DROP INDEX", table)
+		case *parser.AlterTableAddForeignKey:
+			r.add("ALTER ADD CONSTRAINT", table)
+		case *parser.AlterTableDropForeignKey:
+			r.add("ALTER DROP CONSTRAINT", table)
+		case *parser.AlterTableClearIndex:
+			r.add("ALTER CLEAR INDEX", table)
+		case *parser.AlterTableMaterializeIndex:
+			r.add("ALTER MATERIALIZE INDEX", table)
+		case *parser.AlterTableRenameIndex:
+			r.add("ALTER INDEX", table)
+		case *parser.AlterTableModifyOrderBy:
+			r.add("ALTER ORDER BY", table)
+		case *parser.AlterTableAddProjection:
+			r.add("ALTER ADD PROJECTION", table)
+		case *parser.AlterTableDropProjection:
+			r.add("ALTER DROP PROJECTION", table)
+		case *parser.AlterTableClearProjection:
+			r.add("ALTER CLEAR PROJECTION", table)
+		case *parser.AlterTableMaterializeProjection:
+			r.add("ALTER MATERIALIZE PROJECTION", table)
+		case *parser.AlterTableModifyTTL, *parser.AlterTableRemoveTTL:
+			r.add("ALTER MODIFY TTL", table)
+		case *parser.AlterTableModifySetting, *parser.AlterTableResetSetting:
+			r.add("ALTER SETTINGS", table)
+		case *parser.AlterTableModifyComment:
+			r.add("ALTER MODIFY COMMENT", table)
+		case *parser.AlterTableModifyQuery:
+			r.add("ALTER VIEW MODIFY", table)
+		case *parser.AlterTableUpdate:
+			r.add("ALTER UPDATE", table)
+		case *parser.AlterTableDelete, *parser.AlterTableApplyDeletedMask,
+			*parser.AlterTableDropPartition, *parser.AlterTableDetachPartition:
+			r.add("ALTER DELETE", table)
+		case *parser.AlterTableAttachPartition:
+			r.add(parser.KeywordInsert, table)
+		case *parser.AlterTableReplacePartition:
+			r.add("ALTER DELETE", table)
+			r.add(parser.KeywordInsert, table)
+		case *parser.AlterTableMovePartition:
+			r.add("ALTER MOVE PARTITION", table)
+			if expr.ToTable != nil {
+				r.add(parser.KeywordInsert, r.object(expr.ToTable))
+			}
+		case *parser.AlterTableFetchPartition:
+			r.add("ALTER FETCH PARTITION", table)
+		case *parser.AlterTableFreezePartition:
+			r.add("ALTER FREEZE PARTITION", table)
+		case *parser.AlterTableRenameTable:
+			r.add("ALTER TABLE", table)
+			r.add("CREATE TABLE", r.object(expr.NewName))
+		default:
+			r.add("ALTER TABLE", table)
+		}
+	}
+}
@@ -12,6 +12,14 @@ const (
 	OrderDirectionDesc OrderDirection = "DESC"
 )
 
+type NullsOrder string
+
+const (
+	NullsOrderNone  NullsOrder = ""
+	NullsOrderFirst NullsOrder = "NULLS FIRST"
+	NullsOrderLast  NullsOrder = "NULLS LAST"
+)
+
 type Expr interface {
 	Start() Pos
 	End() Pos
@@ -219,6 +227,70 @@ func (p *BinaryOperation) Accept(visitor ASTVisitor) error {
 	return visitor.VisitBinaryExpr(p)
 }
 
+// LambdaExpr is a ClickHouse lambda function such as `x -> x * 2` or `(k, v) -> v > 0`.
+// Params are bound inside Body and do not refer to columns.
+type LambdaExpr struct {
+	LeftParenPos  Pos
+	RightParenPos Pos
+	Params        []*Ident
+	Body          Expr
+}
+
+func (l *LambdaExpr) Start() Pos {
+	if l.RightParenPos != 0 {
+		return l.LeftParenPos
+	}
+	return l.Params[0].Start()
+}
+
+func (l *LambdaExpr) End() Pos {
+	return l.Body.End()
+}
+
+func (l *LambdaExpr) String() string {
+	var builder strings.Builder
+	parenthesized := l.RightParenPos != 0 || len(l.Params) != 1
+	if parenthesized {
+		builder.WriteByte('(')
+	}
+	for i, param := range l.Params {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(param.String())
+	}
+	if parenthesized {
+		builder.WriteByte(')')
+	}
+	builder.WriteString(" -> ")
+	builder.WriteString(l.Body.String())
+	return builder.String()
+}
+
+// HasParam reports whether name is bound by the lambda.
+func (l *LambdaExpr) HasParam(name string) bool {
+	for _, param := range l.Params {
+		if param.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *LambdaExpr) Accept(visitor ASTVisitor) error {
+	visitor.Enter(l)
+	defer visitor.Leave(l)
+	for _, param := range l.Params {
+		if err := param.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := l.Body.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitLambdaExpr(l)
+}
+
 type IndexOperation struct {
 	Object    Expr
 	Operation TokenKind
@@ -636,6 +708,7 @@ type AlterTableAddColumn struct {
 
 	Column      *ColumnDef
 	IfNotExists bool
+	First       bool
 	After       *NestedIdentifier
 }
 
@@ -658,7 +731,9 @@ func (a *AlterTableAddColumn) String() string {
 	if a.IfNotExists {
 		builder.WriteString("IF NOT EXISTS ")
 	}
-	if a.After != nil {
+	if a.First {
+		builder.WriteString(" FIRST")
+	} else if a.After != nil {
 		builder.WriteString(" AFTER ")
 		builder.WriteString(a.After.String())
 	}
@@ -1308,6 +1383,8 @@ type AlterTableModifyColumn struct {
 	IfExists           bool
 	Column             *ColumnDef
 	RemovePropertyType *RemovePropertyType
+	First              bool
+	After              *NestedIdentifier
 }
 
 func (a *AlterTableModifyColumn) Start() Pos {
@@ -1332,6 +1409,12 @@ func (a *AlterTableModifyColumn) String() string {
 	if a.RemovePropertyType != nil {
 		builder.WriteString(a.RemovePropertyType.String())
 	}
+	if a.First {
+		builder.WriteString(" FIRST")
+	} else if a.After != nil {
+		builder.WriteString(" AFTER ")
+		builder.WriteString(a.After.String())
+	}
 	return builder.String()
 }
 
@@ -1346,6 +1429,11 @@ func (a *AlterTableModifyColumn) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if a.After != nil {
+		if err := a.After.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitAlterTableModifyColumn(a)
 }
 
@@ -1388,896 +1476,3305 @@ func (a *AlterTableReplacePartition) Accept(visitor ASTVisitor) error {
 	return visitor.VisitAlterTableReplacePartition(a)
 }
 
-type RemovePropertyType struct {
-	RemovePos Pos
+type AlterTableChangeColumn struct {
+	ChangePos    Pos
+	StatementEnd Pos
 
-	PropertyType Expr
+	OldColumnName *NestedIdentifier
+	Column        *ColumnDef
+	First         bool
+	After         *NestedIdentifier
 }
 
-func (a *RemovePropertyType) Start() Pos {
-	return a.RemovePos
+func (a *AlterTableChangeColumn) Start() Pos {
+	return a.ChangePos
 }
 
-func (a *RemovePropertyType) End() Pos {
-	return a.PropertyType.End()
+func (a *AlterTableChangeColumn) End() Pos {
+	return a.StatementEnd
 }
 
-func (a *RemovePropertyType) String() string {
+func (a *AlterTableChangeColumn) AlterType() string {
+	return "CHANGE_COLUMN"
+}
+
+func (a *AlterTableChangeColumn) String() string {
 	var builder strings.Builder
-	builder.WriteString(" REMOVE ")
-	builder.WriteString(a.PropertyType.String())
+	builder.WriteString("CHANGE COLUMN ")
+	builder.WriteString(a.OldColumnName.String())
+	builder.WriteByte(' ')
+	builder.WriteString(a.Column.String())
+	if a.First {
+		builder.WriteString(" FIRST")
+	} else if a.After != nil {
+		builder.WriteString(" AFTER ")
+		builder.WriteString(a.After.String())
+	}
 	return builder.String()
 }
 
-func (a *RemovePropertyType) Accept(visitor ASTVisitor) error {
+func (a *AlterTableChangeColumn) Accept(visitor ASTVisitor) error {
 	visitor.Enter(a)
 	defer visitor.Leave(a)
-	if err := a.PropertyType.Accept(visitor); err != nil {
+	if err := a.OldColumnName.Accept(visitor); err != nil {
 		return err
 	}
-	return visitor.VisitRemovePropertyType(a)
+	if err := a.Column.Accept(visitor); err != nil {
+		return err
+	}
+	if a.After != nil {
+		if err := a.After.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitAlterTableChangeColumn(a)
 }
 
-type TableIndex struct {
-	IndexPos Pos
+type AlterTableAddKey struct {
+	AddPos Pos
+	// Constraint is the name of `ADD CONSTRAINT name PRIMARY KEY ...`.
+	Constraint *Ident
+	// KeyType is one of PRIMARY KEY, UNIQUE KEY or KEY.
+	KeyType string
+	Key     *Key
+}
 
-	Name        *NestedIdentifier
-	ColumnExpr  *ColumnExpr
-	ColumnType  Expr
-	Granularity *NumberLiteral
+func (a *AlterTableAddKey) Start() Pos {
+	return a.AddPos
 }
 
-func (a *TableIndex) Start() Pos {
-	return a.IndexPos
+func (a *AlterTableAddKey) End() Pos {
+	return a.Key.End()
 }
 
-func (a *TableIndex) End() Pos {
-	return a.Granularity.End()
+func (a *AlterTableAddKey) AlterType() string {
+	switch a.KeyType {
+	case "PRIMARY KEY":
+		return "ADD_PRIMARY_KEY"
+	case "UNIQUE KEY":
+		return "ADD_UNIQUE_KEY"
+	default:
+		return "ADD_KEY"
+	}
 }
 
-func (a *TableIndex) String() string {
+func (a *AlterTableAddKey) String() string {
 	var builder strings.Builder
-	builder.WriteString("INDEX")
-	if a.Name != nil {
+	builder.WriteString("ADD ")
+	if a.Constraint != nil {
+		builder.WriteString("CONSTRAINT ")
+		builder.WriteString(a.Constraint.String())
 		builder.WriteByte(' ')
-		builder.WriteString(a.Name.String())
 	}
-	if a.ColumnExpr != nil {
-		if _, ok := a.ColumnExpr.Expr.(*Ident); ok {
-			builder.WriteByte(' ')
+	builder.WriteString(a.Key.String())
+	return builder.String()
+}
+
+func (a *AlterTableAddKey) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if a.Constraint != nil {
+		if err := a.Constraint.Accept(visitor); err != nil {
+			return err
 		}
-		builder.WriteString(a.ColumnExpr.String())
 	}
-	if a.ColumnType != nil {
-		builder.WriteByte(' ')
-		builder.WriteString("TYPE")
-		builder.WriteByte(' ')
-		builder.WriteString(a.ColumnType.String())
+	if err := a.Key.Accept(visitor); err != nil {
+		return err
 	}
-	if a.Granularity != nil {
+	return visitor.VisitAlterTableAddKey(a)
+}
+
+// AlterTableAddForeignKey is the MySQL and PostgreSQL `ADD [CONSTRAINT name] FOREIGN KEY`
+// clause.
+type AlterTableAddForeignKey struct {
+	AddPos       Pos
+	StatementEnd Pos
+	Constraint   *Ident
+	Name         *Ident
+	Columns      *ColumnExprList
+	RefTable     *TableIdentifier
+	RefColumns   *ColumnExprList
+	// OnDelete and OnUpdate are the referential actions such as CASCADE or SET NULL, or empty.
+	OnDelete string
+	OnUpdate string
+}
+
+func (a *AlterTableAddForeignKey) Start() Pos {
+	return a.AddPos
+}
+
+func (a *AlterTableAddForeignKey) End() Pos {
+	return a.StatementEnd
+}
+
+func (a *AlterTableAddForeignKey) AlterType() string {
+	return "ADD_FOREIGN_KEY"
+}
+
+func (a *AlterTableAddForeignKey) String() string {
+	var builder strings.Builder
+	builder.WriteString("ADD ")
+	if a.Constraint != nil {
+		builder.WriteString("CONSTRAINT ")
+		builder.WriteString(a.Constraint.String())
 		builder.WriteByte(' ')
-		builder.WriteString("GRANULARITY")
+	}
+	builder.WriteString("FOREIGN KEY ")
+	if a.Name != nil {
+		builder.WriteString(a.Name.String())
 		builder.WriteByte(' ')
-		builder.WriteString(a.Granularity.String())
+	}
+	builder.WriteByte('(')
+	builder.WriteString(a.Columns.String())
+	builder.WriteString(") REFERENCES ")
+	builder.WriteString(a.RefTable.String())
+	builder.WriteString(" (")
+	builder.WriteString(a.RefColumns.String())
+	builder.WriteByte(')')
+	if a.OnDelete != "" {
+		builder.WriteString(" ON DELETE ")
+		builder.WriteString(a.OnDelete)
+	}
+	if a.OnUpdate != "" {
+		builder.WriteString(" ON UPDATE ")
+		builder.WriteString(a.OnUpdate)
 	}
 	return builder.String()
 }
 
-func (a *TableIndex) Accept(visitor ASTVisitor) error {
+func (a *AlterTableAddForeignKey) Accept(visitor ASTVisitor) error {
 	visitor.Enter(a)
 	defer visitor.Leave(a)
-	if err := a.Name.Accept(visitor); err != nil {
-		return err
+	if a.Constraint != nil {
+		if err := a.Constraint.Accept(visitor); err != nil {
+			return err
+		}
 	}
-	if err := a.ColumnExpr.Accept(visitor); err != nil {
-		return err
+	if a.Name != nil {
+		if err := a.Name.Accept(visitor); err != nil {
+			return err
+		}
 	}
-	if err := a.ColumnType.Accept(visitor); err != nil {
+	if err := a.Columns.Accept(visitor); err != nil {
 		return err
 	}
-	if err := a.Granularity.Accept(visitor); err != nil {
+	if err := a.RefTable.Accept(visitor); err != nil {
 		return err
 	}
-	return visitor.VisitTableIndex(a)
-}
-
-type Ident struct {
-	Name      string
-	QuoteType int
-	start     Pos
-	end       Pos
-}
-
-func (i *Ident) Start() Pos {
-	return i.start
-}
-
-func (i *Ident) End() Pos {
-	return i.end
-}
-
-func (i *Ident) String() string {
-	switch i.QuoteType {
-	case BackTicks:
-		return "`" + i.Name + "`"
-	case DoubleQuote:
-		return `"` + i.Name + `"`
+	if err := a.RefColumns.Accept(visitor); err != nil {
+		return err
 	}
-	return i.Name
+	return visitor.VisitAlterTableAddForeignKey(a)
 }
 
-func (i *Ident) Accept(visitor ASTVisitor) error {
-	visitor.Enter(i)
-	defer visitor.Leave(i)
-	return visitor.VisitIdent(i)
+type AlterTableDropForeignKey struct {
+	DropPos Pos
+	Name    *Ident
 }
 
-type UUID struct {
-	Value *StringLiteral
+func (a *AlterTableDropForeignKey) Start() Pos {
+	return a.DropPos
 }
 
-func (u *UUID) Start() Pos {
-	return u.Value.LiteralPos
+func (a *AlterTableDropForeignKey) End() Pos {
+	return a.Name.End()
 }
 
-func (u *UUID) End() Pos {
-	return u.Value.LiteralEnd
+func (a *AlterTableDropForeignKey) AlterType() string {
+	return "DROP_FOREIGN_KEY"
 }
 
-func (u *UUID) String() string {
-	return "UUID " + u.Value.String()
+func (a *AlterTableDropForeignKey) String() string {
+	return "DROP FOREIGN KEY " + a.Name.String()
 }
 
-func (u *UUID) Accept(visitor ASTVisitor) error {
-	visitor.Enter(u)
-	defer visitor.Leave(u)
-	return visitor.VisitUUID(u)
+func (a *AlterTableDropForeignKey) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Name.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableDropForeignKey(a)
 }
 
-type CreateDatabase struct {
-	CreatePos    Pos // position of CREATE keyword
+// AlterTableAlterColumn is the MySQL and PostgreSQL `ALTER COLUMN c SET DEFAULT expr` and
+// `ALTER COLUMN c DROP DEFAULT` clause.
+type AlterTableAlterColumn struct {
+	AlterPos     Pos
 	StatementEnd Pos
-	Name         Expr
-	IfNotExists  bool // true if 'IF NOT EXISTS' is specified
-	OnCluster    *ClusterClause
-	Engine       *EngineExpr
-	Comment      *StringLiteral
+	Column       *NestedIdentifier
+	Default      Expr
+	DropDefault  bool
 }
 
-func (c *CreateDatabase) Start() Pos {
-	return c.CreatePos
+func (a *AlterTableAlterColumn) Start() Pos {
+	return a.AlterPos
 }
 
-func (c *CreateDatabase) End() Pos {
-	return c.StatementEnd
+func (a *AlterTableAlterColumn) End() Pos {
+	return a.StatementEnd
 }
 
-func (c *CreateDatabase) Type() string {
-	return "DATABASE"
+func (a *AlterTableAlterColumn) AlterType() string {
+	return "ALTER_COLUMN"
 }
 
-func (c *CreateDatabase) String() string {
+func (a *AlterTableAlterColumn) String() string {
 	var builder strings.Builder
-	builder.WriteString("CREATE DATABASE ")
-	if c.IfNotExists {
-		builder.WriteString("IF NOT EXISTS ")
-	}
-	builder.WriteString(c.Name.String())
-	if c.OnCluster != nil {
-		builder.WriteString(" ")
-		builder.WriteString(c.OnCluster.String())
-	}
-	if c.Engine != nil {
-		builder.WriteString(" ")
-		builder.WriteString(c.Engine.String())
-	}
-	if c.Comment != nil {
-		builder.WriteString(" COMMENT ")
-		builder.WriteString(c.Comment.String())
+	builder.WriteString("ALTER COLUMN ")
+	builder.WriteString(a.Column.String())
+	if a.DropDefault {
+		builder.WriteString(" DROP DEFAULT")
+	} else {
+		builder.WriteString(" SET DEFAULT ")
+		builder.WriteString(a.Default.String())
 	}
 	return builder.String()
 }
 
-func (c *CreateDatabase) Accept(visitor ASTVisitor) error {
-	visitor.Enter(c)
-	defer visitor.Leave(c)
-	if c.OnCluster != nil {
-		if err := c.OnCluster.Accept(visitor); err != nil {
-			return err
-		}
+func (a *AlterTableAlterColumn) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Column.Accept(visitor); err != nil {
+		return err
 	}
-	if c.Engine != nil {
-		if err := c.Engine.Accept(visitor); err != nil {
+	if a.Default != nil {
+		if err := a.Default.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitCreateDatabase(c)
+	return visitor.VisitAlterTableAlterColumn(a)
 }
 
-type TableOption struct {
-	OptionPos Pos
-	Name      *Ident
-	Value     Expr
-	HasEquals bool
+type AlterTableDropPrimaryKey struct {
+	DropPos Pos
+	KeyEnd  Pos
 }
 
-func (t *TableOption) Start() Pos {
-	return t.OptionPos
+func (a *AlterTableDropPrimaryKey) Start() Pos {
+	return a.DropPos
+}
+
+func (a *AlterTableDropPrimaryKey) End() Pos {
+	return a.KeyEnd
+}
+
+func (a *AlterTableDropPrimaryKey) AlterType() string {
+	return "DROP_PRIMARY_KEY"
+}
+
+func (a *AlterTableDropPrimaryKey) String() string {
+	return "DROP PRIMARY KEY"
+}
+
+func (a *AlterTableDropPrimaryKey) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	return visitor.VisitAlterTableDropPrimaryKey(a)
+}
+
+type AlterTableRenameTable struct {
+	RenamePos Pos
+	NewName   *TableIdentifier
+}
+
+func (a *AlterTableRenameTable) Start() Pos {
+	return a.RenamePos
+}
+
+func (a *AlterTableRenameTable) End() Pos {
+	return a.NewName.End()
+}
+
+func (a *AlterTableRenameTable) AlterType() string {
+	return "RENAME_TABLE"
+}
+
+func (a *AlterTableRenameTable) String() string {
+	var builder strings.Builder
+	builder.WriteString("RENAME TO ")
+	builder.WriteString(a.NewName.String())
+	return builder.String()
+}
+
+func (a *AlterTableRenameTable) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.NewName.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableRenameTable(a)
+}
+
+type AlterTableRenameIndex struct {
+	RenamePos    Pos
+	OldIndexName *NestedIdentifier
+	NewIndexName *NestedIdentifier
+}
+
+func (a *AlterTableRenameIndex) Start() Pos {
+	return a.RenamePos
+}
+
+func (a *AlterTableRenameIndex) End() Pos {
+	return a.NewIndexName.End()
+}
+
+func (a *AlterTableRenameIndex) AlterType() string {
+	return "RENAME_INDEX"
+}
+
+func (a *AlterTableRenameIndex) String() string {
+	var builder strings.Builder
+	builder.WriteString("RENAME INDEX ")
+	builder.WriteString(a.OldIndexName.String())
+	builder.WriteString(" TO ")
+	builder.WriteString(a.NewIndexName.String())
+	return builder.String()
+}
+
+func (a *AlterTableRenameIndex) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.OldIndexName.Accept(visitor); err != nil {
+		return err
+	}
+	if err := a.NewIndexName.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableRenameIndex(a)
+}
+
+type AlterTableOption struct {
+	Option *TableOption
+}
+
+func (a *AlterTableOption) Start() Pos {
+	return a.Option.Start()
+}
+
+func (a *AlterTableOption) End() Pos {
+	return a.Option.End()
+}
+
+func (a *AlterTableOption) AlterType() string {
+	return "TABLE_OPTION"
+}
+
+func (a *AlterTableOption) String() string {
+	return a.Option.String()
+}
+
+func (a *AlterTableOption) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Option.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableOption(a)
+}
+
+type AlterTableConvertCharset struct {
+	ConvertPos   Pos
+	StatementEnd Pos
+	Charset      *Ident
+	Collate      *Ident
+}
+
+func (a *AlterTableConvertCharset) Start() Pos {
+	return a.ConvertPos
+}
+
+func (a *AlterTableConvertCharset) End() Pos {
+	return a.StatementEnd
+}
+
+func (a *AlterTableConvertCharset) AlterType() string {
+	return "CONVERT_CHARSET"
+}
+
+func (a *AlterTableConvertCharset) String() string {
+	var builder strings.Builder
+	builder.WriteString("CONVERT TO CHARACTER SET ")
+	builder.WriteString(a.Charset.String())
+	if a.Collate != nil {
+		builder.WriteString(" COLLATE ")
+		builder.WriteString(a.Collate.String())
+	}
+	return builder.String()
+}
+
+func (a *AlterTableConvertCharset) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Charset.Accept(visitor); err != nil {
+		return err
+	}
+	if a.Collate != nil {
+		if err := a.Collate.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitAlterTableConvertCharset(a)
+}
+
+// AlterTableAlgorithm is the MySQL `ALGORITHM = DEFAULT|INSTANT|INPLACE|COPY` clause.
+type AlterTableAlgorithm struct {
+	AlgorithmPos Pos
+	Algorithm    *Ident
+}
+
+func (a *AlterTableAlgorithm) Start() Pos {
+	return a.AlgorithmPos
+}
+
+func (a *AlterTableAlgorithm) End() Pos {
+	return a.Algorithm.End()
+}
+
+func (a *AlterTableAlgorithm) AlterType() string {
+	return "ALGORITHM"
+}
+
+func (a *AlterTableAlgorithm) String() string {
+	var builder strings.Builder
+	builder.WriteString("ALGORITHM = ")
+	builder.WriteString(strings.ToUpper(a.Algorithm.Name))
+	return builder.String()
+}
+
+func (a *AlterTableAlgorithm) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Algorithm.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableAlgorithm(a)
+}
+
+// AlterTableLock is the MySQL `LOCK = DEFAULT|NONE|SHARED|EXCLUSIVE` clause.
+type AlterTableLock struct {
+	LockPos Pos
+	Lock    *Ident
+}
+
+func (a *AlterTableLock) Start() Pos {
+	return a.LockPos
+}
+
+func (a *AlterTableLock) End() Pos {
+	return a.Lock.End()
+}
+
+func (a *AlterTableLock) AlterType() string {
+	return "LOCK"
+}
+
+func (a *AlterTableLock) String() string {
+	var builder strings.Builder
+	builder.WriteString("LOCK = ")
+	builder.WriteString(strings.ToUpper(a.Lock.Name))
+	return builder.String()
+}
+
+func (a *AlterTableLock) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Lock.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableLock(a)
+}
+
+// AlterTableKeys is the MySQL `DISABLE KEYS` and `ENABLE KEYS` clause, as written by mysqldump.
+type AlterTableKeys struct {
+	KeysPos      Pos
+	StatementEnd Pos
+	Enable       bool
+}
+
+func (a *AlterTableKeys) Start() Pos {
+	return a.KeysPos
+}
+
+func (a *AlterTableKeys) End() Pos {
+	return a.StatementEnd
+}
+
+func (a *AlterTableKeys) AlterType() string {
+	return "KEYS"
+}
+
+func (a *AlterTableKeys) String() string {
+	if a.Enable {
+		return "ENABLE KEYS"
+	}
+	return "DISABLE KEYS"
+}
+
+func (a *AlterTableKeys) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	return visitor.VisitAlterTableKeys(a)
+}
+
+type Assignment struct {
+	Column *NestedIdentifier
+	Expr   Expr
+}
+
+func (a *Assignment) Start() Pos {
+	return a.Column.Start()
+}
+
+func (a *Assignment) End() Pos {
+	return a.Expr.End()
+}
+
+func (a *Assignment) String() string {
+	var builder strings.Builder
+	builder.WriteString(a.Column.String())
+	builder.WriteString(" = ")
+	builder.WriteString(a.Expr.String())
+	return builder.String()
+}
+
+func (a *Assignment) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Column.Accept(visitor); err != nil {
+		return err
+	}
+	if err := a.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAssignment(a)
+}
+
+type AlterTableUpdate struct {
+	UpdatePos   Pos
+	Assignments []*Assignment
+	InPartition *PartitionClause
+	Where       *WhereClause
+}
+
+func (a *AlterTableUpdate) Start() Pos {
+	return a.UpdatePos
+}
+
+func (a *AlterTableUpdate) End() Pos {
+	return a.Where.End()
+}
+
+func (a *AlterTableUpdate) AlterType() string {
+	return "UPDATE"
+}
+
+func (a *AlterTableUpdate) String() string {
+	var builder strings.Builder
+	builder.WriteString("UPDATE ")
+	for i, assignment := range a.Assignments {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(assignment.String())
+	}
+	if a.InPartition != nil {
+		builder.WriteString(" IN ")
+		builder.WriteString(a.InPartition.String())
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(a.Where.String())
+	return builder.String()
+}
+
+func (a *AlterTableUpdate) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	for _, assignment := range a.Assignments {
+		if err := assignment.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if a.InPartition != nil {
+		if err := a.InPartition.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := a.Where.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableUpdate(a)
+}
+
+type AlterTableDelete struct {
+	DeletePos   Pos
+	InPartition *PartitionClause
+	Where       *WhereClause
+}
+
+func (a *AlterTableDelete) Start() Pos {
+	return a.DeletePos
+}
+
+func (a *AlterTableDelete) End() Pos {
+	return a.Where.End()
+}
+
+func (a *AlterTableDelete) AlterType() string {
+	return "DELETE"
+}
+
+func (a *AlterTableDelete) String() string {
+	var builder strings.Builder
+	builder.WriteString("DELETE ")
+	if a.InPartition != nil {
+		builder.WriteString("IN ")
+		builder.WriteString(a.InPartition.String())
+		builder.WriteByte(' ')
+	}
+	builder.WriteString(a.Where.String())
+	return builder.String()
+}
+
+func (a *AlterTableDelete) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if a.InPartition != nil {
+		if err := a.InPartition.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := a.Where.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableDelete(a)
+}
+
+type AlterTableMovePartition struct {
+	MovePos      Pos
+	StatementEnd Pos
+	Partition    *PartitionClause
+	ToDisk       *StringLiteral
+	ToVolume     *StringLiteral
+	ToTable      *TableIdentifier
+}
+
+func (a *AlterTableMovePartition) Start() Pos {
+	return a.MovePos
+}
+
+func (a *AlterTableMovePartition) End() Pos {
+	return a.StatementEnd
+}
+
+func (a *AlterTableMovePartition) AlterType() string {
+	return "MOVE_PARTITION"
+}
+
+func (a *AlterTableMovePartition) String() string {
+	var builder strings.Builder
+	builder.WriteString("MOVE ")
+	builder.WriteString(a.Partition.String())
+	builder.WriteString(" TO ")
+	switch {
+	case a.ToDisk != nil:
+		builder.WriteString("DISK ")
+		builder.WriteString(a.ToDisk.String())
+	case a.ToVolume != nil:
+		builder.WriteString("VOLUME ")
+		builder.WriteString(a.ToVolume.String())
+	case a.ToTable != nil:
+		builder.WriteString("TABLE ")
+		builder.WriteString(a.ToTable.String())
+	}
+	return builder.String()
+}
+
+func (a *AlterTableMovePartition) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Partition.Accept(visitor); err != nil {
+		return err
+	}
+	if a.ToDisk != nil {
+		if err := a.ToDisk.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if a.ToVolume != nil {
+		if err := a.ToVolume.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if a.ToTable != nil {
+		if err := a.ToTable.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitAlterTableMovePartition(a)
+}
+
+type AlterTableFetchPartition struct {
+	FetchPos  Pos
+	Partition *PartitionClause
+	From      *StringLiteral
+}
+
+func (a *AlterTableFetchPartition) Start() Pos {
+	return a.FetchPos
+}
+
+func (a *AlterTableFetchPartition) End() Pos {
+	return a.From.End()
+}
+
+func (a *AlterTableFetchPartition) AlterType() string {
+	return "FETCH_PARTITION"
+}
+
+func (a *AlterTableFetchPartition) String() string {
+	var builder strings.Builder
+	builder.WriteString("FETCH ")
+	builder.WriteString(a.Partition.String())
+	builder.WriteString(" FROM ")
+	builder.WriteString(a.From.String())
+	return builder.String()
+}
+
+func (a *AlterTableFetchPartition) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Partition.Accept(visitor); err != nil {
+		return err
+	}
+	if err := a.From.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableFetchPartition(a)
+}
+
+type AlterTableModifySetting struct {
+	ModifyPos    Pos
+	StatementEnd Pos
+	Settings     []*SettingExprList
+}
+
+func (a *AlterTableModifySetting) Start() Pos {
+	return a.ModifyPos
+}
+
+func (a *AlterTableModifySetting) End() Pos {
+	return a.StatementEnd
+}
+
+func (a *AlterTableModifySetting) AlterType() string {
+	return "MODIFY_SETTING"
+}
+
+func (a *AlterTableModifySetting) String() string {
+	var builder strings.Builder
+	builder.WriteString("MODIFY SETTING ")
+	for i, setting := range a.Settings {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(setting.String())
+	}
+	return builder.String()
+}
+
+func (a *AlterTableModifySetting) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	for _, setting := range a.Settings {
+		if err := setting.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitAlterTableModifySetting(a)
+}
+
+type AlterTableResetSetting struct {
+	ResetPos     Pos
+	StatementEnd Pos
+	Settings     []*Ident
+}
+
+func (a *AlterTableResetSetting) Start() Pos {
+	return a.ResetPos
+}
+
+func (a *AlterTableResetSetting) End() Pos {
+	return a.StatementEnd
+}
+
+func (a *AlterTableResetSetting) AlterType() string {
+	return "RESET_SETTING"
+}
+
+func (a *AlterTableResetSetting) String() string {
+	var builder strings.Builder
+	builder.WriteString("RESET SETTING ")
+	for i, setting := range a.Settings {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(setting.String())
+	}
+	return builder.String()
+}
+
+func (a *AlterTableResetSetting) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	for _, setting := range a.Settings {
+		if err := setting.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitAlterTableResetSetting(a)
+}
+
+type AlterTableModifyOrderBy struct {
+	ModifyPos Pos
+	Expr      Expr
+}
+
+func (a *AlterTableModifyOrderBy) Start() Pos {
+	return a.ModifyPos
+}
+
+func (a *AlterTableModifyOrderBy) End() Pos {
+	return a.Expr.End()
+}
+
+func (a *AlterTableModifyOrderBy) AlterType() string {
+	return "MODIFY_ORDER_BY"
+}
+
+func (a *AlterTableModifyOrderBy) String() string {
+	var builder strings.Builder
+	builder.WriteString("MODIFY ORDER BY ")
+	builder.WriteString(a.Expr.String())
+	return builder.String()
+}
+
+func (a *AlterTableModifyOrderBy) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableModifyOrderBy(a)
+}
+
+type AlterTableModifyComment struct {
+	ModifyPos Pos
+	Comment   *StringLiteral
+}
+
+func (a *AlterTableModifyComment) Start() Pos {
+	return a.ModifyPos
+}
+
+func (a *AlterTableModifyComment) End() Pos {
+	return a.Comment.End()
+}
+
+func (a *AlterTableModifyComment) AlterType() string {
+	return "MODIFY_COMMENT"
+}
+
+func (a *AlterTableModifyComment) String() string {
+	var builder strings.Builder
+	builder.WriteString("MODIFY COMMENT ")
+	builder.WriteString(a.Comment.String())
+	return builder.String()
+}
+
+func (a *AlterTableModifyComment) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Comment.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitAlterTableModifyComment(a)
+}
+
+type AlterTableApplyDeletedMask struct {
+	ApplyPos     Pos
+	StatementEnd Pos
+	InPartition  *PartitionClause
+}
+
+func (a *AlterTableApplyDeletedMask) Start() Pos {
+	return a.ApplyPos
+}
+
+func (a *AlterTableApplyDeletedMask) End() Pos {
+	return a.StatementEnd
+}
+
+func (a *AlterTableApplyDeletedMask) AlterType() string {
+	return "APPLY_DELETED_MASK"
+}
+
+func (a *AlterTableApplyDeletedMask) String() string {
+	var builder strings.Builder
+	builder.WriteString("APPLY DELETED MASK")
+	if a.InPartition != nil {
+		builder.WriteString(" IN ")
+		builder.WriteString(a.InPartition.String())
+	}
+	return builder.String()
+}
+
+func (a *AlterTableApplyDeletedMask) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if a.InPartition != nil {
+		if err := a.InPartition.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitAlterTableApplyDeletedMask(a)
+}
+
+type RemovePropertyType struct {
+	RemovePos Pos
+
+	PropertyType Expr
+}
+
+func (a *RemovePropertyType) Start() Pos {
+	return a.RemovePos
+}
+
+func (a *RemovePropertyType) End() Pos {
+	return a.PropertyType.End()
+}
+
+func (a *RemovePropertyType) String() string {
+	var builder strings.Builder
+	builder.WriteString(" REMOVE ")
+	builder.WriteString(a.PropertyType.String())
+	return builder.String()
+}
+
+func (a *RemovePropertyType) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.PropertyType.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitRemovePropertyType(a)
+}
+
+type TableIndex struct {
+	IndexPos Pos
+
+	// Kind is the MySQL FULLTEXT or SPATIAL, empty for a plain index.
+	Kind        string
+	Name        *NestedIdentifier
+	Using       *Ident
+	ColumnExpr  *ColumnExpr
+	ColumnType  Expr
+	Granularity *NumberLiteral
+}
+
+func (a *TableIndex) Start() Pos {
+	return a.IndexPos
+}
+
+func (a *TableIndex) End() Pos {
+	switch {
+	case a.Granularity != nil:
+		return a.Granularity.End()
+	case a.ColumnType != nil:
+		return a.ColumnType.End()
+	case a.Using != nil && a.Using.End() > a.ColumnExpr.End():
+		return a.Using.End()
+	default:
+		return a.ColumnExpr.End()
+	}
+}
+
+func (a *TableIndex) String() string {
+	var builder strings.Builder
+	if a.Kind != "" {
+		builder.WriteString(a.Kind)
+		builder.WriteByte(' ')
+	}
+	builder.WriteString("INDEX")
+	if a.Name != nil {
+		builder.WriteByte(' ')
+		builder.WriteString(a.Name.String())
+	}
+	if a.ColumnExpr != nil {
+		if _, ok := a.ColumnExpr.Expr.(*Ident); ok {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(a.ColumnExpr.String())
+	}
+	if a.Using != nil {
+		builder.WriteString(" USING ")
+		builder.WriteString(a.Using.String())
+	}
+	if a.ColumnType != nil {
+		builder.WriteByte(' ')
+		builder.WriteString("TYPE")
+		builder.WriteByte(' ')
+		builder.WriteString(a.ColumnType.String())
+	}
+	if a.Granularity != nil {
+		builder.WriteByte(' ')
+		builder.WriteString("GRANULARITY")
+		builder.WriteByte(' ')
+		builder.WriteString(a.Granularity.String())
+	}
+	return builder.String()
+}
+
+func (a *TableIndex) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if err := a.ColumnExpr.Accept(visitor); err != nil {
+		return err
+	}
+	if a.Using != nil {
+		if err := a.Using.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if a.ColumnType != nil {
+		if err := a.ColumnType.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if a.Granularity != nil {
+		if err := a.Granularity.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitTableIndex(a)
+}
+
+type Ident struct {
+	Name      string
+	QuoteType int
+	start     Pos
+	end       Pos
+}
+
+func (i *Ident) Start() Pos {
+	return i.start
+}
+
+func (i *Ident) End() Pos {
+	return i.end
+}
+
+func (i *Ident) String() string {
+	switch i.QuoteType {
+	case BackTicks:
+		return "`" + strings.ReplaceAll(i.Name, "`", "``") + "`"
+	case DoubleQuote:
+		return `"` + strings.ReplaceAll(i.Name, `"`, `""`) + `"`
+	}
+	return i.Name
+}
+
+func (i *Ident) Accept(visitor ASTVisitor) error {
+	visitor.Enter(i)
+	defer visitor.Leave(i)
+	return visitor.VisitIdent(i)
+}
+
+type UUID struct {
+	Value *StringLiteral
+}
+
+func (u *UUID) Start() Pos {
+	return u.Value.LiteralPos
+}
+
+func (u *UUID) End() Pos {
+	return u.Value.LiteralEnd
+}
+
+func (u *UUID) String() string {
+	return "UUID " + u.Value.String()
+}
+
+func (u *UUID) Accept(visitor ASTVisitor) error {
+	visitor.Enter(u)
+	defer visitor.Leave(u)
+	return visitor.VisitUUID(u)
+}
+
+type CreateDatabase struct {
+	CreatePos    Pos // position of CREATE keyword
+	StatementEnd Pos
+	Name         Expr
+	IfNotExists  bool // true if 'IF NOT EXISTS' is specified
+	OnCluster    *ClusterClause
+	Engine       *EngineExpr
+	Comment      *StringLiteral
+}
+
+func (c *CreateDatabase) Start() Pos {
+	return c.CreatePos
+}
+
+func (c *CreateDatabase) End() Pos {
+	return c.StatementEnd
+}
+
+func (c *CreateDatabase) Type() string {
+	return "DATABASE"
+}
+
+func (c *CreateDatabase) String() string {
+	var builder strings.Builder
+	builder.WriteString("CREATE DATABASE ")
+	if c.IfNotExists {
+		builder.WriteString("IF NOT EXISTS ")
+	}
+	builder.WriteString(c.Name.String())
+	if c.OnCluster != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.OnCluster.String())
+	}
+	if c.Engine != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Engine.String())
+	}
+	if c.Comment != nil {
+		builder.WriteString(" COMMENT ")
+		builder.WriteString(c.Comment.String())
+	}
+	return builder.String()
+}
+
+func (c *CreateDatabase) Accept(visitor ASTVisitor) error {
+	visitor.Enter(c)
+	defer visitor.Leave(c)
+	if c.OnCluster != nil {
+		if err := c.OnCluster.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Engine != nil {
+		if err := c.Engine.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCreateDatabase(c)
+}
+
+type CreateDictionary struct {
+	CreatePos    Pos // position of CREATE keyword
+	StatementEnd Pos
+	OrReplace    bool
+	IfNotExists  bool
+	Name         *TableIdentifier
+	OnCluster    *ClusterClause
+	Attributes   []*DictionaryAttribute
+	PrimaryKey   *PrimaryKeyClause
+	Source       *DictionarySourceClause
+	Layout       *DictionaryLayoutClause
+	Lifetime     *DictionaryLifetimeClause
+	Range        *DictionaryRangeClause
+	Settings     *SettingsClause
+	Comment      *StringLiteral
+}
+
+func (c *CreateDictionary) Start() Pos {
+	return c.CreatePos
+}
+
+func (c *CreateDictionary) End() Pos {
+	return c.StatementEnd
+}
+
+func (c *CreateDictionary) Type() string {
+	return "DICTIONARY"
+}
+
+func (c *CreateDictionary) String() string {
+	var builder strings.Builder
+	builder.WriteString("CREATE ")
+	if c.OrReplace {
+		builder.WriteString("OR REPLACE ")
+	}
+	builder.WriteString("DICTIONARY ")
+	if c.IfNotExists {
+		builder.WriteString("IF NOT EXISTS ")
+	}
+	builder.WriteString(c.Name.String())
+	if c.OnCluster != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.OnCluster.String())
+	}
+	builder.WriteString(" (")
+	for i, attribute := range c.Attributes {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(attribute.String())
+	}
+	builder.WriteString(")")
+	if c.PrimaryKey != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.PrimaryKey.String())
+	}
+	if c.Source != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Source.String())
+	}
+	if c.Layout != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Layout.String())
+	}
+	if c.Lifetime != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Lifetime.String())
+	}
+	if c.Range != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Range.String())
+	}
+	if c.Settings != nil {
+		builder.WriteString(" SETTINGS(")
+		for i, item := range c.Settings.Items {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(item.String())
+		}
+		builder.WriteString(")")
+	}
+	if c.Comment != nil {
+		builder.WriteString(" COMMENT ")
+		builder.WriteString(c.Comment.String())
+	}
+	return builder.String()
+}
+
+func (c *CreateDictionary) Accept(visitor ASTVisitor) error {
+	visitor.Enter(c)
+	defer visitor.Leave(c)
+	if err := c.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if c.OnCluster != nil {
+		if err := c.OnCluster.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, attribute := range c.Attributes {
+		if err := attribute.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.PrimaryKey != nil {
+		if err := c.PrimaryKey.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Source != nil {
+		if err := c.Source.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Layout != nil {
+		if err := c.Layout.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Lifetime != nil {
+		if err := c.Lifetime.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Range != nil {
+		if err := c.Range.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Settings != nil {
+		if err := c.Settings.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Comment != nil {
+		if err := c.Comment.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCreateDictionary(c)
+}
+
+// DictionaryAttribute is a key or attribute column of a dictionary.
+type DictionaryAttribute struct {
+	Name         *Ident
+	AttributeEnd Pos
+	Type         ColumnType
+	Default      Expr
+	Expression   Expr
+	Hierarchical bool
+	Injective    bool
+	IsObjectID   bool
+}
+
+func (d *DictionaryAttribute) Start() Pos {
+	return d.Name.Start()
+}
+
+func (d *DictionaryAttribute) End() Pos {
+	return d.AttributeEnd
+}
+
+func (d *DictionaryAttribute) String() string {
+	var builder strings.Builder
+	builder.WriteString(d.Name.String())
+	builder.WriteByte(' ')
+	builder.WriteString(d.Type.String())
+	if d.Default != nil {
+		builder.WriteString(" DEFAULT ")
+		builder.WriteString(d.Default.String())
+	}
+	if d.Expression != nil {
+		builder.WriteString(" EXPRESSION ")
+		builder.WriteString(d.Expression.String())
+	}
+	if d.Hierarchical {
+		builder.WriteString(" HIERARCHICAL")
+	}
+	if d.Injective {
+		builder.WriteString(" INJECTIVE")
+	}
+	if d.IsObjectID {
+		builder.WriteString(" IS_OBJECT_ID")
+	}
+	return builder.String()
+}
+
+func (d *DictionaryAttribute) Accept(visitor ASTVisitor) error {
+	visitor.Enter(d)
+	defer visitor.Leave(d)
+	if err := d.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if err := d.Type.Accept(visitor); err != nil {
+		return err
+	}
+	if d.Default != nil {
+		if err := d.Default.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if d.Expression != nil {
+		if err := d.Expression.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitDictionaryAttribute(d)
+}
+
+// DictionaryArg is a `name value` parameter of a dictionary SOURCE or LAYOUT, or a nested
+// `name(...)` list of parameters such as a replica of a MYSQL source when Value is nil.
+type DictionaryArg struct {
+	Name      *Ident
+	Value     Expr
+	Args      []*DictionaryArg
+	RParenPos Pos
+}
+
+func (d *DictionaryArg) Start() Pos {
+	return d.Name.Start()
+}
+
+func (d *DictionaryArg) End() Pos {
+	if d.Value == nil {
+		return d.RParenPos
+	}
+	return d.Value.End()
+}
+
+func (d *DictionaryArg) String() string {
+	if d.Value == nil {
+		var builder strings.Builder
+		writeDictionaryArgs(&builder, d.Name, d.Args)
+		return builder.String()
+	}
+	return d.Name.String() + " " + d.Value.String()
+}
+
+func (d *DictionaryArg) Accept(visitor ASTVisitor) error {
+	visitor.Enter(d)
+	defer visitor.Leave(d)
+	if err := d.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if d.Value != nil {
+		if err := d.Value.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, arg := range d.Args {
+		if err := arg.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitDictionaryArg(d)
+}
+
+func writeDictionaryArgs(builder *strings.Builder, name *Ident, args []*DictionaryArg) {
+	builder.WriteString(name.String())
+	builder.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(arg.String())
+	}
+	builder.WriteByte(')')
+}
+
+type DictionarySourceClause struct {
+	SourcePos Pos
+	RParenPos Pos
+	Source    *Ident
+	Args      []*DictionaryArg
+}
+
+func (d *DictionarySourceClause) Start() Pos {
+	return d.SourcePos
+}
+
+func (d *DictionarySourceClause) End() Pos {
+	return d.RParenPos
+}
+
+func (d *DictionarySourceClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("SOURCE(")
+	writeDictionaryArgs(&builder, d.Source, d.Args)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+func (d *DictionarySourceClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(d)
+	defer visitor.Leave(d)
+	if err := d.Source.Accept(visitor); err != nil {
+		return err
+	}
+	for _, arg := range d.Args {
+		if err := arg.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitDictionarySourceClause(d)
+}
+
+type DictionaryLayoutClause struct {
+	LayoutPos Pos
+	RParenPos Pos
+	Layout    *Ident
+	Args      []*DictionaryArg
+}
+
+func (d *DictionaryLayoutClause) Start() Pos {
+	return d.LayoutPos
+}
+
+func (d *DictionaryLayoutClause) End() Pos {
+	return d.RParenPos
+}
+
+func (d *DictionaryLayoutClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("LAYOUT(")
+	writeDictionaryArgs(&builder, d.Layout, d.Args)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+func (d *DictionaryLayoutClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(d)
+	defer visitor.Leave(d)
+	if err := d.Layout.Accept(visitor); err != nil {
+		return err
+	}
+	for _, arg := range d.Args {
+		if err := arg.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitDictionaryLayoutClause(d)
+}
+
+// DictionaryLifetimeClause is `LIFETIME(MIN a MAX b)`, or `LIFETIME(b)` when Min is nil.
+type DictionaryLifetimeClause struct {
+	LifetimePos Pos
+	RParenPos   Pos
+	Min         *NumberLiteral
+	Max         *NumberLiteral
+}
+
+func (d *DictionaryLifetimeClause) Start() Pos {
+	return d.LifetimePos
+}
+
+func (d *DictionaryLifetimeClause) End() Pos {
+	return d.RParenPos
+}
+
+func (d *DictionaryLifetimeClause) String() string {
+	if d.Min == nil {
+		return "LIFETIME(" + d.Max.String() + ")"
+	}
+	return "LIFETIME(MIN " + d.Min.String() + " MAX " + d.Max.String() + ")"
+}
+
+func (d *DictionaryLifetimeClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(d)
+	defer visitor.Leave(d)
+	if d.Min != nil {
+		if err := d.Min.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := d.Max.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitDictionaryLifetimeClause(d)
+}
+
+// DictionaryRangeClause names the columns bounding a RANGE_HASHED dictionary.
+type DictionaryRangeClause struct {
+	RangePos  Pos
+	RParenPos Pos
+	Min       *Ident
+	Max       *Ident
+}
+
+func (d *DictionaryRangeClause) Start() Pos {
+	return d.RangePos
+}
+
+func (d *DictionaryRangeClause) End() Pos {
+	return d.RParenPos
+}
+
+func (d *DictionaryRangeClause) String() string {
+	return "RANGE(MIN " + d.Min.String() + " MAX " + d.Max.String() + ")"
+}
+
+func (d *DictionaryRangeClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(d)
+	defer visitor.Leave(d)
+	if err := d.Min.Accept(visitor); err != nil {
+		return err
+	}
+	if err := d.Max.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitDictionaryRangeClause(d)
+}
+
+type TableOption struct {
+	OptionPos Pos
+	Name      *Ident
+	Value     Expr
+	HasEquals bool
+}
+
+func (t *TableOption) Start() Pos {
+	return t.OptionPos
+}
+
+func (t *TableOption) End() Pos {
+	if t.Value != nil {
+		return t.Value.End()
+	}
+	return t.Name.End()
+}
+
+func (t *TableOption) String() string {
+	var builder strings.Builder
+	if t.Name != nil {
+		builder.WriteString(t.Name.String())
+	}
+	if t.Value != nil {
+		if t.HasEquals {
+			builder.WriteString(" = ")
+		} else {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(t.Value.String())
+	}
+	return builder.String()
+}
+
+func (t *TableOption) Accept(visitor ASTVisitor) error {
+	visitor.Enter(t)
+	defer visitor.Leave(t)
+	if err := t.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if t.Value != nil {
+		if err := t.Value.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitTableOption(t)
+}
+
+type CreateTable struct {
+	CreatePos    Pos // position of CREATE|ATTACH keyword
+	StatementEnd Pos
+	OrReplace    bool
+	Identifier   *TableIdentifier
+	IfNotExists  bool
+	UUID         *UUID
+	OnCluster    *ClusterClause
+	TableSchema  *SchemaClause
+	SubQuery     *SubQuery
+	HasTemporary bool
+	Inherits     []*TableIdentifier
+	PartitionBy  *PartitionByClause
+	TableOptions []*TableOption
+	Format       *FormatClause
+}
+
+func (c *CreateTable) Start() Pos {
+	return c.CreatePos
+}
+
+func (c *CreateTable) End() Pos {
+	return c.StatementEnd
+}
+
+func (c *CreateTable) Type() string {
+	return "CREATE TABLE"
+}
+
+func (c *CreateTable) GetFormat() *FormatClause {
+	return c.Format
+}
+
+func (c *CreateTable) setFormat(format *FormatClause) {
+	c.Format = format
+	c.StatementEnd = format.End()
+}
+
+func (c *CreateTable) String() string {
+	var builder strings.Builder
+	builder.WriteString("CREATE")
+	if c.OrReplace {
+		builder.WriteString(" OR REPLACE")
+	}
+	if c.HasTemporary {
+		builder.WriteString(" TEMPORARY")
+	}
+	builder.WriteString(" TABLE ")
+	if c.IfNotExists {
+		builder.WriteString("IF NOT EXISTS ")
+	}
+	if c.Identifier != nil {
+		builder.WriteString(c.Identifier.String())
+	}
+	if c.UUID != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.UUID.String())
+	}
+	if c.OnCluster != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.OnCluster.String())
+	}
+	if c.TableSchema != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.TableSchema.String())
+	}
+	if len(c.Inherits) > 0 {
+		builder.WriteString(" INHERITS (")
+		for i, parent := range c.Inherits {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(parent.String())
+		}
+		builder.WriteString(")")
+	}
+	if c.PartitionBy != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.PartitionBy.String())
+	}
+	for _, opt := range c.TableOptions {
+		builder.WriteString(" ")
+		builder.WriteString(opt.String())
+	}
+	if c.SubQuery != nil {
+		builder.WriteString(" AS ")
+		builder.WriteString(c.SubQuery.String())
+	}
+	if c.Format != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Format.String())
+	}
+	return builder.String()
+}
+
+func (c *CreateTable) Accept(visitor ASTVisitor) error {
+	visitor.Enter(c)
+	defer visitor.Leave(c)
+	if err := c.Identifier.Accept(visitor); err != nil {
+		return err
+	}
+	if c.UUID != nil {
+		if err := c.UUID.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.OnCluster != nil {
+		if err := c.OnCluster.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.TableSchema != nil {
+		if err := c.TableSchema.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, parent := range c.Inherits {
+		if err := parent.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.PartitionBy != nil {
+		if err := c.PartitionBy.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, opt := range c.TableOptions {
+		if err := opt.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.SubQuery != nil {
+		if err := c.SubQuery.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Format != nil {
+		if err := c.Format.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCreateTable(c)
+}
+
+func (c *CreateTable) GetComment() string {
+	options := c.TableOptions
+	for _, v := range options {
+		if v.Name.Name == "COMMENT" {
+			if comment, ok := v.Value.(*StringLiteral); ok {
+				return comment.Literal
+			}
+		}
+	}
+	return ""
+}
+
+type CreateMaterializedView struct {
+	CreatePos    Pos // position of CREATE|ATTACH keyword
+	StatementEnd Pos
+	Name         *TableIdentifier
+	IfNotExists  bool
+	OnCluster    *ClusterClause
+	Refresh      *RefreshExpr
+	RandomizeFor *IntervalExpr
+	DependsOn    []*TableIdentifier
+	Settings     *SettingsClause
+	HasAppend    bool
+	Engine       *EngineExpr
+	HasEmpty     bool
+	Destination  *DestinationClause
+	SubQuery     *SubQuery
+	Populate     bool
+	Comment      *StringLiteral
+	Definer      *Ident
+	SQLSecurity  string
+}
+
+func (c *CreateMaterializedView) Start() Pos {
+	return c.CreatePos
+}
+
+func (c *CreateMaterializedView) End() Pos {
+	return c.StatementEnd
+}
+
+func (c *CreateMaterializedView) Type() string {
+	return "MATERIALIZED_VIEW"
+}
+
+func (c *CreateMaterializedView) String() string {
+	var builder strings.Builder
+	builder.WriteString("CREATE MATERIALIZED VIEW ")
+	if c.IfNotExists {
+		builder.WriteString("IF NOT EXISTS ")
+	}
+	builder.WriteString(c.Name.String())
+	if c.OnCluster != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.OnCluster.String())
+	}
+	if c.Refresh != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Refresh.String())
+	}
+	if c.RandomizeFor != nil {
+		builder.WriteString(" RANDOMIZE FOR ")
+		builder.WriteString(c.RandomizeFor.String())
+	}
+	if c.DependsOn != nil {
+		builder.WriteString(" DEPENDS ON ")
+		for i, dep := range c.DependsOn {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(dep.String())
+		}
+	}
+	if c.Settings != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Settings.String())
+	}
+	if c.HasAppend {
+		builder.WriteString(" APPEND")
+	}
+	if c.Engine != nil {
+		builder.WriteString(c.Engine.String())
+	}
+	if c.Destination != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Destination.String())
+		if c.Destination.TableSchema != nil {
+			builder.WriteString(" ")
+			builder.WriteString(c.Destination.TableSchema.String())
+		}
+	}
+	if c.HasEmpty {
+		builder.WriteString(" EMPTY")
+	}
+	if c.Definer != nil {
+		builder.WriteString(" DEFINER = ")
+		builder.WriteString(c.Definer.String())
+	}
+	if c.SQLSecurity != "" {
+		builder.WriteString(" SQL SECURITY ")
+		builder.WriteString(c.SQLSecurity)
+	}
+	if c.Populate {
+		builder.WriteString(" POPULATE")
+	}
+	if c.SubQuery != nil {
+		builder.WriteString(" AS ")
+		builder.WriteString(c.SubQuery.String())
+	}
+	if c.Comment != nil {
+		builder.WriteString(" COMMENT ")
+		builder.WriteString(c.Comment.String())
+	}
+	return builder.String()
+}
+
+func (c *CreateMaterializedView) Accept(visitor ASTVisitor) error {
+	visitor.Enter(c)
+	defer visitor.Leave(c)
+	if err := c.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if c.OnCluster != nil {
+		if err := c.OnCluster.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Refresh != nil {
+		if err := c.Refresh.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.RandomizeFor != nil {
+		if err := c.RandomizeFor.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.DependsOn != nil {
+		for _, dep := range c.DependsOn {
+			if err := dep.Accept(visitor); err != nil {
+				return err
+			}
+		}
+	}
+	if c.Settings != nil {
+		if err := c.Settings.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Engine != nil {
+		if err := c.Engine.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Destination != nil {
+		if err := c.Destination.Accept(visitor); err != nil {
+			return err
+		}
+		if c.Destination.TableSchema != nil {
+			if err := c.Destination.TableSchema.Accept(visitor); err != nil {
+				return err
+			}
+		}
+	}
+	if c.SubQuery != nil {
+		if err := c.SubQuery.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Definer != nil {
+		if err := c.Definer.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Comment != nil {
+		if err := c.Comment.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCreateMaterializedView(c)
+}
+
+type CreateView struct {
+	CreatePos    Pos // position of CREATE|ATTACH keyword
+	StatementEnd Pos
+	OrReplace    bool
+	Name         *TableIdentifier
+	IfNotExists  bool
+	UUID         *UUID
+	OnCluster    *ClusterClause
+	TableSchema  *SchemaClause
+	SubQuery     *SubQuery
+}
+
+func (c *CreateView) Start() Pos {
+	return c.CreatePos
+}
+
+func (c *CreateView) End() Pos {
+	return c.StatementEnd
+}
+
+func (c *CreateView) Type() string {
+	return "VIEW"
+}
+
+func (c *CreateView) String() string {
+	var builder strings.Builder
+	builder.WriteString("CREATE")
+	if c.OrReplace {
+		builder.WriteString(" OR REPLACE")
+	}
+	builder.WriteString(" VIEW ")
+	if c.IfNotExists {
+		builder.WriteString("IF NOT EXISTS ")
+	}
+	builder.WriteString(c.Name.String())
+	if c.UUID != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.UUID.String())
+	}
+
+	if c.OnCluster != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.OnCluster.String())
+	}
+
+	if c.TableSchema != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.TableSchema.String())
+	}
+
+	if c.SubQuery != nil {
+		builder.WriteString(" AS ")
+		builder.WriteString(c.SubQuery.String())
+	}
+	return builder.String()
+}
+
+func (c *CreateView) Accept(visitor ASTVisitor) error {
+	visitor.Enter(c)
+	defer visitor.Leave(c)
+	if err := c.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if c.UUID != nil {
+		if err := c.UUID.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.OnCluster != nil {
+		if err := c.OnCluster.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.TableSchema != nil {
+		if err := c.TableSchema.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.SubQuery != nil {
+		if err := c.SubQuery.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCreateView(c)
+}
+
+type CreateFunction struct {
+	CreatePos    Pos
+	OrReplace    bool
+	IfNotExists  bool
+	FunctionName *Ident
+	OnCluster    *ClusterClause
+	Params       *ParamExprList
+	Expr         Expr
+}
+
+func (c *CreateFunction) Type() string {
+	return "FUNCTION"
+}
+
+func (c *CreateFunction) Start() Pos {
+	return c.CreatePos
+}
+
+func (c *CreateFunction) End() Pos {
+	return c.Expr.End()
+}
+
+func (c *CreateFunction) String() string {
+	var builder strings.Builder
+	builder.WriteString("CREATE")
+	if c.OrReplace {
+		builder.WriteString(" OR REPLACE")
+	}
+	builder.WriteString(" FUNCTION ")
+	if c.IfNotExists {
+		builder.WriteString("IF NOT EXISTS ")
+	}
+	builder.WriteString(c.FunctionName.String())
+	if c.OnCluster != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.OnCluster.String())
+	}
+	builder.WriteString(" AS ")
+	builder.WriteString(c.Params.String())
+	builder.WriteString(" -> ")
+	builder.WriteString(c.Expr.String())
+	return builder.String()
+}
+
+func (c *CreateFunction) Accept(visitor ASTVisitor) error {
+	visitor.Enter(c)
+	defer visitor.Leave(c)
+	if err := c.FunctionName.Accept(visitor); err != nil {
+		return err
+	}
+	if c.OnCluster != nil {
+		if err := c.OnCluster.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := c.Params.Accept(visitor); err != nil {
+		return err
+	}
+	if err := c.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitCreateFunction(c)
+}
+
+type RoleName struct {
+	Name      Expr
+	Scope     *StringLiteral
+	OnCluster *ClusterClause
+}
+
+func (r *RoleName) Start() Pos {
+	return r.Name.Start()
+}
+
+func (r *RoleName) End() Pos {
+	if r.Scope != nil {
+		return r.Scope.End()
+	}
+	if r.OnCluster != nil {
+		return r.OnCluster.End()
+	}
+	return r.Name.End()
+}
+
+func (r *RoleName) String() string {
+	var builder strings.Builder
+	builder.WriteString(r.Name.String())
+	if r.Scope != nil {
+		builder.WriteString("@")
+		builder.WriteString(r.Scope.String())
+	}
+	if r.OnCluster != nil {
+		builder.WriteByte(' ')
+		builder.WriteString(r.OnCluster.String())
+	}
+	return builder.String()
+}
+
+func (r *RoleName) Accept(visitor ASTVisitor) error {
+	visitor.Enter(r)
+	defer visitor.Leave(r)
+	if err := r.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if r.Scope != nil {
+		if err := r.Scope.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if r.OnCluster != nil {
+		if err := r.OnCluster.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitRoleName(r)
+}
+
+type SettingPair struct {
+	Name      *Ident
+	Operation TokenKind
+	Value     Expr
+}
+
+func (s *SettingPair) Start() Pos {
+	return s.Name.start
+}
+
+func (s *SettingPair) End() Pos {
+	return s.Value.End()
+}
+
+func (s *SettingPair) String() string {
+	var builder strings.Builder
+	builder.WriteString(s.Name.String())
+	if s.Value != nil {
+		if s.Operation == TokenKindSingleEQ {
+			builder.WriteString(string(s.Operation))
+		} else {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(s.Value.String())
+	}
+	return builder.String()
+}
+
+func (s *SettingPair) Accept(visitor ASTVisitor) error {
+	visitor.Enter(s)
+	defer visitor.Leave(s)
+	if err := s.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if s.Value != nil {
+		if err := s.Value.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitSettingPair(s)
+}
+
+type RoleSetting struct {
+	SettingPairs []*SettingPair
+	Modifier     *Ident
+}
+
+func (r *RoleSetting) Start() Pos {
+	if len(r.SettingPairs) > 0 {
+		return r.SettingPairs[0].Start()
+	}
+	return r.Modifier.start
+}
+
+func (r *RoleSetting) End() Pos {
+	if r.Modifier != nil {
+		return r.Modifier.end
+	}
+	return r.SettingPairs[len(r.SettingPairs)-1].End()
+}
+
+func (r *RoleSetting) String() string {
+	var builder strings.Builder
+	for i, settingPair := range r.SettingPairs {
+		if i > 0 {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(settingPair.String())
+	}
+	if r.Modifier != nil {
+		if len(r.SettingPairs) > 0 {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(r.Modifier.String())
+	}
+	return builder.String()
+}
+
+func (r *RoleSetting) Accept(visitor ASTVisitor) error {
+	visitor.Enter(r)
+	defer visitor.Leave(r)
+	for _, settingPair := range r.SettingPairs {
+		if err := settingPair.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if r.Modifier != nil {
+		if err := r.Modifier.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitRoleSetting(r)
+}
+
+type CreateRole struct {
+	CreatePos         Pos
+	StatementEnd      Pos
+	IfNotExists       bool
+	OrReplace         bool
+	RoleNames         []*RoleName
+	AccessStorageType *Ident
+	Settings          []*RoleSetting
+}
+
+func (c *CreateRole) Start() Pos {
+	return c.CreatePos
+}
+
+func (c *CreateRole) End() Pos {
+	return c.StatementEnd
+}
+
+func (c *CreateRole) Type() string {
+	return "ROLE"
+}
+
+func (c *CreateRole) String() string {
+	var builder strings.Builder
+	builder.WriteString("CREATE ROLE ")
+	if c.IfNotExists {
+		builder.WriteString("IF NOT EXISTS ")
+	}
+	if c.OrReplace {
+		builder.WriteString("OR REPLACE ")
+	}
+	for i, roleName := range c.RoleNames {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(roleName.String())
+	}
+	if c.AccessStorageType != nil {
+		builder.WriteString(" IN ")
+		builder.WriteString(c.AccessStorageType.String())
+	}
+	if len(c.Settings) > 0 {
+		builder.WriteString(" SETTINGS ")
+		for i, setting := range c.Settings {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(setting.String())
+		}
+	}
+	return builder.String()
+}
+
+func (c *CreateRole) Accept(visitor ASTVisitor) error {
+	visitor.Enter(c)
+	defer visitor.Leave(c)
+	for _, roleName := range c.RoleNames {
+		if err := roleName.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.AccessStorageType != nil {
+		if err := c.AccessStorageType.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, setting := range c.Settings {
+		if err := setting.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCreateRole(c)
+}
+
+type AuthenticationClause struct {
+	AuthPos       Pos
+	AuthEnd       Pos
+	NotIdentified bool
+	AuthType      string // "no_password", "plaintext_password", "sha256_password", etc.
+	AuthValue     *StringLiteral
+	LdapServer    *StringLiteral
+	KerberosRealm *StringLiteral
+	IsKerberos    bool
+}
+
+func (a *AuthenticationClause) Start() Pos {
+	return a.AuthPos
+}
+
+func (a *AuthenticationClause) End() Pos {
+	return a.AuthEnd
+}
+
+func (a *AuthenticationClause) String() string {
+	var builder strings.Builder
+	if a.NotIdentified {
+		builder.WriteString("NOT IDENTIFIED")
+		return builder.String()
+	}
+	builder.WriteString("IDENTIFIED")
+	if a.AuthType != "" {
+		builder.WriteString(" WITH ")
+		builder.WriteString(a.AuthType)
+	}
+	if a.AuthValue != nil {
+		builder.WriteString(" BY ")
+		builder.WriteString(a.AuthValue.String())
+	}
+	if a.LdapServer != nil {
+		builder.WriteString(" WITH ldap SERVER ")
+		builder.WriteString(a.LdapServer.String())
+	}
+	if a.IsKerberos {
+		builder.WriteString(" WITH kerberos")
+		if a.KerberosRealm != nil && a.KerberosRealm.Literal != "" {
+			builder.WriteString(" REALM ")
+			builder.WriteString(a.KerberosRealm.String())
+		}
+	}
+	return builder.String()
+}
+
+func (a *AuthenticationClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if a.AuthValue != nil {
+		if err := a.AuthValue.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if a.LdapServer != nil {
+		if err := a.LdapServer.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if a.KerberosRealm != nil {
+		if err := a.KerberosRealm.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitAuthenticationClause(a)
+}
+
+type HostClause struct {
+	HostPos   Pos
+	HostEnd   Pos
+	HostType  string // "LOCAL", "NAME", "REGEXP", "IP", "LIKE", "ANY", "NONE"
+	HostValue *StringLiteral
+}
+
+func (h *HostClause) Start() Pos {
+	return h.HostPos
+}
+
+func (h *HostClause) End() Pos {
+	return h.HostEnd
+}
+
+func (h *HostClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("HOST ")
+	builder.WriteString(h.HostType)
+	if h.HostValue != nil {
+		builder.WriteString(" ")
+		builder.WriteString(h.HostValue.String())
+	}
+	return builder.String()
+}
+
+func (h *HostClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(h)
+	defer visitor.Leave(h)
+	if h.HostValue != nil {
+		if err := h.HostValue.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitHostClause(h)
+}
+
+type DefaultRoleClause struct {
+	DefaultPos Pos
+	DefaultEnd Pos
+	Roles      []*RoleName
+	None       bool
+	All        bool
+	Except     []*RoleName
+}
+
+func (d *DefaultRoleClause) Start() Pos {
+	return d.DefaultPos
+}
+
+func (d *DefaultRoleClause) End() Pos {
+	return d.DefaultEnd
+}
+
+func (d *DefaultRoleClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("DEFAULT ROLE ")
+	switch {
+	case d.None:
+		builder.WriteString("NONE")
+	case d.All:
+		builder.WriteString("ALL")
+	default:
+		for i, role := range d.Roles {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(role.String())
+		}
+	}
+	if len(d.Except) > 0 {
+		builder.WriteString(" EXCEPT ")
+		for i, role := range d.Except {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(role.String())
+		}
+	}
+	return builder.String()
 }
 
-func (t *TableOption) End() Pos {
-	if t.Value != nil {
-		return t.Value.End()
+func (d *DefaultRoleClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(d)
+	defer visitor.Leave(d)
+	for _, role := range d.Roles {
+		if err := role.Accept(visitor); err != nil {
+			return err
+		}
 	}
-	return t.Name.End()
+	for _, role := range d.Except {
+		if err := role.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitDefaultRoleClause(d)
 }
 
-func (t *TableOption) String() string {
+type GranteesClause struct {
+	GranteesPos Pos
+	GranteesEnd Pos
+	Grantees    []*RoleName
+	ExceptUsers []*RoleName
+	Any         bool
+	None        bool
+}
+
+func (g *GranteesClause) Start() Pos {
+	return g.GranteesPos
+}
+
+func (g *GranteesClause) End() Pos {
+	return g.GranteesEnd
+}
+
+func (g *GranteesClause) String() string {
 	var builder strings.Builder
-	if t.Name != nil {
-		builder.WriteString(t.Name.String())
+	builder.WriteString("GRANTEES ")
+	if g.Any {
+		builder.WriteString("ANY")
+	} else if g.None {
+		builder.WriteString("NONE")
+	} else {
+		for i, grantee := range g.Grantees {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(grantee.String())
+		}
 	}
-	if t.Value != nil {
-		if t.HasEquals {
-			builder.WriteString(" = ")
-		} else {
-			builder.WriteString(" ")
+	if len(g.ExceptUsers) > 0 {
+		builder.WriteString(" EXCEPT ")
+		for i, except := range g.ExceptUsers {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(except.String())
 		}
-		builder.WriteString(t.Value.String())
 	}
 	return builder.String()
 }
 
-func (t *TableOption) Accept(visitor ASTVisitor) error {
-	visitor.Enter(t)
-	defer visitor.Leave(t)
-	if err := t.Name.Accept(visitor); err != nil {
-		return err
+func (g *GranteesClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(g)
+	defer visitor.Leave(g)
+	for _, grantee := range g.Grantees {
+		if err := grantee.Accept(visitor); err != nil {
+			return err
+		}
 	}
-	if t.Value != nil {
-		if err := t.Value.Accept(visitor); err != nil {
+	for _, except := range g.ExceptUsers {
+		if err := except.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitTableOption(t)
+	return visitor.VisitGranteesClause(g)
 }
 
-type CreateTable struct {
-	CreatePos    Pos // position of CREATE|ATTACH keyword
-	StatementEnd Pos
-	OrReplace    bool
-	Identifier   *TableIdentifier
-	IfNotExists  bool
-	UUID         *UUID
-	OnCluster    *ClusterClause
-	TableSchema  *SchemaClause
-	SubQuery     *SubQuery
-	HasTemporary bool
-	TableOptions []*TableOption
+type CreateUser struct {
+	CreatePos       Pos
+	StatementEnd    Pos
+	IfNotExists     bool
+	OrReplace       bool
+	UserNames       []*RoleName
+	Authentication  *AuthenticationClause
+	Hosts           []*HostClause
+	DefaultRole     *DefaultRoleClause
+	DefaultDatabase *Ident
+	DefaultDbNone   bool
+	Grantees        *GranteesClause
+	Settings        []*RoleSetting
 }
 
-func (c *CreateTable) Start() Pos {
+func (c *CreateUser) Start() Pos {
 	return c.CreatePos
 }
 
-func (c *CreateTable) End() Pos {
+func (c *CreateUser) End() Pos {
 	return c.StatementEnd
 }
 
-func (c *CreateTable) Type() string {
-	return "CREATE TABLE"
+func (c *CreateUser) Type() string {
+	return "USER"
 }
 
-func (c *CreateTable) String() string {
+func (c *CreateUser) String() string {
 	var builder strings.Builder
-	builder.WriteString("CREATE")
-	if c.OrReplace {
-		builder.WriteString(" OR REPLACE")
-	}
-	if c.HasTemporary {
-		builder.WriteString(" TEMPORARY")
-	}
-	builder.WriteString(" TABLE ")
+	builder.WriteString("CREATE USER ")
 	if c.IfNotExists {
 		builder.WriteString("IF NOT EXISTS ")
 	}
-	if c.Identifier != nil {
-		builder.WriteString(c.Identifier.String())
+	if c.OrReplace {
+		builder.WriteString("OR REPLACE ")
 	}
-	if c.UUID != nil {
+	for i, userName := range c.UserNames {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(userName.String())
+	}
+	if c.Authentication != nil {
 		builder.WriteString(" ")
-		builder.WriteString(c.UUID.String())
+		builder.WriteString(c.Authentication.String())
 	}
-	if c.OnCluster != nil {
+	if len(c.Hosts) > 0 {
 		builder.WriteString(" ")
-		builder.WriteString(c.OnCluster.String())
+		for i, host := range c.Hosts {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(host.String())
+		}
 	}
-	if c.TableSchema != nil {
+	if c.DefaultRole != nil {
 		builder.WriteString(" ")
-		builder.WriteString(c.TableSchema.String())
+		builder.WriteString(c.DefaultRole.String())
 	}
-	for _, opt := range c.TableOptions {
+	if c.DefaultDatabase != nil {
+		builder.WriteString(" DEFAULT DATABASE ")
+		builder.WriteString(c.DefaultDatabase.String())
+	} else if c.DefaultDbNone {
+		builder.WriteString(" DEFAULT DATABASE NONE")
+	}
+	if c.Grantees != nil {
 		builder.WriteString(" ")
-		builder.WriteString(opt.String())
+		builder.WriteString(c.Grantees.String())
 	}
-	if c.SubQuery != nil {
-		builder.WriteString(" AS ")
-		builder.WriteString(c.SubQuery.String())
+	if len(c.Settings) > 0 {
+		builder.WriteString(" SETTINGS ")
+		for i, setting := range c.Settings {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(setting.String())
+		}
+	}
+	return builder.String()
+}
+
+func (c *CreateUser) Accept(visitor ASTVisitor) error {
+	visitor.Enter(c)
+	defer visitor.Leave(c)
+	for _, userName := range c.UserNames {
+		if err := userName.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Authentication != nil {
+		if err := c.Authentication.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, host := range c.Hosts {
+		if err := host.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.DefaultRole != nil {
+		if err := c.DefaultRole.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.DefaultDatabase != nil {
+		if err := c.DefaultDatabase.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if c.Grantees != nil {
+		if err := c.Grantees.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, setting := range c.Settings {
+		if err := setting.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCreateUser(c)
+}
+
+type AlterRole struct {
+	AlterPos        Pos
+	StatementEnd    Pos
+	IfExists        bool
+	RoleRenamePairs []*RoleRenamePair
+	Settings        []*RoleSetting
+}
+
+func (a *AlterRole) Start() Pos {
+	return a.AlterPos
+}
+
+func (a *AlterRole) End() Pos {
+	return a.StatementEnd
+}
+
+func (a *AlterRole) Type() string {
+	return "ROLE"
+}
+
+func (a *AlterRole) String() string {
+	var builder strings.Builder
+	builder.WriteString("ALTER ROLE ")
+	if a.IfExists {
+		builder.WriteString("IF EXISTS ")
+	}
+	for i, roleRenamePair := range a.RoleRenamePairs {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(roleRenamePair.String())
+	}
+	if len(a.Settings) > 0 {
+		builder.WriteString(" SETTINGS ")
+		for i, setting := range a.Settings {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(setting.String())
+		}
 	}
 	return builder.String()
 }
 
-func (c *CreateTable) Accept(visitor ASTVisitor) error {
-	visitor.Enter(c)
-	defer visitor.Leave(c)
-	if err := c.Identifier.Accept(visitor); err != nil {
-		return err
-	}
-	if c.UUID != nil {
-		if err := c.UUID.Accept(visitor); err != nil {
+func (a *AlterRole) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	for _, roleRenamePair := range a.RoleRenamePairs {
+		if err := roleRenamePair.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.OnCluster != nil {
-		if err := c.OnCluster.Accept(visitor); err != nil {
+	for _, setting := range a.Settings {
+		if err := setting.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.TableSchema != nil {
-		if err := c.TableSchema.Accept(visitor); err != nil {
+	return visitor.VisitAlterRole(a)
+}
+
+type RoleRenamePair struct {
+	RoleName     *RoleName
+	NewName      Expr
+	StatementEnd Pos
+}
+
+func (r *RoleRenamePair) Start() Pos {
+	return r.RoleName.Start()
+}
+
+func (r *RoleRenamePair) End() Pos {
+	return r.StatementEnd
+}
+
+func (r *RoleRenamePair) String() string {
+	var builder strings.Builder
+	builder.WriteString(r.RoleName.String())
+	if r.NewName != nil {
+		builder.WriteString(" RENAME TO ")
+		builder.WriteString(r.NewName.String())
+	}
+	return builder.String()
+}
+
+func (r *RoleRenamePair) Accept(visitor ASTVisitor) error {
+	visitor.Enter(r)
+	defer visitor.Leave(r)
+	if err := r.RoleName.Accept(visitor); err != nil {
+		return err
+	}
+	if r.NewName != nil {
+		if err := r.NewName.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	for _, opt := range c.TableOptions {
-		if err := opt.Accept(visitor); err != nil {
-			return err
+	return visitor.VisitRoleRenamePair(r)
+}
+
+// RoleList is a list of roles or users such as `r1, r2`, `ALL EXCEPT r1`, `NONE` or `DEFAULT`.
+type RoleList struct {
+	ListPos Pos
+	ListEnd Pos
+	Keyword string // "", "DEFAULT", "NONE" or "ALL"
+	Roles   []*RoleName
+	Except  []*RoleName
+}
+
+func (r *RoleList) Start() Pos {
+	return r.ListPos
+}
+
+func (r *RoleList) End() Pos {
+	return r.ListEnd
+}
+
+func (r *RoleList) String() string {
+	var builder strings.Builder
+	builder.WriteString(r.Keyword)
+	for i, role := range r.Roles {
+		if i > 0 {
+			builder.WriteString(", ")
 		}
+		builder.WriteString(role.String())
 	}
-	if c.SubQuery != nil {
-		if err := c.SubQuery.Accept(visitor); err != nil {
-			return err
+	if len(r.Except) > 0 {
+		builder.WriteString(" EXCEPT ")
+		for i, role := range r.Except {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(role.String())
 		}
 	}
-	return visitor.VisitCreateTable(c)
+	return builder.String()
 }
 
-func (c *CreateTable) GetComment() string {
-	options := c.TableOptions
-	for _, v := range options {
-		if v.Name.Name == "COMMENT" {
-			if comment, ok := v.Value.(*StringLiteral); ok {
-				return comment.Literal
-			}
+func (r *RoleList) Accept(visitor ASTVisitor) error {
+	visitor.Enter(r)
+	defer visitor.Leave(r)
+	for _, role := range r.Roles {
+		if err := role.Accept(visitor); err != nil {
+			return err
 		}
 	}
-	return ""
+	for _, role := range r.Except {
+		if err := role.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitRoleList(r)
 }
 
-type CreateMaterializedView struct {
-	CreatePos    Pos // position of CREATE|ATTACH keyword
-	StatementEnd Pos
-	Name         *TableIdentifier
-	IfNotExists  bool
-	OnCluster    *ClusterClause
-	Refresh      *RefreshExpr
-	RandomizeFor *IntervalExpr
-	DependsOn    []*TableIdentifier
-	Settings     *SettingsClause
-	HasAppend    bool
-	Engine       *EngineExpr
-	HasEmpty     bool
-	Destination  *DestinationClause
-	SubQuery     *SubQuery
-	Populate     bool
-	Comment      *StringLiteral
-	Definer      *Ident
-	SQLSecurity  string
+type AlterUser struct {
+	AlterPos        Pos
+	StatementEnd    Pos
+	IfExists        bool
+	RoleRenamePairs []*RoleRenamePair
+	Authentication  *AuthenticationClause
+	Hosts           []*HostClause
+	DefaultRole     *DefaultRoleClause
+	DefaultDatabase *Ident
+	DefaultDbNone   bool
+	Grantees        *GranteesClause
+	Settings        []*RoleSetting
 }
 
-func (c *CreateMaterializedView) Start() Pos {
-	return c.CreatePos
+func (a *AlterUser) Start() Pos {
+	return a.AlterPos
 }
 
-func (c *CreateMaterializedView) End() Pos {
-	return c.StatementEnd
+func (a *AlterUser) End() Pos {
+	return a.StatementEnd
 }
 
-func (c *CreateMaterializedView) Type() string {
-	return "MATERIALIZED_VIEW"
+func (a *AlterUser) Type() string {
+	return "USER"
 }
 
-func (c *CreateMaterializedView) String() string {
+func (a *AlterUser) String() string {
 	var builder strings.Builder
-	builder.WriteString("CREATE MATERIALIZED VIEW ")
-	if c.IfNotExists {
-		builder.WriteString("IF NOT EXISTS ")
+	builder.WriteString("ALTER USER ")
+	if a.IfExists {
+		builder.WriteString("IF EXISTS ")
 	}
-	builder.WriteString(c.Name.String())
-	if c.OnCluster != nil {
-		builder.WriteString(" ")
-		builder.WriteString(c.OnCluster.String())
+	for i, roleRenamePair := range a.RoleRenamePairs {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(roleRenamePair.String())
 	}
-	if c.Refresh != nil {
+	if a.Authentication != nil {
 		builder.WriteString(" ")
-		builder.WriteString(c.Refresh.String())
-	}
-	if c.RandomizeFor != nil {
-		builder.WriteString(" RANDOMIZE FOR ")
-		builder.WriteString(c.RandomizeFor.String())
+		builder.WriteString(a.Authentication.String())
 	}
-	if c.DependsOn != nil {
-		builder.WriteString(" DEPENDS ON ")
-		for i, dep := range c.DependsOn {
+	if len(a.Hosts) > 0 {
+		builder.WriteString(" ")
+		for i, host := range a.Hosts {
 			if i > 0 {
 				builder.WriteString(", ")
 			}
-			builder.WriteString(dep.String())
+			builder.WriteString(host.String())
 		}
 	}
-	if c.Settings != nil {
+	if a.DefaultRole != nil {
 		builder.WriteString(" ")
-		builder.WriteString(c.Settings.String())
+		builder.WriteString(a.DefaultRole.String())
 	}
-	if c.HasAppend {
-		builder.WriteString(" APPEND")
-	}
-	if c.Engine != nil {
-		builder.WriteString(c.Engine.String())
+	if a.DefaultDatabase != nil {
+		builder.WriteString(" DEFAULT DATABASE ")
+		builder.WriteString(a.DefaultDatabase.String())
+	} else if a.DefaultDbNone {
+		builder.WriteString(" DEFAULT DATABASE NONE")
 	}
-	if c.Destination != nil {
+	if a.Grantees != nil {
 		builder.WriteString(" ")
-		builder.WriteString(c.Destination.String())
-		if c.Destination.TableSchema != nil {
-			builder.WriteString(" ")
-			builder.WriteString(c.Destination.TableSchema.String())
-		}
-	}
-	if c.HasEmpty {
-		builder.WriteString(" EMPTY")
-	}
-	if c.Definer != nil {
-		builder.WriteString(" DEFINER = ")
-		builder.WriteString(c.Definer.String())
-	}
-	if c.SQLSecurity != "" {
-		builder.WriteString(" SQL SECURITY ")
-		builder.WriteString(c.SQLSecurity)
-	}
-	if c.Populate {
-		builder.WriteString(" POPULATE")
-	}
-	if c.SubQuery != nil {
-		builder.WriteString(" AS ")
-		builder.WriteString(c.SubQuery.String())
-	}
-	if c.Comment != nil {
-		builder.WriteString(" COMMENT ")
-		builder.WriteString(c.Comment.String())
+		builder.WriteString(a.Grantees.String())
 	}
+	writeRoleSettings(&builder, a.Settings)
 	return builder.String()
 }
 
-func (c *CreateMaterializedView) Accept(visitor ASTVisitor) error {
-	visitor.Enter(c)
-	defer visitor.Leave(c)
-	if err := c.Name.Accept(visitor); err != nil {
-		return err
-	}
-	if c.OnCluster != nil {
-		if err := c.OnCluster.Accept(visitor); err != nil {
+func (a *AlterUser) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	for _, roleRenamePair := range a.RoleRenamePairs {
+		if err := roleRenamePair.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.Refresh != nil {
-		if err := c.Refresh.Accept(visitor); err != nil {
+	if a.Authentication != nil {
+		if err := a.Authentication.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.RandomizeFor != nil {
-		if err := c.RandomizeFor.Accept(visitor); err != nil {
+	for _, host := range a.Hosts {
+		if err := host.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.DependsOn != nil {
-		for _, dep := range c.DependsOn {
-			if err := dep.Accept(visitor); err != nil {
-				return err
-			}
+	if a.DefaultRole != nil {
+		if err := a.DefaultRole.Accept(visitor); err != nil {
+			return err
 		}
 	}
-	if c.Settings != nil {
-		if err := c.Settings.Accept(visitor); err != nil {
+	if a.DefaultDatabase != nil {
+		if err := a.DefaultDatabase.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.Engine != nil {
-		if err := c.Engine.Accept(visitor); err != nil {
+	if a.Grantees != nil {
+		if err := a.Grantees.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.Destination != nil {
-		if err := c.Destination.Accept(visitor); err != nil {
+	for _, setting := range a.Settings {
+		if err := setting.Accept(visitor); err != nil {
 			return err
 		}
-		if c.Destination.TableSchema != nil {
-			if err := c.Destination.TableSchema.Accept(visitor); err != nil {
-				return err
-			}
-		}
 	}
-	if c.SubQuery != nil {
-		if err := c.SubQuery.Accept(visitor); err != nil {
-			return err
+	return visitor.VisitAlterUser(a)
+}
+
+// SetRoleStmt is `SET ROLE roles` or `SET DEFAULT ROLE roles TO users`.
+type SetRoleStmt struct {
+	SetPos       Pos
+	StatementEnd Pos
+	Default      bool
+	Roles        *RoleList
+	To           []*RoleName
+}
+
+func (s *SetRoleStmt) Start() Pos {
+	return s.SetPos
+}
+
+func (s *SetRoleStmt) End() Pos {
+	return s.StatementEnd
+}
+
+func (s *SetRoleStmt) String() string {
+	var builder strings.Builder
+	builder.WriteString("SET ")
+	if s.Default {
+		builder.WriteString("DEFAULT ")
+	}
+	builder.WriteString("ROLE ")
+	builder.WriteString(s.Roles.String())
+	for i, user := range s.To {
+		if i == 0 {
+			builder.WriteString(" TO ")
+		} else {
+			builder.WriteString(", ")
 		}
+		builder.WriteString(user.String())
 	}
-	if c.Definer != nil {
-		if err := c.Definer.Accept(visitor); err != nil {
-			return err
-		}
+	return builder.String()
+}
+
+func (s *SetRoleStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(s)
+	defer visitor.Leave(s)
+	if err := s.Roles.Accept(visitor); err != nil {
+		return err
 	}
-	if c.Comment != nil {
-		if err := c.Comment.Accept(visitor); err != nil {
+	for _, user := range s.To {
+		if err := user.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitCreateMaterializedView(c)
+	return visitor.VisitSetRoleStmt(s)
 }
 
-type CreateView struct {
-	CreatePos    Pos // position of CREATE|ATTACH keyword
-	StatementEnd Pos
-	OrReplace    bool
-	Name         *TableIdentifier
-	IfNotExists  bool
-	UUID         *UUID
-	OnCluster    *ClusterClause
-	TableSchema  *SchemaClause
-	SubQuery     *SubQuery
+type RowPolicyKind string
+
+const (
+	RowPolicyKindNone        RowPolicyKind = ""
+	RowPolicyKindPermissive  RowPolicyKind = "PERMISSIVE"
+	RowPolicyKindRestrictive RowPolicyKind = "RESTRICTIVE"
+)
+
+type CreateRowPolicy struct {
+	CreatePos         Pos
+	StatementEnd      Pos
+	OrReplace         bool
+	IfNotExists       bool
+	Name              *Ident
+	OnCluster         *ClusterClause
+	On                []*TableIdentifier
+	AccessStorageType *Ident
+	ForSelect         bool
+	Using             Expr
+	As                RowPolicyKind
+	To                *RoleList
 }
 
-func (c *CreateView) Start() Pos {
+func (c *CreateRowPolicy) Start() Pos {
 	return c.CreatePos
 }
 
-func (c *CreateView) End() Pos {
+func (c *CreateRowPolicy) End() Pos {
 	return c.StatementEnd
 }
 
-func (c *CreateView) Type() string {
-	return "VIEW"
+func (c *CreateRowPolicy) Type() string {
+	return "ROW POLICY"
 }
 
-func (c *CreateView) String() string {
+func (c *CreateRowPolicy) String() string {
 	var builder strings.Builder
-	builder.WriteString("CREATE")
-	if c.OrReplace {
-		builder.WriteString(" OR REPLACE")
-	}
-	builder.WriteString(" VIEW ")
+	builder.WriteString("CREATE ROW POLICY ")
 	if c.IfNotExists {
 		builder.WriteString("IF NOT EXISTS ")
 	}
-	builder.WriteString(c.Name.String())
-	if c.UUID != nil {
-		builder.WriteString(" ")
-		builder.WriteString(c.UUID.String())
+	if c.OrReplace {
+		builder.WriteString("OR REPLACE ")
 	}
-
+	builder.WriteString(c.Name.String())
 	if c.OnCluster != nil {
 		builder.WriteString(" ")
 		builder.WriteString(c.OnCluster.String())
 	}
-
-	if c.TableSchema != nil {
-		builder.WriteString(" ")
-		builder.WriteString(c.TableSchema.String())
+	writeRowPolicyTables(&builder, c.On)
+	if c.AccessStorageType != nil {
+		builder.WriteString(" IN ")
+		builder.WriteString(c.AccessStorageType.String())
 	}
-
-	if c.SubQuery != nil {
+	if c.ForSelect {
+		builder.WriteString(" FOR SELECT")
+	}
+	if c.Using != nil {
+		builder.WriteString(" USING ")
+		builder.WriteString(c.Using.String())
+	}
+	if c.As != RowPolicyKindNone {
 		builder.WriteString(" AS ")
-		builder.WriteString(c.SubQuery.String())
+		builder.WriteString(string(c.As))
+	}
+	if c.To != nil {
+		builder.WriteString(" TO ")
+		builder.WriteString(c.To.String())
 	}
 	return builder.String()
 }
 
-func (c *CreateView) Accept(visitor ASTVisitor) error {
+func (c *CreateRowPolicy) Accept(visitor ASTVisitor) error {
 	visitor.Enter(c)
 	defer visitor.Leave(c)
 	if err := c.Name.Accept(visitor); err != nil {
 		return err
 	}
-	if c.UUID != nil {
-		if err := c.UUID.Accept(visitor); err != nil {
+	if c.OnCluster != nil {
+		if err := c.OnCluster.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.OnCluster != nil {
-		if err := c.OnCluster.Accept(visitor); err != nil {
+	for _, table := range c.On {
+		if err := table.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.TableSchema != nil {
-		if err := c.TableSchema.Accept(visitor); err != nil {
+	if c.AccessStorageType != nil {
+		if err := c.AccessStorageType.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.SubQuery != nil {
-		if err := c.SubQuery.Accept(visitor); err != nil {
+	if c.Using != nil {
+		if err := c.Using.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitCreateView(c)
+	if c.To != nil {
+		if err := c.To.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCreateRowPolicy(c)
 }
 
-type CreateFunction struct {
-	CreatePos    Pos
-	OrReplace    bool
-	IfNotExists  bool
-	FunctionName *Ident
+type AlterRowPolicy struct {
+	AlterPos     Pos
+	StatementEnd Pos
+	IfExists     bool
+	Name         *Ident
 	OnCluster    *ClusterClause
-	Params       *ParamExprList
-	Expr         Expr
+	On           []*TableIdentifier
+	RenameTo     *Ident
+	ForSelect    bool
+	Using        Expr
+	UsingNone    bool
+	As           RowPolicyKind
+	To           *RoleList
 }
 
-func (c *CreateFunction) Type() string {
-	return "FUNCTION"
+func (a *AlterRowPolicy) Start() Pos {
+	return a.AlterPos
 }
 
-func (c *CreateFunction) Start() Pos {
-	return c.CreatePos
+func (a *AlterRowPolicy) End() Pos {
+	return a.StatementEnd
 }
 
-func (c *CreateFunction) End() Pos {
-	return c.Expr.End()
+func (a *AlterRowPolicy) Type() string {
+	return "ROW POLICY"
 }
 
-func (c *CreateFunction) String() string {
+func (a *AlterRowPolicy) String() string {
 	var builder strings.Builder
-	builder.WriteString("CREATE")
-	if c.OrReplace {
-		builder.WriteString(" OR REPLACE")
-	}
-	builder.WriteString(" FUNCTION ")
-	if c.IfNotExists {
-		builder.WriteString("IF NOT EXISTS ")
+	builder.WriteString("ALTER ROW POLICY ")
+	if a.IfExists {
+		builder.WriteString("IF EXISTS ")
 	}
-	builder.WriteString(c.FunctionName.String())
-	if c.OnCluster != nil {
+	builder.WriteString(a.Name.String())
+	if a.OnCluster != nil {
 		builder.WriteString(" ")
-		builder.WriteString(c.OnCluster.String())
-	}
-	builder.WriteString(" AS ")
-	builder.WriteString(c.Params.String())
-	builder.WriteString(" -> ")
-	builder.WriteString(c.Expr.String())
-	return builder.String()
-}
-
-func (c *CreateFunction) Accept(visitor ASTVisitor) error {
-	visitor.Enter(c)
-	defer visitor.Leave(c)
-	if err := c.FunctionName.Accept(visitor); err != nil {
-		return err
-	}
-	if c.OnCluster != nil {
-		if err := c.OnCluster.Accept(visitor); err != nil {
-			return err
-		}
-	}
-	if err := c.Params.Accept(visitor); err != nil {
-		return err
+		builder.WriteString(a.OnCluster.String())
 	}
-	if err := c.Expr.Accept(visitor); err != nil {
-		return err
+	writeRowPolicyTables(&builder, a.On)
+	if a.RenameTo != nil {
+		builder.WriteString(" RENAME TO ")
+		builder.WriteString(a.RenameTo.String())
 	}
-	return visitor.VisitCreateFunction(c)
-}
-
-type RoleName struct {
-	Name      Expr
-	Scope     *StringLiteral
-	OnCluster *ClusterClause
-}
-
-func (r *RoleName) Start() Pos {
-	return r.Name.Start()
-}
-
-func (r *RoleName) End() Pos {
-	if r.Scope != nil {
-		return r.Scope.End()
+	if a.ForSelect {
+		builder.WriteString(" FOR SELECT")
 	}
-	if r.OnCluster != nil {
-		return r.OnCluster.End()
+	if a.UsingNone {
+		builder.WriteString(" USING NONE")
+	} else if a.Using != nil {
+		builder.WriteString(" USING ")
+		builder.WriteString(a.Using.String())
 	}
-	return r.Name.End()
-}
-
-func (r *RoleName) String() string {
-	var builder strings.Builder
-	builder.WriteString(r.Name.String())
-	if r.Scope != nil {
-		builder.WriteString("@")
-		builder.WriteString(r.Scope.String())
+	if a.As != RowPolicyKindNone {
+		builder.WriteString(" AS ")
+		builder.WriteString(string(a.As))
 	}
-	if r.OnCluster != nil {
-		builder.WriteByte(' ')
-		builder.WriteString(r.OnCluster.String())
+	if a.To != nil {
+		builder.WriteString(" TO ")
+		builder.WriteString(a.To.String())
 	}
 	return builder.String()
 }
 
-func (r *RoleName) Accept(visitor ASTVisitor) error {
-	visitor.Enter(r)
-	defer visitor.Leave(r)
-	if err := r.Name.Accept(visitor); err != nil {
+func (a *AlterRowPolicy) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.Name.Accept(visitor); err != nil {
 		return err
 	}
-	if r.Scope != nil {
-		if err := r.Scope.Accept(visitor); err != nil {
+	if a.OnCluster != nil {
+		if err := a.OnCluster.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if r.OnCluster != nil {
-		if err := r.OnCluster.Accept(visitor); err != nil {
+	for _, table := range a.On {
+		if err := table.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitRoleName(r)
-}
-
-type SettingPair struct {
-	Name      *Ident
-	Operation TokenKind
-	Value     Expr
-}
-
-func (s *SettingPair) Start() Pos {
-	return s.Name.start
-}
-
-func (s *SettingPair) End() Pos {
-	return s.Value.End()
-}
-
-func (s *SettingPair) String() string {
-	var builder strings.Builder
-	builder.WriteString(s.Name.String())
-	if s.Value != nil {
-		if s.Operation == TokenKindSingleEQ {
-			builder.WriteString(string(s.Operation))
-		} else {
-			builder.WriteByte(' ')
-		}
-		builder.WriteString(s.Value.String())
-	}
-	return builder.String()
-}
-
-func (s *SettingPair) Accept(visitor ASTVisitor) error {
-	visitor.Enter(s)
-	defer visitor.Leave(s)
-	if err := s.Name.Accept(visitor); err != nil {
-		return err
-	}
-	if s.Value != nil {
-		if err := s.Value.Accept(visitor); err != nil {
+	if a.RenameTo != nil {
+		if err := a.RenameTo.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitSettingPair(s)
-}
-
-type RoleSetting struct {
-	SettingPairs []*SettingPair
-	Modifier     *Ident
-}
-
-func (r *RoleSetting) Start() Pos {
-	if len(r.SettingPairs) > 0 {
-		return r.SettingPairs[0].Start()
-	}
-	return r.Modifier.start
-}
-
-func (r *RoleSetting) End() Pos {
-	if r.Modifier != nil {
-		return r.Modifier.end
-	}
-	return r.SettingPairs[len(r.SettingPairs)-1].End()
-}
-
-func (r *RoleSetting) String() string {
-	var builder strings.Builder
-	for i, settingPair := range r.SettingPairs {
-		if i > 0 {
-			builder.WriteString(" ")
+	if a.Using != nil {
+		if err := a.Using.Accept(visitor); err != nil {
+			return err
 		}
-		builder.WriteString(settingPair.String())
-	}
-	if r.Modifier != nil {
-		if len(r.SettingPairs) > 0 {
-			builder.WriteString(" ")
+	}
+	if a.To != nil {
+		if err := a.To.Accept(visitor); err != nil {
+			return err
 		}
-		builder.WriteString(r.Modifier.String())
 	}
-	return builder.String()
+	return visitor.VisitAlterRowPolicy(a)
 }
 
-func (r *RoleSetting) Accept(visitor ASTVisitor) error {
-	visitor.Enter(r)
-	defer visitor.Leave(r)
-	for _, settingPair := range r.SettingPairs {
-		if err := settingPair.Accept(visitor); err != nil {
-			return err
+func writeRowPolicyTables(builder *strings.Builder, tables []*TableIdentifier) {
+	for i, table := range tables {
+		if i == 0 {
+			builder.WriteString(" ON ")
+		} else {
+			builder.WriteString(", ")
 		}
+		builder.WriteString(table.String())
 	}
-	if r.Modifier != nil {
-		if err := r.Modifier.Accept(visitor); err != nil {
-			return err
+}
+
+func writeRoleSettings(builder *strings.Builder, settings []*RoleSetting) {
+	for i, setting := range settings {
+		if i == 0 {
+			builder.WriteString(" SETTINGS ")
+		} else {
+			builder.WriteString(", ")
 		}
+		builder.WriteString(setting.String())
 	}
-	return visitor.VisitRoleSetting(r)
 }
 
-type CreateRole struct {
+type CreateSettingsProfile struct {
 	CreatePos         Pos
 	StatementEnd      Pos
-	IfNotExists       bool
 	OrReplace         bool
-	RoleNames         []*RoleName
+	IfNotExists       bool
+	Names             []*RoleName
 	AccessStorageType *Ident
 	Settings          []*RoleSetting
+	To                *RoleList
 }
 
-func (c *CreateRole) Start() Pos {
+func (c *CreateSettingsProfile) Start() Pos {
 	return c.CreatePos
 }
 
-func (c *CreateRole) End() Pos {
+func (c *CreateSettingsProfile) End() Pos {
 	return c.StatementEnd
 }
 
-func (c *CreateRole) Type() string {
-	return "ROLE"
+func (c *CreateSettingsProfile) Type() string {
+	return "SETTINGS PROFILE"
 }
 
-func (c *CreateRole) String() string {
+func (c *CreateSettingsProfile) String() string {
 	var builder strings.Builder
-	builder.WriteString("CREATE ROLE ")
+	builder.WriteString("CREATE SETTINGS PROFILE ")
 	if c.IfNotExists {
 		builder.WriteString("IF NOT EXISTS ")
 	}
 	if c.OrReplace {
 		builder.WriteString("OR REPLACE ")
 	}
-	for i, roleName := range c.RoleNames {
+	for i, name := range c.Names {
 		if i > 0 {
 			builder.WriteString(", ")
 		}
-		builder.WriteString(roleName.String())
+		builder.WriteString(name.String())
 	}
 	if c.AccessStorageType != nil {
 		builder.WriteString(" IN ")
 		builder.WriteString(c.AccessStorageType.String())
 	}
-	if len(c.Settings) > 0 {
-		builder.WriteString(" SETTINGS ")
-		for i, setting := range c.Settings {
-			if i > 0 {
-				builder.WriteString(", ")
-			}
-			builder.WriteString(setting.String())
-		}
+	writeRoleSettings(&builder, c.Settings)
+	if c.To != nil {
+		builder.WriteString(" TO ")
+		builder.WriteString(c.To.String())
 	}
 	return builder.String()
 }
 
-func (c *CreateRole) Accept(visitor ASTVisitor) error {
+func (c *CreateSettingsProfile) Accept(visitor ASTVisitor) error {
 	visitor.Enter(c)
 	defer visitor.Leave(c)
-	for _, roleName := range c.RoleNames {
-		if err := roleName.Accept(visitor); err != nil {
+	for _, name := range c.Names {
+		if err := name.Accept(visitor); err != nil {
 			return err
 		}
 	}
@@ -2291,362 +4788,245 @@ func (c *CreateRole) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
-	return visitor.VisitCreateRole(c)
+	if c.To != nil {
+		if err := c.To.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCreateSettingsProfile(c)
 }
 
-type AuthenticationClause struct {
-	AuthPos       Pos
-	AuthEnd       Pos
-	NotIdentified bool
-	AuthType      string // "no_password", "plaintext_password", "sha256_password", etc.
-	AuthValue     *StringLiteral
-	LdapServer    *StringLiteral
-	KerberosRealm *StringLiteral
-	IsKerberos    bool
+type AlterSettingsProfile struct {
+	AlterPos        Pos
+	StatementEnd    Pos
+	IfExists        bool
+	RoleRenamePairs []*RoleRenamePair
+	Settings        []*RoleSetting
+	To              *RoleList
 }
 
-func (a *AuthenticationClause) Start() Pos {
-	return a.AuthPos
+func (a *AlterSettingsProfile) Start() Pos {
+	return a.AlterPos
 }
 
-func (a *AuthenticationClause) End() Pos {
-	return a.AuthEnd
+func (a *AlterSettingsProfile) End() Pos {
+	return a.StatementEnd
 }
 
-func (a *AuthenticationClause) String() string {
+func (a *AlterSettingsProfile) Type() string {
+	return "SETTINGS PROFILE"
+}
+
+func (a *AlterSettingsProfile) String() string {
 	var builder strings.Builder
-	if a.NotIdentified {
-		builder.WriteString("NOT IDENTIFIED")
-		return builder.String()
-	}
-	builder.WriteString("IDENTIFIED")
-	if a.AuthType != "" {
-		builder.WriteString(" WITH ")
-		builder.WriteString(a.AuthType)
-	}
-	if a.AuthValue != nil {
-		builder.WriteString(" BY ")
-		builder.WriteString(a.AuthValue.String())
-	}
-	if a.LdapServer != nil {
-		builder.WriteString(" WITH ldap SERVER ")
-		builder.WriteString(a.LdapServer.String())
+	builder.WriteString("ALTER SETTINGS PROFILE ")
+	if a.IfExists {
+		builder.WriteString("IF EXISTS ")
 	}
-	if a.IsKerberos {
-		builder.WriteString(" WITH kerberos")
-		if a.KerberosRealm != nil && a.KerberosRealm.Literal != "" {
-			builder.WriteString(" REALM ")
-			builder.WriteString(a.KerberosRealm.String())
+	for i, roleRenamePair := range a.RoleRenamePairs {
+		if i > 0 {
+			builder.WriteString(", ")
 		}
+		builder.WriteString(roleRenamePair.String())
+	}
+	writeRoleSettings(&builder, a.Settings)
+	if a.To != nil {
+		builder.WriteString(" TO ")
+		builder.WriteString(a.To.String())
 	}
 	return builder.String()
 }
 
-func (a *AuthenticationClause) Accept(visitor ASTVisitor) error {
+func (a *AlterSettingsProfile) Accept(visitor ASTVisitor) error {
 	visitor.Enter(a)
 	defer visitor.Leave(a)
-	if a.AuthValue != nil {
-		if err := a.AuthValue.Accept(visitor); err != nil {
+	for _, roleRenamePair := range a.RoleRenamePairs {
+		if err := roleRenamePair.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if a.LdapServer != nil {
-		if err := a.LdapServer.Accept(visitor); err != nil {
+	for _, setting := range a.Settings {
+		if err := setting.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if a.KerberosRealm != nil {
-		if err := a.KerberosRealm.Accept(visitor); err != nil {
+	if a.To != nil {
+		if err := a.To.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitAuthenticationClause(a)
+	return visitor.VisitAlterSettingsProfile(a)
 }
 
-type HostClause struct {
-	HostPos   Pos
-	HostEnd   Pos
-	HostType  string // "LOCAL", "NAME", "REGEXP", "IP", "LIKE", "ANY", "NONE"
-	HostValue *StringLiteral
+// QuotaInterval is one `FOR [RANDOMIZED] INTERVAL n unit {MAX ... | NO LIMITS | TRACKING ONLY}` item of a quota.
+type QuotaInterval struct {
+	ForPos       Pos
+	IntervalEnd  Pos
+	Randomized   bool
+	Interval     *NumberLiteral
+	Unit         *Ident
+	Limits       []*SettingPair
+	NoLimits     bool
+	TrackingOnly bool
 }
 
-func (h *HostClause) Start() Pos {
-	return h.HostPos
+func (q *QuotaInterval) Start() Pos {
+	return q.ForPos
 }
 
-func (h *HostClause) End() Pos {
-	return h.HostEnd
+func (q *QuotaInterval) End() Pos {
+	return q.IntervalEnd
 }
 
-func (h *HostClause) String() string {
+func (q *QuotaInterval) String() string {
 	var builder strings.Builder
-	builder.WriteString("HOST ")
-	builder.WriteString(h.HostType)
-	if h.HostValue != nil {
-		builder.WriteString(" ")
-		builder.WriteString(h.HostValue.String())
-	}
-	return builder.String()
-}
-
-func (h *HostClause) Accept(visitor ASTVisitor) error {
-	visitor.Enter(h)
-	defer visitor.Leave(h)
-	if h.HostValue != nil {
-		if err := h.HostValue.Accept(visitor); err != nil {
-			return err
-		}
+	builder.WriteString("FOR ")
+	if q.Randomized {
+		builder.WriteString("RANDOMIZED ")
 	}
-	return visitor.VisitHostClause(h)
-}
-
-type DefaultRoleClause struct {
-	DefaultPos Pos
-	DefaultEnd Pos
-	Roles      []*RoleName
-	None       bool
-}
-
-func (d *DefaultRoleClause) Start() Pos {
-	return d.DefaultPos
-}
-
-func (d *DefaultRoleClause) End() Pos {
-	return d.DefaultEnd
-}
-
-func (d *DefaultRoleClause) String() string {
-	var builder strings.Builder
-	builder.WriteString("DEFAULT ROLE ")
-	if d.None {
-		builder.WriteString("NONE")
-	} else {
-		for i, role := range d.Roles {
+	builder.WriteString("INTERVAL ")
+	builder.WriteString(q.Interval.String())
+	builder.WriteString(" ")
+	builder.WriteString(q.Unit.String())
+	switch {
+	case q.NoLimits:
+		builder.WriteString(" NO LIMITS")
+	case q.TrackingOnly:
+		builder.WriteString(" TRACKING ONLY")
+	default:
+		builder.WriteString(" MAX ")
+		for i, limit := range q.Limits {
 			if i > 0 {
 				builder.WriteString(", ")
 			}
-			builder.WriteString(role.String())
+			builder.WriteString(limit.String())
 		}
 	}
 	return builder.String()
 }
 
-func (d *DefaultRoleClause) Accept(visitor ASTVisitor) error {
-	visitor.Enter(d)
-	defer visitor.Leave(d)
-	for _, role := range d.Roles {
-		if err := role.Accept(visitor); err != nil {
-			return err
-		}
-	}
-	return visitor.VisitDefaultRoleClause(d)
-}
-
-type GranteesClause struct {
-	GranteesPos Pos
-	GranteesEnd Pos
-	Grantees    []*RoleName
-	ExceptUsers []*RoleName
-	Any         bool
-	None        bool
-}
-
-func (g *GranteesClause) Start() Pos {
-	return g.GranteesPos
-}
-
-func (g *GranteesClause) End() Pos {
-	return g.GranteesEnd
-}
-
-func (g *GranteesClause) String() string {
-	var builder strings.Builder
-	builder.WriteString("GRANTEES ")
-	if g.Any {
-		builder.WriteString("ANY")
-	} else if g.None {
-		builder.WriteString("NONE")
-	} else {
-		for i, grantee := range g.Grantees {
-			if i > 0 {
-				builder.WriteString(", ")
-			}
-			builder.WriteString(grantee.String())
-		}
-	}
-	if len(g.ExceptUsers) > 0 {
-		builder.WriteString(" EXCEPT ")
-		for i, except := range g.ExceptUsers {
-			if i > 0 {
-				builder.WriteString(", ")
-			}
-			builder.WriteString(except.String())
-		}
+func (q *QuotaInterval) Accept(visitor ASTVisitor) error {
+	visitor.Enter(q)
+	defer visitor.Leave(q)
+	if err := q.Interval.Accept(visitor); err != nil {
+		return err
 	}
-	return builder.String()
-}
-
-func (g *GranteesClause) Accept(visitor ASTVisitor) error {
-	visitor.Enter(g)
-	defer visitor.Leave(g)
-	for _, grantee := range g.Grantees {
-		if err := grantee.Accept(visitor); err != nil {
-			return err
-		}
+	if err := q.Unit.Accept(visitor); err != nil {
+		return err
 	}
-	for _, except := range g.ExceptUsers {
-		if err := except.Accept(visitor); err != nil {
+	for _, limit := range q.Limits {
+		if err := limit.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitGranteesClause(g)
+	return visitor.VisitQuotaInterval(q)
 }
 
-type CreateUser struct {
-	CreatePos       Pos
-	StatementEnd    Pos
-	IfNotExists     bool
-	OrReplace       bool
-	UserNames       []*RoleName
-	Authentication  *AuthenticationClause
-	Hosts           []*HostClause
-	DefaultRole     *DefaultRoleClause
-	DefaultDatabase *Ident
-	DefaultDbNone   bool
-	Grantees        *GranteesClause
-	Settings        []*RoleSetting
+type CreateQuota struct {
+	CreatePos         Pos
+	StatementEnd      Pos
+	OrReplace         bool
+	IfNotExists       bool
+	Names             []*RoleName
+	AccessStorageType *Ident
+	KeyedBy           []*Ident
+	NotKeyed          bool
+	Intervals         []*QuotaInterval
+	To                *RoleList
 }
 
-func (c *CreateUser) Start() Pos {
+func (c *CreateQuota) Start() Pos {
 	return c.CreatePos
 }
 
-func (c *CreateUser) End() Pos {
+func (c *CreateQuota) End() Pos {
 	return c.StatementEnd
 }
 
-func (c *CreateUser) Type() string {
-	return "USER"
+func (c *CreateQuota) Type() string {
+	return "QUOTA"
 }
 
-func (c *CreateUser) String() string {
+func (c *CreateQuota) String() string {
 	var builder strings.Builder
-	builder.WriteString("CREATE USER ")
+	builder.WriteString("CREATE QUOTA ")
 	if c.IfNotExists {
 		builder.WriteString("IF NOT EXISTS ")
 	}
 	if c.OrReplace {
 		builder.WriteString("OR REPLACE ")
 	}
-	for i, userName := range c.UserNames {
+	for i, name := range c.Names {
 		if i > 0 {
 			builder.WriteString(", ")
 		}
-		builder.WriteString(userName.String())
-	}
-	if c.Authentication != nil {
-		builder.WriteString(" ")
-		builder.WriteString(c.Authentication.String())
-	}
-	if len(c.Hosts) > 0 {
-		builder.WriteString(" ")
-		for i, host := range c.Hosts {
-			if i > 0 {
-				builder.WriteString(", ")
-			}
-			builder.WriteString(host.String())
-		}
-	}
-	if c.DefaultRole != nil {
-		builder.WriteString(" ")
-		builder.WriteString(c.DefaultRole.String())
-	}
-	if c.DefaultDatabase != nil {
-		builder.WriteString(" DEFAULT DATABASE ")
-		builder.WriteString(c.DefaultDatabase.String())
-	} else if c.DefaultDbNone {
-		builder.WriteString(" DEFAULT DATABASE NONE")
-	}
-	if c.Grantees != nil {
-		builder.WriteString(" ")
-		builder.WriteString(c.Grantees.String())
+		builder.WriteString(name.String())
 	}
-	if len(c.Settings) > 0 {
-		builder.WriteString(" SETTINGS ")
-		for i, setting := range c.Settings {
-			if i > 0 {
-				builder.WriteString(", ")
-			}
-			builder.WriteString(setting.String())
-		}
+	if c.AccessStorageType != nil {
+		builder.WriteString(" IN ")
+		builder.WriteString(c.AccessStorageType.String())
 	}
+	writeQuotaClauses(&builder, c.KeyedBy, c.NotKeyed, c.Intervals, c.To)
 	return builder.String()
 }
 
-func (c *CreateUser) Accept(visitor ASTVisitor) error {
+func (c *CreateQuota) Accept(visitor ASTVisitor) error {
 	visitor.Enter(c)
 	defer visitor.Leave(c)
-	for _, userName := range c.UserNames {
-		if err := userName.Accept(visitor); err != nil {
-			return err
-		}
-	}
-	if c.Authentication != nil {
-		if err := c.Authentication.Accept(visitor); err != nil {
-			return err
-		}
-	}
-	for _, host := range c.Hosts {
-		if err := host.Accept(visitor); err != nil {
+	for _, name := range c.Names {
+		if err := name.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.DefaultRole != nil {
-		if err := c.DefaultRole.Accept(visitor); err != nil {
+	if c.AccessStorageType != nil {
+		if err := c.AccessStorageType.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.DefaultDatabase != nil {
-		if err := c.DefaultDatabase.Accept(visitor); err != nil {
+	for _, key := range c.KeyedBy {
+		if err := key.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if c.Grantees != nil {
-		if err := c.Grantees.Accept(visitor); err != nil {
+	for _, interval := range c.Intervals {
+		if err := interval.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	for _, setting := range c.Settings {
-		if err := setting.Accept(visitor); err != nil {
+	if c.To != nil {
+		if err := c.To.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitCreateUser(c)
+	return visitor.VisitCreateQuota(c)
 }
 
-type AlterRole struct {
+type AlterQuota struct {
 	AlterPos        Pos
 	StatementEnd    Pos
 	IfExists        bool
 	RoleRenamePairs []*RoleRenamePair
-	Settings        []*RoleSetting
+	KeyedBy         []*Ident
+	NotKeyed        bool
+	Intervals       []*QuotaInterval
+	To              *RoleList
 }
 
-func (a *AlterRole) Start() Pos {
+func (a *AlterQuota) Start() Pos {
 	return a.AlterPos
 }
 
-func (a *AlterRole) End() Pos {
+func (a *AlterQuota) End() Pos {
 	return a.StatementEnd
 }
 
-func (a *AlterRole) Type() string {
-	return "ROLE"
+func (a *AlterQuota) Type() string {
+	return "QUOTA"
 }
 
-func (a *AlterRole) String() string {
+func (a *AlterQuota) String() string {
 	var builder strings.Builder
-	builder.WriteString("ALTER ROLE ")
+	builder.WriteString("ALTER QUOTA ")
 	if a.IfExists {
 		builder.WriteString("IF EXISTS ")
 	}
@@ -2656,19 +5036,11 @@ func (a *AlterRole) String() string {
 		}
 		builder.WriteString(roleRenamePair.String())
 	}
-	if len(a.Settings) > 0 {
-		builder.WriteString(" SETTINGS ")
-		for i, setting := range a.Settings {
-			if i > 0 {
-				builder.WriteString(", ")
-			}
-			builder.WriteString(setting.String())
-		}
-	}
+	writeQuotaClauses(&builder, a.KeyedBy, a.NotKeyed, a.Intervals, a.To)
 	return builder.String()
 }
 
-func (a *AlterRole) Accept(visitor ASTVisitor) error {
+func (a *AlterQuota) Accept(visitor ASTVisitor) error {
 	visitor.Enter(a)
 	defer visitor.Leave(a)
 	for _, roleRenamePair := range a.RoleRenamePairs {
@@ -2676,50 +5048,48 @@ func (a *AlterRole) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
-	for _, setting := range a.Settings {
-		if err := setting.Accept(visitor); err != nil {
+	for _, key := range a.KeyedBy {
+		if err := key.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitAlterRole(a)
-}
-
-type RoleRenamePair struct {
-	RoleName     *RoleName
-	NewName      Expr
-	StatementEnd Pos
-}
-
-func (r *RoleRenamePair) Start() Pos {
-	return r.RoleName.Start()
-}
-
-func (r *RoleRenamePair) End() Pos {
-	return r.StatementEnd
-}
-
-func (r *RoleRenamePair) String() string {
-	var builder strings.Builder
-	builder.WriteString(r.RoleName.String())
-	if r.NewName != nil {
-		builder.WriteString(" RENAME TO ")
-		builder.WriteString(r.NewName.String())
+	for _, interval := range a.Intervals {
+		if err := interval.Accept(visitor); err != nil {
+			return err
+		}
 	}
-	return builder.String()
+	if a.To != nil {
+		if err := a.To.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitAlterQuota(a)
 }
 
-func (r *RoleRenamePair) Accept(visitor ASTVisitor) error {
-	visitor.Enter(r)
-	defer visitor.Leave(r)
-	if err := r.RoleName.Accept(visitor); err != nil {
-		return err
+func writeQuotaClauses(builder *strings.Builder, keyedBy []*Ident, notKeyed bool, intervals []*QuotaInterval, to *RoleList) {
+	if notKeyed {
+		builder.WriteString(" NOT KEYED")
 	}
-	if r.NewName != nil {
-		if err := r.NewName.Accept(visitor); err != nil {
-			return err
+	for i, key := range keyedBy {
+		if i == 0 {
+			builder.WriteString(" KEYED BY ")
+		} else {
+			builder.WriteString(", ")
 		}
+		builder.WriteString(key.String())
+	}
+	for i, interval := range intervals {
+		if i == 0 {
+			builder.WriteString(" ")
+		} else {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(interval.String())
+	}
+	if to != nil {
+		builder.WriteString(" TO ")
+		builder.WriteString(to.String())
 	}
-	return visitor.VisitRoleRenamePair(r)
 }
 
 type DestinationClause struct {
@@ -3116,11 +5486,14 @@ func (o *ClusterClause) Accept(visitor ASTVisitor) error {
 	return visitor.VisitOnClusterExpr(o)
 }
 
+// PartitionClause is PARTITION expr|ID 'id'|ALL, or PART 'name' when Part is set, Expr is then
+// the name of the data part.
 type PartitionClause struct {
 	PartitionPos Pos
 	Expr         Expr
 	ID           *StringLiteral
 	All          bool
+	Part         bool
 }
 
 func (p *PartitionClause) Start() Pos {
@@ -3136,7 +5509,11 @@ func (p *PartitionClause) End() Pos {
 
 func (p *PartitionClause) String() string {
 	var builder strings.Builder
-	builder.WriteString("PARTITION ")
+	if p.Part {
+		builder.WriteString("PART ")
+	} else {
+		builder.WriteString("PARTITION ")
+	}
 	if p.ID != nil {
 		builder.WriteString(p.ID.String())
 	} else if p.All {
@@ -3501,83 +5878,259 @@ func (t *TTLClause) Start() Pos {
 	return t.TTLPos
 }
 
-func (t *TTLClause) End() Pos {
-	return t.ListEnd
+func (t *TTLClause) End() Pos {
+	return t.ListEnd
+}
+
+func (t *TTLClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("TTL ")
+	for i, item := range t.Items {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(item.String())
+	}
+	return builder.String()
+}
+
+func (t *TTLClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(t)
+	defer visitor.Leave(t)
+	for _, item := range t.Items {
+		if err := item.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitTTLExprList(t)
+}
+
+type OrderExpr struct {
+	OrderPos  Pos
+	OrderEnd  Pos
+	Expr      Expr
+	Alias     *Ident
+	Direction OrderDirection
+	Nulls     NullsOrder
+	Collate   *StringLiteral
+	WithFill  *WithFillClause
+}
+
+func (o *OrderExpr) Start() Pos {
+	return o.OrderPos
+}
+
+func (o *OrderExpr) End() Pos {
+	if o.OrderEnd != 0 {
+		return o.OrderEnd
+	}
+	if o.Alias != nil {
+		return o.Alias.End()
+	}
+	return o.Expr.End()
+}
+
+func (o *OrderExpr) String() string {
+	var builder strings.Builder
+	builder.WriteString(o.Expr.String())
+	if o.Alias != nil {
+		builder.WriteString(" AS ")
+		builder.WriteString(o.Alias.String())
+	}
+	if o.Direction != OrderDirectionNone {
+		builder.WriteByte(' ')
+		builder.WriteString(string(o.Direction))
+	}
+	if o.Nulls != NullsOrderNone {
+		builder.WriteByte(' ')
+		builder.WriteString(string(o.Nulls))
+	}
+	if o.Collate != nil {
+		builder.WriteString(" COLLATE ")
+		builder.WriteString(o.Collate.String())
+	}
+	if o.WithFill != nil {
+		builder.WriteByte(' ')
+		builder.WriteString(o.WithFill.String())
+	}
+	return builder.String()
+}
+
+func (o *OrderExpr) Accept(visitor ASTVisitor) error {
+	visitor.Enter(o)
+	defer visitor.Leave(o)
+	if err := o.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	if o.Alias != nil {
+		if err := o.Alias.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if o.Collate != nil {
+		if err := o.Collate.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if o.WithFill != nil {
+		if err := o.WithFill.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitOrderByExpr(o)
+}
+
+// WithFillClause is the ClickHouse `WITH FILL [FROM expr] [TO expr] [STEP expr]` order modifier.
+type WithFillClause struct {
+	WithPos Pos
+	FillEnd Pos
+	From    Expr
+	To      Expr
+	Step    Expr
+}
+
+func (w *WithFillClause) Start() Pos {
+	return w.WithPos
+}
+
+func (w *WithFillClause) End() Pos {
+	switch {
+	case w.Step != nil:
+		return w.Step.End()
+	case w.To != nil:
+		return w.To.End()
+	case w.From != nil:
+		return w.From.End()
+	}
+	return w.FillEnd
+}
+
+func (w *WithFillClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("WITH FILL")
+	if w.From != nil {
+		builder.WriteString(" FROM ")
+		builder.WriteString(w.From.String())
+	}
+	if w.To != nil {
+		builder.WriteString(" TO ")
+		builder.WriteString(w.To.String())
+	}
+	if w.Step != nil {
+		builder.WriteString(" STEP ")
+		builder.WriteString(w.Step.String())
+	}
+	return builder.String()
+}
+
+func (w *WithFillClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(w)
+	defer visitor.Leave(w)
+	if w.From != nil {
+		if err := w.From.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if w.To != nil {
+		if err := w.To.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if w.Step != nil {
+		if err := w.Step.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitWithFillClause(w)
+}
+
+// InterpolateClause is the ClickHouse `INTERPOLATE [(col [AS expr], ...)]` clause of ORDER BY ... WITH FILL.
+type InterpolateClause struct {
+	InterpolatePos Pos
+	ListEnd        Pos
+	Items          []*InterpolateItem
+}
+
+func (i *InterpolateClause) Start() Pos {
+	return i.InterpolatePos
+}
+
+func (i *InterpolateClause) End() Pos {
+	return i.ListEnd
 }
 
-func (t *TTLClause) String() string {
+func (i *InterpolateClause) String() string {
 	var builder strings.Builder
-	builder.WriteString("TTL ")
-	for i, item := range t.Items {
-		if i > 0 {
-			builder.WriteString(", ")
+	builder.WriteString("INTERPOLATE")
+	if len(i.Items) > 0 {
+		builder.WriteString(" (")
+		for j, item := range i.Items {
+			if j > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(item.String())
 		}
-		builder.WriteString(item.String())
+		builder.WriteByte(')')
 	}
 	return builder.String()
 }
 
-func (t *TTLClause) Accept(visitor ASTVisitor) error {
-	visitor.Enter(t)
-	defer visitor.Leave(t)
-	for _, item := range t.Items {
+func (i *InterpolateClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(i)
+	defer visitor.Leave(i)
+	for _, item := range i.Items {
 		if err := item.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitTTLExprList(t)
+	return visitor.VisitInterpolateClause(i)
 }
 
-type OrderExpr struct {
-	OrderPos  Pos
-	Expr      Expr
-	Alias     *Ident
-	Direction OrderDirection
+type InterpolateItem struct {
+	Column *Ident
+	Expr   Expr
 }
 
-func (o *OrderExpr) Start() Pos {
-	return o.OrderPos
+func (i *InterpolateItem) Start() Pos {
+	return i.Column.Start()
 }
 
-func (o *OrderExpr) End() Pos {
-	if o.Alias != nil {
-		return o.Alias.End()
+func (i *InterpolateItem) End() Pos {
+	if i.Expr != nil {
+		return i.Expr.End()
 	}
-	return o.Expr.End()
+	return i.Column.End()
 }
 
-func (o *OrderExpr) String() string {
+func (i *InterpolateItem) String() string {
 	var builder strings.Builder
-	builder.WriteString(o.Expr.String())
-	if o.Alias != nil {
+	builder.WriteString(i.Column.String())
+	if i.Expr != nil {
 		builder.WriteString(" AS ")
-		builder.WriteString(o.Alias.String())
-	}
-	if o.Direction != OrderDirectionNone {
-		builder.WriteByte(' ')
-		builder.WriteString(string(o.Direction))
+		builder.WriteString(i.Expr.String())
 	}
 	return builder.String()
 }
 
-func (o *OrderExpr) Accept(visitor ASTVisitor) error {
-	visitor.Enter(o)
-	defer visitor.Leave(o)
-	if err := o.Expr.Accept(visitor); err != nil {
+func (i *InterpolateItem) Accept(visitor ASTVisitor) error {
+	visitor.Enter(i)
+	defer visitor.Leave(i)
+	if err := i.Column.Accept(visitor); err != nil {
 		return err
 	}
-	if o.Alias != nil {
-		if err := o.Alias.Accept(visitor); err != nil {
+	if i.Expr != nil {
+		if err := i.Expr.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitOrderByExpr(o)
+	return visitor.VisitInterpolateItem(i)
 }
 
 type OrderByClause struct {
-	OrderPos Pos
-	ListEnd  Pos
-	Items    []Expr
+	OrderPos    Pos
+	ListEnd     Pos
+	Items       []Expr
+	Interpolate *InterpolateClause
 }
 
 func (o *OrderByClause) Start() Pos {
@@ -3598,6 +6151,10 @@ func (o *OrderByClause) String() string {
 			builder.WriteByte(' ')
 		}
 	}
+	if o.Interpolate != nil {
+		builder.WriteByte(' ')
+		builder.WriteString(o.Interpolate.String())
+	}
 	return builder.String()
 }
 
@@ -3609,6 +6166,11 @@ func (o *OrderByClause) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if o.Interpolate != nil {
+		if err := o.Interpolate.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitOrderByListExpr(o)
 }
 
@@ -3777,6 +6339,8 @@ type QueryParam struct {
 	RBracePos Pos
 	Name      *Ident
 	Type      ColumnType
+	// literal is the value printed instead of the parameter while it is bound, see Bind
+	literal string
 }
 
 func (q *QueryParam) Start() Pos {
@@ -3788,6 +6352,9 @@ func (q *QueryParam) End() Pos {
 }
 
 func (q *QueryParam) String() string {
+	if q.literal != "" {
+		return q.literal
+	}
 	var builder strings.Builder
 	builder.WriteString("{")
 	builder.WriteString(q.Name.String())
@@ -4046,6 +6613,7 @@ type ColumnDef struct {
 	PrimaryKey       bool
 	Unique           bool
 	OnUpdate         *FunctionExpr
+	Generated        *GeneratedColumn
 }
 
 func (c *ColumnDef) GetName() string {
@@ -4094,6 +6662,10 @@ func (c *ColumnDef) String() string {
 		builder.WriteString(" ALIAS ")
 		builder.WriteString(c.AliasExpr.String())
 	}
+	if c.Generated != nil {
+		builder.WriteByte(' ')
+		builder.WriteString(c.Generated.String())
+	}
 	if c.Codec != nil {
 		builder.WriteByte(' ')
 		builder.WriteString(c.Codec.String())
@@ -4145,6 +6717,11 @@ func (c *ColumnDef) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if c.Generated != nil {
+		if err := c.Generated.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	if c.Codec != nil {
 		if err := c.Codec.Accept(visitor); err != nil {
 			return err
@@ -4163,6 +6740,53 @@ func (c *ColumnDef) Accept(visitor ASTVisitor) error {
 	return visitor.VisitColumnDef(c)
 }
 
+// GeneratedColumn is the PostgreSQL `GENERATED {ALWAYS|BY DEFAULT} AS IDENTITY` or
+// `GENERATED ALWAYS AS (expr) STORED` column constraint.
+type GeneratedColumn struct {
+	GeneratedPos Pos
+	GeneratedEnd Pos
+	Always       bool
+	// Expr is nil for identity columns.
+	Expr Expr
+}
+
+func (g *GeneratedColumn) Start() Pos {
+	return g.GeneratedPos
+}
+
+func (g *GeneratedColumn) End() Pos {
+	return g.GeneratedEnd
+}
+
+func (g *GeneratedColumn) String() string {
+	var builder strings.Builder
+	builder.WriteString("GENERATED ")
+	if g.Always {
+		builder.WriteString("ALWAYS")
+	} else {
+		builder.WriteString("BY DEFAULT")
+	}
+	if g.Expr != nil {
+		builder.WriteString(" AS (")
+		builder.WriteString(g.Expr.String())
+		builder.WriteString(") STORED")
+	} else {
+		builder.WriteString(" AS IDENTITY")
+	}
+	return builder.String()
+}
+
+func (g *GeneratedColumn) Accept(visitor ASTVisitor) error {
+	visitor.Enter(g)
+	defer visitor.Leave(g)
+	if g.Expr != nil {
+		if err := g.Expr.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitGeneratedColumn(g)
+}
+
 type ColumnType interface {
 	Expr
 	Type() string
@@ -4197,6 +6821,50 @@ func (s *ScalarType) Type() string {
 	return s.Name.Name
 }
 
+// ArrayType is the PostgreSQL array type suffix, e.g. `int[]` or `text[3]`.
+type ArrayType struct {
+	ElementType     ColumnType
+	RightBracketPos Pos
+	Size            *NumberLiteral
+}
+
+func (a *ArrayType) Start() Pos {
+	return a.ElementType.Start()
+}
+
+func (a *ArrayType) End() Pos {
+	return a.RightBracketPos
+}
+
+func (a *ArrayType) String() string {
+	var builder strings.Builder
+	builder.WriteString(a.ElementType.String())
+	builder.WriteByte('[')
+	if a.Size != nil {
+		builder.WriteString(a.Size.String())
+	}
+	builder.WriteByte(']')
+	return builder.String()
+}
+
+func (a *ArrayType) Accept(visitor ASTVisitor) error {
+	visitor.Enter(a)
+	defer visitor.Leave(a)
+	if err := a.ElementType.Accept(visitor); err != nil {
+		return err
+	}
+	if a.Size != nil {
+		if err := a.Size.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitArrayType(a)
+}
+
+func (a *ArrayType) Type() string {
+	return a.ElementType.Type() + "[]"
+}
+
 type JSONPath struct {
 	Idents []*Ident
 }
@@ -4594,10 +7262,13 @@ func (s *StringLiteral) Accept(visitor ASTVisitor) error {
 	return visitor.VisitStringLiteral(s)
 }
 
+// PlaceHolder is a positional placeholder `?` or a numbered placeholder `$1`, Type is the placeholder as written.
 type PlaceHolder struct {
 	PlaceholderPos Pos
 	PlaceHolderEnd Pos
 	Type           string
+	// literal is the value printed instead of the placeholder while it is bound, see Bind
+	literal string
 }
 
 func (p *PlaceHolder) Start() Pos {
@@ -4609,6 +7280,9 @@ func (p *PlaceHolder) End() Pos {
 }
 
 func (p *PlaceHolder) String() string {
+	if p.literal != "" {
+		return p.literal
+	}
 	return p.Type
 }
 
@@ -4618,6 +7292,40 @@ func (p *PlaceHolder) Accept(visitor ASTVisitor) error {
 	return visitor.VisitPlaceHolderExpr(p)
 }
 
+// NamedParam is a named placeholder bound by the driver.
+//
+// Syntax: :name
+type NamedParam struct {
+	ColonPos Pos
+	Name     *Ident
+	// literal is the value printed instead of the parameter while it is bound, see Bind
+	literal string
+}
+
+func (n *NamedParam) Start() Pos {
+	return n.ColonPos
+}
+
+func (n *NamedParam) End() Pos {
+	return n.Name.End()
+}
+
+func (n *NamedParam) String() string {
+	if n.literal != "" {
+		return n.literal
+	}
+	return ":" + n.Name.String()
+}
+
+func (n *NamedParam) Accept(visitor ASTVisitor) error {
+	visitor.Enter(n)
+	defer visitor.Leave(n)
+	if err := n.Name.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitNamedParam(n)
+}
+
 type RatioExpr struct {
 	Numerator *NumberLiteral
 	// numberLiteral (SLASH numberLiteral)?
@@ -5775,75 +8483,311 @@ func (g *GroupByClause) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
-	return visitor.VisitGroupByExpr(g)
+	return visitor.VisitGroupByExpr(g)
+}
+
+type HavingClause struct {
+	HavingPos Pos
+	Expr      Expr
+}
+
+func (h *HavingClause) Start() Pos {
+	return h.HavingPos
+}
+
+func (h *HavingClause) End() Pos {
+	return h.Expr.End()
+}
+
+func (h *HavingClause) String() string {
+	return "HAVING " + h.Expr.String()
+}
+
+func (h *HavingClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(h)
+	defer visitor.Leave(h)
+	if err := h.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitHavingExpr(h)
+}
+
+type LimitClause struct {
+	LimitPos Pos
+	Limit    Expr
+	Offset   Expr
+	WithTies bool
+	TiesEnd  Pos
+}
+
+func (l *LimitClause) Start() Pos {
+	return l.LimitPos
+}
+
+func (l *LimitClause) End() Pos {
+	if l.WithTies {
+		return l.TiesEnd
+	}
+	if l.Offset != nil {
+		return l.Offset.End()
+	}
+	return l.Limit.End()
+}
+
+func (l *LimitClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("LIMIT ")
+	builder.WriteString(l.Limit.String())
+	if l.Offset != nil {
+		builder.WriteString(" OFFSET ")
+		builder.WriteString(l.Offset.String())
+	}
+	if l.WithTies {
+		builder.WriteString(" WITH TIES")
+	}
+	return builder.String()
+}
+
+func (l *LimitClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(l)
+	defer visitor.Leave(l)
+	if err := l.Limit.Accept(visitor); err != nil {
+		return err
+	}
+	if l.Offset != nil {
+		if err := l.Offset.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitLimitExpr(l)
+}
+
+type QualifyClause struct {
+	QualifyPos Pos
+	Expr       Expr
+}
+
+func (q *QualifyClause) Start() Pos {
+	return q.QualifyPos
+}
+
+func (q *QualifyClause) End() Pos {
+	return q.Expr.End()
+}
+
+func (q *QualifyClause) String() string {
+	return "QUALIFY " + q.Expr.String()
+}
+
+func (q *QualifyClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(q)
+	defer visitor.Leave(q)
+	if err := q.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitQualifyClause(q)
+}
+
+// OffsetClause is the standard `OFFSET n {ROW|ROWS}` clause, LIMIT ... OFFSET is kept in LimitClause.
+type OffsetClause struct {
+	OffsetPos Pos
+	OffsetEnd Pos
+	Offset    Expr
+	Unit      string
+}
+
+func (o *OffsetClause) Start() Pos {
+	return o.OffsetPos
+}
+
+func (o *OffsetClause) End() Pos {
+	return o.OffsetEnd
+}
+
+func (o *OffsetClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("OFFSET ")
+	builder.WriteString(o.Offset.String())
+	if o.Unit != "" {
+		builder.WriteByte(' ')
+		builder.WriteString(o.Unit)
+	}
+	return builder.String()
+}
+
+func (o *OffsetClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(o)
+	defer visitor.Leave(o)
+	if err := o.Offset.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitOffsetClause(o)
+}
+
+// FetchClause is the standard `FETCH {FIRST|NEXT} [n] {ROW|ROWS} {ONLY|WITH TIES}` clause.
+type FetchClause struct {
+	FetchPos Pos
+	FetchEnd Pos
+	Position string
+	Count    Expr
+	Unit     string
+	WithTies bool
+}
+
+func (f *FetchClause) Start() Pos {
+	return f.FetchPos
+}
+
+func (f *FetchClause) End() Pos {
+	return f.FetchEnd
+}
+
+func (f *FetchClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("FETCH ")
+	builder.WriteString(f.Position)
+	if f.Count != nil {
+		builder.WriteByte(' ')
+		builder.WriteString(f.Count.String())
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(f.Unit)
+	if f.WithTies {
+		builder.WriteString(" WITH TIES")
+	} else {
+		builder.WriteString(" ONLY")
+	}
+	return builder.String()
+}
+
+func (f *FetchClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(f)
+	defer visitor.Leave(f)
+	if f.Count != nil {
+		if err := f.Count.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitFetchClause(f)
 }
 
-type HavingClause struct {
-	HavingPos Pos
-	Expr      Expr
+type LockMode string
+
+const (
+	LockModeForUpdate       LockMode = "FOR UPDATE"
+	LockModeForShare        LockMode = "FOR SHARE"
+	LockModeLockInShareMode LockMode = "LOCK IN SHARE MODE"
+)
+
+type LockWait string
+
+const (
+	LockWaitDefault    LockWait = ""
+	LockWaitNowait     LockWait = "NOWAIT"
+	LockWaitSkipLocked LockWait = "SKIP LOCKED"
+)
+
+// LockingClause is a locking read: `FOR {UPDATE|SHARE} [OF table, ...] [NOWAIT|SKIP LOCKED]`
+// or MySQL's `LOCK IN SHARE MODE`.
+type LockingClause struct {
+	LockPos Pos
+	LockEnd Pos
+	Mode    LockMode
+	Tables  []*TableIdentifier
+	Wait    LockWait
 }
 
-func (h *HavingClause) Start() Pos {
-	return h.HavingPos
+func (l *LockingClause) Start() Pos {
+	return l.LockPos
 }
 
-func (h *HavingClause) End() Pos {
-	return h.Expr.End()
+func (l *LockingClause) End() Pos {
+	return l.LockEnd
 }
 
-func (h *HavingClause) String() string {
-	return "HAVING " + h.Expr.String()
+func (l *LockingClause) String() string {
+	var builder strings.Builder
+	builder.WriteString(string(l.Mode))
+	for i, table := range l.Tables {
+		if i == 0 {
+			builder.WriteString(" OF ")
+		} else {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(table.String())
+	}
+	if l.Wait != LockWaitDefault {
+		builder.WriteByte(' ')
+		builder.WriteString(string(l.Wait))
+	}
+	return builder.String()
 }
 
-func (h *HavingClause) Accept(visitor ASTVisitor) error {
-	visitor.Enter(h)
-	defer visitor.Leave(h)
-	if err := h.Expr.Accept(visitor); err != nil {
-		return err
+func (l *LockingClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(l)
+	defer visitor.Leave(l)
+	for _, table := range l.Tables {
+		if err := table.Accept(visitor); err != nil {
+			return err
+		}
 	}
-	return visitor.VisitHavingExpr(h)
+	return visitor.VisitLockingClause(l)
 }
 
-type LimitClause struct {
-	LimitPos Pos
-	Limit    Expr
-	Offset   Expr
+// IntoOutfileClause is `INTO OUTFILE 'file' [COMPRESSION 'method' [LEVEL n]]`.
+type IntoOutfileClause struct {
+	IntoPos     Pos
+	File        *StringLiteral
+	Compression *StringLiteral
+	Level       *NumberLiteral
 }
 
-func (l *LimitClause) Start() Pos {
-	return l.LimitPos
+func (i *IntoOutfileClause) Start() Pos {
+	return i.IntoPos
 }
 
-func (l *LimitClause) End() Pos {
-	if l.Offset != nil {
-		return l.Offset.End()
+func (i *IntoOutfileClause) End() Pos {
+	switch {
+	case i.Level != nil:
+		return i.Level.End()
+	case i.Compression != nil:
+		return i.Compression.End()
 	}
-	return l.Limit.End()
+	return i.File.End()
 }
 
-func (l *LimitClause) String() string {
+func (i *IntoOutfileClause) String() string {
 	var builder strings.Builder
-	builder.WriteString("LIMIT ")
-	builder.WriteString(l.Limit.String())
-	if l.Offset != nil {
-		builder.WriteString(" OFFSET ")
-		builder.WriteString(l.Offset.String())
+	builder.WriteString("INTO OUTFILE ")
+	builder.WriteString(i.File.String())
+	if i.Compression != nil {
+		builder.WriteString(" COMPRESSION ")
+		builder.WriteString(i.Compression.String())
+	}
+	if i.Level != nil {
+		builder.WriteString(" LEVEL ")
+		builder.WriteString(i.Level.String())
 	}
 	return builder.String()
 }
 
-func (l *LimitClause) Accept(visitor ASTVisitor) error {
-	visitor.Enter(l)
-	defer visitor.Leave(l)
-	if err := l.Limit.Accept(visitor); err != nil {
+func (i *IntoOutfileClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(i)
+	defer visitor.Leave(i)
+	if err := i.File.Accept(visitor); err != nil {
 		return err
 	}
-	if l.Offset != nil {
-		if err := l.Offset.Accept(visitor); err != nil {
+	if i.Compression != nil {
+		if err := i.Compression.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitLimitExpr(l)
+	if i.Level != nil {
+		if err := i.Level.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitIntoOutfileClause(i)
 }
 
 type LimitByClause struct {
@@ -6198,41 +9142,150 @@ func (a *ArrayJoinClause) Accept(visitor ASTVisitor) error {
 	return visitor.VisitArrayJoinExpr(a)
 }
 
+// SelectQuery is either a plain SELECT or, when SetOperation is set, a compound
+// query whose OrderBy, Limit, Settings and Format apply to the combined result.
 type SelectQuery struct {
-	SelectPos     Pos
-	StatementEnd  Pos
-	With          *WithClause
-	Top           *TopClause
-	HasDistinct   bool
-	SelectItems   []*SelectItem
-	From          *FromClause
-	ArrayJoin     *ArrayJoinClause
-	Window        *WindowClause
-	Prewhere      *PrewhereClause
-	Where         *WhereClause
-	GroupBy       *GroupByClause
-	WithTotal     bool
-	Having        *HavingClause
-	OrderBy       *OrderByClause
-	LimitBy       *LimitByClause
-	Limit         *LimitClause
-	Settings      *SettingsClause
-	Format        *FormatClause
-	UnionAll      *SelectQuery
-	UnionDistinct *SelectQuery
-	Except        *SelectQuery
+	SelectPos    Pos
+	StatementEnd Pos
+	HasParen     bool
+	With         *WithClause
+	Hints        *OptimizerHints
+	Top          *TopClause
+	HasDistinct  bool
+	SelectItems  []*SelectItem
+	From         *FromClause
+	ArrayJoin    *ArrayJoinClause
+	Window       *WindowClause
+	Prewhere     *PrewhereClause
+	Where        *WhereClause
+	GroupBy      *GroupByClause
+	WithTotal    bool
+	Having       *HavingClause
+	Qualify      *QualifyClause
+	SetOperation *SetOperation
+	OrderBy      *OrderByClause
+	LimitBy      *LimitByClause
+	Limit        *LimitClause
+	Offset       *OffsetClause
+	Fetch        *FetchClause
+	Locking      *LockingClause
+	Settings     *SettingsClause
+	IntoOutfile  *IntoOutfileClause
+	Format       *FormatClause
 }
 
 func (s *SelectQuery) Start() Pos {
 	return s.SelectPos
 }
 
+func (s *SelectQuery) GetFormat() *FormatClause {
+	return s.Format
+}
+
+func (s *SelectQuery) setFormat(format *FormatClause) {
+	s.Format = format
+	s.StatementEnd = format.End()
+}
+
 func (s *SelectQuery) End() Pos {
 	return s.StatementEnd
 }
 
 func (s *SelectQuery) String() string { // nolint: funlen
 	var builder strings.Builder
+	if s.HasParen {
+		builder.WriteByte('(')
+	}
+	if s.SetOperation != nil {
+		builder.WriteString(s.SetOperation.String())
+		// the clauses of a compound query follow the parentheses, inside them the last
+		// operand would take them in the dialects with operand clauses.
+		if s.HasParen {
+			builder.WriteByte(')')
+		}
+	} else {
+		s.writeSelectBody(&builder)
+	}
+	if s.OrderBy != nil {
+		builder.WriteString(" ")
+		builder.WriteString(s.OrderBy.String())
+	}
+	if s.LimitBy != nil {
+		builder.WriteString(" ")
+		builder.WriteString(s.LimitBy.String())
+	}
+	if s.Limit != nil {
+		builder.WriteString(" ")
+		builder.WriteString(s.Limit.String())
+	}
+	if s.Offset != nil {
+		builder.WriteString(" ")
+		builder.WriteString(s.Offset.String())
+	}
+	if s.Fetch != nil {
+		builder.WriteString(" ")
+		builder.WriteString(s.Fetch.String())
+	}
+	if s.Locking != nil {
+		builder.WriteString(" ")
+		builder.WriteString(s.Locking.String())
+	}
+	if s.Settings != nil {
+		builder.WriteString(" ")
+		builder.WriteString(s.Settings.String())
+	}
+	if s.IntoOutfile != nil {
+		builder.WriteString(" ")
+		builder.WriteString(s.IntoOutfile.String())
+	}
+	if s.Format != nil {
+		builder.WriteString(" ")
+		builder.WriteString(s.Format.String())
+	}
+	if s.HasParen && s.SetOperation == nil {
+		builder.WriteByte(')')
+	}
+	return builder.String()
+}
+
+// UnionAll returns the right operand of a `UNION ALL` query.
+//
+// Deprecated: use SetOperation, which also covers INTERSECT and nested operations.
+func (s *SelectQuery) UnionAll() *SelectQuery {
+	return s.setOperand(SetOperatorUnion, SetQuantifierAll)
+}
+
+// UnionDistinct returns the right operand of a `UNION DISTINCT` query.
+//
+// Deprecated: use SetOperation, which also covers INTERSECT and nested operations.
+func (s *SelectQuery) UnionDistinct() *SelectQuery {
+	return s.setOperand(SetOperatorUnion, SetQuantifierDistinct)
+}
+
+// Except returns the right operand of an `EXCEPT` query.
+//
+// Deprecated: use SetOperation, which also covers INTERSECT and nested operations.
+func (s *SelectQuery) Except() *SelectQuery {
+	if s.SetOperation == nil || s.SetOperation.Operator != SetOperatorExcept {
+		return nil
+	}
+	return s.setOperand(SetOperatorExcept, s.SetOperation.Quantifier)
+}
+
+func (s *SelectQuery) setOperand(operator SetOperator, quantifier SetQuantifier) *SelectQuery {
+	if s.SetOperation == nil || s.SetOperation.Operator != operator || s.SetOperation.Quantifier != quantifier {
+		return nil
+	}
+	switch right := s.SetOperation.Right.(type) {
+	case *SelectQuery:
+		return right
+	case *SetOperation:
+		return &SelectQuery{SelectPos: right.Start(), StatementEnd: right.End(), SetOperation: right}
+	}
+	return nil
+}
+
+func (s *SelectQuery) writeSelectBody(builder *strings.Builder) {
 	if s.With != nil {
 		builder.WriteString("WITH")
 		for i, cte := range s.With.CTEs {
@@ -6245,6 +9298,10 @@ func (s *SelectQuery) String() string { // nolint: funlen
 		builder.WriteString(" ")
 	}
 	builder.WriteString("SELECT ")
+	if s.Hints != nil {
+		builder.WriteString(s.Hints.String())
+		builder.WriteString(" ")
+	}
 	if s.HasDistinct {
 		builder.WriteString("DISTINCT ")
 	}
@@ -6286,37 +9343,10 @@ func (s *SelectQuery) String() string { // nolint: funlen
 		builder.WriteString(" ")
 		builder.WriteString(s.Having.String())
 	}
-	if s.OrderBy != nil {
-		builder.WriteString(" ")
-		builder.WriteString(s.OrderBy.String())
-	}
-	if s.LimitBy != nil {
-		builder.WriteString(" ")
-		builder.WriteString(s.LimitBy.String())
-	}
-	if s.Limit != nil {
-		builder.WriteString(" ")
-		builder.WriteString(s.Limit.String())
-	}
-	if s.Settings != nil {
-		builder.WriteString(" ")
-		builder.WriteString(s.Settings.String())
-	}
-	if s.Format != nil {
+	if s.Qualify != nil {
 		builder.WriteString(" ")
-		builder.WriteString(s.Format.String())
-	}
-	if s.UnionAll != nil {
-		builder.WriteString(" UNION ALL ")
-		builder.WriteString(s.UnionAll.String())
-	} else if s.UnionDistinct != nil {
-		builder.WriteString(" UNION DISTINCT ")
-		builder.WriteString(s.UnionDistinct.String())
-	} else if s.Except != nil {
-		builder.WriteString(" EXCEPT ")
-		builder.WriteString(s.Except.String())
+		builder.WriteString(s.Qualify.String())
 	}
-	return builder.String()
 }
 
 func (s *SelectQuery) Accept(visitor ASTVisitor) error {
@@ -6327,6 +9357,11 @@ func (s *SelectQuery) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if s.Hints != nil {
+		if err := s.Hints.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	if s.Top != nil {
 		if err := s.Top.Accept(visitor); err != nil {
 			return err
@@ -6374,6 +9409,16 @@ func (s *SelectQuery) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if s.Qualify != nil {
+		if err := s.Qualify.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if s.SetOperation != nil {
+		if err := s.SetOperation.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	if s.OrderBy != nil {
 		if err := s.OrderBy.Accept(visitor); err != nil {
 			return err
@@ -6389,34 +9434,167 @@ func (s *SelectQuery) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
-	if s.Settings != nil {
-		if err := s.Settings.Accept(visitor); err != nil {
+	if s.Offset != nil {
+		if err := s.Offset.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if s.Format != nil {
-		if err := s.Format.Accept(visitor); err != nil {
+	if s.Fetch != nil {
+		if err := s.Fetch.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if s.UnionAll != nil {
-		if err := s.UnionAll.Accept(visitor); err != nil {
+	if s.Locking != nil {
+		if err := s.Locking.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if s.UnionDistinct != nil {
-		if err := s.UnionDistinct.Accept(visitor); err != nil {
+	if s.Settings != nil {
+		if err := s.Settings.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if s.IntoOutfile != nil {
+		if err := s.IntoOutfile.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	if s.Except != nil {
-		if err := s.Except.Accept(visitor); err != nil {
+	if s.Format != nil {
+		if err := s.Format.Accept(visitor); err != nil {
 			return err
 		}
 	}
 	return visitor.VisitSelectQuery(s)
 }
 
+// OptimizerHints is a MySQL optimizer hint comment following SELECT, INSERT or DELETE.
+//
+// Syntax: /*+ hint_name([arg [, arg] ...]) [hint_name(...) ...] */
+type OptimizerHints struct {
+	HintPos Pos
+	HintEnd Pos
+	Hints   []*OptimizerHint
+}
+
+func (o *OptimizerHints) Start() Pos {
+	return o.HintPos
+}
+
+func (o *OptimizerHints) End() Pos {
+	return o.HintEnd
+}
+
+func (o *OptimizerHints) String() string {
+	var builder strings.Builder
+	builder.WriteString("/*+")
+	for _, hint := range o.Hints {
+		builder.WriteByte(' ')
+		builder.WriteString(hint.String())
+	}
+	builder.WriteString(" */")
+	return builder.String()
+}
+
+func (o *OptimizerHints) Accept(visitor ASTVisitor) error {
+	visitor.Enter(o)
+	defer visitor.Leave(o)
+	for _, hint := range o.Hints {
+		if err := hint.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitOptimizerHints(o)
+}
+
+// OptimizerHint is a single hint, its arguments are kept as written, e.g. `t1@qb1` or `sort_buffer_size = 16M`.
+type OptimizerHint struct {
+	Name       *Ident
+	RightParen Pos
+	Args       []string
+}
+
+func (o *OptimizerHint) Start() Pos {
+	return o.Name.Start()
+}
+
+func (o *OptimizerHint) End() Pos {
+	return o.RightParen + 1
+}
+
+func (o *OptimizerHint) String() string {
+	return o.Name.String() + "(" + strings.Join(o.Args, ", ") + ")"
+}
+
+func (o *OptimizerHint) Accept(visitor ASTVisitor) error {
+	visitor.Enter(o)
+	defer visitor.Leave(o)
+	if err := o.Name.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitOptimizerHint(o)
+}
+
+type SetOperator string
+
+const (
+	SetOperatorUnion     SetOperator = "UNION"
+	SetOperatorIntersect SetOperator = "INTERSECT"
+	SetOperatorExcept    SetOperator = "EXCEPT"
+)
+
+type SetQuantifier string
+
+const (
+	SetQuantifierNone     SetQuantifier = ""
+	SetQuantifierAll      SetQuantifier = "ALL"
+	SetQuantifierDistinct SetQuantifier = "DISTINCT"
+)
+
+// SetOperation combines two queries with UNION, INTERSECT or EXCEPT. Left and Right
+// are either a *SelectQuery (parenthesized when it carries its own ORDER BY or
+// set operation) or a nested *SetOperation bound by precedence.
+type SetOperation struct {
+	Left        Expr
+	OperatorPos Pos
+	Operator    SetOperator
+	Quantifier  SetQuantifier
+	Right       Expr
+}
+
+func (s *SetOperation) Start() Pos {
+	return s.Left.Start()
+}
+
+func (s *SetOperation) End() Pos {
+	return s.Right.End()
+}
+
+func (s *SetOperation) String() string {
+	var builder strings.Builder
+	builder.WriteString(s.Left.String())
+	builder.WriteByte(' ')
+	builder.WriteString(string(s.Operator))
+	if s.Quantifier != SetQuantifierNone {
+		builder.WriteByte(' ')
+		builder.WriteString(string(s.Quantifier))
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(s.Right.String())
+	return builder.String()
+}
+
+func (s *SetOperation) Accept(visitor ASTVisitor) error {
+	visitor.Enter(s)
+	defer visitor.Leave(s)
+	if err := s.Left.Accept(visitor); err != nil {
+		return err
+	}
+	if err := s.Right.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitSetOperation(s)
+}
+
 type SubQuery struct {
 	HasParen bool
 	Select   *SelectQuery
@@ -6570,6 +9748,9 @@ type DropDatabase struct {
 	Name         *Ident
 	IfExists     bool
 	OnCluster    *ClusterClause
+	// IsDetach is set for DETACH, which keeps the data to ATTACH it again.
+	IsDetach bool
+	Format   *FormatClause
 }
 
 func (d *DropDatabase) Start() Pos {
@@ -6584,9 +9765,22 @@ func (d *DropDatabase) Type() string {
 	return "DATABASE"
 }
 
+func (d *DropDatabase) GetFormat() *FormatClause {
+	return d.Format
+}
+
+func (d *DropDatabase) setFormat(format *FormatClause) {
+	d.Format = format
+	d.StatementEnd = format.End()
+}
+
 func (d *DropDatabase) String() string {
 	var builder strings.Builder
-	builder.WriteString("DROP DATABASE ")
+	if d.IsDetach {
+		builder.WriteString("DETACH DATABASE ")
+	} else {
+		builder.WriteString("DROP DATABASE ")
+	}
 	if d.IfExists {
 		builder.WriteString("IF EXISTS ")
 	}
@@ -6595,6 +9789,10 @@ func (d *DropDatabase) String() string {
 		builder.WriteString(" ")
 		builder.WriteString(d.OnCluster.String())
 	}
+	if d.Format != nil {
+		builder.WriteString(" ")
+		builder.WriteString(d.Format.String())
+	}
 	return builder.String()
 }
 
@@ -6609,6 +9807,11 @@ func (d *DropDatabase) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if d.Format != nil {
+		if err := d.Format.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitDropDatabase(d)
 }
 
@@ -6622,6 +9825,9 @@ type DropStmt struct {
 	OnCluster   *ClusterClause
 	IsTemporary bool
 	Modifier    string
+	// IsDetach is set for DETACH, which keeps the data to ATTACH it again.
+	IsDetach bool
+	Format   *FormatClause
 }
 
 func (d *DropStmt) Start() Pos {
@@ -6636,9 +9842,22 @@ func (d *DropStmt) Type() string {
 	return "DROP " + d.DropTarget
 }
 
+func (d *DropStmt) GetFormat() *FormatClause {
+	return d.Format
+}
+
+func (d *DropStmt) setFormat(format *FormatClause) {
+	d.Format = format
+	d.StatementEnd = format.End()
+}
+
 func (d *DropStmt) String() string {
 	var builder strings.Builder
-	builder.WriteString("DROP ")
+	if d.IsDetach {
+		builder.WriteString("DETACH ")
+	} else {
+		builder.WriteString("DROP ")
+	}
 	if d.IsTemporary {
 		builder.WriteString("TEMPORARY ")
 	}
@@ -6654,6 +9873,10 @@ func (d *DropStmt) String() string {
 	if len(d.Modifier) != 0 {
 		builder.WriteString(" " + d.Modifier)
 	}
+	if d.Format != nil {
+		builder.WriteString(" ")
+		builder.WriteString(d.Format.String())
+	}
 	return builder.String()
 }
 
@@ -6668,6 +9891,11 @@ func (d *DropStmt) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if d.Format != nil {
+		if err := d.Format.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitDropStmt(d)
 
 }
@@ -6677,6 +9905,7 @@ type DropUserOrRole struct {
 	Target       string
 	StatementEnd Pos
 	Names        []*RoleName
+	On           []*TableIdentifier
 	IfExists     bool
 	Modifier     string
 	From         *Ident
@@ -6706,6 +9935,7 @@ func (d *DropUserOrRole) String() string {
 		}
 		builder.WriteString(name.String())
 	}
+	writeRowPolicyTables(&builder, d.On)
 	if len(d.Modifier) != 0 {
 		builder.WriteString(" " + d.Modifier)
 	}
@@ -6724,6 +9954,11 @@ func (d *DropUserOrRole) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	for _, table := range d.On {
+		if err := table.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	if d.From != nil {
 		if err := d.From.Accept(visitor); err != nil {
 			return err
@@ -6836,13 +10071,475 @@ func (s *SetStmt) String() string {
 	return builder.String()
 }
 
-func (s *SetStmt) Accept(visitor ASTVisitor) error {
-	visitor.Enter(s)
-	defer visitor.Leave(s)
-	if err := s.Settings.Accept(visitor); err != nil {
-		return err
-	}
-	return visitor.VisitSetExpr(s)
+func (s *SetStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(s)
+	defer visitor.Leave(s)
+	if err := s.Settings.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitSetExpr(s)
+}
+
+// VariableExpr is a MySQL user variable `@name` or system variable `@@[scope.]name`.
+type VariableExpr struct {
+	AtPos  Pos
+	System bool
+	// Scope is the GLOBAL, SESSION, LOCAL, PERSIST or PERSIST_ONLY qualifier of a system variable.
+	Scope string
+	Name  *Ident
+	// literal is the value printed instead of a user variable while it is bound, see BindNamed
+	literal string
+}
+
+func (v *VariableExpr) Start() Pos {
+	return v.AtPos
+}
+
+func (v *VariableExpr) End() Pos {
+	return v.Name.End()
+}
+
+func (v *VariableExpr) String() string {
+	if v.literal != "" {
+		return v.literal
+	}
+	if !v.System {
+		return "@" + v.Name.String()
+	}
+	if v.Scope != "" {
+		return "@@" + v.Scope + "." + v.Name.String()
+	}
+	return "@@" + v.Name.String()
+}
+
+func (v *VariableExpr) Accept(visitor ASTVisitor) error {
+	visitor.Enter(v)
+	defer visitor.Leave(v)
+	if err := v.Name.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitVariableExpr(v)
+}
+
+// VariableAssignment is one assignment of a SetVariableStmt.
+type VariableAssignment struct {
+	// Scope is the GLOBAL, SESSION, LOCAL, PERSIST or PERSIST_ONLY keyword before a plain Name.
+	Scope string
+	// Name is an *Ident or a *VariableExpr.
+	Name Expr
+	// Operator is = or :=.
+	Operator string
+	Value    Expr
+}
+
+func (v *VariableAssignment) Start() Pos {
+	return v.Name.Start()
+}
+
+func (v *VariableAssignment) End() Pos {
+	return v.Value.End()
+}
+
+func (v *VariableAssignment) String() string {
+	var builder strings.Builder
+	if v.Scope != "" {
+		builder.WriteString(v.Scope + " ")
+	}
+	builder.WriteString(v.Name.String())
+	builder.WriteString(" " + v.Operator + " ")
+	builder.WriteString(v.Value.String())
+	return builder.String()
+}
+
+func (v *VariableAssignment) Accept(visitor ASTVisitor) error {
+	visitor.Enter(v)
+	defer visitor.Leave(v)
+	if err := v.Name.Accept(visitor); err != nil {
+		return err
+	}
+	if err := v.Value.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitVariableAssignment(v)
+}
+
+// SetVariableStmt is the MySQL and PostgreSQL SET of session, global and user variables,
+// SetStmt holds the ClickHouse `SET name = value` settings.
+type SetVariableStmt struct {
+	SetPos      Pos
+	Assignments []*VariableAssignment
+}
+
+func (s *SetVariableStmt) Start() Pos {
+	return s.SetPos
+}
+
+func (s *SetVariableStmt) End() Pos {
+	return s.Assignments[len(s.Assignments)-1].End()
+}
+
+func (s *SetVariableStmt) String() string {
+	var builder strings.Builder
+	builder.WriteString("SET ")
+	for i, assignment := range s.Assignments {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(assignment.String())
+	}
+	return builder.String()
+}
+
+func (s *SetVariableStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(s)
+	defer visitor.Leave(s)
+	for _, assignment := range s.Assignments {
+		if err := assignment.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitSetVariableStmt(s)
+}
+
+// SetNamesStmt is `SET NAMES charset [COLLATE collation]` or `SET CHARACTER SET charset`.
+type SetNamesStmt struct {
+	SetPos       Pos
+	StatementEnd Pos
+	CharacterSet bool
+	// Charset is an *Ident, a *StringLiteral or DEFAULT.
+	Charset Expr
+	Collate Expr
+}
+
+func (s *SetNamesStmt) Start() Pos {
+	return s.SetPos
+}
+
+func (s *SetNamesStmt) End() Pos {
+	return s.StatementEnd
+}
+
+func (s *SetNamesStmt) String() string {
+	var builder strings.Builder
+	if s.CharacterSet {
+		builder.WriteString("SET CHARACTER SET ")
+	} else {
+		builder.WriteString("SET NAMES ")
+	}
+	builder.WriteString(s.Charset.String())
+	if s.Collate != nil {
+		builder.WriteString(" COLLATE ")
+		builder.WriteString(s.Collate.String())
+	}
+	return builder.String()
+}
+
+func (s *SetNamesStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(s)
+	defer visitor.Leave(s)
+	if err := s.Charset.Accept(visitor); err != nil {
+		return err
+	}
+	if s.Collate != nil {
+		if err := s.Collate.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitSetNamesStmt(s)
+}
+
+// BeginStmt starts a transaction with BEGIN or START TRANSACTION.
+type BeginStmt struct {
+	BeginPos     Pos
+	StatementEnd Pos
+	// StartTransaction is set for START TRANSACTION, Keyword is the WORK or TRANSACTION following BEGIN.
+	StartTransaction bool
+	Keyword          string
+	// Modes are READ ONLY, READ WRITE or WITH CONSISTENT SNAPSHOT.
+	Modes []string
+}
+
+func (b *BeginStmt) Start() Pos {
+	return b.BeginPos
+}
+
+func (b *BeginStmt) End() Pos {
+	return b.StatementEnd
+}
+
+func (b *BeginStmt) String() string {
+	var builder strings.Builder
+	if b.StartTransaction {
+		builder.WriteString("START TRANSACTION")
+	} else {
+		builder.WriteString("BEGIN")
+	}
+	if b.Keyword != "" {
+		builder.WriteString(" " + b.Keyword)
+	}
+	for i, mode := range b.Modes {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(" " + mode)
+	}
+	return builder.String()
+}
+
+func (b *BeginStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(b)
+	defer visitor.Leave(b)
+	return visitor.VisitBeginStmt(b)
+}
+
+// SetTransactionStmt sets the characteristics of the next transaction, or of those of the session
+// or the server with a Scope.
+type SetTransactionStmt struct {
+	SetPos       Pos
+	StatementEnd Pos
+	// Scope is GLOBAL, SESSION or empty.
+	Scope string
+	// Characteristics are ISOLATION LEVEL level, READ ONLY, READ WRITE, DEFERRABLE or NOT DEFERRABLE.
+	Characteristics []string
+}
+
+func (s *SetTransactionStmt) Start() Pos {
+	return s.SetPos
+}
+
+func (s *SetTransactionStmt) End() Pos {
+	return s.StatementEnd
+}
+
+func (s *SetTransactionStmt) String() string {
+	var builder strings.Builder
+	builder.WriteString("SET ")
+	if s.Scope != "" {
+		builder.WriteString(s.Scope + " ")
+	}
+	builder.WriteString("TRANSACTION ")
+	builder.WriteString(strings.Join(s.Characteristics, ", "))
+	return builder.String()
+}
+
+func (s *SetTransactionStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(s)
+	defer visitor.Leave(s)
+	return visitor.VisitSetTransactionStmt(s)
+}
+
+// CommitStmt ends a transaction with COMMIT.
+type CommitStmt struct {
+	CommitPos    Pos
+	StatementEnd Pos
+	// Keyword is the optional WORK or TRANSACTION.
+	Keyword string
+	// Options are AND [NO] CHAIN and [NO] RELEASE.
+	Options []string
+}
+
+func (c *CommitStmt) Start() Pos {
+	return c.CommitPos
+}
+
+func (c *CommitStmt) End() Pos {
+	return c.StatementEnd
+}
+
+func (c *CommitStmt) String() string {
+	var builder strings.Builder
+	builder.WriteString("COMMIT")
+	if c.Keyword != "" {
+		builder.WriteString(" " + c.Keyword)
+	}
+	for _, option := range c.Options {
+		builder.WriteString(" " + option)
+	}
+	return builder.String()
+}
+
+func (c *CommitStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(c)
+	defer visitor.Leave(c)
+	return visitor.VisitCommitStmt(c)
+}
+
+// RollbackStmt aborts a transaction, or rolls it back to a savepoint.
+type RollbackStmt struct {
+	RollbackPos  Pos
+	StatementEnd Pos
+	// Keyword is the optional WORK or TRANSACTION.
+	Keyword string
+	// Options are AND [NO] CHAIN and [NO] RELEASE.
+	Options   []string
+	Savepoint *Ident
+}
+
+func (r *RollbackStmt) Start() Pos {
+	return r.RollbackPos
+}
+
+func (r *RollbackStmt) End() Pos {
+	return r.StatementEnd
+}
+
+func (r *RollbackStmt) String() string {
+	var builder strings.Builder
+	builder.WriteString("ROLLBACK")
+	if r.Keyword != "" {
+		builder.WriteString(" " + r.Keyword)
+	}
+	if r.Savepoint != nil {
+		builder.WriteString(" TO SAVEPOINT ")
+		builder.WriteString(r.Savepoint.String())
+	}
+	for _, option := range r.Options {
+		builder.WriteString(" " + option)
+	}
+	return builder.String()
+}
+
+func (r *RollbackStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(r)
+	defer visitor.Leave(r)
+	if r.Savepoint != nil {
+		if err := r.Savepoint.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitRollbackStmt(r)
+}
+
+// SavepointStmt is `SAVEPOINT name` or `RELEASE SAVEPOINT name`.
+type SavepointStmt struct {
+	SavepointPos Pos
+	Release      bool
+	Name         *Ident
+}
+
+func (s *SavepointStmt) Start() Pos {
+	return s.SavepointPos
+}
+
+func (s *SavepointStmt) End() Pos {
+	return s.Name.End()
+}
+
+func (s *SavepointStmt) String() string {
+	if s.Release {
+		return "RELEASE SAVEPOINT " + s.Name.String()
+	}
+	return "SAVEPOINT " + s.Name.String()
+}
+
+func (s *SavepointStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(s)
+	defer visitor.Leave(s)
+	if err := s.Name.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitSavepointStmt(s)
+}
+
+// TableLock is one table of LOCK TABLES.
+type TableLock struct {
+	Table   *TableIdentifier
+	Alias   *Ident
+	LockEnd Pos
+	// LockType is READ, READ LOCAL, WRITE or LOW_PRIORITY WRITE.
+	LockType string
+}
+
+func (t *TableLock) Start() Pos {
+	return t.Table.Start()
+}
+
+func (t *TableLock) End() Pos {
+	return t.LockEnd
+}
+
+func (t *TableLock) String() string {
+	var builder strings.Builder
+	builder.WriteString(t.Table.String())
+	if t.Alias != nil {
+		builder.WriteString(" AS ")
+		builder.WriteString(t.Alias.String())
+	}
+	builder.WriteString(" " + t.LockType)
+	return builder.String()
+}
+
+func (t *TableLock) Accept(visitor ASTVisitor) error {
+	visitor.Enter(t)
+	defer visitor.Leave(t)
+	if err := t.Table.Accept(visitor); err != nil {
+		return err
+	}
+	if t.Alias != nil {
+		if err := t.Alias.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitTableLock(t)
+}
+
+type LockTablesStmt struct {
+	LockPos Pos
+	Locks   []*TableLock
+}
+
+func (l *LockTablesStmt) Start() Pos {
+	return l.LockPos
+}
+
+func (l *LockTablesStmt) End() Pos {
+	return l.Locks[len(l.Locks)-1].End()
+}
+
+func (l *LockTablesStmt) String() string {
+	var builder strings.Builder
+	builder.WriteString("LOCK TABLES ")
+	for i, lock := range l.Locks {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(lock.String())
+	}
+	return builder.String()
+}
+
+func (l *LockTablesStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(l)
+	defer visitor.Leave(l)
+	for _, lock := range l.Locks {
+		if err := lock.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitLockTablesStmt(l)
+}
+
+type UnlockTablesStmt struct {
+	UnlockPos    Pos
+	StatementEnd Pos
+}
+
+func (u *UnlockTablesStmt) Start() Pos {
+	return u.UnlockPos
+}
+
+func (u *UnlockTablesStmt) End() Pos {
+	return u.StatementEnd
+}
+
+func (u *UnlockTablesStmt) String() string {
+	return "UNLOCK TABLES"
+}
+
+func (u *UnlockTablesStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(u)
+	defer visitor.Leave(u)
+	return visitor.VisitUnlockTablesStmt(u)
 }
 
 type FormatClause struct {
@@ -6871,6 +10568,50 @@ func (f *FormatClause) Accept(visitor ASTVisitor) error {
 	return visitor.VisitFormatExpr(f)
 }
 
+// FormatStmt is implemented by the statements that keep a trailing `FORMAT name` clause.
+type FormatStmt interface {
+	Expr
+	GetFormat() *FormatClause
+	setFormat(format *FormatClause)
+}
+
+// EffectiveFormat returns the format name requested by a top-level statement, or an
+// empty string if it has none. Compound queries, INSERT ... SELECT and EXPLAIN report
+// the format of the query they wrap when they have no FORMAT of their own.
+func EffectiveFormat(stmt Expr) string {
+	switch stmt := stmt.(type) {
+	case *SelectQuery:
+		if stmt.Format != nil {
+			return stmt.Format.Format.Name
+		}
+		if stmt.SetOperation != nil {
+			right := stmt.SetOperation.Right
+			for {
+				setOperation, ok := right.(*SetOperation)
+				if !ok {
+					break
+				}
+				right = setOperation.Right
+			}
+			return EffectiveFormat(right)
+		}
+	case *InsertStmt:
+		if stmt.Format != nil {
+			return stmt.Format.Format.Name
+		}
+		if stmt.SelectExpr != nil {
+			return EffectiveFormat(stmt.SelectExpr)
+		}
+	case *ExplainStmt:
+		return EffectiveFormat(stmt.Statement)
+	case FormatStmt:
+		if format := stmt.GetFormat(); format != nil {
+			return format.Format.Name
+		}
+	}
+	return ""
+}
+
 type OptimizeStmt struct {
 	OptimizePos  Pos
 	StatementEnd Pos
@@ -6879,6 +10620,7 @@ type OptimizeStmt struct {
 	Partition    *PartitionClause
 	HasFinal     bool
 	Deduplicate  *DeduplicateClause
+	Format       *FormatClause
 }
 
 func (o *OptimizeStmt) Start() Pos {
@@ -6886,9 +10628,20 @@ func (o *OptimizeStmt) Start() Pos {
 }
 
 func (o *OptimizeStmt) End() Pos {
+	if o.Format != nil {
+		return o.Format.End()
+	}
 	return o.StatementEnd
 }
 
+func (o *OptimizeStmt) GetFormat() *FormatClause {
+	return o.Format
+}
+
+func (o *OptimizeStmt) setFormat(format *FormatClause) {
+	o.Format = format
+}
+
 func (o *OptimizeStmt) String() string {
 	var builder strings.Builder
 	builder.WriteString("OPTIMIZE TABLE ")
@@ -6907,6 +10660,10 @@ func (o *OptimizeStmt) String() string {
 	if o.Deduplicate != nil {
 		builder.WriteString(o.Deduplicate.String())
 	}
+	if o.Format != nil {
+		builder.WriteString(" ")
+		builder.WriteString(o.Format.String())
+	}
 	return builder.String()
 }
 
@@ -6931,6 +10688,11 @@ func (o *OptimizeStmt) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if o.Format != nil {
+		if err := o.Format.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitOptimizeExpr(o)
 }
 
@@ -6986,6 +10748,7 @@ func (d *DeduplicateClause) Accept(visitor ASTVisitor) error {
 type SystemStmt struct {
 	SystemPos Pos
 	Expr      Expr
+	Format    *FormatClause
 }
 
 func (s *SystemStmt) Start() Pos {
@@ -6993,10 +10756,24 @@ func (s *SystemStmt) Start() Pos {
 }
 
 func (s *SystemStmt) End() Pos {
+	if s.Format != nil {
+		return s.Format.End()
+	}
 	return s.Expr.End()
 }
 
+func (s *SystemStmt) GetFormat() *FormatClause {
+	return s.Format
+}
+
+func (s *SystemStmt) setFormat(format *FormatClause) {
+	s.Format = format
+}
+
 func (s *SystemStmt) String() string {
+	if s.Format != nil {
+		return "SYSTEM " + s.Expr.String() + " " + s.Format.String()
+	}
 	return "SYSTEM " + s.Expr.String()
 }
 
@@ -7006,6 +10783,11 @@ func (s *SystemStmt) Accept(visitor ASTVisitor) error {
 	if err := s.Expr.Accept(visitor); err != nil {
 		return err
 	}
+	if s.Format != nil {
+		if err := s.Format.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitSystemExpr(s)
 }
 
@@ -7272,9 +11054,11 @@ func (s *SampleClause) Accept(visitor ASTVisitor) error {
 
 type DeleteClause struct {
 	DeletePos Pos
+	Hints     *OptimizerHints
 	Table     *TableIdentifier
 	OnCluster *ClusterClause
 	WhereExpr Expr
+	Returning *ReturningClause
 }
 
 func (d *DeleteClause) Start() Pos {
@@ -7282,12 +11066,26 @@ func (d *DeleteClause) Start() Pos {
 }
 
 func (d *DeleteClause) End() Pos {
-	return d.WhereExpr.End()
+	if d.Returning != nil {
+		return d.Returning.End()
+	}
+	if d.WhereExpr != nil {
+		return d.WhereExpr.End()
+	}
+	if d.OnCluster != nil {
+		return d.OnCluster.End()
+	}
+	return d.Table.End()
 }
 
 func (d *DeleteClause) String() string {
 	var builder strings.Builder
-	builder.WriteString("DELETE FROM ")
+	builder.WriteString("DELETE ")
+	if d.Hints != nil {
+		builder.WriteString(d.Hints.String())
+		builder.WriteString(" ")
+	}
+	builder.WriteString("FROM ")
 	builder.WriteString(d.Table.String())
 	if d.OnCluster != nil {
 		builder.WriteString(" ")
@@ -7297,12 +11095,21 @@ func (d *DeleteClause) String() string {
 		builder.WriteString(" WHERE ")
 		builder.WriteString(d.WhereExpr.String())
 	}
+	if d.Returning != nil {
+		builder.WriteString(" ")
+		builder.WriteString(d.Returning.String())
+	}
 	return builder.String()
 }
 
 func (d *DeleteClause) Accept(visitor ASTVisitor) error {
 	visitor.Enter(d)
 	defer visitor.Leave(d)
+	if d.Hints != nil {
+		if err := d.Hints.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	if err := d.Table.Accept(visitor); err != nil {
 		return err
 	}
@@ -7316,6 +11123,11 @@ func (d *DeleteClause) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if d.Returning != nil {
+		if err := d.Returning.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitDeleteFromExpr(d)
 }
 
@@ -7397,12 +11209,18 @@ func (v *AssignmentValues) Accept(visitor ASTVisitor) error {
 
 type InsertStmt struct {
 	InsertPos       Pos
+	Hints           *OptimizerHints
 	Format          *FormatClause
 	HasTableKeyword bool
 	Table           Expr
 	ColumnNames     *ColumnNamesExpr
 	Values          []*AssignmentValues
 	SelectExpr      *SelectQuery
+	OnConflict      *OnConflictClause
+	Returning       *ReturningClause
+	// Data is the raw inline payload following `FORMAT name`, it runs to the end of the input.
+	Data    string
+	DataPos Pos
 }
 
 func (i *InsertStmt) Start() Pos {
@@ -7410,15 +11228,41 @@ func (i *InsertStmt) Start() Pos {
 }
 
 func (i *InsertStmt) End() Pos {
-	if i.SelectExpr != nil {
+	switch {
+	case i.Returning != nil:
+		return i.Returning.End()
+	case i.OnConflict != nil:
+		return i.OnConflict.End()
+	case i.SelectExpr != nil:
 		return i.SelectExpr.End()
+	case i.Data != "":
+		return i.DataPos + Pos(len(i.Data))
+	case len(i.Values) > 0 && (i.Format == nil || i.Format.Start() < i.Values[0].Start()):
+		return i.Values[len(i.Values)-1].End()
+	case i.Format != nil:
+		return i.Format.End()
+	case i.ColumnNames != nil:
+		return i.ColumnNames.End()
 	}
-	return i.Values[len(i.Values)-1].End()
+	return i.Table.End()
+}
+
+func (i *InsertStmt) GetFormat() *FormatClause {
+	return i.Format
+}
+
+func (i *InsertStmt) setFormat(format *FormatClause) {
+	i.Format = format
 }
 
 func (i *InsertStmt) String() string {
 	var builder strings.Builder
-	builder.WriteString("INSERT INTO ")
+	builder.WriteString("INSERT ")
+	if i.Hints != nil {
+		builder.WriteString(i.Hints.String())
+		builder.WriteString(" ")
+	}
+	builder.WriteString("INTO ")
 	if i.HasTableKeyword {
 		builder.WriteString("TABLE ")
 	}
@@ -7427,9 +11271,13 @@ func (i *InsertStmt) String() string {
 		builder.WriteString(" ")
 		builder.WriteString(i.ColumnNames.String())
 	}
-	if i.Format != nil {
+	if i.Format != nil && len(i.Values) == 0 {
 		builder.WriteString(" ")
 		builder.WriteString(i.Format.String())
+		if i.Data != "" {
+			builder.WriteByte('\n')
+			builder.WriteString(i.Data)
+		}
 	}
 
 	if i.SelectExpr != nil {
@@ -7443,6 +11291,18 @@ func (i *InsertStmt) String() string {
 			}
 			builder.WriteString(value.String())
 		}
+		if i.Format != nil {
+			builder.WriteString(" ")
+			builder.WriteString(i.Format.String())
+		}
+	}
+	if i.OnConflict != nil {
+		builder.WriteString(" ")
+		builder.WriteString(i.OnConflict.String())
+	}
+	if i.Returning != nil {
+		builder.WriteString(" ")
+		builder.WriteString(i.Returning.String())
 	}
 	return builder.String()
 }
@@ -7450,6 +11310,11 @@ func (i *InsertStmt) String() string {
 func (i *InsertStmt) Accept(visitor ASTVisitor) error {
 	visitor.Enter(i)
 	defer visitor.Leave(i)
+	if i.Hints != nil {
+		if err := i.Hints.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	if i.Format != nil {
 		if err := i.Format.Accept(visitor); err != nil {
 			return err
@@ -7473,13 +11338,134 @@ func (i *InsertStmt) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if i.OnConflict != nil {
+		if err := i.OnConflict.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if i.Returning != nil {
+		if err := i.Returning.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitInsertExpr(i)
 }
 
+// OnConflictClause is the PostgreSQL `ON CONFLICT ... DO NOTHING|DO UPDATE` clause of INSERT.
+type OnConflictClause struct {
+	OnPos        Pos
+	StatementEnd Pos
+	Columns      *ColumnNamesExpr
+	Constraint   *Ident
+	DoNothing    bool
+	Assignments  []*Assignment
+	Where        *WhereClause
+}
+
+func (o *OnConflictClause) Start() Pos {
+	return o.OnPos
+}
+
+func (o *OnConflictClause) End() Pos {
+	return o.StatementEnd
+}
+
+func (o *OnConflictClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("ON CONFLICT")
+	if o.Columns != nil {
+		builder.WriteByte(' ')
+		builder.WriteString(o.Columns.String())
+	} else if o.Constraint != nil {
+		builder.WriteString(" ON CONSTRAINT ")
+		builder.WriteString(o.Constraint.String())
+	}
+	if o.DoNothing {
+		builder.WriteString(" DO NOTHING")
+		return builder.String()
+	}
+	builder.WriteString(" DO UPDATE SET ")
+	for i, assignment := range o.Assignments {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(assignment.String())
+	}
+	if o.Where != nil {
+		builder.WriteByte(' ')
+		builder.WriteString(o.Where.String())
+	}
+	return builder.String()
+}
+
+func (o *OnConflictClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(o)
+	defer visitor.Leave(o)
+	if o.Columns != nil {
+		if err := o.Columns.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if o.Constraint != nil {
+		if err := o.Constraint.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, assignment := range o.Assignments {
+		if err := assignment.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if o.Where != nil {
+		if err := o.Where.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitOnConflictClause(o)
+}
+
+// ReturningClause is the PostgreSQL `RETURNING` clause of INSERT and DELETE.
+type ReturningClause struct {
+	ReturningPos Pos
+	Items        []*SelectItem
+}
+
+func (r *ReturningClause) Start() Pos {
+	return r.ReturningPos
+}
+
+func (r *ReturningClause) End() Pos {
+	return r.Items[len(r.Items)-1].End()
+}
+
+func (r *ReturningClause) String() string {
+	var builder strings.Builder
+	builder.WriteString("RETURNING ")
+	for i, item := range r.Items {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(item.String())
+	}
+	return builder.String()
+}
+
+func (r *ReturningClause) Accept(visitor ASTVisitor) error {
+	visitor.Enter(r)
+	defer visitor.Leave(r)
+	for _, item := range r.Items {
+		if err := item.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitReturningClause(r)
+}
+
 type CheckStmt struct {
 	CheckPos  Pos
 	Table     *TableIdentifier
 	Partition *PartitionClause
+	Format    *FormatClause
 }
 
 func (c *CheckStmt) Start() Pos {
@@ -7487,7 +11473,21 @@ func (c *CheckStmt) Start() Pos {
 }
 
 func (c *CheckStmt) End() Pos {
-	return c.Partition.End()
+	if c.Format != nil {
+		return c.Format.End()
+	}
+	if c.Partition != nil {
+		return c.Partition.End()
+	}
+	return c.Table.End()
+}
+
+func (c *CheckStmt) GetFormat() *FormatClause {
+	return c.Format
+}
+
+func (c *CheckStmt) setFormat(format *FormatClause) {
+	c.Format = format
 }
 
 func (c *CheckStmt) String() string {
@@ -7498,6 +11498,10 @@ func (c *CheckStmt) String() string {
 		builder.WriteString(" ")
 		builder.WriteString(c.Partition.String())
 	}
+	if c.Format != nil {
+		builder.WriteString(" ")
+		builder.WriteString(c.Format.String())
+	}
 	return builder.String()
 }
 
@@ -7512,9 +11516,83 @@ func (c *CheckStmt) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
+	if c.Format != nil {
+		if err := c.Format.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitCheckExpr(c)
 }
 
+// KillStmt is `KILL QUERY|MUTATION [ON CLUSTER c] WHERE expr [SYNC|ASYNC|TEST]`.
+type KillStmt struct {
+	KillPos      Pos
+	StatementEnd Pos
+	// Target is QUERY or MUTATION.
+	Target    string
+	OnCluster *ClusterClause
+	Where     *WhereClause
+	// Modifier is SYNC, ASYNC, TEST or empty.
+	Modifier string
+	Format   *FormatClause
+}
+
+func (k *KillStmt) Start() Pos {
+	return k.KillPos
+}
+
+func (k *KillStmt) End() Pos {
+	return k.StatementEnd
+}
+
+func (k *KillStmt) GetFormat() *FormatClause {
+	return k.Format
+}
+
+func (k *KillStmt) setFormat(format *FormatClause) {
+	k.Format = format
+	k.StatementEnd = format.End()
+}
+
+func (k *KillStmt) String() string {
+	var builder strings.Builder
+	builder.WriteString("KILL ")
+	builder.WriteString(k.Target)
+	if k.OnCluster != nil {
+		builder.WriteString(" ")
+		builder.WriteString(k.OnCluster.String())
+	}
+	builder.WriteString(" ")
+	builder.WriteString(k.Where.String())
+	if k.Modifier != "" {
+		builder.WriteString(" " + k.Modifier)
+	}
+	if k.Format != nil {
+		builder.WriteString(" ")
+		builder.WriteString(k.Format.String())
+	}
+	return builder.String()
+}
+
+func (k *KillStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(k)
+	defer visitor.Leave(k)
+	if k.OnCluster != nil {
+		if err := k.OnCluster.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := k.Where.Accept(visitor); err != nil {
+		return err
+	}
+	if k.Format != nil {
+		if err := k.Format.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitKillStmt(k)
+}
+
 type UnaryExpr struct {
 	UnaryPos Pos
 	Kind     TokenKind
@@ -7695,8 +11773,10 @@ type GrantPrivilegeStmt struct {
 	OnCluster    *ClusterClause
 	Privileges   []*PrivilegeClause
 	On           *TableIdentifier
-	To           []*Ident
-	WithOptions  []string
+	// Roles is set instead of Privileges and On for `GRANT role TO user`.
+	Roles       []*RoleName
+	To          []*RoleName
+	WithOptions []string
 }
 
 func (g *GrantPrivilegeStmt) Start() Pos {
@@ -7724,8 +11804,16 @@ func (g *GrantPrivilegeStmt) String() string {
 		}
 		builder.WriteString(privilege.String())
 	}
-	builder.WriteString(" ON ")
-	builder.WriteString(g.On.String())
+	if g.On != nil {
+		builder.WriteString(" ON ")
+		builder.WriteString(g.On.String())
+	}
+	for i, role := range g.Roles {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(role.String())
+	}
 	builder.WriteString(" TO ")
 	for i, role := range g.To {
 		if i > 0 {
@@ -7753,8 +11841,15 @@ func (g *GrantPrivilegeStmt) Accept(visitor ASTVisitor) error {
 			return err
 		}
 	}
-	if err := g.On.Accept(visitor); err != nil {
-		return err
+	if g.On != nil {
+		if err := g.On.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, role := range g.Roles {
+		if err := role.Accept(visitor); err != nil {
+			return err
+		}
 	}
 	for _, role := range g.To {
 		if err := role.Accept(visitor); err != nil {
@@ -7763,3 +11858,90 @@ func (g *GrantPrivilegeStmt) Accept(visitor ASTVisitor) error {
 	}
 	return visitor.VisitGrantPrivilegeExpr(g)
 }
+
+type RevokeStmt struct {
+	RevokePos      Pos
+	StatementEnd   Pos
+	OnCluster      *ClusterClause
+	GrantOptionFor bool
+	AdminOptionFor bool
+	Privileges     []*PrivilegeClause
+	On             *TableIdentifier
+	Roles          []*RoleName
+	From           *RoleList
+}
+
+func (r *RevokeStmt) Start() Pos {
+	return r.RevokePos
+}
+
+func (r *RevokeStmt) End() Pos {
+	return r.StatementEnd
+}
+
+func (r *RevokeStmt) Type() string {
+	return "REVOKE"
+}
+
+func (r *RevokeStmt) String() string {
+	var builder strings.Builder
+	builder.WriteString("REVOKE ")
+	if r.OnCluster != nil {
+		builder.WriteString(r.OnCluster.String())
+		builder.WriteString(" ")
+	}
+	if r.GrantOptionFor {
+		builder.WriteString("GRANT OPTION FOR ")
+	}
+	if r.AdminOptionFor {
+		builder.WriteString("ADMIN OPTION FOR ")
+	}
+	for i, privilege := range r.Privileges {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(privilege.String())
+	}
+	if r.On != nil {
+		builder.WriteString(" ON ")
+		builder.WriteString(r.On.String())
+	}
+	for i, role := range r.Roles {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(role.String())
+	}
+	builder.WriteString(" FROM ")
+	builder.WriteString(r.From.String())
+	return builder.String()
+}
+
+func (r *RevokeStmt) Accept(visitor ASTVisitor) error {
+	visitor.Enter(r)
+	defer visitor.Leave(r)
+	if r.OnCluster != nil {
+		if err := r.OnCluster.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, privilege := range r.Privileges {
+		if err := privilege.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if r.On != nil {
+		if err := r.On.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, role := range r.Roles {
+		if err := role.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := r.From.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitRevokeStmt(r)
+}
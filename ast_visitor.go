@@ -5,6 +5,7 @@ type ASTVisitor interface {
 	VisitOperationExpr(expr *OperationExpr) error
 	VisitTernaryExpr(expr *TernaryOperation) error
 	VisitBinaryExpr(expr *BinaryOperation) error
+	VisitLambdaExpr(expr *LambdaExpr) error
 	VisitIndexOperation(expr *IndexOperation) error
 	VisitAlterTable(expr *AlterTable) error
 	VisitAlterTableAttachPartition(expr *AlterTableAttachPartition) error
@@ -31,11 +32,45 @@ type ASTVisitor interface {
 	VisitAlterTableModifyQuery(expr *AlterTableModifyQuery) error
 	VisitAlterTableModifyColumn(expr *AlterTableModifyColumn) error
 	VisitAlterTableReplacePartition(expr *AlterTableReplacePartition) error
+	VisitAlterTableUpdate(expr *AlterTableUpdate) error
+	VisitAlterTableDelete(expr *AlterTableDelete) error
+	VisitAlterTableMovePartition(expr *AlterTableMovePartition) error
+	VisitAlterTableFetchPartition(expr *AlterTableFetchPartition) error
+	VisitAlterTableModifySetting(expr *AlterTableModifySetting) error
+	VisitAlterTableResetSetting(expr *AlterTableResetSetting) error
+	VisitAlterTableModifyOrderBy(expr *AlterTableModifyOrderBy) error
+	VisitAlterTableModifyComment(expr *AlterTableModifyComment) error
+	VisitAlterTableApplyDeletedMask(expr *AlterTableApplyDeletedMask) error
+	VisitAlterTableChangeColumn(expr *AlterTableChangeColumn) error
+	VisitAlterTableAddKey(expr *AlterTableAddKey) error
+	VisitAlterTableDropPrimaryKey(expr *AlterTableDropPrimaryKey) error
+	VisitAlterTableAddForeignKey(expr *AlterTableAddForeignKey) error
+	VisitAlterTableDropForeignKey(expr *AlterTableDropForeignKey) error
+	VisitAlterTableAlterColumn(expr *AlterTableAlterColumn) error
+	VisitAlterTableRenameTable(expr *AlterTableRenameTable) error
+	VisitAlterTableRenameIndex(expr *AlterTableRenameIndex) error
+	VisitAlterTableOption(expr *AlterTableOption) error
+	VisitAlterTableConvertCharset(expr *AlterTableConvertCharset) error
+	VisitAlterTableAlgorithm(expr *AlterTableAlgorithm) error
+	VisitAlterTableLock(expr *AlterTableLock) error
+	VisitAlterTableKeys(expr *AlterTableKeys) error
+	VisitGeneratedColumn(expr *GeneratedColumn) error
+	VisitArrayType(expr *ArrayType) error
+	VisitOnConflictClause(expr *OnConflictClause) error
+	VisitReturningClause(expr *ReturningClause) error
+	VisitAssignment(expr *Assignment) error
 	VisitRemovePropertyType(expr *RemovePropertyType) error
 	VisitTableIndex(expr *TableIndex) error
 	VisitIdent(expr *Ident) error
 	VisitUUID(expr *UUID) error
 	VisitCreateDatabase(c *CreateDatabase) error
+	VisitCreateDictionary(expr *CreateDictionary) error
+	VisitDictionaryAttribute(expr *DictionaryAttribute) error
+	VisitDictionaryArg(expr *DictionaryArg) error
+	VisitDictionarySourceClause(expr *DictionarySourceClause) error
+	VisitDictionaryLayoutClause(expr *DictionaryLayoutClause) error
+	VisitDictionaryLifetimeClause(expr *DictionaryLifetimeClause) error
+	VisitDictionaryRangeClause(expr *DictionaryRangeClause) error
 	VisitTableOption(t *TableOption) error
 	VisitCreateTable(c *CreateTable) error
 	VisitCreateMaterializedView(expr *CreateMaterializedView) error
@@ -52,6 +87,26 @@ type ASTVisitor interface {
 	VisitGranteesClause(expr *GranteesClause) error
 	VisitAlterRole(expr *AlterRole) error
 	VisitRoleRenamePair(expr *RoleRenamePair) error
+	VisitRoleList(expr *RoleList) error
+	VisitAlterUser(expr *AlterUser) error
+	VisitSetRoleStmt(expr *SetRoleStmt) error
+	VisitCreateRowPolicy(expr *CreateRowPolicy) error
+	VisitAlterRowPolicy(expr *AlterRowPolicy) error
+	VisitCreateSettingsProfile(expr *CreateSettingsProfile) error
+	VisitAlterSettingsProfile(expr *AlterSettingsProfile) error
+	VisitQuotaInterval(expr *QuotaInterval) error
+	VisitCreateQuota(expr *CreateQuota) error
+	VisitAlterQuota(expr *AlterQuota) error
+	RoleList(expr *RoleList) error
+	AlterUser(expr *AlterUser) error
+	SetRoleStmt(expr *SetRoleStmt) error
+	CreateRowPolicy(expr *CreateRowPolicy) error
+	AlterRowPolicy(expr *AlterRowPolicy) error
+	CreateSettingsProfile(expr *CreateSettingsProfile) error
+	AlterSettingsProfile(expr *AlterSettingsProfile) error
+	QuotaInterval(expr *QuotaInterval) error
+	CreateQuota(expr *CreateQuota) error
+	AlterQuota(expr *AlterQuota) error
 	VisitDestinationExpr(expr *DestinationClause) error
 	VisitConstraintExpr(expr *ConstraintClause) error
 	VisitNullLiteral(expr *NullLiteral) error
@@ -75,6 +130,9 @@ type ASTVisitor interface {
 	VisitRefreshExpr(expr *RefreshExpr) error
 	VisitOrderByExpr(expr *OrderExpr) error
 	VisitOrderByListExpr(expr *OrderByClause) error
+	VisitWithFillClause(expr *WithFillClause) error
+	VisitInterpolateClause(expr *InterpolateClause) error
+	VisitInterpolateItem(expr *InterpolateItem) error
 	VisitSettingsExpr(expr *SettingExprList) error
 	VisitSettingsExprList(expr *SettingsClause) error
 	VisitParamExprList(expr *ParamExprList) error
@@ -126,6 +184,11 @@ type ASTVisitor interface {
 	VisitGroupByExpr(expr *GroupByClause) error
 	VisitHavingExpr(expr *HavingClause) error
 	VisitLimitExpr(expr *LimitClause) error
+	VisitQualifyClause(expr *QualifyClause) error
+	VisitOffsetClause(expr *OffsetClause) error
+	VisitFetchClause(expr *FetchClause) error
+	VisitLockingClause(expr *LockingClause) error
+	VisitIntoOutfileClause(expr *IntoOutfileClause) error
 	VisitLimitByExpr(expr *LimitByClause) error
 	VisitWindowConditionExpr(expr *WindowExpr) error
 	VisitWindowExpr(expr *WindowClause) error
@@ -137,6 +200,9 @@ type ASTVisitor interface {
 	VisitWindowFrameNumber(expr *WindowFrameNumber) error
 	VisitArrayJoinExpr(expr *ArrayJoinClause) error
 	VisitSelectQuery(expr *SelectQuery) error
+	VisitOptimizerHints(expr *OptimizerHints) error
+	VisitOptimizerHint(expr *OptimizerHint) error
+	VisitSetOperation(expr *SetOperation) error
 	VisitSubQueryExpr(expr *SubQuery) error
 	VisitNotExpr(expr *NotExpr) error
 	VisitNegateExpr(expr *NegateExpr) error
@@ -147,6 +213,18 @@ type ASTVisitor interface {
 	VisitDropUserOrRole(expr *DropUserOrRole) error
 	VisitUseExpr(expr *UseStmt) error
 	VisitSetExpr(expr *SetStmt) error
+	VisitVariableExpr(expr *VariableExpr) error
+	VisitVariableAssignment(expr *VariableAssignment) error
+	VisitSetVariableStmt(expr *SetVariableStmt) error
+	VisitSetNamesStmt(expr *SetNamesStmt) error
+	VisitBeginStmt(expr *BeginStmt) error
+	VisitSetTransactionStmt(expr *SetTransactionStmt) error
+	VisitCommitStmt(expr *CommitStmt) error
+	VisitRollbackStmt(expr *RollbackStmt) error
+	VisitSavepointStmt(expr *SavepointStmt) error
+	VisitTableLock(expr *TableLock) error
+	VisitLockTablesStmt(expr *LockTablesStmt) error
+	VisitUnlockTablesStmt(expr *UnlockTablesStmt) error
 	VisitFormatExpr(expr *FormatClause) error
 	VisitOptimizeExpr(expr *OptimizeStmt) error
 	VisitDeduplicateExpr(expr *DeduplicateClause) error
@@ -159,16 +237,20 @@ type ASTVisitor interface {
 	VisitTruncateTable(expr *TruncateTable) error
 	VisitSampleRatioExpr(expr *SampleClause) error
 	VisitPlaceHolderExpr(expr *PlaceHolder) error
+	VisitNamedParam(expr *NamedParam) error
 	VisitDeleteFromExpr(expr *DeleteClause) error
 	VisitColumnNamesExpr(expr *ColumnNamesExpr) error
 	VisitValuesExpr(expr *AssignmentValues) error
 	VisitInsertExpr(expr *InsertStmt) error
 	VisitCheckExpr(expr *CheckStmt) error
+	VisitKillStmt(expr *KillStmt) error
 	VisitUnaryExpr(expr *UnaryExpr) error
 	VisitRenameStmt(expr *RenameStmt) error
 	VisitExplainExpr(expr *ExplainStmt) error
 	VisitPrivilegeExpr(expr *PrivilegeClause) error
 	VisitGrantPrivilegeExpr(expr *GrantPrivilegeStmt) error
+	VisitRevokeStmt(expr *RevokeStmt) error
+	RevokeStmt(expr *RevokeStmt) error
 	VisitSelectItem(expr *SelectItem) error
 	VisitKey(k *Key) error
 
@@ -203,6 +285,13 @@ func (v *DefaultASTVisitor) VisitBinaryExpr(expr *BinaryOperation) error {
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitLambdaExpr(expr *LambdaExpr) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitIndexOperation(expr *IndexOperation) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -392,6 +481,195 @@ func (v *DefaultASTVisitor) VisitAlterTableReplacePartition(expr *AlterTableRepl
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitAlterTableUpdate(expr *AlterTableUpdate) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableDelete(expr *AlterTableDelete) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableMovePartition(expr *AlterTableMovePartition) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableFetchPartition(expr *AlterTableFetchPartition) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableModifySetting(expr *AlterTableModifySetting) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableResetSetting(expr *AlterTableResetSetting) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableModifyOrderBy(expr *AlterTableModifyOrderBy) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableModifyComment(expr *AlterTableModifyComment) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableApplyDeletedMask(expr *AlterTableApplyDeletedMask) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableChangeColumn(expr *AlterTableChangeColumn) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableAddKey(expr *AlterTableAddKey) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableDropPrimaryKey(expr *AlterTableDropPrimaryKey) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableAddForeignKey(expr *AlterTableAddForeignKey) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableDropForeignKey(expr *AlterTableDropForeignKey) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableAlterColumn(expr *AlterTableAlterColumn) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableRenameTable(expr *AlterTableRenameTable) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableRenameIndex(expr *AlterTableRenameIndex) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableOption(expr *AlterTableOption) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableConvertCharset(expr *AlterTableConvertCharset) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableAlgorithm(expr *AlterTableAlgorithm) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableLock(expr *AlterTableLock) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterTableKeys(expr *AlterTableKeys) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitGeneratedColumn(expr *GeneratedColumn) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitArrayType(expr *ArrayType) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitOnConflictClause(expr *OnConflictClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitReturningClause(expr *ReturningClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAssignment(expr *Assignment) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitRemovePropertyType(expr *RemovePropertyType) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -441,6 +719,55 @@ func (v *DefaultASTVisitor) VisitCreateDatabase(expr *CreateDatabase) error {
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitCreateDictionary(expr *CreateDictionary) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitDictionaryAttribute(expr *DictionaryAttribute) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitDictionaryArg(expr *DictionaryArg) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitDictionarySourceClause(expr *DictionarySourceClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitDictionaryLayoutClause(expr *DictionaryLayoutClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitDictionaryLifetimeClause(expr *DictionaryLifetimeClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitDictionaryRangeClause(expr *DictionaryRangeClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitCreateTable(expr *CreateTable) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -546,6 +873,146 @@ func (v *DefaultASTVisitor) VisitRoleRenamePair(expr *RoleRenamePair) error {
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitRoleList(expr *RoleList) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterUser(expr *AlterUser) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitSetRoleStmt(expr *SetRoleStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitCreateRowPolicy(expr *CreateRowPolicy) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterRowPolicy(expr *AlterRowPolicy) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitCreateSettingsProfile(expr *CreateSettingsProfile) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterSettingsProfile(expr *AlterSettingsProfile) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitQuotaInterval(expr *QuotaInterval) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitCreateQuota(expr *CreateQuota) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitAlterQuota(expr *AlterQuota) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) RoleList(expr *RoleList) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) AlterUser(expr *AlterUser) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) SetRoleStmt(expr *SetRoleStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) CreateRowPolicy(expr *CreateRowPolicy) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) AlterRowPolicy(expr *AlterRowPolicy) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) CreateSettingsProfile(expr *CreateSettingsProfile) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) AlterSettingsProfile(expr *AlterSettingsProfile) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) QuotaInterval(expr *QuotaInterval) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) CreateQuota(expr *CreateQuota) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) AlterQuota(expr *AlterQuota) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitDestinationExpr(expr *DestinationClause) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -707,6 +1174,27 @@ func (v *DefaultASTVisitor) VisitOrderByListExpr(expr *OrderByClause) error {
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitWithFillClause(expr *WithFillClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitInterpolateClause(expr *InterpolateClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitInterpolateItem(expr *InterpolateItem) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitSettingsExpr(expr *SettingExprList) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -1057,6 +1545,41 @@ func (v *DefaultASTVisitor) VisitLimitExpr(expr *LimitClause) error {
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitQualifyClause(expr *QualifyClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitOffsetClause(expr *OffsetClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitFetchClause(expr *FetchClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitLockingClause(expr *LockingClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitIntoOutfileClause(expr *IntoOutfileClause) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitLimitByExpr(expr *LimitByClause) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -1134,6 +1657,27 @@ func (v *DefaultASTVisitor) VisitSelectQuery(expr *SelectQuery) error {
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitOptimizerHints(expr *OptimizerHints) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitOptimizerHint(expr *OptimizerHint) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitSetOperation(expr *SetOperation) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitSubQueryExpr(expr *SubQuery) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -1211,6 +1755,90 @@ func (v *DefaultASTVisitor) VisitSetExpr(expr *SetStmt) error {
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitVariableExpr(expr *VariableExpr) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitVariableAssignment(expr *VariableAssignment) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitSetVariableStmt(expr *SetVariableStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitSetNamesStmt(expr *SetNamesStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitBeginStmt(expr *BeginStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitSetTransactionStmt(expr *SetTransactionStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitCommitStmt(expr *CommitStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitRollbackStmt(expr *RollbackStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitSavepointStmt(expr *SavepointStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitTableLock(expr *TableLock) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitLockTablesStmt(expr *LockTablesStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) VisitUnlockTablesStmt(expr *UnlockTablesStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitFormatExpr(expr *FormatClause) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -1295,6 +1923,13 @@ func (v *DefaultASTVisitor) VisitPlaceHolderExpr(expr *PlaceHolder) error {
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitNamedParam(expr *NamedParam) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitDeleteFromExpr(expr *DeleteClause) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -1323,6 +1958,13 @@ func (v *DefaultASTVisitor) VisitInsertExpr(expr *InsertStmt) error {
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitKillStmt(expr *KillStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitCheckExpr(expr *CheckStmt) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
@@ -1365,6 +2007,20 @@ func (v *DefaultASTVisitor) VisitGrantPrivilegeExpr(expr *GrantPrivilegeStmt) er
 	return nil
 }
 
+func (v *DefaultASTVisitor) VisitRevokeStmt(expr *RevokeStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
+func (v *DefaultASTVisitor) RevokeStmt(expr *RevokeStmt) error {
+	if v.Visit != nil {
+		return v.Visit(expr)
+	}
+	return nil
+}
+
 func (v *DefaultASTVisitor) VisitSelectItem(expr *SelectItem) error {
 	if v.Visit != nil {
 		return v.Visit(expr)
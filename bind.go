@@ -0,0 +1,491 @@
+package parser
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Binder renders statements with their parameters replaced by literals of a dialect.
+//
+// Values are rendered as follows:
+//   - nil and nil pointers as NULL, a driver.Valuer by its value
+//   - strings quoted and escaped, []byte as a hex literal
+//   - time.Time as a date time literal in its location, Local times are converted to UTC for ClickHouse
+//   - a slice bound to `x IN ?` or `x IN (?)` as the list of its elements, elsewhere as an array
+//   - maps as ClickHouse map literals
+type Binder struct {
+	Dialect Dialect
+}
+
+// Bind renders stmt in the default dialect with its `?` or `$1` placeholders replaced by args.
+func Bind(stmt Expr, args ...any) (string, error) {
+	return Binder{}.Bind(stmt, args...)
+}
+
+// BindNamed renders stmt in the default dialect with its `:name` placeholders, `{name: Type}`
+// query parameters and `@name` user variables replaced by args.
+func BindNamed(stmt Expr, args map[string]any) (string, error) {
+	return Binder{}.BindNamed(stmt, args)
+}
+
+// bindable is a parameter node that can print a literal instead of itself.
+type bindable interface {
+	Expr
+	bind(literal string)
+}
+
+func (p *PlaceHolder) bind(literal string) {
+	p.literal = literal
+}
+
+func (n *NamedParam) bind(literal string) {
+	n.literal = literal
+}
+
+func (v *VariableExpr) bind(literal string) {
+	v.literal = literal
+}
+
+func (q *QueryParam) bind(literal string) {
+	q.literal = literal
+}
+
+// Bind renders stmt with its `?` or `$1` placeholders replaced by args. Every placeholder must
+// have an argument and every argument must be used. stmt is unchanged once Bind returns, but
+// must not be printed or bound concurrently.
+func (b Binder) Bind(stmt Expr, args ...any) (string, error) {
+	values := make(map[bindable]any)
+	positional, numbered := 0, 0
+	used := make([]bool, len(args))
+	for _, parameter := range Parameters(stmt, nil) {
+		switch parameter.Kind {
+		case ParameterPositional:
+			positional++
+			if positional > len(args) {
+				continue
+			}
+		case ParameterNumbered:
+			numbered++
+			if parameter.Index < 1 || parameter.Index > len(args) {
+				return "", fmt.Errorf("no argument for placeholder %s, got %d arguments", parameter.Expr, len(args))
+			}
+		case ParameterNamed, ParameterQuery:
+			return "", fmt.Errorf("named parameter %s must be bound by BindNamed", parameter.Expr)
+		default:
+			continue
+		}
+		if positional > 0 && numbered > 0 {
+			return "", fmt.Errorf("placeholders ? and $N can not be mixed")
+		}
+		used[parameter.Index-1] = true
+		values[parameter.Expr.(bindable)] = args[parameter.Index-1]
+	}
+	if positional > 0 && positional != len(args) {
+		return "", fmt.Errorf("expected %d arguments, got %d", positional, len(args))
+	}
+	for i, ok := range used {
+		if !ok {
+			return "", fmt.Errorf("argument %d is not used by any placeholder", i+1)
+		}
+	}
+	return b.render(stmt, values)
+}
+
+// BindNamed renders stmt with its `:name` placeholders, `{name: Type}` query parameters and
+// `@name` user variables replaced by args. Placeholders and query parameters must have an
+// argument, user variables without one and the targets of `@name = ...` assignments are left
+// as they are. The value of a query parameter must fit its declared type. Like Bind, it must
+// not run concurrently on the same stmt.
+func (b Binder) BindNamed(stmt Expr, args map[string]any) (string, error) {
+	values := make(map[bindable]any)
+	used := make(map[string]bool)
+	for _, parameter := range Parameters(stmt, nil) {
+		switch parameter.Kind {
+		case ParameterPositional, ParameterNumbered:
+			return "", fmt.Errorf("placeholder %s must be bound by Bind", parameter.Expr)
+		}
+		value, ok := args[parameter.Name]
+		if !ok {
+			if parameter.Kind == ParameterVariable {
+				continue
+			}
+			return "", fmt.Errorf("missing argument for %s", parameter.Expr)
+		}
+		if parameter.Kind == ParameterQuery {
+			if err := checkDeclaredType(parameter.Type, value); err != nil {
+				return "", fmt.Errorf("argument %s: %w", parameter.Expr, err)
+			}
+		}
+		used[parameter.Name] = true
+		values[parameter.Expr.(bindable)] = value
+	}
+	var unused []string
+	for name := range args {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return "", fmt.Errorf("arguments not used by any parameter: %s", strings.Join(unused, ", "))
+	}
+	return b.render(stmt, values)
+}
+
+// render prints stmt with the values in place of the parameters.
+func (b Binder) render(stmt Expr, values map[bindable]any) (string, error) {
+	lists := inLists(stmt)
+	defer func() {
+		for parameter := range values {
+			parameter.bind("")
+		}
+	}()
+	for parameter, value := range values {
+		literal, err := b.bindLiteral(value, lists[parameter])
+		if err != nil {
+			return "", fmt.Errorf("argument %s: %w", parameter, err)
+		}
+		parameter.bind(literal)
+	}
+	return Format(stmt, b.Dialect)
+}
+
+// inList tells how a parameter is used as the right side of IN.
+type inList int
+
+const (
+	notInList inList = iota
+	// inListBare is `x IN ?`, the list needs its parentheses
+	inListBare
+	// inListItem is `x IN (?)`, the parentheses are already there
+	inListItem
+)
+
+// inLists finds the parameters standing for the whole list of an IN operator.
+func inLists(stmt Expr) map[bindable]inList {
+	lists := make(map[bindable]inList)
+	visitor := &DefaultASTVisitor{
+		Visit: func(expr Expr) error {
+			operation, ok := expr.(*BinaryOperation)
+			if !ok || !strings.HasSuffix(strings.ToUpper(string(operation.Operation)), "IN") {
+				return nil
+			}
+			if parameter, ok := operation.RightExpr.(bindable); ok {
+				lists[parameter] = inListBare
+			}
+			if list, ok := operation.RightExpr.(*ParamExprList); ok && list.Items != nil && len(list.Items.Items) == 1 {
+				item := list.Items.Items[0]
+				if columnExpr, ok := item.(*ColumnExpr); ok {
+					item = columnExpr.Expr
+				}
+				if parameter, ok := item.(bindable); ok {
+					lists[parameter] = inListItem
+				}
+			}
+			return nil
+		},
+	}
+	_ = stmt.Accept(visitor)
+	return lists
+}
+
+// bindLiteral renders the value of a parameter, expanding slices bound to IN lists.
+func (b Binder) bindLiteral(value any, list inList) (string, error) {
+	value, err := driverValue(value)
+	if err != nil {
+		return "", err
+	}
+	rv := reflect.ValueOf(value)
+	if list == notInList || value == nil || !isList(rv) {
+		return b.literal(value)
+	}
+	items, err := b.listItems(rv)
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		// an empty list matches nothing, unlike `IN ()` it is valid in every dialect
+		items = []string{"NULL"}
+	}
+	if list == inListBare {
+		return "(" + strings.Join(items, ", ") + ")", nil
+	}
+	return strings.Join(items, ", "), nil
+}
+
+// literal renders a value as a SQL literal of the dialect.
+func (b Binder) literal(value any) (string, error) { // nolint: funlen
+	value, err := driverValue(value)
+	if err != nil {
+		return "", err
+	}
+	switch value := value.(type) {
+	case nil:
+		return "NULL", nil
+	case []byte:
+		return b.bytesLiteral(value), nil
+	case time.Time:
+		return b.timeLiteral(value), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		return b.literal(rv.Elem().Interface())
+	case reflect.Bool:
+		if rv.Bool() {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return b.floatLiteral(rv.Float())
+	case reflect.String:
+		return b.stringLiteral(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(bytes), rv)
+			return b.bytesLiteral(bytes), nil
+		}
+		items, err := b.listItems(rv)
+		if err != nil {
+			return "", err
+		}
+		switch b.Dialect {
+		case DialectMySQL:
+			return "", fmt.Errorf("%T can only be bound to an IN list in %s", value, b.Dialect)
+		case DialectPostgreSQL:
+			return "ARRAY[" + strings.Join(items, ", ") + "]", nil
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case reflect.Map:
+		if b.Dialect == DialectMySQL || b.Dialect == DialectPostgreSQL {
+			return "", fmt.Errorf("%T can not be bound in %s", value, b.Dialect)
+		}
+		return b.mapLiteral(rv)
+	}
+	return "", fmt.Errorf("unsupported argument type %T", value)
+}
+
+func (b Binder) listItems(rv reflect.Value) ([]string, error) {
+	items := make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		item, err := b.literal(rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (b Binder) mapLiteral(rv reflect.Value) (string, error) {
+	entries := make([]string, 0, rv.Len())
+	for _, key := range rv.MapKeys() {
+		k, err := b.literal(key.Interface())
+		if err != nil {
+			return "", err
+		}
+		v, err := b.literal(rv.MapIndex(key).Interface())
+		if err != nil {
+			return "", err
+		}
+		entries = append(entries, k+": "+v)
+	}
+	// map iteration is random, sort to render the same SQL every time
+	sort.Strings(entries)
+	return "{" + strings.Join(entries, ", ") + "}", nil
+}
+
+func (b Binder) stringLiteral(s string) string {
+	if b.Dialect == DialectPostgreSQL {
+		// standard_conforming_strings keeps backslashes as they are
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return "'" + stringEscaper.Replace(s) + "'"
+}
+
+// stringEscaper escapes strings for MySQL and ClickHouse, where backslash starts an escape sequence.
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `''`, "\x00", `\0`)
+
+func (b Binder) bytesLiteral(bytes []byte) string {
+	digits := strings.ToUpper(hex.EncodeToString(bytes))
+	switch b.Dialect {
+	case DialectMySQL:
+		return "X'" + digits + "'"
+	case DialectPostgreSQL:
+		return `'\x` + digits + "'::bytea"
+	}
+	return "unhex('" + digits + "')"
+}
+
+func (b Binder) floatLiteral(f float64) (string, error) {
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, 64), nil
+	}
+	switch b.Dialect {
+	case DialectClickHouse:
+		return strings.ToLower(strconv.FormatFloat(f, 'g', -1, 64)), nil
+	case DialectPostgreSQL:
+		return "'" + strconv.FormatFloat(f, 'g', -1, 64) + "'::float8", nil
+	}
+	return "", fmt.Errorf("%v can not be bound in %s", f, b.Dialect)
+}
+
+func (b Binder) timeLiteral(t time.Time) string {
+	switch b.Dialect {
+	case DialectClickHouse:
+		if t.Location() == time.Local {
+			t = t.UTC()
+		}
+		if t.Nanosecond() == 0 {
+			return fmt.Sprintf("toDateTime('%s', '%s')", t.Format(time.DateTime), t.Location())
+		}
+		return fmt.Sprintf("toDateTime64('%s', 9, '%s')", t.Format("2006-01-02 15:04:05.000000000"), t.Location())
+	case DialectPostgreSQL:
+		return "'" + t.Format("2006-01-02 15:04:05.999999Z07:00") + "'::timestamptz"
+	}
+	if t.Nanosecond() == 0 {
+		return "'" + t.Format(time.DateTime) + "'"
+	}
+	return "'" + t.Format("2006-01-02 15:04:05.000000") + "'"
+}
+
+// driverValue unwraps a driver.Valuer.
+func driverValue(value any) (any, error) {
+	for {
+		valuer, ok := value.(driver.Valuer)
+		if !ok {
+			return value, nil
+		}
+		if rv := reflect.ValueOf(valuer); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil, nil
+		}
+		next, err := valuer.Value()
+		if err != nil {
+			return nil, err
+		}
+		value = next
+	}
+}
+
+// isList reports whether a value binds to the items of an IN list.
+func isList(rv reflect.Value) bool {
+	kind := rv.Kind()
+	return (kind == reflect.Slice || kind == reflect.Array) && rv.Type().Elem().Kind() != reflect.Uint8
+}
+
+// checkDeclaredType checks that a value fits the ClickHouse type of a query parameter.
+func checkDeclaredType(columnType ColumnType, value any) error { // nolint: funlen
+	value, err := driverValue(value)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if value == nil || rv.Kind() == reflect.Pointer {
+		if complexType, ok := columnType.(*ComplexType); ok && complexType.Name.Name == "Nullable" {
+			return nil
+		}
+		return fmt.Errorf("NULL does not fit %s", columnType)
+	}
+
+	if complexType, ok := columnType.(*ComplexType); ok && len(complexType.Params) > 0 {
+		switch complexType.Name.Name {
+		case "Nullable", "LowCardinality":
+			return checkDeclaredType(complexType.Params[0], rv.Interface())
+		case "Array":
+			if !isList(rv) {
+				return fmt.Errorf("%T does not fit %s", value, columnType)
+			}
+			for i := 0; i < rv.Len(); i++ {
+				if err := checkDeclaredType(complexType.Params[0], rv.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		case "Map":
+			if rv.Kind() != reflect.Map || len(complexType.Params) != 2 {
+				return fmt.Errorf("%T does not fit %s", value, columnType)
+			}
+			for _, key := range rv.MapKeys() {
+				if err := checkDeclaredType(complexType.Params[0], key.Interface()); err != nil {
+					return err
+				}
+				if err := checkDeclaredType(complexType.Params[1], rv.MapIndex(key).Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return nil
+	}
+
+	name := columnType.Type()
+	fits := true
+	switch {
+	case strings.HasPrefix(name, "UInt"):
+		bits, err := strconv.Atoi(strings.TrimPrefix(name, "UInt"))
+		if err != nil {
+			break
+		}
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			fits = rv.Int() >= 0 && (bits >= 64 || rv.Int() < 1<<bits)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			fits = bits >= 64 || rv.Uint() < 1<<bits
+		default:
+			fits = false
+		}
+	case strings.HasPrefix(name, "Int"):
+		bits, err := strconv.Atoi(strings.TrimPrefix(name, "Int"))
+		if err != nil || bits < 8 {
+			break
+		}
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			fits = bits >= 64 || rv.Int() >= -1<<(bits-1) && rv.Int() < 1<<(bits-1)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			fits = bits > 64 || rv.Uint() < 1<<(bits-1)
+		default:
+			fits = false
+		}
+	case strings.HasPrefix(name, "Float"), strings.HasPrefix(name, "Decimal"):
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+		default:
+			fits = false
+		}
+	case name == "Bool":
+		fits = rv.Kind() == reflect.Bool
+	case name == "String", name == "FixedString":
+		fits = rv.Kind() == reflect.String || rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8
+	case name == "UUID", name == "IPv4", name == "IPv6", strings.HasPrefix(name, "Enum"):
+		fits = rv.Kind() == reflect.String
+	case strings.HasPrefix(name, "Date"):
+		_, isTime := rv.Interface().(time.Time)
+		fits = isTime || rv.Kind() == reflect.String
+	}
+	if !fits {
+		return fmt.Errorf("%v (%T) does not fit %s", value, value, columnType)
+	}
+	return nil
+}
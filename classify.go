@@ -0,0 +1,336 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatementCategory is the kind of a top-level statement, see Classify.
+type StatementCategory string
+
+const (
+	// StatementCategoryDQL queries data: SELECT, set operations, EXPLAIN.
+	StatementCategoryDQL StatementCategory = "DQL"
+	// StatementCategoryDML changes data: INSERT, DELETE, ALTER TABLE ... UPDATE|DELETE.
+	StatementCategoryDML StatementCategory = "DML"
+	// StatementCategoryDDL changes schema objects: CREATE, ALTER, DROP, TRUNCATE, RENAME.
+	StatementCategoryDDL StatementCategory = "DDL"
+	// StatementCategoryDCL changes access control: GRANT, REVOKE, users, roles, policies, profiles and quotas.
+	StatementCategoryDCL StatementCategory = "DCL"
+	// StatementCategoryTCL controls transactions.
+	StatementCategoryTCL StatementCategory = "TCL"
+	// StatementCategoryAdmin covers session and server administration: USE, SET, SYSTEM, KILL, OPTIMIZE, CHECK.
+	StatementCategoryAdmin StatementCategory = "ADMIN"
+)
+
+// StatementInfo describes a top-level statement for routing and auditing.
+type StatementInfo struct {
+	Category StatementCategory
+	// ReadOnly is set for statements that change neither data, schema, access control nor the server.
+	ReadOnly bool
+	// Destructive is set for statements that remove data or objects, e.g. DROP, TRUNCATE and DELETE.
+	Destructive bool
+	// Reads lists the tables the statement reads from, names of common table expressions are skipped.
+	Reads []*TableIdentifier
+	// ReadColumns are the columns read from the tables of Reads by table name. A table missing
+	// from it is read whole, e.g. by `SELECT *` or where its columns can not be told apart.
+	ReadColumns map[string][]string
+	// Writes lists the tables the statement creates, changes or removes, a database is written as `db.*`
+	// and a table function as its call, e.g. `remote('host', db.t)`.
+	Writes []*TableIdentifier
+}
+
+// Classify returns the category, side effects and objects of a top-level statement.
+func Classify(stmt Expr) (*StatementInfo, error) {
+	info := &StatementInfo{}
+	switch stmt := stmt.(type) {
+	case *SelectQuery:
+		info.Category = StatementCategoryDQL
+		info.ReadOnly = stmt.IntoOutfile == nil
+	case *SetOperation:
+		info.Category = StatementCategoryDQL
+		info.ReadOnly = true
+	case *ExplainStmt:
+		explained, err := Classify(stmt.Statement)
+		if err != nil {
+			return nil, err
+		}
+		info.Category = StatementCategoryDQL
+		info.ReadOnly = true
+		info.Reads = explained.Reads
+		info.Reads = appendTables(info.Reads, explained.Writes...)
+		info.ReadColumns = explained.ReadColumns
+		return info, nil
+	case *InsertStmt:
+		info.Category = StatementCategoryDML
+		switch table := stmt.Table.(type) {
+		case *TableIdentifier:
+			info.Writes = appendTables(info.Writes, table)
+		case *FunctionExpr:
+			// INSERT INTO FUNCTION
+			info.Writes = appendTables(info.Writes, functionTable(table))
+		}
+	case *DeleteClause:
+		info.Category = StatementCategoryDML
+		info.Destructive = true
+		info.Writes = appendTables(info.Writes, stmt.Table)
+	case *AlterTable:
+		info.classifyAlterTable(stmt)
+	case *CreateTable:
+		info.Category = StatementCategoryDDL
+		info.Writes = appendTables(info.Writes, stmt.Identifier)
+		// CREATE TABLE t2 AS t1 copies the structure of t1
+		if stmt.TableSchema != nil && stmt.TableSchema.AliasTable != nil {
+			info.Reads = appendTables(info.Reads, stmt.TableSchema.AliasTable)
+		}
+	case *CreateView:
+		info.Category = StatementCategoryDDL
+		info.Writes = appendTables(info.Writes, stmt.Name)
+	case *CreateMaterializedView:
+		info.Category = StatementCategoryDDL
+		info.Writes = appendTables(info.Writes, stmt.Name)
+	case *CreateLiveView:
+		info.Category = StatementCategoryDDL
+		info.Writes = appendTables(info.Writes, stmt.Name)
+	case *CreateDictionary:
+		info.Category = StatementCategoryDDL
+		info.Writes = appendTables(info.Writes, stmt.Name)
+	case *CreateDatabase:
+		info.Category = StatementCategoryDDL
+		info.Writes = appendTables(info.Writes, databaseTables(stmt.Name))
+	case *CreateFunction:
+		info.Category = StatementCategoryDDL
+	case *DropStmt:
+		info.Category = StatementCategoryDDL
+		// DETACH keeps the data
+		info.Destructive = !stmt.IsDetach
+		info.Writes = appendTables(info.Writes, stmt.Name)
+	case *DropDatabase:
+		info.Category = StatementCategoryDDL
+		info.Destructive = !stmt.IsDetach
+		info.Writes = appendTables(info.Writes, databaseTables(stmt.Name))
+	case *TruncateTable:
+		info.Category = StatementCategoryDDL
+		info.Destructive = true
+		info.Writes = appendTables(info.Writes, stmt.Name)
+	case *RenameStmt:
+		info.Category = StatementCategoryDDL
+		for _, pair := range stmt.TargetPairList {
+			info.Writes = appendTables(info.Writes, pair.Old, pair.New)
+		}
+	case *GrantPrivilegeStmt, *RevokeStmt, *SetRoleStmt,
+		*CreateUser, *AlterUser, *CreateRole, *AlterRole,
+		*CreateRowPolicy, *AlterRowPolicy, *CreateSettingsProfile, *AlterSettingsProfile,
+		*CreateQuota, *AlterQuota:
+		info.Category = StatementCategoryDCL
+		// SET ROLE only changes the roles of the session
+		_, info.ReadOnly = stmt.(*SetRoleStmt)
+	case *DropUserOrRole:
+		info.Category = StatementCategoryDCL
+		info.Destructive = true
+	case *UseStmt, *SetStmt, *SetNamesStmt:
+		info.Category = StatementCategoryAdmin
+		info.ReadOnly = true
+	case *SetVariableStmt:
+		info.Category = StatementCategoryAdmin
+		info.ReadOnly = !stmt.changesServer()
+	case *BeginStmt, *SetTransactionStmt, *CommitStmt, *RollbackStmt, *SavepointStmt, *UnlockTablesStmt:
+		info.Category = StatementCategoryTCL
+	case *LockTablesStmt:
+		info.Category = StatementCategoryTCL
+		for _, lock := range stmt.Locks {
+			if strings.HasSuffix(lock.LockType, KeywordWrite) {
+				info.Writes = appendTables(info.Writes, lock.Table)
+			} else {
+				info.Reads = appendTables(info.Reads, lock.Table)
+			}
+		}
+	case *CheckStmt:
+		info.Category = StatementCategoryAdmin
+		info.ReadOnly = true
+		info.Reads = appendTables(info.Reads, stmt.Table)
+	case *OptimizeStmt:
+		info.Category = StatementCategoryAdmin
+		info.Writes = appendTables(info.Writes, stmt.Table)
+	case *SystemStmt, *KillStmt:
+		info.Category = StatementCategoryAdmin
+	default:
+		return nil, fmt.Errorf("%T is not a statement", stmt)
+	}
+	tables := readTables(stmt)
+	info.Reads = appendTables(info.Reads, tables...)
+	info.ReadColumns = readColumns(stmt, tables)
+	return info, nil
+}
+
+func (s *StatementInfo) classifyAlterTable(stmt *AlterTable) {
+	s.Category = StatementCategoryDML
+	s.Writes = appendTables(s.Writes, stmt.TableIdentifier)
+	for _, expr := range stmt.AlterExprs {
+		switch expr.(type) {
+		case *AlterTableUpdate, *AlterTableDelete:
+			// UPDATE and DELETE mutate the data, every other change is to the table itself
+		default:
+			s.Category = StatementCategoryDDL
+		}
+		switch expr := expr.(type) {
+		case *AlterTableDelete, *AlterTableDropPartition, *AlterTableDropColumn, *AlterTableClearColumn:
+			s.Destructive = true
+		case *AlterTableAttachPartition:
+			if expr.From != nil {
+				s.Reads = appendTables(s.Reads, expr.From)
+			}
+		case *AlterTableReplacePartition:
+			s.Destructive = true
+			s.Reads = appendTables(s.Reads, expr.Table)
+		case *AlterTableMovePartition:
+			if expr.ToTable != nil {
+				s.Writes = appendTables(s.Writes, expr.ToTable)
+			}
+		case *AlterTableRenameTable:
+			s.Writes = appendTables(s.Writes, expr.NewName)
+		}
+	}
+}
+
+// changesServer reports whether s sets a global or persisted system variable.
+func (s *SetVariableStmt) changesServer() bool {
+	for _, assignment := range s.Assignments {
+		scope := assignment.Scope
+		if variable, ok := assignment.Name.(*VariableExpr); ok {
+			scope = variable.Scope
+		}
+		switch scope {
+		case KeywordGlobal, KeywordPersist, KeywordPersistOnly:
+			return true
+		}
+	}
+	return false
+}
+
+// appendTables appends the tables not listed yet.
+func appendTables(list []*TableIdentifier, tables ...*TableIdentifier) []*TableIdentifier {
+	for _, table := range tables {
+		found := false
+		for _, listed := range list {
+			if listed.String() == table.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, table)
+		}
+	}
+	return list
+}
+
+// databaseTables is the `db.*` object standing for every table of a database.
+func databaseTables(name Expr) *TableIdentifier {
+	database, ok := name.(*Ident)
+	if !ok {
+		database = &Ident{Name: name.String(), start: name.Start(), end: name.End()}
+	}
+	return &TableIdentifier{
+		Schema: database,
+		Table:  &Ident{Name: "*", start: database.End(), end: database.End()},
+	}
+}
+
+// functionTable is the object standing for the table a table function writes to, named by the call.
+func functionTable(function *FunctionExpr) *TableIdentifier {
+	return &TableIdentifier{
+		Table: &Ident{Name: function.String(), start: function.Start(), end: function.End()},
+	}
+}
+
+// readColumns returns the columns stmt reads from tables by table name, leaving out the tables
+// read whole and those read where the column references are not resolved.
+func readColumns(stmt Expr, tables []*TableIdentifier) map[string][]string {
+	v := &validator{types: NewTypeScope(nil, stmt), reads: &columnReads{}}
+	v.statement(stmt)
+	if v.reads.unresolved {
+		return nil
+	}
+	sources := make(map[*TableIdentifier]*validationSource)
+	for _, source := range v.reads.sources {
+		sources[source.table] = source
+	}
+	columns := make(map[string][]string)
+	whole := make(map[string]bool)
+	for _, table := range tables {
+		name := table.String()
+		source, ok := sources[table]
+		if !ok || source.whole || len(source.read) == 0 {
+			whole[name] = true
+			continue
+		}
+		for _, column := range source.read {
+			if !containsString(columns[name], column) {
+				columns[name] = append(columns[name], column)
+			}
+		}
+	}
+	for name := range whole {
+		delete(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	return columns
+}
+
+// readTables returns the tables in FROM and JOIN clauses anywhere in expr,
+// skipping table functions and the names of common table expressions in their scope.
+func readTables(expr Expr) []*TableIdentifier {
+	visitor := &tableReadVisitor{}
+	_ = expr.Accept(visitor)
+	return visitor.tables
+}
+
+// tableReadVisitor collects the tables read by a statement. A CTE is in the scope of the rest
+// of its WITH query, subqueries included, but not of its own body.
+type tableReadVisitor struct {
+	DefaultASTVisitor
+	// scopes are the CTE names of the queries being visited, innermost last
+	scopes []map[string]bool
+	tables []*TableIdentifier
+}
+
+func (v *tableReadVisitor) Enter(expr Expr) {
+	if _, ok := expr.(*SelectQuery); ok {
+		v.scopes = append(v.scopes, make(map[string]bool))
+	}
+}
+
+func (v *tableReadVisitor) Leave(expr Expr) {
+	switch expr := expr.(type) {
+	case *SelectQuery:
+		v.scopes = v.scopes[:len(v.scopes)-1]
+	case *CTEStmt:
+		// the body has been visited, the name is defined for what follows
+		if alias, ok := expr.Alias.(*Ident); ok && len(v.scopes) > 0 {
+			v.scopes[len(v.scopes)-1][alias.Name] = true
+		}
+	case *TableExpr:
+		table := expr.Expr
+		if alias, ok := table.(*AliasExpr); ok {
+			table = alias.Expr
+		}
+		if identifier, ok := table.(*TableIdentifier); ok && !v.isCTE(identifier) {
+			v.tables = append(v.tables, identifier)
+		}
+	}
+}
+
+func (v *tableReadVisitor) isCTE(table *TableIdentifier) bool {
+	if table.Schema != nil {
+		return false
+	}
+	for _, scope := range v.scopes {
+		if scope[table.Table.Name] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect selects the SQL dialect accepted by the parser and emitted by Format.
+type Dialect int
+
+const (
+	// DialectDefault accepts the mix of MySQL and ClickHouse syntax understood by NewParser.
+	DialectDefault Dialect = iota
+	DialectMySQL
+	DialectClickHouse
+	DialectPostgreSQL
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case DialectDefault:
+		return "Default"
+	case DialectMySQL:
+		return "MySQL"
+	case DialectClickHouse:
+		return "ClickHouse"
+	case DialectPostgreSQL:
+		return "PostgreSQL"
+	}
+	return fmt.Sprintf("Dialect(%d)", int(d))
+}
+
+func (d Dialect) valid() bool {
+	return d >= DialectDefault && d <= DialectPostgreSQL
+}
+
+// Options configures a Parser created by NewParserWithOptions.
+type Options struct {
+	Dialect Dialect
+	// MySQLVersion runs the MySQL executable comments /*!NNNNN ... */ up to this version,
+	// e.g. 80032 for 8.0.32, newer ones are skipped as comments. 0 runs them all.
+	MySQLVersion int
+}
+
+func NewParserWithOptions(buffer string, opts Options) *Parser {
+	lexer := NewLexer(buffer)
+	lexer.dialect = opts.Dialect
+	lexer.mysqlVersion = opts.MySQLVersion
+	return &Parser{
+		lexer:   lexer,
+		dialect: opts.Dialect,
+	}
+}
+
+// clickHouseKeywords are reserved only in ClickHouse, MySQL and PostgreSQL treat them as identifiers.
+var clickHouseKeywords = NewSet(
+	KeywordCodec,
+	KeywordFill,
+	KeywordFinal,
+	KeywordGranularity,
+	KeywordInherit,
+	KeywordInterpolate,
+	KeywordKeyed,
+	KeywordLimits,
+	KeywordPermissive,
+	KeywordPrewhere,
+	KeywordProfile,
+	KeywordProjection,
+	KeywordRandomized,
+	KeywordRestrictive,
+	KeywordSample,
+	KeywordStep,
+	KeywordTracking,
+	KeywordTtl,
+)
+
+// postgreSQLKeywords are reserved only in PostgreSQL.
+var postgreSQLKeywords = NewSet(
+	KeywordAlways,
+	KeywordConflict,
+	KeywordDo,
+	KeywordGenerated,
+	KeywordIdentity,
+	KeywordInherits,
+	KeywordNothing,
+	KeywordReturning,
+	KeywordStored,
+)
+
+// mySQLKeywords are reserved only in MySQL.
+var mySQLKeywords = NewSet(
+	KeywordAlgorithm,
+	KeywordAutoIncrement,
+	KeywordChange,
+)
+
+func (d Dialect) isKeyword(ident string) bool {
+	if !keywords.Contains(ident) {
+		return false
+	}
+	switch d {
+	case DialectMySQL:
+		return !clickHouseKeywords.Contains(ident) && !postgreSQLKeywords.Contains(ident)
+	case DialectClickHouse:
+		return !mySQLKeywords.Contains(ident) && !postgreSQLKeywords.Contains(ident)
+	case DialectPostgreSQL:
+		return !clickHouseKeywords.Contains(ident) && !mySQLKeywords.Contains(ident)
+	}
+	return true
+}
+
+// clickHouseAlterTypes are the ALTER TABLE clauses only ClickHouse understands.
+var clickHouseAlterTypes = NewSet(
+	"ATTACH_PARTITION", "DETACH_PARTITION", "FREEZE_PARTITION", "REPLACE_PARTITION",
+	"CLEAR_COLUMN", "CLEAR_INDEX", "CLEAR_PROJECTION", "MATERIALIZE_INDEX", "MATERIALIZE_PROJECTION",
+	"ADD_PROJECTION", "DROP_PROJECTION", "REMOVE_TTL", "MODIFY_TTL", "MODIFY_QUERY", "UPDATE", "DELETE",
+	"MOVE_PARTITION", "FETCH_PARTITION", "MODIFY_SETTING", "RESET_SETTING", "MODIFY_ORDER_BY",
+	"MODIFY_COMMENT", "APPLY_DELETED_MASK",
+)
+
+// mySQLAlterTypes are the ALTER TABLE clauses only MySQL understands.
+var mySQLAlterTypes = NewSet(
+	"CHANGE_COLUMN", "ADD_KEY", "ADD_UNIQUE_KEY", "RENAME_INDEX", "TABLE_OPTION", "CONVERT_CHARSET",
+	"ALGORITHM", "LOCK", "KEYS", "DROP_FOREIGN_KEY",
+)
+
+// alterTableKeywords are the keywords starting an ALTER TABLE clause in each dialect.
+var alterTableKeywords = map[Dialect]string{
+	DialectDefault: "ADD|DROP|ALTER|RENAME|MODIFY|ATTACH|DETACH|FREEZE|REMOVE|CLEAR|REPLACE|MATERIALIZE|" +
+		"UPDATE|DELETE|MOVE|FETCH|RESET|APPLY|CHANGE|CONVERT|ALGORITHM|LOCK|DISABLE|ENABLE",
+	DialectMySQL: "ADD|DROP|ALTER|RENAME|MODIFY|CHANGE|CONVERT|ALGORITHM|LOCK|DISABLE|ENABLE",
+	DialectClickHouse: "ADD|DROP|RENAME|MODIFY|ATTACH|DETACH|FREEZE|REMOVE|CLEAR|REPLACE|MATERIALIZE|" +
+		"UPDATE|DELETE|MOVE|FETCH|RESET|APPLY",
+	DialectPostgreSQL: "ADD|DROP|ALTER|RENAME",
+}
+
+// expectDialect returns an error unless the parser runs in the default dialect or one of the given dialects.
+func (p *Parser) expectDialect(feature string, dialects ...Dialect) error {
+	if p.dialect == DialectDefault {
+		return nil
+	}
+	for _, dialect := range dialects {
+		if p.dialect == dialect {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not supported in %s", feature, p.dialect)
+}
+
+func (p *Parser) checkAlterTableClause(alter AlterTableClause) error {
+	alterType := alter.AlterType()
+	switch {
+	case clickHouseAlterTypes.Contains(alterType):
+		return p.expectDialect("ALTER TABLE "+alterType, DialectClickHouse)
+	case mySQLAlterTypes.Contains(alterType):
+		return p.expectDialect("ALTER TABLE "+alterType, DialectMySQL)
+	}
+	return nil
+}
+
+// Format prints the expression as SQL of the given dialect. It fails if the
+// expression uses a construct the target dialect cannot express.
+func Format(expr Expr, dialect Dialect) (string, error) {
+	if !dialect.valid() {
+		return "", fmt.Errorf("unknown dialect: %s", dialect)
+	}
+	var formatErr error
+	visitor := &DefaultASTVisitor{
+		Visit: func(expr Expr) error {
+			if construct := clickHouseConstruct(expr); construct != "" && (dialect == DialectMySQL || dialect == DialectPostgreSQL) {
+				formatErr = fmt.Errorf("%s can not be printed as %s", construct, dialect)
+			}
+			if op, ok := expr.(*BinaryOperation); ok && dialect == DialectMySQL {
+				switch op.Operation {
+				case TokenKindConcat:
+					formatErr = fmt.Errorf("operator || can not be printed as %s", dialect)
+				case TokenKindDash:
+					formatErr = fmt.Errorf("operator :: can not be printed as %s", dialect)
+				}
+			}
+			return formatErr
+		},
+	}
+	_ = expr.Accept(visitor)
+	if formatErr != nil {
+		return "", formatErr
+	}
+
+	sql := expr.String()
+	quote := dialect.identQuote()
+	if quote == "" {
+		return sql, nil
+	}
+
+	// requote the quoted identifiers, everything else is shared by all dialects
+	var builder strings.Builder
+	// strings are lexed as the target dialect escapes them, as Binder does for bound values
+	lexer := NewLexer(sql)
+	lexer.dialect = dialect
+	lexer.printed = true
+	last := 0
+	for {
+		if err := lexer.consumeToken(); err != nil {
+			return "", err
+		}
+		token := lexer.lastToken
+		if token == nil {
+			break
+		}
+		if token.Kind != TokenKindIdent || token.QuoteType == Unquoted {
+			continue
+		}
+		builder.WriteString(sql[last : token.Pos-1])
+		builder.WriteString(quote)
+		// the quote is escaped by doubling it
+		builder.WriteString(strings.ReplaceAll(token.String, quote, quote+quote))
+		builder.WriteString(quote)
+		last = int(token.End) + 1
+	}
+	builder.WriteString(sql[last:])
+	return builder.String(), nil
+}
+
+// clickHouseConstruct names the ClickHouse-only construct expr is, or returns empty.
+func clickHouseConstruct(expr Expr) string {
+	switch expr := expr.(type) {
+	case *TableExpr:
+		if expr.HasFinal {
+			return "FINAL"
+		}
+	case *JoinTableExpr:
+		if expr.HasFinal {
+			return "FINAL"
+		}
+	case *SampleClause:
+		return "SAMPLE"
+	case *PrewhereClause:
+		return "PREWHERE"
+	case *ArrayJoinClause:
+		return "ARRAY JOIN"
+	case *JoinExpr:
+		for _, modifier := range expr.Modifiers {
+			if strings.EqualFold(modifier, KeywordArray) {
+				return "ARRAY JOIN"
+			}
+		}
+	case *LimitByClause:
+		return "LIMIT BY"
+	case *SettingsClause:
+		return "SETTINGS"
+	case *FormatClause:
+		return "FORMAT"
+	case *QueryParam:
+		// a bound parameter is printed as its value
+		if expr.literal == "" {
+			return "query parameter"
+		}
+	}
+	return ""
+}
+
+// identQuote returns the quote used for identifiers, or empty if both styles are kept as they are.
+func (d Dialect) identQuote() string {
+	switch d {
+	case DialectMySQL:
+		return "`"
+	case DialectPostgreSQL:
+		return `"`
+	}
+	return ""
+}
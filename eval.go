@@ -0,0 +1,1112 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EvalFunc is a scalar function for Eval, it gets the evaluated arguments.
+type EvalFunc func(args []any) (any, error)
+
+// EvalEnv supplies the values of names and the functions for Eval, it may be nil.
+type EvalEnv struct {
+	// Vars are the values of identifiers, `:name` parameters and `@name` variables by name,
+	// `t.a` columns are looked up as "t.a" and then as "a", system variables as "@@name".
+	Vars map[string]any
+	// Funcs are scalar functions by lower case name, they take precedence over the built-in ones.
+	Funcs map[string]EvalFunc
+	// Now is the time returned by now() and today(), time.Now is used if nil.
+	Now func() time.Time
+	// Dialect is the dialect of the string literals, backslash escapes are decoded except in
+	// PostgreSQL.
+	Dialect Dialect
+
+	// values are the values of nodes computed beforehand, e.g. the aggregates of a group.
+	values map[Expr]any
+}
+
+// Interval is the value of an INTERVAL expression, Unit is singular and upper case, e.g. DAY.
+type Interval struct {
+	Value int64
+	Unit  string
+}
+
+// EvalError is an error evaluating an expression or a query, positioned at the node that caused it.
+type EvalError struct {
+	Pos Pos
+	End Pos
+	Err error
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("position %d: %v", e.Pos, e.Err)
+}
+
+func (e *EvalError) Unwrap() error {
+	return e.Err
+}
+
+// Eval evaluates an expression made of literals, operators, casts and functions, with names
+// bound by env. Values are nil for NULL, bool, int64, uint64 for integers above the int64
+// range, float64, string, time.Time, Interval, and []any for arrays and tuples. Comparisons and
+// boolean operators follow SQL's three-valued logic and return nil when an operand is NULL.
+func Eval(expr Expr, env *EvalEnv) (any, error) {
+	return (&evaluator{env: env}).eval(expr)
+}
+
+type evaluator struct {
+	env *EvalEnv
+}
+
+func (e *evaluator) errorf(expr Expr, format string, args ...any) error {
+	return &EvalError{Pos: expr.Start(), End: expr.End(), Err: fmt.Errorf(format, args...)}
+}
+
+// wrap positions err at expr unless it is positioned already.
+func (e *evaluator) wrap(expr Expr, err error) error {
+	return wrapEvalError(expr, err)
+}
+
+func wrapEvalError(expr Expr, err error) error {
+	var evalErr *EvalError
+	if err == nil || errors.As(err, &evalErr) {
+		return err
+	}
+	return &EvalError{Pos: expr.Start(), End: expr.End(), Err: err}
+}
+
+func (e *evaluator) eval(expr Expr) (any, error) { // nolint: funlen
+	if e.env != nil {
+		if value, ok := e.env.values[expr]; ok {
+			return value, nil
+		}
+	}
+	switch expr := expr.(type) {
+	case *NumberLiteral:
+		value, err := parseNumberLiteral(expr)
+		return value, e.wrap(expr, err)
+	case *StringLiteral:
+		if e.env != nil && e.env.Dialect == DialectPostgreSQL {
+			return strings.ReplaceAll(expr.Literal, "''", "'"), nil
+		}
+		return unescapeString(expr.Literal), nil
+	case *NullLiteral:
+		return nil, nil
+	case *Ident:
+		return e.lookupIdent(expr)
+	case *ColumnIdentifier:
+		if expr.Table != nil {
+			if value, ok := e.lookup(expr.Table.Name + "." + expr.Column.Name); ok {
+				return value, nil
+			}
+		}
+		if value, ok := e.lookup(expr.Column.Name); ok {
+			return value, nil
+		}
+		return nil, e.errorf(expr, "unknown identifier %s", expr)
+	case *NamedParam:
+		if value, ok := e.lookup(expr.Name.Name); ok {
+			return value, nil
+		}
+		return nil, e.errorf(expr, "unbound parameter %s", expr)
+	case *VariableExpr:
+		name := expr.Name.Name
+		if expr.System {
+			name = "@@" + name
+		}
+		if value, ok := e.lookup(name); ok {
+			return value, nil
+		}
+		return nil, e.errorf(expr, "unbound variable %s", expr)
+	case *ColumnExpr:
+		return e.eval(expr.Expr)
+	case *ParamExprList:
+		values, err := e.evalList(expr.Items)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 1 {
+			return values[0], nil
+		}
+		return values, nil
+	case *ArrayParamList:
+		return e.evalList(expr.Items)
+	case *UnaryExpr:
+		switch strings.ToUpper(string(expr.Kind)) {
+		case KeywordNot:
+			return e.evalNot(expr.Expr)
+		case string(TokenKindMinus):
+			return e.evalNegate(expr, expr.Expr)
+		case string(TokenKindPlus):
+			return e.eval(expr.Expr)
+		}
+		return nil, e.errorf(expr, "unsupported unary operator %s", expr.Kind)
+	case *NotExpr:
+		return e.evalNot(expr.Expr)
+	case *NegateExpr:
+		return e.evalNegate(expr, expr.Expr)
+	case *BinaryOperation:
+		return e.evalBinary(expr)
+	case *BetweenClause:
+		return e.evalBetween(expr)
+	case *IsNullExpr:
+		value, err := e.eval(expr.Expr)
+		return value == nil, err
+	case *IsNotNullExpr:
+		value, err := e.eval(expr.Expr)
+		return value != nil, err
+	case *TernaryOperation:
+		return e.evalCondition(expr.Condition, expr.TrueExpr, expr.FalseExpr)
+	case *CaseExpr:
+		return e.evalCase(expr)
+	case *CastExpr:
+		value, err := e.eval(expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return e.cast(expr, value, expr.AsType)
+	case *IntervalExpr:
+		value, err := e.eval(expr.Expr)
+		if err != nil || value == nil {
+			return nil, err
+		}
+		n, err := toInt(value)
+		if err != nil {
+			return nil, e.wrap(expr, err)
+		}
+		unit, err := intervalUnit(expr.Unit.Name)
+		return Interval{Value: n, Unit: unit}, e.wrap(expr, err)
+	case *FunctionExpr:
+		return e.evalFunction(expr)
+	}
+	return nil, e.errorf(expr, "can not evaluate %T", expr)
+}
+
+func (e *evaluator) lookup(name string) (any, bool) {
+	if e.env == nil {
+		return nil, false
+	}
+	value, ok := e.env.Vars[name]
+	return normalizeValue(value), ok
+}
+
+func (e *evaluator) lookupIdent(ident *Ident) (any, error) {
+	if value, ok := e.lookup(ident.Name); ok {
+		return value, nil
+	}
+	if ident.QuoteType == Unquoted {
+		switch strings.ToUpper(ident.Name) {
+		case KeywordNull:
+			return nil, nil
+		case KeywordTrue:
+			return true, nil
+		case KeywordFalse:
+			return false, nil
+		}
+	}
+	return nil, e.errorf(ident, "unknown identifier %s", ident.Name)
+}
+
+func (e *evaluator) evalList(list *ColumnExprList) ([]any, error) {
+	if list == nil {
+		return []any{}, nil
+	}
+	values := make([]any, 0, len(list.Items))
+	for _, item := range list.Items {
+		value, err := e.eval(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (e *evaluator) evalNot(expr Expr) (any, error) {
+	value, err := e.eval(expr)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	b, err := toBool(value)
+	return !b, e.wrap(expr, err)
+}
+
+func (e *evaluator) evalNegate(expr, operand Expr) (any, error) {
+	value, err := e.eval(operand)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	switch value := value.(type) {
+	case int64:
+		return -value, nil
+	case uint64:
+		// -9223372036854775808 is read as the negation of a literal just above MaxInt64
+		if value == math.MaxInt64+1 {
+			return int64(math.MinInt64), nil
+		}
+		return -float64(value), nil
+	case float64:
+		return -value, nil
+	case Interval:
+		return Interval{Value: -value.Value, Unit: value.Unit}, nil
+	}
+	return nil, e.errorf(expr, "can not negate %T", value)
+}
+
+func (e *evaluator) evalCondition(condition, then, otherwise Expr) (any, error) {
+	value, err := e.eval(condition)
+	if err != nil {
+		return nil, err
+	}
+	ok := false
+	if value != nil {
+		if ok, err = toBool(value); err != nil {
+			return nil, e.wrap(condition, err)
+		}
+	}
+	if ok {
+		return e.eval(then)
+	}
+	if otherwise == nil {
+		return nil, nil
+	}
+	return e.eval(otherwise)
+}
+
+func (e *evaluator) evalCase(expr *CaseExpr) (any, error) {
+	var subject any
+	if expr.Expr != nil {
+		var err error
+		if subject, err = e.eval(expr.Expr); err != nil {
+			return nil, err
+		}
+	}
+	for _, when := range expr.Whens {
+		value, err := e.eval(when.When)
+		if err != nil {
+			return nil, err
+		}
+		var matched bool
+		switch {
+		case expr.Expr != nil:
+			result, err := compareValues(subject, value, "=")
+			if err != nil {
+				return nil, e.wrap(when.When, err)
+			}
+			matched = result == true
+		case value != nil:
+			if matched, err = toBool(value); err != nil {
+				return nil, e.wrap(when.When, err)
+			}
+		}
+		if matched {
+			return e.eval(when.Then)
+		}
+	}
+	if expr.Else == nil {
+		return nil, nil
+	}
+	return e.eval(expr.Else)
+}
+
+func (e *evaluator) evalBetween(expr *BetweenClause) (any, error) {
+	var values [3]any
+	for i, operand := range []Expr{expr.Expr, expr.Between, expr.And} {
+		value, err := e.eval(operand)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	low, err := compareValues(values[0], values[1], ">=")
+	if err != nil {
+		return nil, e.wrap(expr, err)
+	}
+	high, err := compareValues(values[0], values[2], "<=")
+	if err != nil {
+		return nil, e.wrap(expr, err)
+	}
+	return and(low, high), nil
+}
+
+func (e *evaluator) evalBinary(expr *BinaryOperation) (any, error) { // nolint: funlen
+	operation := strings.ToUpper(string(expr.Operation))
+	if expr.HasNot {
+		operation = "NOT " + operation
+	}
+	if operation == string(TokenKindDash) {
+		value, err := e.eval(expr.LeftExpr)
+		if err != nil {
+			return nil, err
+		}
+		return e.cast(expr, value, expr.RightExpr)
+	}
+	left, err := e.eval(expr.LeftExpr)
+	if err != nil {
+		return nil, err
+	}
+	// AND and OR skip the right side once the result is known
+	switch operation {
+	case KeywordAnd, KeywordOr:
+		if left != nil {
+			b, err := toBool(left)
+			if err != nil {
+				return nil, e.wrap(expr.LeftExpr, err)
+			}
+			if b == (operation == KeywordOr) {
+				return b, nil
+			}
+			left = b
+		}
+	}
+	right, err := e.eval(expr.RightExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	switch operation {
+	case KeywordAnd, KeywordOr:
+		if right != nil {
+			b, err := toBool(right)
+			if err != nil {
+				return nil, e.wrap(expr.RightExpr, err)
+			}
+			right = b
+		}
+		if operation == KeywordAnd {
+			return and(left, right), nil
+		}
+		return or(left, right), nil
+	case "=", "==", "!=", "<>", "<", "<=", ">", ">=":
+		result, err = compareValues(left, right, operation)
+	case "+", "-", "*", "/", "%":
+		result, err = arithmetic(left, right, operation)
+	case string(TokenKindConcat):
+		if left == nil || right == nil {
+			return nil, nil
+		}
+		result = toString(left) + toString(right)
+	case KeywordLike, KeywordIlike, "NOT " + KeywordLike, "NOT " + KeywordIlike:
+		if left == nil || right == nil {
+			return nil, nil
+		}
+		matched := likeMatch(toString(left), toString(right), strings.HasSuffix(operation, KeywordIlike))
+		result = matched != strings.HasPrefix(operation, "NOT ")
+	case KeywordIn, "NOT " + KeywordIn, "GLOBAL IN", "NOT GLOBAL IN":
+		result, err = in(left, right)
+		if b, ok := result.(bool); ok && strings.HasPrefix(operation, "NOT ") {
+			result = !b
+		}
+	default:
+		return nil, e.errorf(expr, "unsupported operator %s", expr.Operation)
+	}
+	return result, e.wrap(expr, err)
+}
+
+func (e *evaluator) evalFunction(expr *FunctionExpr) (any, error) {
+	name := strings.ToLower(expr.Name.Name)
+	var args []any
+	if expr.Params != nil {
+		var err error
+		if args, err = e.evalList(expr.Params.Items); err != nil {
+			return nil, err
+		}
+	}
+	if e.env != nil {
+		if function, ok := e.env.Funcs[name]; ok {
+			value, err := function(args)
+			value = normalizeValue(value)
+			return value, e.wrap(expr, err)
+		}
+	}
+	function, ok := builtinFunctions[name]
+	if !ok {
+		return nil, e.errorf(expr, "unknown function %s", expr.Name.Name)
+	}
+	value, err := function(e, args)
+	return value, e.wrap(expr, err)
+}
+
+// stringEscapes are the characters of the backslash escapes of ClickHouse and MySQL strings.
+var stringEscapes = map[byte]string{
+	'0': "\x00", 'a': "\a", 'b': "\b", 'f': "\f", 'n': "\n", 'r': "\r", 't': "\t", 'v': "\v",
+	'Z': "\x1a", 'e': "\x1b",
+}
+
+// unescapeString decodes the quotes and backslash escapes of a string literal, `\%` and `\_`
+// are kept for LIKE patterns.
+func unescapeString(literal string) string {
+	if !strings.Contains(literal, "\\") {
+		return strings.ReplaceAll(literal, "''", "'")
+	}
+	var builder strings.Builder
+	for i := 0; i < len(literal); i++ {
+		c := literal[i]
+		switch {
+		case c == '\'' && i+1 < len(literal) && literal[i+1] == '\'':
+			i++
+		case c != '\\' || i+1 == len(literal):
+		case literal[i+1] == '%' || literal[i+1] == '_':
+			builder.WriteByte(c)
+			i++
+			c = literal[i]
+		case literal[i+1] == 'x' && i+3 < len(literal):
+			if n, err := strconv.ParseUint(literal[i+2:i+4], 16, 8); err == nil {
+				builder.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+			i++
+			c = literal[i]
+		default:
+			i++
+			if escaped, ok := stringEscapes[literal[i]]; ok {
+				builder.WriteString(escaped)
+				continue
+			}
+			c = literal[i]
+		}
+		builder.WriteByte(c)
+	}
+	return builder.String()
+}
+
+func (e *evaluator) now() time.Time {
+	if e.env != nil && e.env.Now != nil {
+		return e.env.Now()
+	}
+	return time.Now()
+}
+
+// cast converts value to the type of CAST(x AS T), x::T or CAST(x, 'T').
+func (e *evaluator) cast(expr Expr, value any, target Expr) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	columnType := castTarget(target)
+	if columnType == nil {
+		return nil, e.errorf(expr, "can not cast to %s", target)
+	}
+	if complexType, ok := columnType.(*ComplexType); ok {
+		if len(complexType.Params) == 1 && (complexType.Name.Name == "Nullable" || complexType.Name.Name == "LowCardinality") {
+			return e.cast(expr, value, complexType.Params[0])
+		}
+		return nil, e.errorf(expr, "can not cast to %s", complexType)
+	}
+	typeName := columnType.Type()
+	result, err := castValue(value, typeName)
+	return result, e.wrap(expr, err)
+}
+
+// builtinFunctions are the functions available to Eval without EvalEnv.Funcs.
+var builtinFunctions = map[string]func(e *evaluator, args []any) (any, error){
+	"now": func(e *evaluator, args []any) (any, error) {
+		return e.now(), expectArgs(args, 0)
+	},
+	"today": func(e *evaluator, args []any) (any, error) {
+		return castValue(e.now(), "Date")
+	},
+	"todate": func(_ *evaluator, args []any) (any, error) {
+		return castArg(args, "Date")
+	},
+	"todatetime": func(_ *evaluator, args []any) (any, error) {
+		return castArg(args, "DateTime")
+	},
+	"tostring": func(_ *evaluator, args []any) (any, error) {
+		return castArg(args, "String")
+	},
+	"concat": func(_ *evaluator, args []any) (any, error) {
+		var builder strings.Builder
+		for _, arg := range args {
+			if arg == nil {
+				return nil, nil
+			}
+			builder.WriteString(toString(arg))
+		}
+		return builder.String(), nil
+	},
+	"if": func(_ *evaluator, args []any) (any, error) {
+		if err := expectArgs(args, 3); err != nil {
+			return nil, err
+		}
+		if args[0] == nil {
+			return args[2], nil
+		}
+		condition, err := toBool(args[0])
+		if err != nil || !condition {
+			return args[2], err
+		}
+		return args[1], nil
+	},
+	"coalesce": func(_ *evaluator, args []any) (any, error) {
+		for _, arg := range args {
+			if arg != nil {
+				return arg, nil
+			}
+		}
+		return nil, nil
+	},
+	"lower": func(_ *evaluator, args []any) (any, error) {
+		return mapString(args, strings.ToLower)
+	},
+	"upper": func(_ *evaluator, args []any) (any, error) {
+		return mapString(args, strings.ToUpper)
+	},
+	"length": func(_ *evaluator, args []any) (any, error) {
+		if err := expectArgs(args, 1); err != nil || args[0] == nil {
+			return nil, err
+		}
+		if list, ok := args[0].([]any); ok {
+			return int64(len(list)), nil
+		}
+		return int64(len(toString(args[0]))), nil
+	},
+}
+
+func expectArgs(args []any, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("expected %d arguments, got %d", n, len(args))
+	}
+	return nil
+}
+
+func castArg(args []any, typeName string) (any, error) {
+	if err := expectArgs(args, 1); err != nil || args[0] == nil {
+		return nil, err
+	}
+	return castValue(args[0], typeName)
+}
+
+func mapString(args []any, f func(string) string) (any, error) {
+	if err := expectArgs(args, 1); err != nil || args[0] == nil {
+		return nil, err
+	}
+	return f(toString(args[0])), nil
+}
+
+// normalizeValue converts the Go integer and float types to the int64, uint64 and float64 values
+// Eval works with.
+func normalizeValue(value any) any {
+	switch value := value.(type) {
+	case int:
+		return int64(value)
+	case int8:
+		return int64(value)
+	case int16:
+		return int64(value)
+	case int32:
+		return int64(value)
+	case uint:
+		return normalizeValue(uint64(value))
+	case uint8:
+		return int64(value)
+	case uint16:
+		return int64(value)
+	case uint32:
+		return int64(value)
+	case uint64:
+		if value <= math.MaxInt64 {
+			return int64(value)
+		}
+	case float32:
+		return float64(value)
+	}
+	return value
+}
+
+func parseNumberLiteral(number *NumberLiteral) (any, error) {
+	literal, base := number.Literal, number.Base
+	if base == 0 {
+		base = 10
+	}
+	if base == 10 && strings.ContainsAny(literal, ".eEpP") {
+		return strconv.ParseFloat(literal, 64)
+	}
+	// the digits are read in the base of the literal, so 017 is 17 and not an octal number
+	sign, digits := "", literal
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+	switch base {
+	case 16:
+		digits = strings.TrimPrefix(strings.TrimPrefix(digits, "0x"), "0X")
+	case 2:
+		digits = strings.TrimPrefix(strings.TrimPrefix(digits, "0b"), "0B")
+	}
+	if value, err := strconv.ParseInt(sign+digits, base, 64); err == nil {
+		return value, nil
+	}
+	if sign != "-" {
+		if value, err := strconv.ParseUint(digits, base, 64); err == nil {
+			return value, nil
+		}
+	}
+	if base != 10 {
+		return nil, fmt.Errorf("invalid number %s", literal)
+	}
+	return strconv.ParseFloat(literal, 64)
+}
+
+func intervalUnit(unit string) (string, error) {
+	unit = strings.ToUpper(unit)
+	switch unit {
+	case "NANOSECOND", "MICROSECOND", "MILLISECOND", "SECOND", "MINUTE", "HOUR",
+		"DAY", "WEEK", "MONTH", "QUARTER", "YEAR":
+		return unit, nil
+	}
+	if singular := strings.TrimSuffix(unit, "S"); singular != unit {
+		return intervalUnit(singular)
+	}
+	return "", fmt.Errorf("unknown interval unit %s", unit)
+}
+
+// addInterval adds n units to t, months are clamped to the last day like ClickHouse does.
+func addInterval(t time.Time, interval Interval, sign int64) time.Time {
+	n := interval.Value * sign
+	switch interval.Unit {
+	case "NANOSECOND":
+		return t.Add(time.Duration(n))
+	case "MICROSECOND":
+		return t.Add(time.Duration(n) * time.Microsecond)
+	case "MILLISECOND":
+		return t.Add(time.Duration(n) * time.Millisecond)
+	case "SECOND":
+		return t.Add(time.Duration(n) * time.Second)
+	case "MINUTE":
+		return t.Add(time.Duration(n) * time.Minute)
+	case "HOUR":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "DAY":
+		return t.AddDate(0, 0, int(n))
+	case "WEEK":
+		return t.AddDate(0, 0, int(7*n))
+	case "QUARTER":
+		n *= 3
+	case "YEAR":
+		n *= 12
+	}
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+func toBool(value any) (bool, error) {
+	switch value := value.(type) {
+	case bool:
+		return value, nil
+	case int64:
+		return value != 0, nil
+	case uint64:
+		return value != 0, nil
+	case float64:
+		return value != 0, nil
+	}
+	return false, fmt.Errorf("%v (%T) is not a boolean", value, value)
+}
+
+func toInt(value any) (int64, error) {
+	switch value := value.(type) {
+	case int64:
+		return value, nil
+	case uint64:
+		if value > math.MaxInt64 {
+			return 0, fmt.Errorf("%d overflows Int64", value)
+		}
+		return int64(value), nil
+	case float64:
+		return int64(value), nil
+	case bool:
+		if value {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		if n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+			return n, nil
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0, fmt.Errorf("can not convert %q to a number", value)
+		}
+		return int64(f), nil
+	}
+	return 0, fmt.Errorf("%v (%T) is not a number", value, value)
+}
+
+func toFloat(value any) (float64, error) {
+	switch value := value.(type) {
+	case int64:
+		return float64(value), nil
+	case uint64:
+		return float64(value), nil
+	case float64:
+		return value, nil
+	case bool:
+		if value {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0, fmt.Errorf("can not convert %q to a number", value)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("%v (%T) is not a number", value, value)
+}
+
+func toString(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	case time.Time:
+		if value.Nanosecond() != 0 {
+			return value.Format("2006-01-02 15:04:05.999999999")
+		}
+		return value.Format(time.DateTime)
+	case []any:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = toString(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case nil:
+		return "NULL"
+	}
+	return fmt.Sprint(value)
+}
+
+var timeLayouts = []string{
+	time.DateOnly,
+	time.DateTime,
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+}
+
+func toTime(value any) (time.Time, error) {
+	switch value := value.(type) {
+	case time.Time:
+		return value, nil
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("can not parse %q as a date", value)
+	case int64:
+		return time.Unix(value, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%v (%T) is not a date", value, value)
+}
+
+func castValue(value any, typeName string) (any, error) { // nolint: funlen
+	if value == nil {
+		return nil, nil
+	}
+	switch {
+	case strings.HasPrefix(typeName, "UInt"):
+		return castInt(value, strings.TrimPrefix(typeName, "UInt"), true)
+	case strings.HasPrefix(typeName, "Int"):
+		return castInt(value, strings.TrimPrefix(typeName, "Int"), false)
+	case strings.EqualFold(typeName, "SIGNED"), strings.EqualFold(typeName, "INTEGER"), strings.EqualFold(typeName, "BIGINT"):
+		return castInt(value, "64", false)
+	case strings.HasPrefix(typeName, "Float"), strings.HasPrefix(typeName, "Decimal"),
+		strings.EqualFold(typeName, "DOUBLE"), strings.EqualFold(typeName, "DECIMAL"):
+		return toFloat(value)
+	case typeName == "String", typeName == "FixedString", strings.EqualFold(typeName, "CHAR"),
+		strings.EqualFold(typeName, "VARCHAR"), strings.EqualFold(typeName, "TEXT"):
+		return toString(value), nil
+	case typeName == "Bool", strings.EqualFold(typeName, "BOOLEAN"):
+		if s, ok := value.(string); ok {
+			return strconv.ParseBool(s)
+		}
+		return toBool(value)
+	case typeName == "Date", typeName == "Date32", strings.EqualFold(typeName, "DATE"):
+		// as in ClickHouse, a number below 65536 is a day number rather than a timestamp
+		if n, ok := value.(int64); ok && n >= 0 && n < 65536 {
+			return time.Unix(0, 0).UTC().AddDate(0, 0, int(n)), nil
+		}
+		t, err := toTime(value)
+		if err != nil {
+			return nil, err
+		}
+		year, month, day := t.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location()), nil
+	case strings.HasPrefix(typeName, "DateTime"), strings.EqualFold(typeName, "DATETIME"),
+		strings.EqualFold(typeName, "TIMESTAMP"):
+		return toTime(value)
+	}
+	return nil, fmt.Errorf("can not cast to %s", typeName)
+}
+
+// castInt converts value to an integer of the given bits, wrapping it around as ClickHouse does,
+// e.g. CAST(300 AS UInt8) is 44. Unsigned values above the int64 range are uint64.
+func castInt(value any, bits string, unsigned bool) (any, error) {
+	var n uint64
+	if u, ok := value.(uint64); ok {
+		n = u
+	} else {
+		i, err := toInt(value)
+		if err != nil {
+			return nil, err
+		}
+		n = uint64(i)
+	}
+	if width, err := strconv.Atoi(bits); err == nil && width > 0 && width < 64 {
+		mask := uint64(1)<<width - 1
+		n &= mask
+		if !unsigned && n>>(width-1) == 1 {
+			n |= ^mask
+		}
+	}
+	if unsigned && n > math.MaxInt64 {
+		return n, nil
+	}
+	return int64(n), nil
+}
+
+// compareValues compares two values with a comparison operator, strings are converted to
+// numbers or dates when compared with them.
+func compareValues(left, right any, operation string) (any, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	c, err := compare(left, right)
+	if err != nil {
+		return nil, err
+	}
+	switch operation {
+	case "=", "==":
+		return c == 0, nil
+	case "!=", "<>":
+		return c != 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	case ">=":
+		return c >= 0, nil
+	}
+	return nil, fmt.Errorf("unsupported comparison %s", operation)
+}
+
+func compare(left, right any) (int, error) {
+	switch l := left.(type) {
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	case time.Time:
+		r, err := toTime(right)
+		if err != nil {
+			return 0, err
+		}
+		return l.Compare(r), nil
+	case []any:
+		r, ok := right.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < len(l) && i < len(r); i++ {
+			if c, err := compare(l[i], r[i]); err != nil || c != 0 {
+				return c, err
+			}
+		}
+		return len(l) - len(r), nil
+	}
+	if _, ok := right.(time.Time); ok {
+		c, err := compare(right, left)
+		return -c, err
+	}
+	if l, ok := left.(int64); ok {
+		if r, ok := right.(int64); ok {
+			return cmpOrdered(l, r), nil
+		}
+	}
+	l, err := toFloat(left)
+	if err != nil {
+		return 0, err
+	}
+	r, err := toFloat(right)
+	if err != nil {
+		return 0, err
+	}
+	return cmpOrdered(l, r), nil
+}
+
+func cmpOrdered[T int64 | float64](l, r T) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+func arithmetic(left, right any, operation string) (any, error) { // nolint: funlen
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	if interval, ok := right.(Interval); ok {
+		t, err := toTime(left)
+		if err != nil {
+			return nil, err
+		}
+		switch operation {
+		case "+":
+			return addInterval(t, interval, 1), nil
+		case "-":
+			return addInterval(t, interval, -1), nil
+		}
+		return nil, fmt.Errorf("unsupported operator %s on an interval", operation)
+	}
+	if interval, ok := left.(Interval); ok && operation == "+" {
+		return arithmetic(right, interval, operation)
+	}
+
+	l, lok := left.(int64)
+	r, rok := right.(int64)
+	if lok && rok && operation != "/" {
+		switch operation {
+		case "+":
+			if r > 0 && l > math.MaxInt64-r || r < 0 && l < math.MinInt64-r {
+				return nil, fmt.Errorf("%d + %d overflows Int64", l, r)
+			}
+			return l + r, nil
+		case "-":
+			if r < 0 && l > math.MaxInt64+r || r > 0 && l < math.MinInt64+r {
+				return nil, fmt.Errorf("%d - %d overflows Int64", l, r)
+			}
+			return l - r, nil
+		case "*":
+			product := l * r
+			if l != 0 && (product/l != r || l == -1 && r == math.MinInt64) {
+				return nil, fmt.Errorf("%d * %d overflows Int64", l, r)
+			}
+			return product, nil
+		case "%":
+			if r == 0 {
+				return nil, errors.New("division by zero")
+			}
+			return l % r, nil
+		}
+	}
+	lf, err := toFloat(left)
+	if err != nil {
+		return nil, err
+	}
+	rf, err := toFloat(right)
+	if err != nil {
+		return nil, err
+	}
+	switch operation {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "/":
+		return lf / rf, nil
+	case "%":
+		return math.Mod(lf, rf), nil
+	}
+	return nil, fmt.Errorf("unsupported operator %s", operation)
+}
+
+// and is the three-valued AND of two booleans or nils.
+func and(left, right any) any {
+	if left == false || right == false {
+		return false
+	}
+	if left == nil || right == nil {
+		return nil
+	}
+	return true
+}
+
+// or is the three-valued OR of two booleans or nils.
+func or(left, right any) any {
+	if left == true || right == true {
+		return true
+	}
+	if left == nil || right == nil {
+		return nil
+	}
+	return false
+}
+
+// in reports whether value is one of the list, NULL if it is not and the list has a NULL.
+func in(value, list any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := list.([]any)
+	if !ok {
+		items = []any{list}
+	}
+	var result any = false
+	for _, item := range items {
+		if item == nil {
+			result = nil
+			continue
+		}
+		c, err := compare(value, item)
+		if err != nil {
+			return nil, err
+		}
+		if c == 0 {
+			return true, nil
+		}
+	}
+	return result, nil
+}
+
+// likeMatch matches s against a LIKE pattern with the % and _ wildcards and backslash escapes.
+func likeMatch(s, pattern string, ignoreCase bool) bool {
+	if ignoreCase {
+		s, pattern = strings.ToLower(s), strings.ToLower(pattern)
+	}
+	runes, wildcards := []rune(s), []rune(pattern)
+	// star and mark are the positions to retry from after the last %
+	star, mark := -1, 0
+	i, j := 0, 0
+	for i < len(runes) {
+		switch {
+		case j < len(wildcards) && wildcards[j] == '%':
+			star, mark = j, i
+			j++
+			continue
+		case j < len(wildcards) && wildcards[j] == '\\' && j+1 < len(wildcards) && wildcards[j+1] == runes[i]:
+			i, j = i+1, j+2
+			continue
+		case j < len(wildcards) && wildcards[j] != '\\' && (wildcards[j] == '_' || wildcards[j] == runes[i]):
+			i, j = i+1, j+1
+			continue
+		case star >= 0:
+			mark++
+			i, j = mark, star+1
+			continue
+		}
+		return false
+	}
+	for j < len(wildcards) && wildcards[j] == '%' {
+		j++
+	}
+	return j == len(wildcards)
+}
@@ -0,0 +1,1178 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Executor runs SELECT queries against in-memory tables. It is meant for testing code that
+// generates queries without a database, so what it does not implement is reported as an error
+// rather than approximated.
+type Executor struct {
+	// Funcs are scalar functions by lower case name, in addition to the built-in ones of Eval.
+	Funcs  map[string]EvalFunc
+	tables map[string]*executorTable
+}
+
+type executorTable struct {
+	columns []string
+	rows    []map[string]any
+}
+
+// Result is the result of a query, the values of each row are in the order of Columns.
+type Result struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// NewExecutor returns an Executor without tables.
+func NewExecutor() *Executor {
+	return &Executor{tables: make(map[string]*executorTable)}
+}
+
+// Register registers rows as the table name, which is qualified as db.table to be used with a
+// database. Columns are in the order SELECT * returns them, they default to the sorted keys of
+// the rows, and a column missing from a row is NULL.
+func (x *Executor) Register(name string, columns []string, rows []map[string]any) {
+	if columns == nil {
+		seen := make(map[string]bool)
+		for _, row := range rows {
+			for column := range row {
+				if !seen[column] {
+					seen[column] = true
+					columns = append(columns, column)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+	x.tables[name] = &executorTable{columns: columns, rows: rows}
+}
+
+// Execute runs a SELECT query, it supports projections with aliases, WHERE, inner, left and
+// cross joins with ON or USING, GROUP BY with the count, sum, min, max, avg and uniq aggregates,
+// HAVING, DISTINCT, ORDER BY, LIMIT and UNION ALL. Expressions are evaluated with Eval.
+func (x *Executor) Execute(query Expr) (*Result, error) {
+	switch query := query.(type) {
+	case *SelectQuery:
+		return x.executeSelect(query)
+	case *SubQuery:
+		return x.executeSelect(query.Select)
+	}
+	return nil, executorError(query, "only SELECT queries can be executed")
+}
+
+func executorError(expr Expr, format string, args ...any) error {
+	return &EvalError{Pos: expr.Start(), End: expr.End(), Err: fmt.Errorf(format, args...)}
+}
+
+var aggregateFunctions = NewSet("count", "sum", "min", "max", "avg", "uniq")
+
+// isAggregate reports whether expr is a call of an aggregate function.
+func isAggregate(expr Expr) bool {
+	function, ok := expr.(*FunctionExpr)
+	return ok && aggregateFunctions.Contains(strings.ToLower(function.Name.Name))
+}
+
+// output is a column of the result, either an expression or a column of SELECT *.
+type output struct {
+	name string
+	expr Expr
+	key  string
+}
+
+// outputRow is a row of the result with what its ORDER BY expressions are evaluated with.
+type outputRow struct {
+	values     []any
+	vars       map[string]any
+	aggregates map[Expr]any
+}
+
+func (x *Executor) executeSelect(query *SelectQuery) (*Result, error) { // nolint: funlen
+	if err := checkSelectSupported(query); err != nil {
+		return nil, err
+	}
+	if query.SetOperation != nil {
+		result, err := x.executeSetOperation(query.SetOperation)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]*outputRow, len(result.Rows))
+		for i, values := range result.Rows {
+			vars := make(map[string]any, len(values))
+			for j, column := range result.Columns {
+				vars[column] = values[j]
+			}
+			rows[i] = &outputRow{values: values, vars: vars}
+		}
+		return x.finish(query, result.Columns, rows)
+	}
+
+	rel, err := x.from(query)
+	if err != nil {
+		return nil, err
+	}
+	if query.Where != nil {
+		if err := rel.check(query.Where.Expr, nil); err != nil {
+			return nil, err
+		}
+		if err := rejectAggregates(query.Where.Expr, "WHERE"); err != nil {
+			return nil, err
+		}
+		rows := rel.rows[:0:0]
+		for _, row := range rel.rows {
+			ok, err := x.test(query.Where.Expr, rel.bind(row), nil)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rows = append(rows, row)
+			}
+		}
+		rel.rows = rows
+	}
+
+	outputs, err := rel.outputs(query.SelectItems)
+	if err != nil {
+		return nil, err
+	}
+	aliases := make(map[string]bool)
+	for _, output := range outputs {
+		if output.expr != nil {
+			aliases[output.name] = true
+		}
+	}
+	var selectExprs, laterExprs []Expr
+	for _, output := range outputs {
+		if output.expr != nil {
+			selectExprs = append(selectExprs, output.expr)
+		}
+	}
+	if query.Having != nil {
+		laterExprs = append(laterExprs, query.Having.Expr)
+	}
+	if query.OrderBy != nil {
+		for _, item := range query.OrderBy.Items {
+			if item, ok := item.(*OrderExpr); ok {
+				laterExprs = append(laterExprs, item.Expr)
+			}
+		}
+	}
+	for _, expr := range selectExprs {
+		if err := rel.check(expr, nil); err != nil {
+			return nil, err
+		}
+	}
+	for _, expr := range laterExprs {
+		if err := rel.check(expr, aliases); err != nil {
+			return nil, err
+		}
+	}
+	var aggregates []*FunctionExpr
+	for _, expr := range append(selectExprs, laterExprs...) {
+		if err := collectAggregates(expr, &aggregates); err != nil {
+			return nil, err
+		}
+	}
+
+	columns := make([]string, len(outputs))
+	for i, output := range outputs {
+		columns[i] = output.name
+	}
+	if query.GroupBy == nil && len(aggregates) == 0 {
+		if query.Having != nil {
+			return nil, executorError(query.Having, "HAVING requires GROUP BY or an aggregate function")
+		}
+		rows := make([]*outputRow, 0, len(rel.rows))
+		for _, row := range rel.rows {
+			output, err := x.project(outputs, rel.bind(row), nil)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, output)
+		}
+		return x.finish(query, columns, rows)
+	}
+	rows, err := x.group(query, rel, outputs, aliases, aggregates)
+	if err != nil {
+		return nil, err
+	}
+	return x.finish(query, columns, rows)
+}
+
+// checkSelectSupported returns an error for the first clause of query the executor does not
+// implement.
+func checkSelectSupported(query *SelectQuery) error {
+	for _, clause := range []struct {
+		present bool
+		node    Expr
+		name    string
+	}{
+		{query.With != nil, query.With, "WITH"},
+		{query.Top != nil, query.Top, "TOP"},
+		{query.ArrayJoin != nil, query.ArrayJoin, "ARRAY JOIN"},
+		{query.Window != nil, query.Window, "WINDOW"},
+		{query.Prewhere != nil, query.Prewhere, "PREWHERE"},
+		{query.GroupBy != nil && query.GroupBy.AggregateType != "", query.GroupBy, "GROUP BY modifier"},
+		{query.GroupBy != nil && (query.GroupBy.WithCube || query.GroupBy.WithRollup || query.GroupBy.WithTotals),
+			query.GroupBy, "WITH CUBE, ROLLUP or TOTALS"},
+		{query.WithTotal, query, "WITH TOTALS"},
+		{query.Qualify != nil, query.Qualify, "QUALIFY"},
+		{query.OrderBy != nil && query.OrderBy.Interpolate != nil, query.OrderBy, "INTERPOLATE"},
+		{query.LimitBy != nil, query.LimitBy, "LIMIT BY"},
+		{query.Limit != nil && query.Limit.WithTies, query.Limit, "WITH TIES"},
+		{query.Fetch != nil, query.Fetch, "FETCH"},
+		{query.Locking != nil, query.Locking, "locking clause"},
+		{query.Settings != nil, query.Settings, "SETTINGS"},
+		{query.IntoOutfile != nil, query.IntoOutfile, "INTO OUTFILE"},
+		{query.Format != nil, query.Format, "FORMAT"},
+	} {
+		if clause.present {
+			return executorError(clause.node, "%s is not supported", clause.name)
+		}
+	}
+	return nil
+}
+
+func (x *Executor) executeSetOperation(operation *SetOperation) (*Result, error) {
+	if operation.Operator != SetOperatorUnion || operation.Quantifier != SetQuantifierAll {
+		name := strings.TrimSpace(string(operation.Operator) + " " + string(operation.Quantifier))
+		return nil, &EvalError{
+			Pos: operation.OperatorPos,
+			End: operation.OperatorPos + Pos(len(operation.Operator)),
+			Err: fmt.Errorf("%s is not supported, only UNION ALL is", name),
+		}
+	}
+	var results [2]*Result
+	for i, operand := range []Expr{operation.Left, operation.Right} {
+		var err error
+		switch operand := operand.(type) {
+		case *SelectQuery:
+			results[i], err = x.executeSelect(operand)
+		case *SetOperation:
+			results[i], err = x.executeSetOperation(operand)
+		default:
+			err = executorError(operand, "%T is not supported in UNION ALL", operand)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	left, right := results[0], results[1]
+	if len(left.Columns) != len(right.Columns) {
+		return nil, executorError(operation.Right, "UNION ALL operands have %d and %d columns",
+			len(left.Columns), len(right.Columns))
+	}
+	return &Result{Columns: left.Columns, Rows: append(left.Rows, right.Rows...)}, nil
+}
+
+// project evaluates the outputs of a row, and adds their aliases to vars for ORDER BY.
+func (x *Executor) project(outputs []output, vars map[string]any, aggregates map[Expr]any) (*outputRow, error) {
+	values := make([]any, len(outputs))
+	for i, output := range outputs {
+		if output.expr == nil {
+			values[i] = vars[output.key]
+			continue
+		}
+		value, err := x.eval(output.expr, vars, aggregates)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	for i, output := range outputs {
+		if output.expr != nil {
+			vars[output.name] = values[i]
+		}
+	}
+	return &outputRow{values: values, vars: vars, aggregates: aggregates}, nil
+}
+
+// group aggregates the rows of rel by the GROUP BY expressions, or into a single group.
+func (x *Executor) group(query *SelectQuery, rel *relation, outputs []output, aliases map[string]bool,
+	aggregates []*FunctionExpr) ([]*outputRow, error) { // nolint: funlen
+	var keys []Expr
+	grouped := make(map[string]bool)
+	if query.GroupBy != nil {
+		list, ok := query.GroupBy.Expr.(*ColumnExprList)
+		if !ok {
+			return nil, executorError(query.GroupBy, "GROUP BY %s is not supported", query.GroupBy.Expr)
+		}
+		for _, item := range list.Items {
+			key, err := rel.groupKey(item, outputs)
+			if err != nil {
+				return nil, err
+			}
+			if err := rejectAggregates(key, "GROUP BY"); err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+			grouped[key.String()] = true
+			if name, err := rel.resolve(key); err == nil {
+				grouped[name] = true
+			}
+		}
+	}
+	for _, output := range outputs {
+		if output.expr == nil && !grouped[output.key] {
+			return nil, executorError(query, "column %s is neither grouped nor aggregated", output.key)
+		}
+		if output.expr != nil {
+			if err := rel.checkGrouped(output.expr, grouped, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if query.Having != nil {
+		if err := rel.checkGrouped(query.Having.Expr, grouped, aliases); err != nil {
+			return nil, err
+		}
+	}
+	if query.OrderBy != nil {
+		for _, item := range query.OrderBy.Items {
+			if item, ok := item.(*OrderExpr); ok {
+				if err := rel.checkGrouped(item.Expr, grouped, aliases); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	var groups [][]map[string]any
+	index := make(map[string]int)
+	for _, row := range rel.rows {
+		vars := rel.bind(row)
+		values := make([]any, len(keys))
+		for i, key := range keys {
+			value, err := x.eval(key, vars, nil)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		key := valueKey(values)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], vars)
+	}
+	if query.GroupBy == nil && len(groups) == 0 {
+		groups = append(groups, nil)
+	}
+
+	rows := make([]*outputRow, 0, len(groups))
+	for _, group := range groups {
+		values := make(map[Expr]any, len(aggregates))
+		for _, aggregate := range aggregates {
+			value, err := x.aggregate(aggregate, group)
+			if err != nil {
+				return nil, err
+			}
+			values[aggregate] = value
+		}
+		vars := make(map[string]any)
+		if len(group) > 0 {
+			for name, value := range group[0] {
+				vars[name] = value
+			}
+		}
+		row, err := x.project(outputs, vars, values)
+		if err != nil {
+			return nil, err
+		}
+		if query.Having != nil {
+			ok, err := x.test(query.Having.Expr, vars, values)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// aggregate computes an aggregate function over the bound rows of a group. NULL values are
+// skipped, and sum, min, max and avg are NULL if there is no value.
+func (x *Executor) aggregate(call *FunctionExpr, rows []map[string]any) (any, error) { // nolint: funlen
+	name := strings.ToLower(call.Name.Name)
+	var args []Expr
+	distinct := false
+	if call.Params != nil && call.Params.Items != nil {
+		args = call.Params.Items.Items
+		distinct = call.Params.Items.HasDistinct
+	}
+	if name == "count" && (len(args) == 0 || isStar(args[0])) {
+		if distinct {
+			return nil, executorError(call, "count(DISTINCT *) is not supported")
+		}
+		return int64(len(rows)), nil
+	}
+	if len(args) != 1 {
+		return nil, executorError(call, "%s expects 1 argument, got %d", name, len(args))
+	}
+
+	var values []any
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		value, err := x.eval(args[0], row, nil)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		if distinct || name == "uniq" {
+			key := valueKey([]any{value})
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		values = append(values, value)
+	}
+	switch name {
+	case "count", "uniq":
+		return int64(len(values)), nil
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	var result any
+	var err error
+	switch name {
+	case "sum", "avg":
+		result = int64(0)
+		for _, value := range values {
+			if result, err = arithmetic(result, value, "+"); err != nil {
+				return nil, wrapEvalError(call, err)
+			}
+		}
+		if name == "avg" {
+			result, err = arithmetic(result, int64(len(values)), "/")
+		}
+	case "min", "max":
+		result = values[0]
+		for _, value := range values[1:] {
+			c, err := compare(value, result)
+			if err != nil {
+				return nil, wrapEvalError(call, err)
+			}
+			if (name == "min" && c < 0) || (name == "max" && c > 0) {
+				result = value
+			}
+		}
+	}
+	return result, wrapEvalError(call, err)
+}
+
+// finish applies DISTINCT, ORDER BY and LIMIT to the rows of a query.
+func (x *Executor) finish(query *SelectQuery, columns []string, rows []*outputRow) (*Result, error) { // nolint: funlen
+	if query.HasDistinct {
+		seen := make(map[string]bool)
+		distinct := rows[:0:0]
+		for _, row := range rows {
+			key := valueKey(row.values)
+			if !seen[key] {
+				seen[key] = true
+				distinct = append(distinct, row)
+			}
+		}
+		rows = distinct
+	}
+
+	if query.OrderBy != nil {
+		var items []*OrderExpr
+		for _, item := range query.OrderBy.Items {
+			item, ok := item.(*OrderExpr)
+			if !ok {
+				return nil, executorError(query.OrderBy, "ORDER BY %s is not supported", query.OrderBy)
+			}
+			if item.Collate != nil || item.WithFill != nil {
+				return nil, executorError(item, "COLLATE and WITH FILL are not supported")
+			}
+			items = append(items, item)
+		}
+		keys := make([][]any, len(rows))
+		for i, row := range rows {
+			keys[i] = make([]any, len(items))
+			for j, item := range items {
+				if number, ok := item.Expr.(*NumberLiteral); ok {
+					position, err := parseNumberLiteral(number)
+					n, ok := position.(int64)
+					if err != nil || !ok || n < 1 || int(n) > len(row.values) {
+						return nil, executorError(number, "ORDER BY position %s is out of range", number)
+					}
+					keys[i][j] = row.values[n-1]
+					continue
+				}
+				value, err := x.eval(item.Expr, row.vars, row.aggregates)
+				if err != nil {
+					return nil, err
+				}
+				keys[i][j] = value
+			}
+		}
+		order := make([]int, len(rows))
+		for i := range order {
+			order[i] = i
+		}
+		var sortErr error
+		sort.SliceStable(order, func(a, b int) bool {
+			for j, item := range items {
+				left, right := keys[order[a]][j], keys[order[b]][j]
+				c := 0
+				switch {
+				case left == nil && right == nil:
+				case left == nil || right == nil:
+					// NULLs are last unless NULLS FIRST, whatever the direction
+					return (left == nil) == (item.Nulls == NullsOrderFirst)
+				default:
+					var err error
+					if c, err = compare(left, right); err != nil && sortErr == nil {
+						sortErr = wrapEvalError(item, err)
+					}
+				}
+				if item.Direction == OrderDirectionDesc {
+					c = -c
+				}
+				if c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+		if sortErr != nil {
+			return nil, sortErr
+		}
+		sorted := make([]*outputRow, len(rows))
+		for i, j := range order {
+			sorted[i] = rows[j]
+		}
+		rows = sorted
+	}
+
+	offset, limit := int64(0), int64(-1)
+	if query.Limit != nil {
+		var err error
+		if limit, err = x.count(query.Limit.Limit); err != nil {
+			return nil, err
+		}
+		if query.Limit.Offset != nil {
+			if offset, err = x.count(query.Limit.Offset); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if query.Offset != nil {
+		switch strings.ToUpper(query.Offset.Unit) {
+		case "", "ROW", "ROWS":
+		default:
+			return nil, executorError(query.Offset, "OFFSET %s is not supported", query.Offset.Unit)
+		}
+		var err error
+		if offset, err = x.count(query.Offset.Offset); err != nil {
+			return nil, err
+		}
+	}
+	if offset > int64(len(rows)) {
+		offset = int64(len(rows))
+	}
+	rows = rows[offset:]
+	if limit >= 0 && limit < int64(len(rows)) {
+		rows = rows[:limit]
+	}
+
+	result := &Result{Columns: columns, Rows: make([][]any, len(rows))}
+	for i, row := range rows {
+		result.Rows[i] = row.values
+	}
+	return result, nil
+}
+
+// count evaluates a LIMIT or OFFSET expression.
+func (x *Executor) count(expr Expr) (int64, error) {
+	value, err := x.eval(expr, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	n, err := toInt(value)
+	if err == nil && n < 0 {
+		err = fmt.Errorf("%d is negative", n)
+	}
+	return n, wrapEvalError(expr, err)
+}
+
+func (x *Executor) eval(expr Expr, vars map[string]any, aggregates map[Expr]any) (any, error) {
+	return Eval(expr, &EvalEnv{Vars: vars, Funcs: x.Funcs, values: aggregates})
+}
+
+// test evaluates a condition, NULL is false.
+func (x *Executor) test(expr Expr, vars map[string]any, aggregates map[Expr]any) (bool, error) {
+	value, err := x.eval(expr, vars, aggregates)
+	if err != nil || value == nil {
+		return false, err
+	}
+	ok, err := toBool(value)
+	return ok, wrapEvalError(expr, err)
+}
+
+// valueKey identifies a list of values, for grouping and DISTINCT.
+func valueKey(values []any) string {
+	var builder strings.Builder
+	for _, value := range values {
+		fmt.Fprintf(&builder, "%T:%v\x00", value, value)
+	}
+	return builder.String()
+}
+
+func isStar(expr Expr) bool {
+	if columnExpr, ok := expr.(*ColumnExpr); ok {
+		expr = columnExpr.Expr
+	}
+	ident, ok := expr.(*Ident)
+	return ok && ident.Name == "*"
+}
+
+// isLiteralIdent reports whether ident is TRUE, FALSE or NULL, which Eval does not look up.
+func isLiteralIdent(ident *Ident) bool {
+	if ident.QuoteType != Unquoted {
+		return false
+	}
+	switch strings.ToUpper(ident.Name) {
+	case KeywordTrue, KeywordFalse, KeywordNull:
+		return true
+	}
+	return false
+}
+
+// walkEvalExpr calls fn for expr and, while fn returns true, for the operands Eval evaluates.
+func walkEvalExpr(expr Expr, fn func(Expr) (bool, error)) error {
+	descend, err := fn(expr)
+	if err != nil || !descend {
+		return err
+	}
+	var children []Expr
+	switch expr := expr.(type) {
+	case *ColumnExpr:
+		children = []Expr{expr.Expr}
+	case *ParamExprList:
+		if expr.Items != nil {
+			children = expr.Items.Items
+		}
+	case *ArrayParamList:
+		if expr.Items != nil {
+			children = expr.Items.Items
+		}
+	case *UnaryExpr:
+		children = []Expr{expr.Expr}
+	case *NotExpr:
+		children = []Expr{expr.Expr}
+	case *NegateExpr:
+		children = []Expr{expr.Expr}
+	case *BinaryOperation:
+		children = []Expr{expr.LeftExpr}
+		if expr.Operation != TokenKindDash {
+			children = append(children, expr.RightExpr)
+		}
+	case *BetweenClause:
+		children = []Expr{expr.Expr, expr.Between, expr.And}
+	case *IsNullExpr:
+		children = []Expr{expr.Expr}
+	case *IsNotNullExpr:
+		children = []Expr{expr.Expr}
+	case *TernaryOperation:
+		children = []Expr{expr.Condition, expr.TrueExpr, expr.FalseExpr}
+	case *CaseExpr:
+		children = []Expr{expr.Expr}
+		for _, when := range expr.Whens {
+			children = append(children, when.When, when.Then)
+		}
+		children = append(children, expr.Else)
+	case *CastExpr:
+		children = []Expr{expr.Expr}
+	case *IntervalExpr:
+		children = []Expr{expr.Expr}
+	case *FunctionExpr:
+		if expr.Params != nil && expr.Params.Items != nil {
+			children = expr.Params.Items.Items
+		}
+	}
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+		if err := walkEvalExpr(child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectAggregates appends the aggregate calls of expr, which may not be nested.
+func collectAggregates(expr Expr, aggregates *[]*FunctionExpr) error {
+	return walkEvalExpr(expr, func(expr Expr) (bool, error) {
+		if !isAggregate(expr) {
+			return true, nil
+		}
+		call := expr.(*FunctionExpr)
+		*aggregates = append(*aggregates, call)
+		if call.Params != nil {
+			if err := rejectAggregates(call.Params, "an aggregate function"); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	})
+}
+
+// rejectAggregates returns an error for the first aggregate call of expr.
+func rejectAggregates(expr Expr, context string) error {
+	return walkEvalExpr(expr, func(expr Expr) (bool, error) {
+		if isAggregate(expr) {
+			return false, executorError(expr, "aggregate function %s is not allowed in %s", expr, context)
+		}
+		return true, nil
+	})
+}
+
+// relation is the rows of the FROM clause, whose values are keyed by qualified column names,
+// e.g. "t.id" for the column id of the table or alias t.
+type relation struct {
+	sources []*relationSource
+	// merged are the columns of USING clauses, they are read from the left table.
+	merged map[string]string
+	// unqualified are the qualified names of the columns whose name is not ambiguous.
+	unqualified map[string]string
+	ambiguous   map[string]bool
+	rows        []map[string]any
+}
+
+type relationSource struct {
+	name    string
+	columns []string
+}
+
+func (r *relation) index() {
+	r.unqualified = make(map[string]string)
+	r.ambiguous = make(map[string]bool)
+	for _, source := range r.sources {
+		for _, column := range source.columns {
+			if _, ok := r.unqualified[column]; ok || r.ambiguous[column] {
+				r.ambiguous[column] = true
+				delete(r.unqualified, column)
+				continue
+			}
+			r.unqualified[column] = source.name + "." + column
+		}
+	}
+	for column, name := range r.merged {
+		r.unqualified[column] = name
+		delete(r.ambiguous, column)
+	}
+}
+
+// bind returns the values of a row by qualified and unqualified names, for Eval.
+func (r *relation) bind(row map[string]any) map[string]any {
+	vars := make(map[string]any, len(row)+len(r.unqualified))
+	for name, value := range row {
+		vars[name] = value
+	}
+	for column, name := range r.unqualified {
+		vars[column] = row[name]
+	}
+	return vars
+}
+
+// resolve returns the qualified name of an *Ident or *ColumnIdentifier column reference.
+func (r *relation) resolve(expr Expr) (string, error) {
+	switch expr := expr.(type) {
+	case *ColumnExpr:
+		return r.resolve(expr.Expr)
+	case *Ident:
+		if name, ok := r.unqualified[expr.Name]; ok {
+			return name, nil
+		}
+		if r.ambiguous[expr.Name] {
+			return "", executorError(expr, "column %s is ambiguous", expr.Name)
+		}
+		return "", executorError(expr, "unknown column %s", expr.Name)
+	case *ColumnIdentifier:
+		if expr.Table == nil {
+			return r.resolve(expr.Column)
+		}
+		for _, source := range r.sources {
+			if source.name != expr.Table.Name {
+				continue
+			}
+			for _, column := range source.columns {
+				if column == expr.Column.Name {
+					return source.name + "." + column, nil
+				}
+			}
+			return "", executorError(expr, "unknown column %s", expr)
+		}
+		return "", executorError(expr, "unknown table %s", expr.Table.Name)
+	}
+	return "", executorError(expr, "%s is not a column", expr)
+}
+
+// check returns an error for the first unknown or ambiguous column of expr, or subquery.
+func (r *relation) check(expr Expr, aliases map[string]bool) error {
+	return walkEvalExpr(expr, func(expr Expr) (bool, error) {
+		switch expr := expr.(type) {
+		case *SubQuery, *SelectQuery:
+			return false, executorError(expr, "subqueries in expressions are not supported")
+		case *Ident:
+			if aliases[expr.Name] || isLiteralIdent(expr) || expr.Name == "*" {
+				return false, nil
+			}
+			_, err := r.resolve(expr)
+			return false, err
+		case *ColumnIdentifier:
+			_, err := r.resolve(expr)
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// checkGrouped returns an error for the first column of expr that is used outside of aggregate
+// functions without being grouped.
+func (r *relation) checkGrouped(expr Expr, grouped, aliases map[string]bool) error {
+	return walkEvalExpr(expr, func(expr Expr) (bool, error) {
+		if grouped[expr.String()] || isAggregate(expr) {
+			return false, nil
+		}
+		switch expr := expr.(type) {
+		case *Ident:
+			if aliases[expr.Name] || isLiteralIdent(expr) {
+				return false, nil
+			}
+		case *ColumnIdentifier:
+		default:
+			return true, nil
+		}
+		name, err := r.resolve(expr)
+		if err != nil {
+			return false, err
+		}
+		if !grouped[name] {
+			return false, executorError(expr, "column %s is neither grouped nor aggregated", expr)
+		}
+		return false, nil
+	})
+}
+
+// groupKey returns the expression of a GROUP BY item, which may be a select alias or position.
+func (r *relation) groupKey(item Expr, outputs []output) (Expr, error) {
+	if columnExpr, ok := item.(*ColumnExpr); ok {
+		item = columnExpr.Expr
+	}
+	switch key := item.(type) {
+	case *NumberLiteral:
+		position, err := parseNumberLiteral(key)
+		n, ok := position.(int64)
+		if err != nil || !ok || n < 1 || int(n) > len(outputs) || outputs[n-1].expr == nil {
+			return nil, executorError(key, "GROUP BY position %s is out of range", key)
+		}
+		return outputs[n-1].expr, nil
+	case *Ident:
+		if _, err := r.resolve(key); err != nil {
+			for _, output := range outputs {
+				if output.expr != nil && output.name == key.Name {
+					return output.expr, nil
+				}
+			}
+		}
+	}
+	return item, r.check(item, nil)
+}
+
+// outputs returns the columns of the select items, with * and t.* expanded.
+func (r *relation) outputs(items []*SelectItem) ([]output, error) {
+	var outputs []output
+	for _, item := range items {
+		if len(item.Modifiers) > 0 {
+			return nil, executorError(item.Modifiers[0], "select item modifiers are not supported")
+		}
+		star := ""
+		switch expr := item.Expr.(type) {
+		case *Ident:
+			if expr.Name == "*" {
+				star = "*"
+			}
+		case *NestedIdentifier:
+			if expr.DotIdent != nil && expr.DotIdent.Name == "*" {
+				star = expr.Ident.Name
+			}
+		case *ColumnIdentifier:
+			if expr.Column.Name == "*" && expr.Table != nil {
+				star = expr.Table.Name
+			}
+		}
+		if star != "" {
+			found := false
+			for _, source := range r.sources {
+				if star != "*" && source.name != star {
+					continue
+				}
+				found = true
+				for _, column := range source.columns {
+					name := source.name + "." + column
+					if merged, ok := r.merged[column]; ok && star == "*" {
+						if merged != name {
+							continue
+						}
+					}
+					outputs = append(outputs, output{name: column, key: name})
+				}
+			}
+			if !found {
+				return nil, executorError(item.Expr, "unknown table %s", star)
+			}
+			continue
+		}
+
+		name := item.Expr.String()
+		switch expr := item.Expr.(type) {
+		case *Ident:
+			name = expr.Name
+		case *ColumnIdentifier:
+			name = expr.Column.Name
+		}
+		if item.Alias != nil {
+			name = item.Alias.Name
+		}
+		outputs = append(outputs, output{name: name, expr: item.Expr})
+	}
+	return outputs, nil
+}
+
+// from returns the rows of the FROM clause, or a single empty row without it.
+func (x *Executor) from(query *SelectQuery) (*relation, error) { // nolint: funlen
+	rel := &relation{merged: make(map[string]string), rows: []map[string]any{{}}}
+	rel.index()
+	if query.From == nil {
+		return rel, nil
+	}
+
+	expr := query.From.Expr
+	for first := true; expr != nil; first = false {
+		var modifiers []string
+		var table, constraints Expr
+		if join, ok := expr.(*JoinExpr); ok {
+			modifiers, table, constraints, expr = join.Modifiers, join.Left, join.Constraints, join.Right
+		} else {
+			table, expr = expr, nil
+		}
+		source, rows, err := x.source(table)
+		if err != nil {
+			return nil, err
+		}
+		for _, existing := range rel.sources {
+			if existing.name == source.name {
+				return nil, executorError(table, "table %s is used twice, alias it", source.name)
+			}
+		}
+
+		kind := strings.ToUpper(strings.Join(modifiers, " "))
+		switch kind {
+		case "", KeywordCross + " " + KeywordJoin:
+			if constraints != nil {
+				return nil, executorError(constraints, "a cross join can not have %s", constraints)
+			}
+		case KeywordJoin, KeywordInner + " " + KeywordJoin, KeywordLeft + " " + KeywordJoin,
+			KeywordLeft + " " + KeywordOuter + " " + KeywordJoin:
+			if constraints == nil {
+				return nil, executorError(table, "%s requires ON or USING", kind)
+			}
+		default:
+			return nil, executorError(table, "%s is not supported", kind)
+		}
+		if first {
+			rel.sources = []*relationSource{source}
+			rel.rows = rows
+			rel.index()
+			continue
+		}
+
+		joined := &relation{
+			sources: append(rel.sources[:len(rel.sources):len(rel.sources)], source),
+			merged:  make(map[string]string, len(rel.merged)),
+		}
+		for column, name := range rel.merged {
+			joined.merged[column] = name
+		}
+		var match func(left, right map[string]any) (bool, error)
+		switch constraints := constraints.(type) {
+		case nil:
+			match = func(_, _ map[string]any) (bool, error) { return true, nil }
+		case *OnClause:
+			joined.index()
+			for _, condition := range constraints.On.Items {
+				if err := joined.check(condition, nil); err != nil {
+					return nil, err
+				}
+				if err := rejectAggregates(condition, "ON"); err != nil {
+					return nil, err
+				}
+			}
+			match = func(left, right map[string]any) (bool, error) {
+				vars := joined.bind(merge(left, right))
+				for _, condition := range constraints.On.Items {
+					if ok, err := x.test(condition, vars, nil); err != nil || !ok {
+						return false, err
+					}
+				}
+				return true, nil
+			}
+		case *UsingClause:
+			var pairs [][2]string
+			for _, item := range constraints.Using.Items {
+				column, ok := item.(*ColumnExpr)
+				var ident *Ident
+				if ok {
+					ident, ok = column.Expr.(*Ident)
+				}
+				if !ok {
+					return nil, executorError(item, "USING %s is not a column", item)
+				}
+				left, err := rel.resolve(ident)
+				if err != nil {
+					return nil, err
+				}
+				right, err := joined.resolve(&ColumnIdentifier{Table: &Ident{Name: source.name}, Column: ident})
+				if err != nil {
+					return nil, executorError(item, "unknown column %s in %s", ident.Name, source.name)
+				}
+				pairs = append(pairs, [2]string{left, right})
+				joined.merged[ident.Name] = left
+			}
+			joined.index()
+			match = func(left, right map[string]any) (bool, error) {
+				for _, pair := range pairs {
+					equal, err := compareValues(left[pair[0]], right[pair[1]], "=")
+					if err != nil {
+						return false, executorError(constraints, "%v", err)
+					}
+					if equal != true {
+						return false, nil
+					}
+				}
+				return true, nil
+			}
+		default:
+			return nil, executorError(constraints, "%s is not supported", constraints)
+		}
+
+		nulls := make(map[string]any, len(source.columns))
+		for _, column := range source.columns {
+			nulls[source.name+"."+column] = nil
+		}
+		for _, left := range rel.rows {
+			matched := false
+			for _, right := range rows {
+				ok, err := match(left, right)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					matched = true
+					joined.rows = append(joined.rows, merge(left, right))
+				}
+			}
+			if !matched && strings.HasPrefix(kind, KeywordLeft) {
+				joined.rows = append(joined.rows, merge(left, nulls))
+			}
+		}
+		rel = joined
+	}
+	return rel, nil
+}
+
+// source returns a table or subquery of the FROM clause with its rows keyed by qualified names.
+func (x *Executor) source(expr Expr) (*relationSource, []map[string]any, error) { // nolint: funlen
+	joinTable, ok := expr.(*JoinTableExpr)
+	if !ok {
+		return nil, nil, executorError(expr, "%s is not supported in FROM", expr)
+	}
+	if joinTable.HasFinal || joinTable.Table.HasFinal || joinTable.SampleRatio != nil {
+		return nil, nil, executorError(expr, "FINAL and SAMPLE are not supported")
+	}
+	target, name := joinTable.Table.Expr, ""
+	alias := joinTable.Table.Alias
+	if aliasExpr, ok := target.(*AliasExpr); ok {
+		alias = aliasExpr
+		target = aliasExpr.Expr
+	}
+	if alias != nil {
+		ident, ok := alias.Alias.(*Ident)
+		if !ok {
+			return nil, nil, executorError(alias, "alias %s is not supported", alias.Alias)
+		}
+		name = ident.Name
+	}
+
+	var columns []string
+	var rows []map[string]any
+	switch target := target.(type) {
+	case *TableIdentifier:
+		var table *executorTable
+		if target.Schema != nil {
+			table = x.tables[target.Schema.Name+"."+target.Table.Name]
+		}
+		if table == nil {
+			table = x.tables[target.Table.Name]
+		}
+		if table == nil {
+			return nil, nil, executorError(target, "unknown table %s", target)
+		}
+		if name == "" {
+			name = target.Table.Name
+		}
+		columns, rows = table.columns, table.rows
+	case *SubQuery:
+		result, err := x.executeSelect(target.Select)
+		if err != nil {
+			return nil, nil, err
+		}
+		columns = result.Columns
+		rows = make([]map[string]any, len(result.Rows))
+		for i, values := range result.Rows {
+			rows[i] = make(map[string]any, len(values))
+			for j, column := range columns {
+				rows[i][column] = values[j]
+			}
+		}
+	default:
+		return nil, nil, executorError(target, "%s is not supported in FROM", target)
+	}
+
+	qualified := make([]map[string]any, len(rows))
+	for i, row := range rows {
+		qualified[i] = make(map[string]any, len(columns))
+		for _, column := range columns {
+			qualified[i][name+"."+column] = normalizeValue(row[column])
+		}
+	}
+	return &relationSource{name: name, columns: columns}, qualified, nil
+}
+
+func merge(left, right map[string]any) map[string]any {
+	merged := make(map[string]any, len(left)+len(right))
+	for name, value := range left {
+		merged[name] = value
+	}
+	for name, value := range right {
+		merged[name] = value
+	}
+	return merged
+}
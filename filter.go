@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter is a predicate over a row of column values by name, see FilterCompiler.
+type Filter func(row map[string]any) (bool, error)
+
+// FilterCompiler compiles WHERE expressions to Filters.
+type FilterCompiler struct {
+	// Funcs are the functions a filter may call by lower case name, no other function is allowed.
+	Funcs map[string]EvalFunc
+}
+
+// CompileFilter compiles expr to a Filter that can not call any function.
+func CompileFilter(expr Expr) (Filter, error) {
+	return FilterCompiler{}.Compile(expr)
+}
+
+// Compile compiles a *WhereClause or a condition to a Filter. The condition may use literals,
+// columns, comparison, arithmetic, LIKE, ILIKE, IN, BETWEEN, IS [NOT] NULL, AND, OR, NOT and
+// the functions of c.Funcs, anything else is rejected with an *EvalError positioned at it.
+// The filter evaluates the condition with Eval, columns missing from the row are an error and a
+// NULL result does not match.
+func (c FilterCompiler) Compile(expr Expr) (Filter, error) {
+	if where, ok := expr.(*WhereClause); ok {
+		expr = where.Expr
+	}
+	funcs := make(map[string]EvalFunc, len(c.Funcs))
+	for name, function := range c.Funcs {
+		funcs[strings.ToLower(name)] = function
+	}
+	if err := checkFilterExpr(expr, funcs); err != nil {
+		return nil, err
+	}
+	return func(row map[string]any) (bool, error) {
+		value, err := Eval(expr, &EvalEnv{Vars: row, Funcs: funcs})
+		if err != nil || value == nil {
+			return false, err
+		}
+		matched, err := toBool(value)
+		if err != nil {
+			return false, &EvalError{Pos: expr.Start(), End: expr.End(), Err: err}
+		}
+		return matched, nil
+	}, nil
+}
+
+// checkFilterExpr returns an error for the first node of expr a filter does not support.
+func checkFilterExpr(expr Expr, funcs map[string]EvalFunc) error { // nolint: funlen
+	unsupported := func(format string, args ...any) error {
+		return &EvalError{Pos: expr.Start(), End: expr.End(), Err: fmt.Errorf(format, args...)}
+	}
+	switch expr := expr.(type) {
+	case *NumberLiteral, *StringLiteral, *NullLiteral, *Ident, *ColumnIdentifier:
+		return nil
+	case *ColumnExpr:
+		if expr.Alias != nil {
+			return unsupported("alias %s is not supported in a filter", expr.Alias)
+		}
+		return checkFilterExpr(expr.Expr, funcs)
+	case *ParamExprList:
+		return checkFilterList(expr.Items, funcs)
+	case *ArrayParamList:
+		return checkFilterList(expr.Items, funcs)
+	case *UnaryExpr:
+		switch strings.ToUpper(string(expr.Kind)) {
+		case KeywordNot, string(TokenKindMinus), string(TokenKindPlus):
+			return checkFilterExpr(expr.Expr, funcs)
+		}
+		return unsupported("operator %s is not supported in a filter", expr.Kind)
+	case *NotExpr:
+		return checkFilterExpr(expr.Expr, funcs)
+	case *NegateExpr:
+		return checkFilterExpr(expr.Expr, funcs)
+	case *BinaryOperation:
+		switch strings.ToUpper(string(expr.Operation)) {
+		case "=", "==", "!=", "<>", "<", "<=", ">", ">=", "+", "-", "*", "/", "%", string(TokenKindConcat),
+			KeywordAnd, KeywordOr, KeywordLike, KeywordIlike, "NOT " + KeywordLike, "NOT " + KeywordIlike:
+		case KeywordIn, "NOT " + KeywordIn:
+			if expr.HasGlobal {
+				return unsupported("GLOBAL IN is not supported in a filter")
+			}
+		default:
+			return unsupported("operator %s is not supported in a filter", expr.Operation)
+		}
+		if err := checkFilterExpr(expr.LeftExpr, funcs); err != nil {
+			return err
+		}
+		return checkFilterExpr(expr.RightExpr, funcs)
+	case *BetweenClause:
+		for _, operand := range []Expr{expr.Expr, expr.Between, expr.And} {
+			if err := checkFilterExpr(operand, funcs); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *IsNullExpr:
+		return checkFilterExpr(expr.Expr, funcs)
+	case *IsNotNullExpr:
+		return checkFilterExpr(expr.Expr, funcs)
+	case *FunctionExpr:
+		if _, ok := funcs[strings.ToLower(expr.Name.Name)]; !ok {
+			return unsupported("function %s is not allowed in a filter", expr.Name.Name)
+		}
+		if expr.Params == nil {
+			return nil
+		}
+		return checkFilterList(expr.Params.Items, funcs)
+	}
+	return unsupported("%s is not supported in a filter", expr)
+}
+
+func checkFilterList(list *ColumnExprList, funcs map[string]EvalFunc) error {
+	if list == nil {
+		return nil
+	}
+	for _, item := range list.Items {
+		if err := checkFilterExpr(item, funcs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,9 +4,11 @@ const (
 	KeywordAdd              = "ADD"
 	KeywordAdmin            = "ADMIN"
 	KeywordAfter            = "AFTER"
+	KeywordAlgorithm        = "ALGORITHM"
 	KeywordAlias            = "ALIAS"
 	KeywordAll              = "ALL"
 	KeywordAlter            = "ALTER"
+	KeywordAlways           = "ALWAYS"
 	KeywordAnd              = "AND"
 	KeywordAnti             = "ANTI"
 	KeywordAny              = "ANY"
@@ -21,12 +23,16 @@ const (
 	KeywordAsync            = "ASYNC"
 	KeywordAttach           = "ATTACH"
 	KeywordAutoIncrement    = "AUTO_INCREMENT"
+	KeywordBegin            = "BEGIN"
 	KeywordBetween          = "BETWEEN"
 	KeywordBoth             = "BOTH"
 	KeywordBy               = "BY"
 	KeywordCache            = "CACHE"
 	KeywordCase             = "CASE"
 	KeywordCast             = "CAST"
+	KeywordChain            = "CHAIN"
+	KeywordChange           = "CHANGE"
+	KeywordCharacter        = "CHARACTER"
 	KeywordCharset          = "CHARSET"
 	KeywordCheck            = "CHECK"
 	KeywordClear            = "CLEAR"
@@ -36,9 +42,14 @@ const (
 	KeywordColumn           = "COLUMN"
 	KeywordColumns          = "COLUMNS"
 	KeywordComment          = "COMMENT"
+	KeywordCommit           = "COMMIT"
 	KeywordCompiled         = "COMPILED"
+	KeywordCompression      = "COMPRESSION"
 	KeywordConfig           = "CONFIG"
+	KeywordConflict         = "CONFLICT"
+	KeywordConsistent       = "CONSISTENT"
 	KeywordConstraint       = "CONSTRAINT"
+	KeywordConvert          = "CONVERT"
 	KeywordCreate           = "CREATE"
 	KeywordCross            = "CROSS"
 	KeywordCube             = "CUBE"
@@ -52,6 +63,7 @@ const (
 	KeywordDefault          = "DEFAULT"
 	KeywordDelay            = "DELAY"
 	KeywordDelete           = "DELETE"
+	KeywordDeleted          = "DELETED"
 	KeywordDepends          = "DEPENDS"
 	KeywordDesc             = "DESC"
 	KeywordDescending       = "DESCENDING"
@@ -63,6 +75,7 @@ const (
 	KeywordDisk             = "DISK"
 	KeywordDistinct         = "DISTINCT"
 	KeywordDistributed      = "DISTRIBUTED"
+	KeywordDo               = "DO"
 	KeywordDrop             = "DROP"
 	KeywordDNS              = "DNS"
 	KeywordElse             = "ELSE"
@@ -79,8 +92,10 @@ const (
 	KeywordExpression       = "EXPRESSION"
 	KeywordExtract          = "EXTRACT"
 	KeywordFalse            = "FALSE"
+	KeywordFetch            = "FETCH"
 	KeywordFetches          = "FETCHES"
 	KeywordFileSystem       = "FILESYSTEM"
+	KeywordFill             = "FILL"
 	KeywordFinal            = "FINAL"
 	KeywordFirst            = "FIRST"
 	KeywordFlush            = "FLUSH"
@@ -92,6 +107,7 @@ const (
 	KeywordFull             = "FULL"
 	KeywordFunction         = "FUNCTION"
 	KeywordFunctions        = "FUNCTIONS"
+	KeywordGenerated        = "GENERATED"
 	KeywordGlobal           = "GLOBAL"
 	KeywordGrant            = "GRANT"
 	KeywordGrantees         = "GRANTEES"
@@ -104,14 +120,19 @@ const (
 	KeywordHour             = "HOUR"
 	KeywordId               = "ID"
 	KeywordIdentified       = "IDENTIFIED"
+	KeywordIdentity         = "IDENTITY"
 	KeywordIf               = "IF"
 	KeywordIlike            = "ILIKE"
 	KeywordIn               = "IN"
 	KeywordIndex            = "INDEX"
 	KeywordInf              = "INF"
+	KeywordInherit          = "INHERIT"
+	KeywordInherits         = "INHERITS"
 	KeywordInjective        = "INJECTIVE"
 	KeywordInner            = "INNER"
 	KeywordInsert           = "INSERT"
+	KeywordInterpolate      = "INTERPOLATE"
+	KeywordIntersect        = "INTERSECT"
 	KeywordInterval         = "INTERVAL"
 	KeywordInto             = "INTO"
 	KeywordIp               = "IP"
@@ -120,6 +141,7 @@ const (
 	KeywordJoin             = "JOIN"
 	KeywordJSON             = "JSON"
 	KeywordKey              = "KEY"
+	KeywordKeyed            = "KEYED"
 	KeywordKill             = "KILL"
 	KeywordKerberos         = "KERBEROS"
 	KeywordLast             = "LAST"
@@ -127,33 +149,46 @@ const (
 	KeywordLdap             = "LDAP"
 	KeywordLeading          = "LEADING"
 	KeywordLeft             = "LEFT"
+	KeywordLevel            = "LEVEL"
 	KeywordLifetime         = "LIFETIME"
 	KeywordLike             = "LIKE"
 	KeywordLimit            = "LIMIT"
+	KeywordLimits           = "LIMITS"
 	KeywordLive             = "LIVE"
 	KeywordLocal            = "LOCAL"
+	KeywordLock             = "LOCK"
+	KeywordLocked           = "LOCKED"
 	KeywordLogs             = "LOGS"
+	KeywordLowPriority      = "LOW_PRIORITY"
 	KeywordMark             = "MARK"
+	KeywordMask             = "MASK"
 	KeywordMaterialize      = "MATERIALIZE"
 	KeywordMaterialized     = "MATERIALIZED"
 	KeywordMax              = "MAX"
 	KeywordMerges           = "MERGES"
 	KeywordMin              = "MIN"
 	KeywordMinute           = "MINUTE"
+	KeywordMode             = "MODE"
 	KeywordModify           = "MODIFY"
 	KeywordMonth            = "MONTH"
 	KeywordMove             = "MOVE"
 	KeywordMoves            = "MOVES"
 	KeywordMutation         = "MUTATION"
 	KeywordName             = "NAME"
+	KeywordNames            = "NAMES"
 	KeywordNan_sql          = "NAN_SQL"
+	KeywordNext             = "NEXT"
 	KeywordNo               = "NO"
 	KeywordNone             = "NONE"
 	KeywordNot              = "NOT"
+	KeywordNothing          = "NOTHING"
+	KeywordNowait           = "NOWAIT"
 	KeywordNull             = "NULL"
 	KeywordNulls            = "NULLS"
+	KeywordOf               = "OF"
 	KeywordOffset           = "OFFSET"
 	KeywordOn               = "ON"
+	KeywordOnly             = "ONLY"
 	KeywordOptimize         = "OPTIMIZE"
 	KeywordOption           = "OPTION"
 	KeywordOr               = "OR"
@@ -162,23 +197,31 @@ const (
 	KeywordOutfile          = "OUTFILE"
 	KeywordOver             = "OVER"
 	KeywordPartition        = "PARTITION"
+	KeywordPermissive       = "PERMISSIVE"
+	KeywordPersist          = "PERSIST"
+	KeywordPersistOnly      = "PERSIST_ONLY"
 	KeywordPipeline         = "PIPELINE"
 	KeywordPolicy           = "POLICY"
 	KeywordPopulate         = "POPULATE"
 	KeywordPreceding        = "PRECEDING"
 	KeywordPrewhere         = "PREWHERE"
 	KeywordPrimary          = "PRIMARY"
+	KeywordProfile          = "PROFILE"
 	KeywordProjection       = "PROJECTION"
+	KeywordQualify          = "QUALIFY"
 	KeywordQuarter          = "QUARTER"
 	KeywordQuery            = "QUERY"
 	KeywordQueues           = "QUEUES"
 	KeywordQuota            = "QUOTA"
 	KeywordRandomize        = "RANDOMIZE"
+	KeywordRandomized       = "RANDOMIZED"
 	KeywordRange            = "RANGE"
+	KeywordRead             = "READ"
 	KeywordRealm            = "REALM"
 	KeywordRecompress       = "RECOMPRESS"
 	KeywordRefresh          = "REFRESH"
 	KeywordRegexp           = "REGEXP"
+	KeywordRelease          = "RELEASE"
 	KeywordReload           = "RELOAD"
 	KeywordRemove           = "REMOVE"
 	KeywordRename           = "RENAME"
@@ -186,27 +229,39 @@ const (
 	KeywordReplica          = "REPLICA"
 	KeywordReplicated       = "REPLICATED"
 	KeywordReplication      = "REPLICATION"
+	KeywordReset            = "RESET"
 	KeywordRestart          = "RESTART"
+	KeywordRestrictive      = "RESTRICTIVE"
+	KeywordReturning        = "RETURNING"
+	KeywordRevoke           = "REVOKE"
 	KeywordRight            = "RIGHT"
 	KeywordRole             = "ROLE"
+	KeywordRollback         = "ROLLBACK"
 	KeywordRollup           = "ROLLUP"
 	KeywordRow              = "ROW"
 	KeywordRows             = "ROWS"
 	KeywordSample           = "SAMPLE"
+	KeywordSavepoint        = "SAVEPOINT"
 	KeywordSecond           = "SECOND"
 	KeywordSelect           = "SELECT"
 	KeywordSemi             = "SEMI"
 	KeywordSends            = "SENDS"
 	KeywordServer           = "SERVER"
+	KeywordSession          = "SESSION"
 	KeywordSet              = "SET"
 	KeywordSets             = "SETS"
+	KeywordSetting          = "SETTING"
 	KeywordSettings         = "SETTINGS"
+	KeywordShare            = "SHARE"
 	KeywordShow             = "SHOW"
 	KeywordShutdown         = "SHUTDOWN"
 	KeywordSkip             = "SKIP"
+	KeywordSnapshot         = "SNAPSHOT"
 	KeywordSource           = "SOURCE"
 	KeywordStart            = "START"
+	KeywordStep             = "STEP"
 	KeywordStop             = "STOP"
+	KeywordStored           = "STORED"
 	KeywordSubstring        = "SUBSTRING"
 	KeywordSync             = "SYNC"
 	KeywordSyntax           = "SYNTAX"
@@ -222,7 +277,9 @@ const (
 	KeywordTo               = "TO"
 	KeywordTop              = "TOP"
 	KeywordTotals           = "TOTALS"
+	KeywordTracking         = "TRACKING"
 	KeywordTrailing         = "TRAILING"
+	KeywordTransaction      = "TRANSACTION"
 	KeywordTrim             = "TRIM"
 	KeywordTrue             = "TRUE"
 	KeywordTruncate         = "TRUNCATE"
@@ -233,6 +290,7 @@ const (
 	KeywordUncompressed     = "UNCOMPRESSED"
 	KeywordUnion            = "UNION"
 	KeywordUnique           = "UNIQUE"
+	KeywordUnlock           = "UNLOCK"
 	KeywordUpdate           = "UPDATE"
 	KeywordUse              = "USE"
 	KeywordUser             = "USER"
@@ -247,6 +305,8 @@ const (
 	KeywordWhere            = "WHERE"
 	KeywordWindow           = "WINDOW"
 	KeywordWith             = "WITH"
+	KeywordWork             = "WORK"
+	KeywordWrite            = "WRITE"
 	KeywordYear             = "YEAR"
 	KeywordDefiner          = "DEFINER"
 	KeywordSQL              = "SQL"
@@ -257,9 +317,11 @@ var keywords = NewSet(
 	KeywordAdd,
 	KeywordAdmin,
 	KeywordAfter,
+	KeywordAlgorithm,
 	KeywordAlias,
 	KeywordAll,
 	KeywordAlter,
+	KeywordAlways,
 	KeywordAnd,
 	KeywordAnti,
 	KeywordAny,
@@ -274,12 +336,16 @@ var keywords = NewSet(
 	KeywordAsync,
 	KeywordAttach,
 	KeywordAutoIncrement,
+	KeywordBegin,
 	KeywordBetween,
 	KeywordBoth,
 	KeywordBy,
 	KeywordCache,
 	KeywordCase,
 	KeywordCast,
+	KeywordChain,
+	KeywordChange,
+	KeywordCharacter,
 	KeywordCharset,
 	KeywordCheck,
 	KeywordClear,
@@ -289,9 +355,14 @@ var keywords = NewSet(
 	KeywordColumn,
 	KeywordColumns,
 	KeywordComment,
+	KeywordCommit,
 	KeywordCompiled,
+	KeywordCompression,
 	KeywordConfig,
+	KeywordConflict,
+	KeywordConsistent,
 	KeywordConstraint,
+	KeywordConvert,
 	KeywordCreate,
 	KeywordCross,
 	KeywordCube,
@@ -305,6 +376,7 @@ var keywords = NewSet(
 	KeywordDefault,
 	KeywordDelay,
 	KeywordDelete,
+	KeywordDeleted,
 	KeywordDepends,
 	KeywordDesc,
 	KeywordDescending,
@@ -316,6 +388,7 @@ var keywords = NewSet(
 	KeywordDisk,
 	KeywordDistinct,
 	KeywordDistributed,
+	KeywordDo,
 	KeywordDrop,
 	KeywordDNS,
 	KeywordElse,
@@ -332,8 +405,10 @@ var keywords = NewSet(
 	KeywordExpression,
 	KeywordExtract,
 	KeywordFalse,
+	KeywordFetch,
 	KeywordFetches,
 	KeywordFileSystem,
+	KeywordFill,
 	KeywordFinal,
 	KeywordFirst,
 	KeywordFlush,
@@ -345,6 +420,7 @@ var keywords = NewSet(
 	KeywordFull,
 	KeywordFunction,
 	KeywordFunctions,
+	KeywordGenerated,
 	KeywordGlobal,
 	KeywordGrant,
 	KeywordGrantees,
@@ -357,14 +433,19 @@ var keywords = NewSet(
 	KeywordHour,
 	KeywordId,
 	KeywordIdentified,
+	KeywordIdentity,
 	KeywordIf,
 	KeywordIlike,
 	KeywordIn,
 	KeywordIndex,
 	KeywordInf,
+	KeywordInherit,
+	KeywordInherits,
 	KeywordInjective,
 	KeywordInner,
 	KeywordInsert,
+	KeywordInterpolate,
+	KeywordIntersect,
 	KeywordInterval,
 	KeywordInto,
 	KeywordIp,
@@ -373,6 +454,7 @@ var keywords = NewSet(
 	KeywordJoin,
 	KeywordJSON,
 	KeywordKey,
+	KeywordKeyed,
 	KeywordKill,
 	KeywordKerberos,
 	KeywordLast,
@@ -380,33 +462,46 @@ var keywords = NewSet(
 	KeywordLdap,
 	KeywordLeading,
 	KeywordLeft,
+	KeywordLevel,
 	KeywordLifetime,
 	KeywordLike,
 	KeywordLimit,
+	KeywordLimits,
 	KeywordLive,
 	KeywordLocal,
+	KeywordLock,
+	KeywordLocked,
 	KeywordLogs,
+	KeywordLowPriority,
 	KeywordMark,
+	KeywordMask,
 	KeywordMaterialize,
 	KeywordMaterialized,
 	KeywordMax,
 	KeywordMerges,
 	KeywordMin,
 	KeywordMinute,
+	KeywordMode,
 	KeywordModify,
 	KeywordMonth,
 	KeywordMove,
 	KeywordMoves,
 	KeywordMutation,
 	KeywordName,
+	KeywordNames,
 	KeywordNan_sql,
+	KeywordNext,
 	KeywordNo,
 	KeywordNone,
 	KeywordNot,
+	KeywordNothing,
+	KeywordNowait,
 	KeywordNull,
 	KeywordNulls,
+	KeywordOf,
 	KeywordOffset,
 	KeywordOn,
+	KeywordOnly,
 	KeywordOptimize,
 	KeywordOption,
 	KeywordOr,
@@ -415,23 +510,31 @@ var keywords = NewSet(
 	KeywordOutfile,
 	KeywordOver,
 	KeywordPartition,
+	KeywordPermissive,
+	KeywordPersist,
+	KeywordPersistOnly,
 	KeywordPipeline,
 	KeywordPolicy,
 	KeywordPopulate,
 	KeywordPreceding,
 	KeywordPrewhere,
 	KeywordPrimary,
+	KeywordProfile,
 	KeywordProjection,
+	KeywordQualify,
 	KeywordQuarter,
 	KeywordQuery,
 	KeywordQueues,
 	KeywordQuota,
 	KeywordRandomize,
+	KeywordRandomized,
 	KeywordRange,
+	KeywordRead,
 	KeywordRealm,
 	KeywordRecompress,
 	KeywordRefresh,
 	KeywordRegexp,
+	KeywordRelease,
 	KeywordReload,
 	KeywordRemove,
 	KeywordRename,
@@ -439,27 +542,39 @@ var keywords = NewSet(
 	KeywordReplica,
 	KeywordReplicated,
 	KeywordReplication,
+	KeywordReset,
 	KeywordRestart,
+	KeywordRestrictive,
+	KeywordReturning,
+	KeywordRevoke,
 	KeywordRight,
 	KeywordRole,
+	KeywordRollback,
 	KeywordRollup,
 	KeywordRow,
 	KeywordRows,
 	KeywordSample,
+	KeywordSavepoint,
 	KeywordSecond,
 	KeywordSelect,
 	KeywordSemi,
 	KeywordSends,
 	KeywordServer,
+	KeywordSession,
 	KeywordSet,
 	KeywordSets,
+	KeywordSetting,
 	KeywordSettings,
+	KeywordShare,
 	KeywordShow,
 	KeywordShutdown,
 	KeywordSkip,
+	KeywordSnapshot,
 	KeywordSource,
 	KeywordStart,
+	KeywordStep,
 	KeywordStop,
+	KeywordStored,
 	KeywordSubstring,
 	KeywordSync,
 	KeywordSyntax,
@@ -475,7 +590,9 @@ var keywords = NewSet(
 	KeywordTo,
 	KeywordTop,
 	KeywordTotals,
+	KeywordTracking,
 	KeywordTrailing,
+	KeywordTransaction,
 	KeywordTrim,
 	KeywordTrue,
 	KeywordTruncate,
@@ -486,6 +603,7 @@ var keywords = NewSet(
 	KeywordUncompressed,
 	KeywordUnique,
 	KeywordUnion,
+	KeywordUnlock,
 	KeywordUpdate,
 	KeywordUse,
 	KeywordUser,
@@ -500,6 +618,8 @@ var keywords = NewSet(
 	KeywordWhere,
 	KeywordWindow,
 	KeywordWith,
+	KeywordWork,
+	KeywordWrite,
 	KeywordYear,
 	KeywordDefiner,
 	KeywordSQL,
@@ -3,6 +3,7 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -15,6 +16,9 @@ const (
 	TokenKindInt          TokenKind = "<int>"
 	TokenKindFloat        TokenKind = "<float>"
 	TokenKindString       TokenKind = "<string>"
+	TokenKindParam        TokenKind = "<param>"
+	TokenKindWhitespace   TokenKind = "<whitespace>"
+	TokenKindComment      TokenKind = "<comment>"
 	TokenKindDot                    = "."
 	TokenKindSingleEQ     TokenKind = "="
 	TokenKindDoubleEQ     TokenKind = "=="
@@ -31,8 +35,9 @@ const (
 	TokenKindDiv   TokenKind = "/"
 	TokenKindMod   TokenKind = "%"
 
-	TokenKindArrow TokenKind = "->"
-	TokenKindDash  TokenKind = "::"
+	TokenKindArrow  TokenKind = "->"
+	TokenKindDash   TokenKind = "::"
+	TokenKindConcat TokenKind = "||"
 
 	TokenKindLParen   TokenKind = "("
 	TokenKindRParen   TokenKind = ")"
@@ -51,6 +56,9 @@ const (
 	Unquoted = iota + 1
 	DoubleQuote
 	BackTicks
+	// SingleQuote and DollarQuote only quote strings, e.g. 'text' and $tag$text$tag$.
+	SingleQuote
+	DollarQuote
 )
 
 type Pos int
@@ -63,7 +71,11 @@ type Token struct {
 	Kind      TokenKind
 	String    string
 	Base      int // 10 or 16 on TokenKindInt
-	QuoteType int
+	QuoteType int // the quotes of identifiers and strings
+
+	// Line and Column are the 1-based position of Pos in lines and characters, they are only set by Tokenize
+	Line   int
+	Column int
 }
 
 func (t *Token) ToString() string {
@@ -76,12 +88,21 @@ func (t *Token) ToString() string {
 type lexerState struct {
 	current   int
 	lastToken *Token
+	// executable is set inside a MySQL executable comment, its closing */ is skipped
+	executable bool
 }
 
 type Lexer struct {
 	lexerState
 
-	input string
+	input   string
+	dialect Dialect
+	// mysqlVersion limits the versioned executable comments to run, 0 runs them all
+	mysqlVersion int
+	// printed lexes the output of String, where backticks and double quotes always quote identifiers
+	printed bool
+	// err keeps the last lexing error, the parser reports it when the statement fails
+	err error
 }
 
 func NewLexer(buf string) *Lexer {
@@ -112,8 +133,21 @@ func (l *Lexer) peekOk(n int) bool {
 	return l.current+n < len(l.input)
 }
 
+// peekRune decodes the UTF-8 rune at offset n, its size is 0 at the end of the input.
+func (l *Lexer) peekRune(n int) (rune, int) {
+	return utf8.DecodeRuneInString(l.input[l.current+n:])
+}
+
+// identPartSize returns the size of the rune at offset n if it can be part of an identifier, or 0.
+func (l *Lexer) identPartSize(n int) int {
+	if r, size := l.peekRune(n); size > 0 && IsIdentPartRune(r) {
+		return size
+	}
+	return 0
+}
+
 func (l *Lexer) isKeyword(ident string) bool {
-	return keywords.Contains(ident)
+	return l.dialect.isKeyword(ident)
 }
 
 func (l *Lexer) consumeNumber() error {
@@ -161,7 +195,7 @@ func (l *Lexer) consumeNumber() error {
 		}
 		break
 	}
-	if (l.peekOk(i) && IsIdentPart(l.peekN(i))) || !hasNumberPart {
+	if l.identPartSize(i) > 0 || !hasNumberPart {
 		return errors.New("invalid number")
 	}
 	l.lastToken = &Token{
@@ -192,20 +226,35 @@ func (l *Lexer) consumeIdent(_ Pos) error {
 		if l.peekOk(i) && l.peekN(i) == '$' {
 			i++
 		}
-		for l.peekOk(i) && IsIdentPart(l.peekN(i)) {
-			i++
+		for size := l.identPartSize(i); size > 0; size = l.identPartSize(i) {
+			i += size
 		}
-	} else {
-		for l.peekOk(i) && (quoteType == BackTicks && l.peekN(i) != '`' ||
-			quoteType == DoubleQuote && l.peekN(i) != '"') {
-			i++
-		}
-		if !l.peekOk(i) || (quoteType == BackTicks && l.peekN(i) != '`') ||
-			(quoteType == DoubleQuote && l.peekN(i) != '"') {
-			return fmt.Errorf("unclosed quoted identifier: %s", l.slice(0, i))
+	}
+	quote := byte('`')
+	if quoteType == DoubleQuote {
+		quote = '"'
+	}
+	escaped := false
+	if quoteType != Unquoted {
+		for {
+			for l.peekOk(i) && l.peekN(i) != quote {
+				i++
+			}
+			if !l.peekOk(i) {
+				return fmt.Errorf("unclosed quoted identifier: %s", l.slice(0, i))
+			}
+			// a doubled quote is an escaped quote
+			if !l.peekOk(i+1) || l.peekN(i+1) != quote {
+				break
+			}
+			escaped = true
+			i += 2
 		}
 	}
 	slice := l.slice(0, i)
+	if escaped {
+		slice = strings.ReplaceAll(slice, string([]byte{quote, quote}), string(quote))
+	}
 	if quoteType == Unquoted && l.isKeyword(strings.ToUpper(slice)) {
 		token.Kind = TokenKindKeyword
 	} else {
@@ -233,38 +282,148 @@ func (l *Lexer) consumeSingleLineComment() {
 	l.skipN(i + 1)
 }
 
-func (l *Lexer) consumeMultiLineComment() {
+// consumeMultiLineComment consumes a /* comment, it returns false if the input ends before */.
+func (l *Lexer) consumeMultiLineComment() bool {
 	l.skipN(2)
 	i := 0
-	for !l.isEOF() {
+	for l.peekOk(i) {
 		if l.peekOk(i+1) && l.peekN(i) == '*' && l.peekN(i+1) == '/' {
-			i += 2
-			break
+			l.skipN(i + 2)
+			return true
 		}
 		i++
 	}
 	l.skipN(i)
+	return false
+}
+
+// consumeTrivia consumes the whitespace or the comment at the current position,
+// it returns nil if the next token is neither. An unterminated comment is returned with an error.
+func (l *Lexer) consumeTrivia() (*Token, error) {
+	start := l.current
+	kind := TokenKindComment
+	var err error
+	switch {
+	case l.isEOF():
+		return nil, nil // nolint
+	case l.peekOk(1) && l.peekN(0) == '-' && l.peekN(1) == '-',
+		l.peekN(0) == '#' && l.dialect == DialectMySQL:
+		// unlike consumeSingleLineComment, the line break is left to the whitespace
+		l.skipN(1)
+		for !l.isEOF() && l.peekN(0) != '\r' && l.peekN(0) != '\n' {
+			l.skipN(1)
+		}
+	case l.peekOk(1) && l.peekN(0) == '/' && l.peekN(1) == '*':
+		// the markers of an executable comment are comments, the text inside is SQL
+		if n, ok := l.executableComment(); ok {
+			l.executable = true
+			l.skipN(n)
+		} else if !l.consumeMultiLineComment() {
+			err = errors.New("unterminated comment")
+		}
+	case l.executable && l.peekOk(1) && l.peekN(0) == '*' && l.peekN(1) == '/':
+		l.executable = false
+		l.skipN(2)
+	default:
+		kind = TokenKindWhitespace
+		l.skipSpace()
+		if l.current == start {
+			return nil, nil // nolint
+		}
+	}
+	return &Token{
+		Pos:    Pos(start),
+		End:    Pos(l.current),
+		Kind:   kind,
+		String: l.input[start:l.current],
+	}, err
 }
 
 func (l *Lexer) consumeString() error {
 	i := 1
-	endChar := byte('\'')
-	for l.peekOk(i) && l.peekN(i) != endChar {
+	endChar := l.peekN(0)
+	for l.peekOk(i) {
+		// ClickHouse and MySQL escape characters with a backslash, PostgreSQL strings keep it
+		if l.peekN(i) == '\\' && l.dialect != DialectPostgreSQL {
+			i += 2
+			continue
+		}
+		if l.peekN(i) == endChar {
+			// a doubled quote is an escaped quote
+			if !l.peekOk(i+1) || l.peekN(i+1) != endChar {
+				break
+			}
+			i++
+		}
 		i++
 	}
 	if !l.peekOk(i) {
 		return errors.New("invalid string")
 	}
+	literal := l.slice(1, i)
+	quoteType := SingleQuote
+	if endChar == '"' {
+		// a MySQL "..." string is kept as its '...' equivalent, which is how it prints
+		literal = strings.ReplaceAll(strings.ReplaceAll(literal, `""`, `"`), "'", "''")
+		quoteType = DoubleQuote
+	}
 	l.lastToken = &Token{
-		Kind:   TokenKindString,
-		String: l.slice(1, i),
-		Pos:    Pos(l.current + 1),
-		End:    Pos(l.current + i),
+		Kind:      TokenKindString,
+		String:    literal,
+		Pos:       Pos(l.current + 1),
+		End:       Pos(l.current + i),
+		QuoteType: quoteType,
 	}
 	l.skipN(i + 1)
 	return nil
 }
 
+// consumePositionalParam consumes the PostgreSQL positional parameter, e.g. $1
+func (l *Lexer) consumePositionalParam() error {
+	i := 1
+	for l.peekOk(i) && IsDigit(l.peekN(i)) {
+		i++
+	}
+	if size := l.identPartSize(i); size > 0 {
+		return fmt.Errorf("invalid positional parameter: %s", l.slice(0, i+size))
+	}
+	l.lastToken = &Token{
+		Kind:   TokenKindParam,
+		String: l.slice(0, i),
+		Pos:    Pos(l.current),
+		End:    Pos(l.current + i),
+	}
+	l.skipN(i)
+	return nil
+}
+
+// tryConsumeDollarString consumes the PostgreSQL dollar-quoted string, e.g. $$text$$ or $tag$text$tag$.
+// The body is stored as a standard string literal with its single quotes doubled.
+func (l *Lexer) tryConsumeDollarString() (bool, error) {
+	i := 1
+	for size := l.identPartSize(i); size > 0 && l.peekN(i) != '$'; size = l.identPartSize(i) {
+		i += size
+	}
+	if !l.peekOk(i) || l.peekN(i) != '$' {
+		return false, nil
+	}
+	tag := l.slice(0, i+1)
+	end := strings.Index(l.input[l.current+len(tag):], tag)
+	if end < 0 {
+		return true, fmt.Errorf("unclosed dollar-quoted string: %s", tag)
+	}
+	bodyStart := l.current + len(tag)
+	l.lastToken = &Token{
+		Kind:      TokenKindString,
+		String:    strings.ReplaceAll(l.input[bodyStart:bodyStart+end], "'", "''"),
+		Pos:       Pos(bodyStart),
+		End:       Pos(bodyStart + end),
+		QuoteType: DollarQuote,
+	}
+	l.skipN(len(tag) + end + len(tag))
+	return true, nil
+}
+
 func (l *Lexer) skipComments() {
 	for !l.isEOF() {
 		l.skipSpace()
@@ -280,7 +439,25 @@ func (l *Lexer) skipComments() {
 			return
 		case '/': // multi-line comment
 			if l.peekOk(1) && l.peekN(1) == '*' {
-				l.consumeMultiLineComment()
+				if n, ok := l.executableComment(); ok {
+					l.executable = true
+					l.skipN(n)
+				} else {
+					l.consumeMultiLineComment()
+				}
+				continue
+			}
+			return
+		case '*': // end of an executable comment
+			if l.executable && l.peekOk(1) && l.peekN(1) == '/' {
+				l.executable = false
+				l.skipN(2)
+				continue
+			}
+			return
+		case '#':
+			if l.dialect == DialectMySQL {
+				l.consumeSingleLineComment()
 				continue
 			}
 			return
@@ -293,6 +470,24 @@ func (l *Lexer) skipComments() {
 	}
 }
 
+// executableComment reports whether the comment at the current position is a MySQL
+// executable comment /*!NNNNN ... */ to run as SQL, and the length of its opening marker.
+func (l *Lexer) executableComment() (int, bool) {
+	if l.dialect != DialectMySQL || l.executable || !l.peekOk(2) || l.peekN(2) != '!' {
+		return 0, false
+	}
+	i := 3
+	for l.peekOk(i) && IsDigit(l.peekN(i)) {
+		i++
+	}
+	if version := l.slice(3, i); version != "" && l.mysqlVersion != 0 {
+		if n, err := strconv.Atoi(version); err != nil || n > l.mysqlVersion {
+			return 0, false
+		}
+	}
+	return i, true
+}
+
 func (l *Lexer) peekToken() (*Token, error) {
 	savedState := l.saveState()
 	if err := l.consumeToken(); err != nil {
@@ -309,10 +504,16 @@ func (l *Lexer) hasPrecedenceToken(last *Token) bool {
 		last.Kind == TokenKindKeyword ||
 		last.Kind == TokenKindInt ||
 		last.Kind == TokenKindFloat ||
-		last.Kind == TokenKindString)
+		last.Kind == TokenKindString ||
+		last.Kind == TokenKindParam)
 }
 
-func (l *Lexer) consumeToken() error {
+func (l *Lexer) consumeToken() (err error) {
+	defer func() {
+		if err != nil {
+			l.err = err
+		}
+	}()
 	// clear last token
 	lastToken := l.lastToken
 	l.lastToken = nil
@@ -353,7 +554,27 @@ func (l *Lexer) consumeToken() error {
 		}
 	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 		return l.consumeNumber()
-	case '`', '$', '"':
+	case '`':
+		if l.dialect == DialectPostgreSQL && !l.printed {
+			return errors.New("backtick quoted identifiers are not supported in PostgreSQL")
+		}
+		return l.consumeIdent(Pos(l.current))
+	case '"':
+		// MySQL treats double quoted text as a string literal
+		if l.dialect == DialectMySQL && !l.printed {
+			return l.consumeString()
+		}
+		return l.consumeIdent(Pos(l.current))
+	case '$':
+		// ClickHouse has no positional parameters, they are lexed to be rejected by the parser
+		if (l.dialect == DialectPostgreSQL || l.dialect == DialectClickHouse) && l.peekOk(1) && IsDigit(l.peekN(1)) {
+			return l.consumePositionalParam()
+		}
+		if l.dialect == DialectPostgreSQL {
+			if ok, err := l.tryConsumeDollarString(); ok || err != nil {
+				return err
+			}
+		}
 		return l.consumeIdent(Pos(l.current))
 	case '\'':
 		return l.consumeString()
@@ -379,16 +600,18 @@ func (l *Lexer) consumeToken() error {
 		return nil
 	}
 
-	if IsIdentStart(l.peekN(0)) {
+	r, size := l.peekRune(0)
+	if IsIdentStartRune(r) {
 		return l.consumeIdent(Pos(l.current))
 	}
 
+	// any other rune is a token of its own, e.g. a full-width comma the parser rejects
 	token := &Token{}
 	token.Pos = Pos(l.current)
-	token.End = Pos(l.current + 1)
-	token.String = l.input[l.current : l.current+1]
+	token.End = Pos(l.current + size)
+	token.String = l.slice(0, size)
 	token.Kind = TokenKind(token.String)
-	l.skipN(1)
+	l.skipN(size)
 	l.lastToken = token
 	return nil
 }
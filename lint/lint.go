@@ -0,0 +1,235 @@
+// Package lint reports SQL which is valid but usually a mistake or slow, such as a DELETE without
+// WHERE or a LIKE pattern starting with a wildcard.
+//
+// A Rule is a function called for every node of the parsed statements, which are walked with the
+// parser's visitor. The built-in rules are returned by DefaultRules, they can be disabled or
+// given another severity by Config and more rules can be added. A `-- lint:ignore` comment
+// suppresses the diagnostics of the line it ends, or of the next line when it is on a line of its
+// own, and `-- lint:ignore rule, ...` only those of the listed rules.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	parser "github.com/carmel/go-sql-parser"
+)
+
+// Severity is how serious a diagnostic is.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Fix is a replacement of the source text between Pos and End which solves a diagnostic.
+type Fix struct {
+	Pos  parser.Pos
+	End  parser.Pos
+	Text string
+}
+
+// Diagnostic is a problem a rule found, Line and Column are the 1-based location of Pos.
+type Diagnostic struct {
+	Rule     string
+	Severity Severity
+	Pos      parser.Pos
+	End      parser.Pos
+	Line     int
+	Column   int
+	Message  string
+	// Fix is nil if the problem can not be solved automatically.
+	Fix *Fix
+}
+
+func (d *Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s (%s)", d.Line, d.Column, d.Severity, d.Message, d.Rule)
+}
+
+// Rule checks the nodes of statements.
+type Rule struct {
+	// Name identifies the rule in Config and in lint:ignore comments.
+	Name string
+	// Severity is the severity of the diagnostics of the rule, unless Config overrides it.
+	Severity Severity
+	// Check is called for every node of every statement, children first, and reports the
+	// problems it finds with ctx.Report.
+	Check func(ctx *Context, node parser.Expr)
+}
+
+// Config selects and configures the rules of Lint.
+type Config struct {
+	Dialect parser.Dialect
+	// Rules are the rules to run, DefaultRules() if nil.
+	Rules []*Rule
+	// Disabled are the names of the rules not to run.
+	Disabled []string
+	// Severities overrides the severity of rules by name.
+	Severities map[string]Severity
+	// LargeTables are the tables on which FINAL is reported, every table if empty. A name
+	// without database matches the table in any database.
+	LargeTables []string
+}
+
+// Context is what a rule reports its problems with.
+type Context struct {
+	// Source is the SQL being linted.
+	Source string
+	// Config is the configuration of the run, it is never nil.
+	Config *Config
+	// Statement is the statement being checked.
+	Statement parser.Expr
+
+	rule        *Rule
+	severity    Severity
+	diagnostics []*Diagnostic
+}
+
+// Report adds a diagnostic about node, fix may be nil.
+func (c *Context) Report(node parser.Expr, message string, fix *Fix) {
+	c.diagnostics = append(c.diagnostics, &Diagnostic{
+		Rule:     c.rule.Name,
+		Severity: c.severity,
+		Pos:      node.Start(),
+		End:      node.End(),
+		Message:  message,
+		Fix:      fix,
+	})
+}
+
+// Lint parses sql and returns the diagnostics of the rules of config sorted by position,
+// config may be nil to run the built-in rules.
+func Lint(sql string, config *Config) ([]*Diagnostic, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	rules := config.Rules
+	if rules == nil {
+		rules = DefaultRules()
+	}
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		names[rule.Name] = true
+	}
+	disabled := make(map[string]bool, len(config.Disabled))
+	for _, name := range config.Disabled {
+		if !names[name] {
+			return nil, fmt.Errorf("unknown rule %s", name)
+		}
+		disabled[name] = true
+	}
+	for name := range config.Severities {
+		if !names[name] {
+			return nil, fmt.Errorf("unknown rule %s", name)
+		}
+	}
+
+	stmts, err := parser.NewParserWithOptions(sql, parser.Options{Dialect: config.Dialect}).Parse()
+	if err != nil {
+		return nil, err
+	}
+	ctx := &Context{Source: sql, Config: config}
+	for _, stmt := range stmts {
+		ctx.Statement = stmt
+		visitor := &parser.DefaultASTVisitor{
+			Visit: func(node parser.Expr) error {
+				for _, rule := range rules {
+					if disabled[rule.Name] {
+						continue
+					}
+					ctx.rule = rule
+					ctx.severity = rule.Severity
+					if severity, ok := config.Severities[rule.Name]; ok {
+						ctx.severity = severity
+					}
+					rule.Check(ctx, node)
+				}
+				return nil
+			},
+		}
+		if err := stmt.Accept(visitor); err != nil {
+			return nil, err
+		}
+	}
+
+	ignored, err := ignoredLines(sql, config.Dialect)
+	if err != nil {
+		return nil, err
+	}
+	diagnostics := ctx.diagnostics[:0]
+	for _, diagnostic := range ctx.diagnostics {
+		diagnostic.Line, diagnostic.Column = parser.Locate(sql, diagnostic.Pos)
+		if rules, ok := ignored[diagnostic.Line]; ok && (rules == nil || rules[diagnostic.Rule]) {
+			continue
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Pos < diagnostics[j].Pos
+	})
+	return diagnostics, nil
+}
+
+const ignoreDirective = "lint:ignore"
+
+// ignoredLines returns the lines of the lint:ignore comments of sql with the rules they ignore,
+// nil for all of them.
+func ignoredLines(sql string, dialect parser.Dialect) (map[int]map[string]bool, error) {
+	tokens, err := parser.Tokenize(sql, parser.TokenizeOptions{Dialect: dialect, Comments: true})
+	if err != nil {
+		return nil, err
+	}
+	ignored := make(map[int]map[string]bool)
+	// the line of the last token which is not a comment
+	codeLine := 0
+	for _, token := range tokens {
+		if token.Kind != parser.TokenKindComment {
+			codeLine = token.Line
+			continue
+		}
+		text := strings.TrimSpace(strings.TrimSuffix(strings.TrimLeft(token.String, "-#/*"), "*/"))
+		args, ok := strings.CutPrefix(text, ignoreDirective)
+		if !ok || args != "" && args[0] != ' ' && args[0] != '\t' {
+			continue
+		}
+		line := token.Line
+		if codeLine != token.Line {
+			line++
+		}
+		var rules map[string]bool
+		for _, name := range strings.FieldsFunc(args, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		}) {
+			if rules == nil {
+				rules = make(map[string]bool)
+			}
+			rules[name] = true
+		}
+		if previous, ok := ignored[line]; ok {
+			if previous == nil || rules == nil {
+				rules = nil
+			} else {
+				for name := range previous {
+					rules[name] = true
+				}
+			}
+		}
+		ignored[line] = rules
+	}
+	return ignored, nil
+}
@@ -0,0 +1,205 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	parser "github.com/carmel/go-sql-parser"
+)
+
+func messages(diagnostics []*Diagnostic) []string {
+	var result []string
+	for _, diagnostic := range diagnostics {
+		result = append(result, diagnostic.Rule+": "+diagnostic.Message)
+	}
+	return result
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected []string
+	}{
+		{"SELECT id, name FROM users WHERE id = 1", nil},
+		{"SELECT * FROM users LIMIT 10", []string{
+			"select-star: SELECT * returns whatever columns the table has, list them",
+		}},
+		{"SELECT u.* FROM users AS u LIMIT 10", []string{
+			"select-star: SELECT u.* returns whatever columns the table has, list them",
+		}},
+		{"SELECT count(*) FROM users", nil},
+		{"DELETE FROM users", []string{
+			"delete-without-where: statement without WHERE affects every row",
+		}},
+		{"DELETE FROM users WHERE true", []string{
+			"delete-without-where: statement with WHERE true affects every row",
+		}},
+		{"DELETE FROM users WHERE 1 = 1", []string{
+			"delete-without-where: statement with WHERE 1 = 1 affects every row",
+		}},
+		{"ALTER TABLE users UPDATE name = '' WHERE 1", []string{
+			"delete-without-where: statement with WHERE 1 affects every row",
+		}},
+		{"ALTER TABLE users DELETE WHERE id = 1", nil},
+		{"SELECT id FROM users ORDER BY id", []string{"order-by-without-limit: ORDER BY without LIMIT sorts every row"}},
+		{"SELECT id FROM users ORDER BY id LIMIT 5", nil},
+		{"SELECT id FROM (SELECT id FROM users ORDER BY id) LIMIT 10", nil},
+		{"SELECT id FROM users UNION ALL SELECT id FROM admins ORDER BY id", []string{
+			"order-by-without-limit: ORDER BY without LIMIT sorts every row",
+		}},
+		{"SELECT id FROM users UNION ALL SELECT id FROM admins ORDER BY id LIMIT 5", nil},
+		{"INSERT INTO ids SELECT id FROM users ORDER BY id", []string{
+			"order-by-without-limit: ORDER BY without LIMIT sorts every row",
+		}},
+		{"SELECT 1 FROM a, b AS bb CROSS JOIN c", []string{
+			"implicit-cross-join: implicit cross join of b AS bb, use an explicit JOIN",
+		}},
+		{"SELECT 1 FROM a JOIN b ON a.id = b.id", nil},
+		{"SELECT 1 FROM a JOIN b ON a.id = b.id, c", []string{
+			"implicit-cross-join: implicit cross join of c, use an explicit JOIN",
+		}},
+		{"SELECT 1 FROM a JOIN b ON a.id IN (1, 2) JOIN c USING (id)", nil},
+		{"SELECT id FROM users WHERE name LIKE '%son' OR name NOT ILIKE 'jo%'", []string{
+			"leading-wildcard: LIKE pattern starting with % can not use an index",
+		}},
+		{"SELECT id FROM users WHERE id NOT IN (SELECT user_id FROM bans)", []string{
+			"not-in-subquery: NOT IN (subquery) is never true if the subquery returns a NULL, use NOT EXISTS",
+		}},
+		{"SELECT id FROM users WHERE id NOT IN (1, 2)", nil},
+		{"CREATE TABLE t (id UInt64) ENGINE = MergeTree ORDER BY (id) COMMENT 'ids'", nil},
+		{"CREATE TABLE t (id UInt64) ENGINE = Log", []string{
+			"missing-primary-key: table t has no primary key",
+			"missing-table-comment: table t has no comment",
+		}},
+		{"SELECT id FROM events FINAL WHERE id = 1", []string{
+			"final: FINAL on events merges its parts at query time",
+		}},
+	}
+	for _, tt := range tests {
+		diagnostics, err := Lint(tt.sql, nil)
+		if err != nil {
+			t.Fatalf("Lint %q: %v", tt.sql, err)
+		}
+		if actual := messages(diagnostics); fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+			t.Errorf("Expected %q to report %q, got %q", tt.sql, tt.expected, actual)
+		}
+	}
+
+	sql := "CREATE TABLE t (id INT PRIMARY KEY, name VARCHAR, code VARCHAR(3)) COMMENT = 'codes'"
+	diagnostics, err := Lint(sql, &Config{Dialect: parser.DialectMySQL})
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	expected := "[varchar-without-length: VARCHAR column name has no length]"
+	if actual := fmt.Sprint(messages(diagnostics)); actual != expected {
+		t.Fatalf("Expected %s, got %s", expected, actual)
+	}
+	if fix := diagnostics[0].Fix; fix == nil || sql[:fix.Pos]+fix.Text+sql[fix.End:] !=
+		strings.Replace(sql, "name VARCHAR,", "name VARCHAR(255),", 1) {
+		t.Errorf("Expected a fix to VARCHAR(255), got %+v", fix)
+	}
+}
+
+func TestLintFixAndLocation(t *testing.T) {
+	sql := "SELECT a.id\nFROM a, b\nWHERE a.id = b.id"
+	diagnostics, err := Lint(sql, nil)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	if len(diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %v", diagnostics)
+	}
+	diagnostic := diagnostics[0]
+	expected := "2:9: warning: implicit cross join of b, use an explicit JOIN (implicit-cross-join)"
+	if diagnostic.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, diagnostic)
+	}
+	fix := diagnostic.Fix
+	if fix == nil {
+		t.Fatal("Expected a fix")
+	}
+	if fixed := sql[:fix.Pos] + fix.Text + sql[fix.End:]; fixed != "SELECT a.id\nFROM a CROSS JOIN b\nWHERE a.id = b.id" {
+		t.Errorf("Unexpected fixed SQL %q", fixed)
+	}
+
+	// lines end with \n, \r\n or \r as for the parser's tokens
+	for _, sql := range []string{"SELECT a.id\r\nFROM a, b", "SELECT a.id\rFROM a, b"} {
+		diagnostics, err := Lint(sql, nil)
+		if err != nil {
+			t.Fatalf("Lint: %v", err)
+		}
+		if len(diagnostics) != 1 || diagnostics[0].Line != 2 || diagnostics[0].Column != 9 {
+			t.Errorf("Expected a diagnostic at 2:9 in %q, got %v", sql, diagnostics)
+		}
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	sql := "SELECT * FROM events FINAL ORDER BY id; SELECT id FROM logs FINAL LIMIT 1"
+	config := &Config{
+		Disabled:    []string{"order-by-without-limit"},
+		Severities:  map[string]Severity{"select-star": SeverityError},
+		LargeTables: []string{"analytics.logs", "events"},
+	}
+	diagnostics, err := Lint(sql, config)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	var actual []string
+	for _, diagnostic := range diagnostics {
+		actual = append(actual, diagnostic.Severity.String()+" "+diagnostic.Rule)
+	}
+	expected := "[error select-star warning final]"
+	if fmt.Sprint(actual) != expected {
+		t.Errorf("Expected %s, got %v", expected, actual)
+	}
+
+	if _, err := Lint(sql, &Config{Disabled: []string{"select-stars"}}); err == nil {
+		t.Error("Expected an error for an unknown rule")
+	}
+
+	custom := &Rule{
+		Name:     "no-truncate",
+		Severity: SeverityError,
+		Check: func(ctx *Context, node parser.Expr) {
+			if _, ok := node.(*parser.TruncateTable); ok {
+				ctx.Report(node, "TRUNCATE is not allowed", nil)
+			}
+		},
+	}
+	diagnostics, err = Lint("TRUNCATE TABLE events", &Config{Rules: append(DefaultRules(), custom)})
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	if actual := fmt.Sprint(messages(diagnostics)); actual != "[no-truncate: TRUNCATE is not allowed]" {
+		t.Errorf("Unexpected diagnostics %s", actual)
+	}
+}
+
+func TestLintIgnore(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected []string
+	}{
+		{"DELETE FROM users WHERE 1 -- lint:ignore", nil},
+		{"-- lint:ignore\nDELETE FROM users WHERE 1;\nDELETE FROM logs WHERE 1", []string{"delete-without-where"}},
+		{"SELECT * FROM users ORDER BY id -- lint:ignore select-star", []string{"order-by-without-limit"}},
+		{"SELECT * FROM users ORDER BY id -- lint:ignore select-star, order-by-without-limit", nil},
+		{"/* lint:ignore order-by-without-limit */\nSELECT id FROM users ORDER BY id", nil},
+		{"SELECT * FROM users LIMIT 1 -- lint:ignored", []string{"select-star"}},
+	}
+	for _, tt := range tests {
+		diagnostics, err := Lint(tt.sql, nil)
+		if err != nil {
+			t.Fatalf("Lint %q: %v", tt.sql, err)
+		}
+		var actual []string
+		for _, diagnostic := range diagnostics {
+			actual = append(actual, diagnostic.Rule)
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+			t.Errorf("Expected %q to report %v, got %v", tt.sql, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package lint
+
+import (
+	"slices"
+	"strings"
+
+	parser "github.com/carmel/go-sql-parser"
+)
+
+// DefaultRules returns the built-in rules:
+//   - select-star: SELECT * or t.*, whose columns change with the table;
+//   - delete-without-where: a DELETE or ALTER TABLE UPDATE/DELETE of every row;
+//   - order-by-without-limit: a statement's SELECT sorting all of its rows;
+//   - implicit-cross-join: tables joined with a comma, fixed by CROSS JOIN;
+//   - leading-wildcard: a LIKE pattern starting with %, which can not use an index;
+//   - not-in-subquery: NOT IN (SELECT ...), which is never true if the subquery returns a NULL;
+//   - missing-primary-key and missing-table-comment: a CREATE TABLE without them;
+//   - varchar-without-length: a VARCHAR column without length, fixed by VARCHAR(255);
+//   - final: FINAL on the Config.LargeTables, which merges their parts at query time.
+func DefaultRules() []*Rule {
+	return []*Rule{
+		{Name: "select-star", Severity: SeverityWarning, Check: checkSelectStar},
+		{Name: "delete-without-where", Severity: SeverityError, Check: checkDeleteWithoutWhere},
+		{Name: "order-by-without-limit", Severity: SeverityInfo, Check: checkOrderByWithoutLimit},
+		{Name: "implicit-cross-join", Severity: SeverityWarning, Check: checkImplicitCrossJoin},
+		{Name: "leading-wildcard", Severity: SeverityWarning, Check: checkLeadingWildcard},
+		{Name: "not-in-subquery", Severity: SeverityWarning, Check: checkNotInSubquery},
+		{Name: "missing-primary-key", Severity: SeverityWarning, Check: checkMissingPrimaryKey},
+		{Name: "missing-table-comment", Severity: SeverityInfo, Check: checkMissingTableComment},
+		{Name: "varchar-without-length", Severity: SeverityWarning, Check: checkVarcharWithoutLength},
+		{Name: "final", Severity: SeverityWarning, Check: checkFinal},
+	}
+}
+
+func checkSelectStar(ctx *Context, node parser.Expr) {
+	item, ok := node.(*parser.SelectItem)
+	if !ok {
+		return
+	}
+	switch expr := item.Expr.(type) {
+	case *parser.Ident:
+		if expr.Name != "*" {
+			return
+		}
+	case *parser.NestedIdentifier:
+		if expr.DotIdent == nil || expr.DotIdent.Name != "*" {
+			return
+		}
+	case *parser.ColumnIdentifier:
+		if expr.Column.Name != "*" {
+			return
+		}
+	default:
+		return
+	}
+	ctx.Report(item, "SELECT "+item.Expr.String()+" returns whatever columns the table has, list them", nil)
+}
+
+func checkDeleteWithoutWhere(ctx *Context, node parser.Expr) {
+	var where parser.Expr
+	switch node := node.(type) {
+	case *parser.DeleteClause:
+		where = node.WhereExpr
+	case *parser.AlterTableDelete:
+		if node.Where != nil {
+			where = node.Where.Expr
+		}
+	case *parser.AlterTableUpdate:
+		if node.Where != nil {
+			where = node.Where.Expr
+		}
+	default:
+		return
+	}
+	switch {
+	case where == nil:
+		ctx.Report(node, "statement without WHERE affects every row", nil)
+	case alwaysTrue(where):
+		ctx.Report(node, "statement with WHERE "+where.String()+" affects every row", nil)
+	}
+}
+
+// alwaysTrue reports whether a condition is TRUE, a non-zero number or a comparison of
+// a literal with itself.
+func alwaysTrue(expr parser.Expr) bool {
+	switch expr := expr.(type) {
+	case *parser.ColumnExpr:
+		return alwaysTrue(expr.Expr)
+	case *parser.NumberLiteral:
+		return strings.Trim(expr.Literal, "0.") != ""
+	case *parser.Ident:
+		return expr.QuoteType == parser.Unquoted && strings.EqualFold(expr.Name, parser.KeywordTrue)
+	case *parser.BinaryOperation:
+		if expr.Operation != "=" && expr.Operation != "==" {
+			return false
+		}
+		switch expr.LeftExpr.(type) {
+		case *parser.NumberLiteral, *parser.StringLiteral:
+			return expr.LeftExpr.String() == expr.RightExpr.String()
+		}
+	}
+	return false
+}
+
+// checkOrderByWithoutLimit only checks the top-level queries of the statement, the rows of a
+// subquery are limited or aggregated by the query using them.
+func checkOrderByWithoutLimit(ctx *Context, node parser.Expr) {
+	query, ok := node.(*parser.SelectQuery)
+	if !ok || query.OrderBy == nil || !slices.Contains(topLevelQueries(ctx.Statement), query) {
+		return
+	}
+	if query.Limit != nil || query.Fetch != nil || query.Top != nil {
+		return
+	}
+	ctx.Report(query.OrderBy, "ORDER BY without LIMIT sorts every row", nil)
+}
+
+// topLevelQueries returns the queries whose rows a statement returns or inserts: its SELECT, the
+// SELECT of an INSERT and every arm of their UNION, INTERSECT or EXCEPT.
+func topLevelQueries(expr parser.Expr) []*parser.SelectQuery {
+	switch expr := expr.(type) {
+	case *parser.InsertStmt:
+		if expr.SelectExpr != nil {
+			return topLevelQueries(expr.SelectExpr)
+		}
+	case *parser.SelectQuery:
+		queries := []*parser.SelectQuery{expr}
+		if expr.SetOperation != nil {
+			queries = append(queries, topLevelQueries(expr.SetOperation)...)
+		}
+		return queries
+	case *parser.SetOperation:
+		return append(topLevelQueries(expr.Left), topLevelQueries(expr.Right)...)
+	}
+	return nil
+}
+
+// checkImplicitCrossJoin reports the tables joined with a comma, the right table of a JoinExpr is
+// joined by the modifiers of its JoinExpr, a comma has none. Every JoinExpr of the chain is
+// visited, so a comma after an ON or USING constraint is found in the JoinExpr it follows.
+func checkImplicitCrossJoin(ctx *Context, node parser.Expr) {
+	join, ok := node.(*parser.JoinExpr)
+	if !ok || join.Right == nil {
+		return
+	}
+	right := join.Right
+	if next, ok := right.(*parser.JoinExpr); ok {
+		if len(next.Modifiers) > 0 {
+			return
+		}
+		right = next.Left
+	}
+	var fix *Fix
+	end, start := int(join.Left.End()), int(right.Start())
+	if join.Constraints != nil {
+		end = int(join.Constraints.End())
+	}
+	if end <= start && start <= len(ctx.Source) {
+		if comma := strings.IndexByte(ctx.Source[end:start], ','); comma >= 0 {
+			pos := parser.Pos(end + comma)
+			fix = &Fix{Pos: pos, End: pos + 1, Text: " CROSS JOIN"}
+		}
+	}
+	ctx.Report(right, "implicit cross join of "+right.String()+", use an explicit JOIN", fix)
+}
+
+func checkLeadingWildcard(ctx *Context, node parser.Expr) {
+	operation, ok := node.(*parser.BinaryOperation)
+	if !ok {
+		return
+	}
+	switch strings.ToUpper(string(operation.Operation)) {
+	case parser.KeywordLike, parser.KeywordIlike, "NOT " + parser.KeywordLike, "NOT " + parser.KeywordIlike:
+	default:
+		return
+	}
+	pattern := operation.RightExpr
+	if columnExpr, ok := pattern.(*parser.ColumnExpr); ok {
+		pattern = columnExpr.Expr
+	}
+	if literal, ok := pattern.(*parser.StringLiteral); ok && strings.HasPrefix(literal.Literal, "%") {
+		ctx.Report(operation, "LIKE pattern starting with % can not use an index", nil)
+	}
+}
+
+func checkNotInSubquery(ctx *Context, node parser.Expr) {
+	operation, ok := node.(*parser.BinaryOperation)
+	if !ok || strings.ToUpper(string(operation.Operation)) != "NOT "+parser.KeywordIn {
+		return
+	}
+	if _, ok := operation.RightExpr.(*parser.SubQuery); ok {
+		ctx.Report(operation, "NOT IN (subquery) is never true if the subquery returns a NULL, use NOT EXISTS", nil)
+	}
+}
+
+// createTableColumns returns the columns of a CREATE TABLE, nil if they are copied from
+// a query or another table.
+func createTableColumns(node parser.Expr) (*parser.CreateTable, []parser.Expr) {
+	table, ok := node.(*parser.CreateTable)
+	if !ok || table.TableSchema == nil || len(table.TableSchema.Columns) == 0 {
+		return nil, nil
+	}
+	return table, table.TableSchema.Columns
+}
+
+// checkMissingPrimaryKey accepts a PRIMARY KEY column or table constraint, and the ORDER BY or
+// PRIMARY KEY of a ClickHouse engine.
+func checkMissingPrimaryKey(ctx *Context, node parser.Expr) {
+	table, columns := createTableColumns(node)
+	if table == nil {
+		return
+	}
+	for _, column := range columns {
+		switch column := column.(type) {
+		case *parser.ColumnDef:
+			if column.PrimaryKey {
+				return
+			}
+		case *parser.Key:
+			if strings.HasPrefix(column.Name, "PRIMARY KEY") {
+				return
+			}
+		}
+	}
+	for _, option := range table.TableOptions {
+		switch strings.ToUpper(option.Name.Name) {
+		case parser.KeywordOrder, parser.KeywordPrimary:
+			return
+		}
+		if value, ok := option.Value.(*parser.Ident); ok && strings.EqualFold(value.Name, parser.KeywordPrimary) {
+			return
+		}
+	}
+	ctx.Report(table.Identifier, "table "+table.Identifier.String()+" has no primary key", nil)
+}
+
+func checkMissingTableComment(ctx *Context, node parser.Expr) {
+	table, _ := createTableColumns(node)
+	if table == nil {
+		return
+	}
+	for _, option := range table.TableOptions {
+		if strings.EqualFold(option.Name.Name, parser.KeywordComment) {
+			return
+		}
+	}
+	ctx.Report(table.Identifier, "table "+table.Identifier.String()+" has no comment", nil)
+}
+
+func checkVarcharWithoutLength(ctx *Context, node parser.Expr) {
+	column, ok := node.(*parser.ColumnDef)
+	if !ok {
+		return
+	}
+	scalar, ok := column.Type.(*parser.ScalarType)
+	if !ok || !strings.EqualFold(scalar.Name.Name, "VARCHAR") {
+		return
+	}
+	ctx.Report(scalar, "VARCHAR column "+column.Name.String()+" has no length",
+		&Fix{Pos: scalar.Start(), End: scalar.End(), Text: scalar.Name.Name + "(255)"})
+}
+
+func checkFinal(ctx *Context, node parser.Expr) {
+	var table *parser.TableExpr
+	switch node := node.(type) {
+	case *parser.TableExpr:
+		if !node.HasFinal {
+			return
+		}
+		table = node
+	case *parser.JoinTableExpr:
+		if !node.HasFinal || node.Table == nil || node.Table.HasFinal {
+			return
+		}
+		table = node.Table
+	default:
+		return
+	}
+	expr := table.Expr
+	if alias, ok := expr.(*parser.AliasExpr); ok {
+		expr = alias.Expr
+	}
+	identifier, ok := expr.(*parser.TableIdentifier)
+	if !ok || !isLargeTable(ctx.Config, identifier) {
+		return
+	}
+	ctx.Report(table, "FINAL on "+identifier.String()+" merges its parts at query time", nil)
+}
+
+func isLargeTable(config *Config, table *parser.TableIdentifier) bool {
+	if len(config.LargeTables) == 0 {
+		return true
+	}
+	for _, name := range config.LargeTables {
+		database, tableName, qualified := strings.Cut(name, ".")
+		if !qualified {
+			database, tableName = "", name
+		}
+		if tableName != table.Table.Name {
+			continue
+		}
+		if database == "" || table.Schema != nil && table.Schema.Name == database {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParameterKind is the syntax of a statement parameter, see Parameters.
+type ParameterKind string
+
+const (
+	// ParameterPositional is a `?` placeholder.
+	ParameterPositional ParameterKind = "positional"
+	// ParameterNumbered is a `$1` placeholder.
+	ParameterNumbered ParameterKind = "numbered"
+	// ParameterNamed is a `:name` placeholder.
+	ParameterNamed ParameterKind = "named"
+	// ParameterVariable is a `@name` user variable, drivers such as clickhouse-go bind them.
+	ParameterVariable ParameterKind = "variable"
+	// ParameterQuery is a ClickHouse `{name: Type}` query parameter.
+	ParameterQuery ParameterKind = "query"
+)
+
+// Parameter is a value bound to a statement when it is run.
+type Parameter struct {
+	Kind ParameterKind
+	// Expr is the *PlaceHolder, *NamedParam, *VariableExpr or *QueryParam node.
+	Expr Expr
+	// Name is set on named, variable and query parameters.
+	Name string
+	// Index is the number of a numbered parameter, or the 1-based ordinal of a positional one.
+	Index int
+	// Type is the declared type of a query parameter, or the type inferred from the context,
+	// e.g. the column the parameter is compared with. It is nil if unknown.
+	Type ColumnType
+}
+
+func (p *Parameter) Start() Pos {
+	return p.Expr.Start()
+}
+
+func (p *Parameter) End() Pos {
+	return p.Expr.End()
+}
+
+// Parameters returns the parameters of stmt in the order they appear. Column types are
+// looked up in schema, which may be nil.
+func Parameters(stmt Expr, schema *Schema) []*Parameter {
+	var parameters []*Parameter
+	var contexts []Expr
+	// the variables assigned by SET @a = ... or @a := ... are not read
+	targets := make(map[Expr]bool)
+	visitor := &DefaultASTVisitor{
+		Visit: func(expr Expr) error {
+			switch expr := expr.(type) {
+			case *PlaceHolder:
+				parameter := &Parameter{Kind: ParameterPositional, Expr: expr}
+				if number, ok := strings.CutPrefix(expr.Type, "$"); ok {
+					parameter.Kind = ParameterNumbered
+					parameter.Index, _ = strconv.Atoi(number)
+				}
+				parameters = append(parameters, parameter)
+			case *NamedParam:
+				parameters = append(parameters, &Parameter{Kind: ParameterNamed, Expr: expr, Name: expr.Name.Name})
+			case *VariableExpr:
+				if !expr.System {
+					parameters = append(parameters, &Parameter{Kind: ParameterVariable, Expr: expr, Name: expr.Name.Name})
+				}
+			case *QueryParam:
+				parameters = append(parameters, &Parameter{
+					Kind: ParameterQuery,
+					Expr: expr,
+					Name: expr.Name.Name,
+					Type: expr.Type,
+				})
+			case *VariableAssignment:
+				targets[expr.Name] = true
+			default:
+				contexts = append(contexts, expr)
+			}
+			return nil
+		},
+	}
+	_ = stmt.Accept(visitor)
+	if len(targets) > 0 {
+		read := parameters[:0]
+		for _, parameter := range parameters {
+			if !targets[parameter.Expr] {
+				read = append(read, parameter)
+			}
+		}
+		parameters = read
+	}
+
+	sort.SliceStable(parameters, func(i, j int) bool {
+		return parameters[i].Start() < parameters[j].Start()
+	})
+	positional := 0
+	for _, parameter := range parameters {
+		if parameter.Kind == ParameterPositional {
+			positional++
+			parameter.Index = positional
+		}
+	}
+
+	inference := &parameterInference{
+		schema:     schema,
+		tables:     schema.statementTables(stmt),
+		parameters: make(map[Expr]*Parameter, len(parameters)),
+	}
+	for _, parameter := range parameters {
+		inference.parameters[parameter.Expr] = parameter
+	}
+	for _, context := range contexts {
+		inference.infer(context)
+	}
+	return parameters
+}
+
+// parameterInference sets the type of parameters from the expressions around them.
+type parameterInference struct {
+	schema     *Schema
+	tables     *statementTables
+	parameters map[Expr]*Parameter
+}
+
+func (i *parameterInference) infer(expr Expr) {
+	switch expr := expr.(type) {
+	case *BinaryOperation:
+		switch strings.ToUpper(string(expr.Operation)) {
+		case "=", "==", "!=", "<>", "<", "<=", ">", ">=", "+", "-", "*", "/", "%",
+			"LIKE", "NOT LIKE", "ILIKE", "NOT ILIKE":
+			i.setType(expr.LeftExpr, i.typeOf(expr.RightExpr))
+			i.setType(expr.RightExpr, i.typeOf(expr.LeftExpr))
+		case "IN", "NOT IN", "GLOBAL IN":
+			elementType := i.typeOf(expr.LeftExpr)
+			if list, ok := expr.RightExpr.(*ParamExprList); ok && list.Items != nil {
+				for _, item := range list.Items.Items {
+					i.setType(item, elementType)
+				}
+			} else {
+				i.setType(expr.RightExpr, elementType)
+			}
+		case string(TokenKindDash):
+			if columnType, ok := expr.RightExpr.(ColumnType); ok {
+				i.setType(expr.LeftExpr, columnType)
+			}
+		}
+	case *BetweenClause:
+		i.setType(expr.Between, i.typeOf(expr.Expr))
+		i.setType(expr.And, i.typeOf(expr.Expr))
+	case *CastExpr:
+		if columnType, ok := expr.AsType.(ColumnType); ok {
+			i.setType(expr.Expr, columnType)
+		}
+	case *LimitClause:
+		i.setType(expr.Limit, scalarType("UInt64"))
+		i.setType(expr.Offset, scalarType("UInt64"))
+	case *InsertStmt:
+		table, ok := expr.Table.(*TableIdentifier)
+		if !ok {
+			return
+		}
+		definition := i.schema.Table(table)
+		columns := i.schema.Columns(definition)
+		if expr.ColumnNames != nil {
+			columns = columns[:0:0]
+			for _, name := range expr.ColumnNames.ColumnNames {
+				columns = append(columns, i.schema.Column(definition, name.String()))
+			}
+		}
+		for _, row := range expr.Values {
+			for j, value := range row.Values {
+				if j < len(columns) && columns[j] != nil {
+					i.setType(value, columns[j].Type)
+				}
+			}
+		}
+	}
+}
+
+// setType sets the type of expr if it is a parameter whose type is unknown.
+func (i *parameterInference) setType(expr Expr, columnType ColumnType) {
+	if columnExpr, ok := expr.(*ColumnExpr); ok {
+		expr = columnExpr.Expr
+	}
+	if parameter, ok := i.parameters[expr]; ok && parameter.Type == nil && columnType != nil {
+		parameter.Type = columnType
+	}
+}
+
+// typeOf returns the type of a column reference, a cast or a typed parameter, or nil.
+func (i *parameterInference) typeOf(expr Expr) ColumnType {
+	switch expr := expr.(type) {
+	case *ColumnExpr:
+		return i.typeOf(expr.Expr)
+	case *CastExpr:
+		columnType, _ := expr.AsType.(ColumnType)
+		return columnType
+	case *Ident, *ColumnIdentifier:
+		if column := i.schema.resolveColumn(i.tables, expr); column != nil {
+			return column.Type
+		}
+	default:
+		if parameter, ok := i.parameters[expr]; ok {
+			return parameter.Type
+		}
+	}
+	return nil
+}
+
+func scalarType(name string) *ScalarType {
+	return &ScalarType{Name: &Ident{Name: name, QuoteType: Unquoted}}
+}
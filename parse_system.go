@@ -341,6 +341,42 @@ func (p *Parser) parseCheckStmt(pos Pos) (*CheckStmt, error) {
 	}, nil
 }
 
+func (p *Parser) parseKillStmt(pos Pos) (*KillStmt, error) {
+	if err := p.expectKeyword(KeywordKill); err != nil {
+		return nil, err
+	}
+	var target string
+	switch {
+	case p.tryConsumeKeywords(KeywordQuery):
+		target = KeywordQuery
+	case p.tryConsumeKeywords(KeywordMutation):
+		target = KeywordMutation
+	default:
+		return nil, fmt.Errorf("expected keyword: QUERY|MUTATION, but got %q", p.lastTokenKind())
+	}
+	onCluster, err := p.tryParseClusterClause(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	where, err := p.parseWhereClause(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	kill := &KillStmt{
+		KillPos:      pos,
+		StatementEnd: where.End(),
+		Target:       target,
+		OnCluster:    onCluster,
+		Where:        where,
+	}
+	if p.matchOneOfKeywords(KeywordSync, KeywordAsync, KeywordTest) {
+		kill.Modifier = strings.ToUpper(p.last().String)
+		kill.StatementEnd = p.last().End
+		_ = p.lexer.consumeToken()
+	}
+	return kill, nil
+}
+
 func (p *Parser) parseRoleName(_ Pos) (*RoleName, error) {
 	switch {
 	case p.matchTokenKind(TokenKindIdent):
@@ -369,12 +405,21 @@ func (p *Parser) parseRoleName(_ Pos) (*RoleName, error) {
 		if err != nil {
 			return nil, err
 		}
+		// MySQL account names are written as 'user'@'host'
+		var scope *StringLiteral
+		if p.tryConsumeTokenKind(TokenKindAtSign) != nil {
+			scope, err = p.parseString(p.Start())
+			if err != nil {
+				return nil, err
+			}
+		}
 		onCluster, err := p.tryParseClusterClause(p.Start())
 		if err != nil {
 			return nil, err
 		}
 		return &RoleName{
 			Name:      name,
+			Scope:     scope,
 			OnCluster: onCluster,
 		}, nil
 	default:
@@ -391,13 +436,18 @@ func (p *Parser) tryParseRoleSettings(pos Pos) ([]*RoleSetting, error) {
 
 func (p *Parser) parseRoleSetting(_ Pos) (*RoleSetting, error) {
 	pairs := make([]*SettingPair, 0)
-	for p.matchTokenKind(TokenKindIdent) {
+	// TO starts the role list of CREATE SETTINGS PROFILE
+	for p.matchTokenKind(TokenKindIdent) && !p.matchKeyword(KeywordTo) {
 		name, err := p.parseIdent()
 		if err != nil {
 			return nil, err
 		}
 		switch name.Name {
-		case "NONE", "READABLE", "WRITABLE", "CONST", "CHANGEABLE_IN_READONLY":
+		case "NONE", "READABLE", "READONLY", "WRITABLE", "CONST", "CHANGEABLE_IN_READONLY":
+			// READONLY is also a setting name, e.g. `SETTINGS readonly = 1`
+			if p.matchTokenKind(TokenKindSingleEQ) {
+				break
+			}
 			return &RoleSetting{
 				Modifier:     name,
 				SettingPairs: pairs,
@@ -418,8 +468,8 @@ func (p *Parser) parseRoleSetting(_ Pos) (*RoleSetting, error) {
 			}
 			// docs: https://clickhouse.com/docs/en/sql-reference/statements/alter/role
 			// the operator "=" was required if the variable name is NOT in
-			// ["MIN", "MAX", "PROFILE"] and value is existed.
-			if value != nil && name.Name != "MIN" && name.Name != "MAX" && name.Name != "PROFILE" && op != TokenKindSingleEQ {
+			// ["MIN", "MAX", "PROFILE", "INHERIT"] and value is existed.
+			if value != nil && name.Name != "MIN" && name.Name != "MAX" && name.Name != "PROFILE" && name.Name != "INHERIT" && op != TokenKindSingleEQ {
 				return nil, fmt.Errorf("expected operator = or no value, but got %s", op)
 			}
 			pairs = append(pairs, &SettingPair{
@@ -539,6 +589,16 @@ func (p *Parser) parseAuthenticationClause(pos Pos) (*AuthenticationClause, erro
 	}
 	auth.AuthEnd = p.last().End
 
+	if p.tryConsumeKeywords(KeywordBy) {
+		value, err := p.parseString(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		auth.AuthValue = value
+		auth.AuthEnd = value.End()
+		return auth, nil
+	}
+
 	if p.tryConsumeKeywords(KeywordWith) {
 		if p.matchKeyword(KeywordLdap) {
 			_ = p.lexer.consumeToken()
@@ -624,12 +684,37 @@ func (p *Parser) parseDefaultRoleClause(pos Pos) (*DefaultRoleClause, error) {
 
 	defaultRole := &DefaultRoleClause{DefaultPos: pos}
 
-	if p.tryConsumeKeywords(KeywordNone) {
+	switch {
+	case p.matchKeyword(KeywordNone):
 		defaultRole.None = true
-		defaultRole.DefaultEnd = p.last().End
+		defaultRole.DefaultEnd = p.End()
+		_ = p.lexer.consumeToken()
 		return defaultRole, nil
+	case p.matchKeyword(KeywordAll):
+		defaultRole.All = true
+		defaultRole.DefaultEnd = p.End()
+		_ = p.lexer.consumeToken()
+	default:
+		roles, err := p.parseRoleNames()
+		if err != nil {
+			return nil, err
+		}
+		defaultRole.Roles = roles
+		defaultRole.DefaultEnd = roles[len(roles)-1].End()
+	}
+
+	if p.tryConsumeKeywords(KeywordExcept) {
+		except, err := p.parseRoleNames()
+		if err != nil {
+			return nil, err
+		}
+		defaultRole.Except = except
+		defaultRole.DefaultEnd = except[len(except)-1].End()
 	}
+	return defaultRole, nil
+}
 
+func (p *Parser) parseRoleNames() ([]*RoleName, error) {
 	roles := make([]*RoleName, 0)
 	role, err := p.parseRoleName(p.Start())
 	if err != nil {
@@ -644,10 +729,7 @@ func (p *Parser) parseDefaultRoleClause(pos Pos) (*DefaultRoleClause, error) {
 		}
 		roles = append(roles, role)
 	}
-
-	defaultRole.Roles = roles
-	defaultRole.DefaultEnd = roles[len(roles)-1].End()
-	return defaultRole, nil
+	return roles, nil
 }
 
 func (p *Parser) parseGranteesClause(pos Pos) (*GranteesClause, error) {
@@ -657,12 +739,14 @@ func (p *Parser) parseGranteesClause(pos Pos) (*GranteesClause, error) {
 
 	grantees := &GranteesClause{GranteesPos: pos}
 
-	if p.tryConsumeKeywords(KeywordAny) {
+	if p.matchKeyword(KeywordAny) {
 		grantees.Any = true
-		grantees.GranteesEnd = p.last().End
-	} else if p.tryConsumeKeywords(KeywordNone) {
+		grantees.GranteesEnd = p.End()
+		_ = p.lexer.consumeToken()
+	} else if p.matchKeyword(KeywordNone) {
 		grantees.None = true
-		grantees.GranteesEnd = p.last().End
+		grantees.GranteesEnd = p.End()
+		_ = p.lexer.consumeToken()
 	} else {
 		// Parse list of grantees
 		granteeList := make([]*RoleName, 0)
@@ -888,8 +972,24 @@ func (p *Parser) parserDropUserOrRole(pos Pos) (*DropUserOrRole, error) {
 	case p.matchOneOfKeywords(KeywordUser, KeywordRole):
 		target = p.last().String
 		_ = p.lexer.consumeToken()
+	case p.matchOneOfKeywords(KeywordRow, KeywordPolicy):
+		if err := p.expectRowPolicy(); err != nil {
+			return nil, err
+		}
+		target = "ROW POLICY"
+	case p.matchOneOfKeywords(KeywordSettings, KeywordProfile):
+		if err := p.expectSettingsProfile(); err != nil {
+			return nil, err
+		}
+		target = "SETTINGS PROFILE"
+	case p.matchKeyword(KeywordQuota):
+		_ = p.lexer.consumeToken()
+		if err := p.expectDialect("QUOTA", DialectClickHouse); err != nil {
+			return nil, err
+		}
+		target = KeywordQuota
 	default:
-		return nil, fmt.Errorf("expected USER|ROLE")
+		return nil, fmt.Errorf("expected USER|ROLE|ROW POLICY|SETTINGS PROFILE|QUOTA")
 	}
 
 	ifExists, err := p.tryParseIfExists()
@@ -912,6 +1012,15 @@ func (p *Parser) parserDropUserOrRole(pos Pos) (*DropUserOrRole, error) {
 	}
 	statementEnd := names[len(names)-1].End()
 
+	var tables []*TableIdentifier
+	if target == "ROW POLICY" {
+		tables, err = p.parseRowPolicyTables()
+		if err != nil {
+			return nil, err
+		}
+		statementEnd = tables[len(tables)-1].End()
+	}
+
 	onCluster, err := p.tryParseClusterClause(p.Start())
 	if err != nil {
 		return nil, err
@@ -939,6 +1048,7 @@ func (p *Parser) parserDropUserOrRole(pos Pos) (*DropUserOrRole, error) {
 		Target:       target,
 		IfExists:     ifExists,
 		Names:        names,
+		On:           tables,
 		From:         from,
 		Modifier:     modifier,
 	}, nil
@@ -966,8 +1076,12 @@ func (p *Parser) parsePrivilegeSelectOrInsert(pos Pos) (*PrivilegeClause, error)
 func (p *Parser) parsePrivilegeAlter(pos Pos) (*PrivilegeClause, error) {
 	keywords := []string{KeywordAlter}
 	switch {
-	case p.tryConsumeKeywords(KeywordIndex):
-		keywords = append(keywords, KeywordIndex)
+	case p.matchKeyword(KeywordOn), p.matchTokenKind(TokenKindComma):
+		// a bare ALTER covers every ALTER privilege
+	case p.matchOneOfKeywords(KeywordIndex, KeywordTable, KeywordColumn):
+		keyword := p.last().String
+		_ = p.lexer.consumeToken()
+		keywords = append(keywords, keyword)
 	case p.matchOneOfKeywords(KeywordUpdate, KeywordDelete, KeywordUser, KeywordRole, KeywordQuota):
 		keyword := p.last().String
 		_ = p.lexer.consumeToken()
@@ -1029,6 +1143,8 @@ func (p *Parser) parsePrivilegeAlter(pos Pos) (*PrivilegeClause, error) {
 func (p *Parser) parsePrivilegeCreate(pos Pos) (*PrivilegeClause, error) {
 	keywords := []string{KeywordCreate}
 	switch {
+	case p.matchKeyword(KeywordOn), p.matchTokenKind(TokenKindComma):
+		// a bare CREATE covers every CREATE privilege
 	case p.matchOneOfKeywords(KeywordDatabase, KeywordDictionary, KeywordTable, KeywordFunction, KeywordView, KeywordUser, KeywordRole, KeywordQuota):
 		keyword := p.last().String
 		_ = p.lexer.consumeToken()
@@ -1055,6 +1171,8 @@ func (p *Parser) parsePrivilegeCreate(pos Pos) (*PrivilegeClause, error) {
 func (p *Parser) parsePrivilegeDrop(pos Pos) (*PrivilegeClause, error) {
 	keywords := []string{KeywordDrop}
 	switch {
+	case p.matchKeyword(KeywordOn), p.matchTokenKind(TokenKindComma):
+		// a bare DROP covers every DROP privilege
 	case p.matchOneOfKeywords(KeywordDatabase, KeywordDictionary, KeywordUser, KeywordRole, KeywordQuota, KeywordTable, KeywordFunction, KeywordView):
 		keyword := p.last().String
 		_ = p.lexer.consumeToken()
@@ -1085,8 +1203,10 @@ func (p *Parser) parsePrivilegeShow(pos Pos) (*PrivilegeClause, error) {
 }
 
 func (p *Parser) parsePrivilegeSystem(pos Pos) (*PrivilegeClause, error) {
-	keywords := []string{KeywordShow}
+	keywords := []string{KeywordSystem}
 	switch {
+	case p.matchKeyword(KeywordOn), p.matchTokenKind(TokenKindComma):
+		// a bare SYSTEM covers every SYSTEM privilege
 	case p.matchOneOfKeywords(KeywordShutdown, KeywordMerges, KeywordFetches, KeywordSends, KeywordMoves, KeywordCluster):
 		keyword := p.last().String
 		_ = p.lexer.consumeToken()
@@ -1110,6 +1230,7 @@ func (p *Parser) parsePrivilegeSystem(pos Pos) (*PrivilegeClause, error) {
 	case p.tryConsumeKeywords(KeywordReload):
 		keywords = append(keywords, KeywordReload)
 		switch {
+		case p.matchKeyword(KeywordOn), p.matchTokenKind(TokenKindComma):
 		case p.matchOneOfKeywords(KeywordDictionary, KeywordFunction, KeywordFunctions, KeywordConfig):
 			keyword := p.last().String
 			_ = p.lexer.consumeToken()
@@ -1120,6 +1241,7 @@ func (p *Parser) parsePrivilegeSystem(pos Pos) (*PrivilegeClause, error) {
 	case p.tryConsumeKeywords(KeywordFlush):
 		keywords = append(keywords, KeywordFlush)
 		switch {
+		case p.matchKeyword(KeywordOn), p.matchTokenKind(TokenKindComma):
 		case p.matchOneOfKeywords(KeywordLogs, KeywordDistributed):
 			keyword := p.last().String
 			_ = p.lexer.consumeToken()
@@ -1216,25 +1338,27 @@ func (p *Parser) parsePrivilegeClause(pos Pos) (*PrivilegeClause, error) {
 			PrivilegePos: pos,
 			Keywords:     []string{KeywordRole, KeywordAdmin},
 		}, nil
+	case p.tryConsumeWords("ACCESS", "MANAGEMENT") != 0:
+		return &PrivilegeClause{
+			PrivilegePos: pos,
+			Keywords:     []string{"ACCESS", "MANAGEMENT"},
+		}, nil
 	}
-	return nil, fmt.Errorf("expected SELECT|INSERT|ALTER|CREATE|DROP|SHOW|KILL|SYSTEM|OPTIMIZE|TRUNCATE")
-}
-
-func (p *Parser) parsePrivilegeRoles(_ Pos) ([]*Ident, error) {
-	roles := make([]*Ident, 0)
-	role, err := p.parseIdent()
-	if err != nil {
-		return nil, err
-	}
-	roles = append(roles, role)
-	for p.tryConsumeTokenKind(TokenKindComma) != nil {
-		role, err := p.parseIdent()
-		if err != nil {
-			return nil, err
+	for _, source := range sourcePrivileges {
+		if p.tryConsumeWords(source) != 0 {
+			return &PrivilegeClause{
+				PrivilegePos: pos,
+				Keywords:     []string{source},
+			}, nil
 		}
-		roles = append(roles, role)
 	}
-	return roles, nil
+	return nil, fmt.Errorf("expected SELECT|INSERT|ALTER|CREATE|DROP|SHOW|KILL|SYSTEM|OPTIMIZE|TRUNCATE|ACCESS|SOURCES")
+}
+
+// sourcePrivileges allow reading and writing external data through table functions and engines.
+var sourcePrivileges = []string{
+	"SOURCES", "FILE", "URL", "REMOTE", "MYSQL", "POSTGRES", "ODBC", "JDBC",
+	"HDFS", "S3", "AZURE", "MONGO", "REDIS", "SQLITE",
 }
 
 func (p *Parser) parseGrantOptions(_ Pos) ([]string, error) {
@@ -1293,38 +1417,48 @@ func (p *Parser) parseGrantPrivilegeStmt(pos Pos) (*GrantPrivilegeStmt, error) {
 		return nil, err
 	}
 	var privileges []*PrivilegeClause
-	privilege, err := p.parsePrivilegeClause(p.Start())
-	if err != nil {
-		return nil, err
-	}
-	privileges = append(privileges, privilege)
-	for p.tryConsumeTokenKind(TokenKindComma) != nil {
+	var on *TableIdentifier
+	var roles []*RoleName
+	var statementEnd Pos
+	if p.matchPrivilege() {
 		privilege, err := p.parsePrivilegeClause(p.Start())
 		if err != nil {
 			return nil, err
 		}
 		privileges = append(privileges, privilege)
-	}
-	statementEnd := privileges[len(privileges)-1].End()
+		for p.tryConsumeTokenKind(TokenKindComma) != nil {
+			privilege, err := p.parsePrivilegeClause(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			privileges = append(privileges, privilege)
+		}
+		statementEnd = privileges[len(privileges)-1].End()
 
-	if err := p.expectKeyword(KeywordOn); err != nil {
-		return nil, err
-	}
-	on, err := p.parseGrantSource(p.Start())
-	if err != nil {
-		return nil, err
+		if err := p.expectKeyword(KeywordOn); err != nil {
+			return nil, err
+		}
+		on, err = p.parseGrantSource(p.Start())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// GRANT role [,...] TO user [,...] [WITH ADMIN OPTION]
+		roles, err = p.parseUserNames()
+		if err != nil {
+			return nil, err
+		}
+		statementEnd = roles[len(roles)-1].End()
 	}
 
 	if err := p.expectKeyword(KeywordTo); err != nil {
 		return nil, err
 	}
-	toRoles, err := p.parsePrivilegeRoles(p.Start())
+	toRoles, err := p.parseUserNames()
 	if err != nil {
 		return nil, err
 	}
-	if len(toRoles) != 0 {
-		statementEnd = toRoles[len(toRoles)-1].end
-	}
+	statementEnd = toRoles[len(toRoles)-1].End()
 	options, err := p.parseGrantOptions(p.Start())
 	if err != nil {
 		return nil, err
@@ -1339,6 +1473,7 @@ func (p *Parser) parseGrantPrivilegeStmt(pos Pos) (*GrantPrivilegeStmt, error) {
 		OnCluster:    onCluster,
 		Privileges:   privileges,
 		On:           on,
+		Roles:        roles,
 		To:           toRoles,
 		WithOptions:  options,
 	}, nil
@@ -1354,19 +1489,10 @@ func (p *Parser) parseAlterRole(pos Pos) (*AlterRole, error) {
 		return nil, err
 	}
 
-	roleRenamePairs := make([]*RoleRenamePair, 0)
-	roleRenamePair, err := p.parseRoleRenamePair(p.Start())
+	roleRenamePairs, err := p.parseRoleRenamePairs()
 	if err != nil {
 		return nil, err
 	}
-	roleRenamePairs = append(roleRenamePairs, roleRenamePair)
-	for p.tryConsumeTokenKind(TokenKindComma) != nil {
-		roleRenamePair, err := p.parseRoleRenamePair(p.Start())
-		if err != nil {
-			return nil, err
-		}
-		roleRenamePairs = append(roleRenamePairs, roleRenamePair)
-	}
 	statementEnd := roleRenamePairs[len(roleRenamePairs)-1].End()
 
 	settings, err := p.tryParseRoleSettings(p.Start())
@@ -1408,3 +1534,243 @@ func (p *Parser) parseRoleRenamePair(_ Pos) (*RoleRenamePair, error) {
 	}
 	return roleRenamePair, nil
 }
+
+func (p *Parser) parseRoleRenamePairs() ([]*RoleRenamePair, error) {
+	roleRenamePairs := make([]*RoleRenamePair, 0)
+	for {
+		roleRenamePair, err := p.parseRoleRenamePair(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		roleRenamePairs = append(roleRenamePairs, roleRenamePair)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			return roleRenamePairs, nil
+		}
+	}
+}
+
+// Syntax: {NONE | ALL [EXCEPT role [,...]] | role [,...] [EXCEPT role [,...]]}
+//
+// DEFAULT is only a keyword for SET ROLE, elsewhere it names the default user.
+func (p *Parser) parseRoleList(pos Pos) (*RoleList, error) {
+	roleList := &RoleList{ListPos: pos}
+	if p.matchOneOfKeywords(KeywordNone, KeywordAll) {
+		roleList.Keyword = strings.ToUpper(p.last().String)
+		roleList.ListEnd = p.End()
+		_ = p.lexer.consumeToken()
+		if roleList.Keyword != KeywordAll {
+			return roleList, nil
+		}
+	} else {
+		roles, err := p.parseUserNames()
+		if err != nil {
+			return nil, err
+		}
+		roleList.Roles = roles
+		roleList.ListEnd = roles[len(roles)-1].End()
+	}
+	if p.tryConsumeKeywords(KeywordExcept) {
+		except, err := p.parseUserNames()
+		if err != nil {
+			return nil, err
+		}
+		roleList.Except = except
+		roleList.ListEnd = except[len(except)-1].End()
+	}
+	return roleList, nil
+}
+
+// matchPrivilege reports whether the last token starts a privilege rather than a role name.
+func (p *Parser) matchPrivilege() bool {
+	if p.matchTokenKind(TokenKindIdent) && p.last().String == "dictGet" {
+		return true
+	}
+	// ADMIN and ROLE may as well be the names of granted roles
+	switch {
+	case p.matchKeyword(KeywordAdmin):
+		return p.peekKeyword(KeywordOption)
+	case p.matchKeyword(KeywordRole):
+		return p.peekKeyword(KeywordAdmin)
+	}
+	if p.matchOneOfKeywords(KeywordSelect, KeywordInsert, KeywordAlter, KeywordCreate, KeywordDrop,
+		KeywordShow, KeywordAll, KeywordNone, KeywordKill, KeywordSystem, KeywordOptimize, KeywordTruncate) {
+		return true
+	}
+	// ACCESS MANAGEMENT and the source privileges are not keywords, a list of them is followed by ON
+	savedState := p.lexer.saveState()
+	defer p.lexer.restoreState(savedState)
+	for {
+		if p.tryConsumeWords("ACCESS", "MANAGEMENT") == 0 && !p.tryConsumeSourcePrivilege() {
+			return false
+		}
+		if p.matchKeyword(KeywordOn) {
+			return true
+		}
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			return false
+		}
+		if p.matchPrivilegeKeyword() {
+			return true
+		}
+	}
+}
+
+func (p *Parser) matchPrivilegeKeyword() bool {
+	return p.matchOneOfKeywords(KeywordSelect, KeywordInsert, KeywordAlter, KeywordCreate, KeywordDrop,
+		KeywordShow, KeywordKill, KeywordSystem, KeywordOptimize, KeywordTruncate)
+}
+
+func (p *Parser) tryConsumeSourcePrivilege() bool {
+	for _, source := range sourcePrivileges {
+		if p.tryConsumeWords(source) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRevokeStmt parses a REVOKE statement.
+//
+// The syntax is as follows:
+// REVOKE [ON CLUSTER cluster] [GRANT OPTION FOR] privilege [,...] ON {db.table | db.* | *.* | table | *}
+// FROM {user | CURRENT_USER} [,...] | ALL | ALL EXCEPT {user | CURRENT_USER} [,...]
+//
+// REVOKE [ON CLUSTER cluster] [ADMIN OPTION FOR] role [,...]
+// FROM {user | role | CURRENT_USER} [,...] | ALL | ALL EXCEPT {user_name | role_name | CURRENT_USER} [,...]
+func (p *Parser) parseRevokeStmt(pos Pos) (*RevokeStmt, error) {
+	if err := p.expectKeyword(KeywordRevoke); err != nil {
+		return nil, err
+	}
+	onCluster, err := p.tryParseClusterClause(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	revoke := &RevokeStmt{RevokePos: pos, OnCluster: onCluster}
+	switch {
+	case p.tryConsumeKeywords(KeywordGrant, KeywordOption, KeywordFor):
+		revoke.GrantOptionFor = true
+	case p.tryConsumeKeywords(KeywordAdmin, KeywordOption, KeywordFor):
+		revoke.AdminOptionFor = true
+	}
+
+	if !revoke.AdminOptionFor && p.matchPrivilege() {
+		for {
+			privilege, err := p.parsePrivilegeClause(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			revoke.Privileges = append(revoke.Privileges, privilege)
+			if p.tryConsumeTokenKind(TokenKindComma) == nil {
+				break
+			}
+		}
+		if err := p.expectKeyword(KeywordOn); err != nil {
+			return nil, err
+		}
+		revoke.On, err = p.parseGrantSource(p.Start())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		revoke.Roles, err = p.parseUserNames()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expectKeyword(KeywordFrom); err != nil {
+		return nil, err
+	}
+	revoke.From, err = p.parseRoleList(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	revoke.StatementEnd = revoke.From.End()
+	return revoke, nil
+}
+
+// parseAlterUser parses an ALTER USER statement.
+//
+// The syntax is as follows:
+// ALTER USER [IF EXISTS] name1 [RENAME TO new_name |, name2 [,...]]
+// [NOT IDENTIFIED | IDENTIFIED {[WITH type] BY 'value' | WITH ldap SERVER 'server' | WITH kerberos [REALM 'realm']}]
+// [HOST {LOCAL | NAME 'name' | REGEXP 'name_regexp' | IP 'address' | LIKE 'pattern'} [,...] | ANY | NONE]
+// [DEFAULT ROLE role [,...] | NONE] [DEFAULT DATABASE database | NONE]
+// [GRANTEES {user | role | ANY | NONE} [,...] [EXCEPT {user | role} [,...]]]
+// [SETTINGS variable [= value] [MIN [=] min_value] [MAX [=] max_value] [READONLY | WRITABLE] | PROFILE 'profile_name'] [,...]
+func (p *Parser) parseAlterUser(pos Pos) (*AlterUser, error) {
+	if err := p.expectKeyword(KeywordUser); err != nil {
+		return nil, err
+	}
+	ifExists, err := p.tryParseIfExists()
+	if err != nil {
+		return nil, err
+	}
+	roleRenamePairs, err := p.parseRoleRenamePairs()
+	if err != nil {
+		return nil, err
+	}
+
+	// ALTER USER accepts the same clauses as CREATE USER
+	options := &CreateUser{StatementEnd: roleRenamePairs[len(roleRenamePairs)-1].End()}
+	if err := p.parseOptionalClauses(options); err != nil {
+		return nil, err
+	}
+
+	return &AlterUser{
+		AlterPos:        pos,
+		StatementEnd:    options.StatementEnd,
+		IfExists:        ifExists,
+		RoleRenamePairs: roleRenamePairs,
+		Authentication:  options.Authentication,
+		Hosts:           options.Hosts,
+		DefaultRole:     options.DefaultRole,
+		DefaultDatabase: options.DefaultDatabase,
+		DefaultDbNone:   options.DefaultDbNone,
+		Grantees:        options.Grantees,
+		Settings:        options.Settings,
+	}, nil
+}
+
+// Syntax: SET ROLE {DEFAULT | NONE | role [,...] | ALL | ALL EXCEPT role [,...]}
+// or: SET DEFAULT ROLE {NONE | role [,...] | ALL | ALL EXCEPT role [,...]} TO {user | CURRENT_USER} [,...]
+func (p *Parser) parseSetRoleStmt(pos Pos) (*SetRoleStmt, error) {
+	if err := p.expectKeyword(KeywordSet); err != nil {
+		return nil, err
+	}
+	setRole := &SetRoleStmt{SetPos: pos}
+	if p.tryConsumeKeywords(KeywordDefault) {
+		if err := p.expectDialect("SET DEFAULT ROLE", DialectClickHouse, DialectMySQL); err != nil {
+			return nil, err
+		}
+		setRole.Default = true
+	}
+	if err := p.expectKeyword(KeywordRole); err != nil {
+		return nil, err
+	}
+	if !setRole.Default && p.matchKeyword(KeywordDefault) {
+		setRole.Roles = &RoleList{ListPos: p.Start(), ListEnd: p.End(), Keyword: KeywordDefault}
+		setRole.StatementEnd = p.End()
+		_ = p.lexer.consumeToken()
+		return setRole, nil
+	}
+	roles, err := p.parseRoleList(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	setRole.Roles = roles
+	setRole.StatementEnd = roles.End()
+	if !setRole.Default {
+		return setRole, nil
+	}
+
+	if err := p.expectKeyword(KeywordTo); err != nil {
+		return nil, err
+	}
+	setRole.To, err = p.parseUserNames()
+	if err != nil {
+		return nil, err
+	}
+	setRole.StatementEnd = setRole.To[len(setRole.To)-1].End()
+	return setRole, nil
+}
@@ -0,0 +1,546 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (p *Parser) tryParseAccessStorageType() (*Ident, error) {
+	if !p.tryConsumeKeywords(KeywordIn) {
+		return nil, nil // nolint
+	}
+	return p.parseIdent()
+}
+
+// Syntax: ON [db.]table | db.* [, ...]
+func (p *Parser) parseRowPolicyTables() ([]*TableIdentifier, error) {
+	if err := p.expectKeyword(KeywordOn); err != nil {
+		return nil, err
+	}
+	tables := make([]*TableIdentifier, 0)
+	for {
+		table, err := p.parseGrantSource(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			return tables, nil
+		}
+	}
+}
+
+// expectRowPolicy consumes `ROW POLICY` or its short form `POLICY`.
+func (p *Parser) expectRowPolicy() error {
+	_ = p.tryConsumeKeywords(KeywordRow)
+	if err := p.expectKeyword(KeywordPolicy); err != nil {
+		return err
+	}
+	return p.expectDialect("ROW POLICY", DialectClickHouse)
+}
+
+// expectSettingsProfile consumes `SETTINGS PROFILE` or its short form `PROFILE`.
+func (p *Parser) expectSettingsProfile() error {
+	_ = p.tryConsumeKeywords(KeywordSettings)
+	if err := p.expectKeyword(KeywordProfile); err != nil {
+		return err
+	}
+	return p.expectDialect("SETTINGS PROFILE", DialectClickHouse)
+}
+
+// tryParseIfNotExistsOrReplace parses the `IF NOT EXISTS | OR REPLACE` modifier
+// that follows the entity keyword of CREATE ROW POLICY, SETTINGS PROFILE and QUOTA.
+func (p *Parser) tryParseIfNotExistsOrReplace() (ifNotExists bool, orReplace bool, err error) {
+	if p.tryConsumeKeywords(KeywordOr, KeywordReplace) {
+		return false, true, nil
+	}
+	ifNotExists, err = p.tryParseIfNotExists()
+	return ifNotExists, false, err
+}
+
+// rowPolicyClauses holds the clauses shared by CREATE and ALTER ROW POLICY.
+type rowPolicyClauses struct {
+	forSelect bool
+	using     Expr
+	usingNone bool
+	as        RowPolicyKind
+	to        *RoleList
+	end       Pos
+}
+
+// Syntax: [FOR SELECT] [USING {condition | NONE}] [AS {PERMISSIVE | RESTRICTIVE}] [TO {role [,...] | ALL | ALL EXCEPT role [,...]}]
+func (p *Parser) parseRowPolicyClauses(end Pos) (*rowPolicyClauses, error) {
+	clauses := &rowPolicyClauses{end: end}
+	for {
+		switch {
+		case p.tryConsumeKeywords(KeywordFor):
+			clauses.end = p.End()
+			if err := p.expectKeyword(KeywordSelect); err != nil {
+				return nil, err
+			}
+			clauses.forSelect = true
+		case p.tryConsumeKeywords(KeywordUsing):
+			if p.matchKeyword(KeywordNone) {
+				clauses.usingNone = true
+				clauses.end = p.End()
+				_ = p.lexer.consumeToken()
+				continue
+			}
+			using, err := p.parseExpr(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			clauses.using = using
+			clauses.end = using.End()
+		case p.tryConsumeKeywords(KeywordAs):
+			if !p.matchOneOfKeywords(KeywordPermissive, KeywordRestrictive) {
+				return nil, fmt.Errorf("expected keyword: PERMISSIVE|RESTRICTIVE, but got %q", p.lastTokenKind())
+			}
+			clauses.as = RowPolicyKind(strings.ToUpper(p.last().String))
+			clauses.end = p.End()
+			_ = p.lexer.consumeToken()
+		case p.tryConsumeKeywords(KeywordTo):
+			to, err := p.parseRoleList(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			clauses.to = to
+			clauses.end = to.End()
+		default:
+			return clauses, nil
+		}
+	}
+}
+
+// parseCreateRowPolicy parses a CREATE ROW POLICY statement.
+//
+// The syntax is as follows:
+// CREATE [ROW] POLICY [IF NOT EXISTS | OR REPLACE] policy_name [ON CLUSTER cluster_name]
+// ON [db.]table | db.* [, ...]
+// [IN access_storage_type]
+// [FOR SELECT] USING condition
+// [AS {PERMISSIVE | RESTRICTIVE}]
+// [TO {role [,...] | ALL | ALL EXCEPT role [,...]}]
+func (p *Parser) parseCreateRowPolicy(pos Pos) (*CreateRowPolicy, error) {
+	if err := p.expectRowPolicy(); err != nil {
+		return nil, err
+	}
+	ifNotExists, orReplace, err := p.tryParseIfNotExistsOrReplace()
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	onCluster, err := p.tryParseClusterClause(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	tables, err := p.parseRowPolicyTables()
+	if err != nil {
+		return nil, err
+	}
+	accessStorageType, err := p.tryParseAccessStorageType()
+	if err != nil {
+		return nil, err
+	}
+	statementEnd := tables[len(tables)-1].End()
+	if accessStorageType != nil {
+		statementEnd = accessStorageType.End()
+	}
+	clauses, err := p.parseRowPolicyClauses(statementEnd)
+	if err != nil {
+		return nil, err
+	}
+	if clauses.usingNone {
+		return nil, errors.New("USING NONE is only allowed in ALTER ROW POLICY")
+	}
+
+	return &CreateRowPolicy{
+		CreatePos:         pos,
+		StatementEnd:      clauses.end,
+		OrReplace:         orReplace,
+		IfNotExists:       ifNotExists,
+		Name:              name,
+		OnCluster:         onCluster,
+		On:                tables,
+		AccessStorageType: accessStorageType,
+		ForSelect:         clauses.forSelect,
+		Using:             clauses.using,
+		As:                clauses.as,
+		To:                clauses.to,
+	}, nil
+}
+
+// parseAlterRowPolicy parses an ALTER ROW POLICY statement.
+//
+// The syntax is as follows:
+// ALTER [ROW] POLICY [IF EXISTS] policy_name [ON CLUSTER cluster_name]
+// ON [db.]table | db.* [, ...]
+// [RENAME TO new_name]
+// [FOR SELECT] [USING {condition | NONE}]
+// [AS {PERMISSIVE | RESTRICTIVE}]
+// [TO {role [,...] | ALL | ALL EXCEPT role [,...]}]
+func (p *Parser) parseAlterRowPolicy(pos Pos) (*AlterRowPolicy, error) {
+	if err := p.expectRowPolicy(); err != nil {
+		return nil, err
+	}
+	ifExists, err := p.tryParseIfExists()
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	onCluster, err := p.tryParseClusterClause(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	tables, err := p.parseRowPolicyTables()
+	if err != nil {
+		return nil, err
+	}
+	statementEnd := tables[len(tables)-1].End()
+
+	var renameTo *Ident
+	if p.tryConsumeKeywords(KeywordRename, KeywordTo) {
+		renameTo, err = p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		statementEnd = renameTo.End()
+	}
+	clauses, err := p.parseRowPolicyClauses(statementEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AlterRowPolicy{
+		AlterPos:     pos,
+		StatementEnd: clauses.end,
+		IfExists:     ifExists,
+		Name:         name,
+		OnCluster:    onCluster,
+		On:           tables,
+		RenameTo:     renameTo,
+		ForSelect:    clauses.forSelect,
+		Using:        clauses.using,
+		UsingNone:    clauses.usingNone,
+		As:           clauses.as,
+		To:           clauses.to,
+	}, nil
+}
+
+// parseCreateSettingsProfile parses a CREATE SETTINGS PROFILE statement.
+//
+// The syntax is as follows:
+// CREATE SETTINGS PROFILE [IF NOT EXISTS | OR REPLACE] name1 [, name2 [,...]] [ON CLUSTER cluster_name]
+// [IN access_storage_type]
+// [SETTINGS variable [= value] [MIN [=] min_value] [MAX [=] max_value] [CONST|READONLY|WRITABLE|CHANGEABLE_IN_READONLY] | INHERIT 'profile_name'] [,...]
+// [TO {role [,...] | ALL | ALL EXCEPT role [,...]}]
+func (p *Parser) parseCreateSettingsProfile(pos Pos) (*CreateSettingsProfile, error) {
+	if err := p.expectSettingsProfile(); err != nil {
+		return nil, err
+	}
+	ifNotExists, orReplace, err := p.tryParseIfNotExistsOrReplace()
+	if err != nil {
+		return nil, err
+	}
+	names, err := p.parseUserNames()
+	if err != nil {
+		return nil, err
+	}
+	statementEnd := names[len(names)-1].End()
+
+	accessStorageType, err := p.tryParseAccessStorageType()
+	if err != nil {
+		return nil, err
+	}
+	if accessStorageType != nil {
+		statementEnd = accessStorageType.End()
+	}
+	settings, err := p.tryParseRoleSettings(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) > 0 {
+		statementEnd = settings[len(settings)-1].End()
+	}
+	var to *RoleList
+	if p.tryConsumeKeywords(KeywordTo) {
+		to, err = p.parseRoleList(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		statementEnd = to.End()
+	}
+
+	return &CreateSettingsProfile{
+		CreatePos:         pos,
+		StatementEnd:      statementEnd,
+		OrReplace:         orReplace,
+		IfNotExists:       ifNotExists,
+		Names:             names,
+		AccessStorageType: accessStorageType,
+		Settings:          settings,
+		To:                to,
+	}, nil
+}
+
+// parseAlterSettingsProfile parses an ALTER SETTINGS PROFILE statement.
+//
+// The syntax is as follows:
+// ALTER SETTINGS PROFILE [IF EXISTS] name1 [RENAME TO new_name |, name2 [,...]] [ON CLUSTER cluster_name]
+// [SETTINGS variable [= value] [MIN [=] min_value] [MAX [=] max_value] [CONST|READONLY|WRITABLE|CHANGEABLE_IN_READONLY] | INHERIT 'profile_name'] [,...]
+// [TO {role [,...] | ALL | ALL EXCEPT role [,...]}]
+func (p *Parser) parseAlterSettingsProfile(pos Pos) (*AlterSettingsProfile, error) {
+	if err := p.expectSettingsProfile(); err != nil {
+		return nil, err
+	}
+	ifExists, err := p.tryParseIfExists()
+	if err != nil {
+		return nil, err
+	}
+	roleRenamePairs, err := p.parseRoleRenamePairs()
+	if err != nil {
+		return nil, err
+	}
+	statementEnd := roleRenamePairs[len(roleRenamePairs)-1].End()
+
+	settings, err := p.tryParseRoleSettings(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) > 0 {
+		statementEnd = settings[len(settings)-1].End()
+	}
+	var to *RoleList
+	if p.tryConsumeKeywords(KeywordTo) {
+		to, err = p.parseRoleList(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		statementEnd = to.End()
+	}
+
+	return &AlterSettingsProfile{
+		AlterPos:        pos,
+		StatementEnd:    statementEnd,
+		IfExists:        ifExists,
+		RoleRenamePairs: roleRenamePairs,
+		Settings:        settings,
+		To:              to,
+	}, nil
+}
+
+// Syntax: FOR [RANDOMIZED] INTERVAL number unit {MAX {resource = number} [,...] | NO LIMITS | TRACKING ONLY}
+func (p *Parser) parseQuotaInterval(pos Pos) (*QuotaInterval, error) {
+	if err := p.expectKeyword(KeywordFor); err != nil {
+		return nil, err
+	}
+	interval := &QuotaInterval{ForPos: pos, Randomized: p.tryConsumeKeywords(KeywordRandomized)}
+	if err := p.expectKeyword(KeywordInterval); err != nil {
+		return nil, err
+	}
+	var err error
+	if interval.Interval, err = p.parseNumber(p.Start()); err != nil {
+		return nil, err
+	}
+	if interval.Unit, err = p.parseIdent(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.matchKeyword(KeywordNo):
+		_ = p.lexer.consumeToken()
+		interval.NoLimits = true
+		interval.IntervalEnd = p.End()
+		if err := p.expectKeyword(KeywordLimits); err != nil {
+			return nil, err
+		}
+	case p.matchKeyword(KeywordTracking):
+		_ = p.lexer.consumeToken()
+		interval.TrackingOnly = true
+		interval.IntervalEnd = p.End()
+		if err := p.expectKeyword(KeywordOnly); err != nil {
+			return nil, err
+		}
+	case p.tryConsumeKeywords(KeywordMax):
+		for {
+			name, err := p.parseIdent()
+			if err != nil {
+				return nil, err
+			}
+			var op TokenKind
+			if token := p.tryConsumeTokenKind(TokenKindSingleEQ); token != nil {
+				op = token.Kind
+			}
+			value, err := p.parseNumber(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			interval.Limits = append(interval.Limits, &SettingPair{Name: name, Operation: op, Value: value})
+			interval.IntervalEnd = value.End()
+			// the comma before FOR starts the next interval
+			if !p.matchTokenKind(TokenKindComma) || p.peekKeyword(KeywordFor) {
+				break
+			}
+			_ = p.lexer.consumeToken()
+		}
+	default:
+		return nil, fmt.Errorf("expected keyword: MAX|NO LIMITS|TRACKING ONLY, but got %q", p.lastTokenKind())
+	}
+	return interval, nil
+}
+
+// quotaClauses holds the clauses shared by CREATE and ALTER QUOTA.
+type quotaClauses struct {
+	keyedBy   []*Ident
+	notKeyed  bool
+	intervals []*QuotaInterval
+	to        *RoleList
+	end       Pos
+}
+
+// Syntax: [KEYED BY key [,...] | NOT KEYED] [FOR [RANDOMIZED] INTERVAL ... [,...]] [TO {role [,...] | ALL | ALL EXCEPT role [,...]}]
+func (p *Parser) parseQuotaClauses(end Pos) (*quotaClauses, error) {
+	clauses := &quotaClauses{end: end}
+	for {
+		switch {
+		case p.tryConsumeKeywords(KeywordKeyed, KeywordBy):
+			for {
+				key, err := p.parseIdent()
+				if err != nil {
+					return nil, err
+				}
+				clauses.keyedBy = append(clauses.keyedBy, key)
+				clauses.end = key.End()
+				if p.tryConsumeTokenKind(TokenKindComma) == nil {
+					break
+				}
+			}
+		case p.tryConsumeKeywords(KeywordNot):
+			clauses.notKeyed = true
+			clauses.end = p.End()
+			if err := p.expectKeyword(KeywordKeyed); err != nil {
+				return nil, err
+			}
+		case p.matchKeyword(KeywordFor):
+			for {
+				interval, err := p.parseQuotaInterval(p.Start())
+				if err != nil {
+					return nil, err
+				}
+				clauses.intervals = append(clauses.intervals, interval)
+				clauses.end = interval.End()
+				if p.tryConsumeTokenKind(TokenKindComma) == nil {
+					break
+				}
+			}
+		case p.tryConsumeKeywords(KeywordTo):
+			to, err := p.parseRoleList(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			clauses.to = to
+			clauses.end = to.End()
+		default:
+			return clauses, nil
+		}
+	}
+}
+
+// parseCreateQuota parses a CREATE QUOTA statement.
+//
+// The syntax is as follows:
+// CREATE QUOTA [IF NOT EXISTS | OR REPLACE] name [ON CLUSTER cluster_name]
+// [IN access_storage_type]
+// [KEYED BY {user_name | ip_address | client_key | client_key,user_name | client_key,ip_address} | NOT KEYED]
+// [FOR [RANDOMIZED] INTERVAL number {second | minute | hour | day | week | month | quarter | year}
+// {MAX { {queries | query_selects | query_inserts | errors | result_rows | result_bytes | read_rows | read_bytes | execution_time} = number } [,...] |
+// NO LIMITS | TRACKING ONLY} [,...]]
+// [TO {role [,...] | ALL | ALL EXCEPT role [,...]}]
+func (p *Parser) parseCreateQuota(pos Pos) (*CreateQuota, error) {
+	if err := p.expectKeyword(KeywordQuota); err != nil {
+		return nil, err
+	}
+	if err := p.expectDialect("QUOTA", DialectClickHouse); err != nil {
+		return nil, err
+	}
+	ifNotExists, orReplace, err := p.tryParseIfNotExistsOrReplace()
+	if err != nil {
+		return nil, err
+	}
+	names, err := p.parseUserNames()
+	if err != nil {
+		return nil, err
+	}
+	statementEnd := names[len(names)-1].End()
+
+	accessStorageType, err := p.tryParseAccessStorageType()
+	if err != nil {
+		return nil, err
+	}
+	if accessStorageType != nil {
+		statementEnd = accessStorageType.End()
+	}
+	clauses, err := p.parseQuotaClauses(statementEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateQuota{
+		CreatePos:         pos,
+		StatementEnd:      clauses.end,
+		OrReplace:         orReplace,
+		IfNotExists:       ifNotExists,
+		Names:             names,
+		AccessStorageType: accessStorageType,
+		KeyedBy:           clauses.keyedBy,
+		NotKeyed:          clauses.notKeyed,
+		Intervals:         clauses.intervals,
+		To:                clauses.to,
+	}, nil
+}
+
+// parseAlterQuota parses an ALTER QUOTA statement.
+//
+// The syntax is as follows:
+// ALTER QUOTA [IF EXISTS] name [RENAME TO new_name |, name2 [,...]] [ON CLUSTER cluster_name]
+// [KEYED BY {user_name | ip_address | client_key | client_key,user_name | client_key,ip_address} | NOT KEYED]
+// [FOR [RANDOMIZED] INTERVAL number unit {MAX resource = number [,...] | NO LIMITS | TRACKING ONLY} [,...]]
+// [TO {role [,...] | ALL | ALL EXCEPT role [,...]}]
+func (p *Parser) parseAlterQuota(pos Pos) (*AlterQuota, error) {
+	if err := p.expectKeyword(KeywordQuota); err != nil {
+		return nil, err
+	}
+	if err := p.expectDialect("QUOTA", DialectClickHouse); err != nil {
+		return nil, err
+	}
+	ifExists, err := p.tryParseIfExists()
+	if err != nil {
+		return nil, err
+	}
+	roleRenamePairs, err := p.parseRoleRenamePairs()
+	if err != nil {
+		return nil, err
+	}
+	clauses, err := p.parseQuotaClauses(roleRenamePairs[len(roleRenamePairs)-1].End())
+	if err != nil {
+		return nil, err
+	}
+
+	return &AlterQuota{
+		AlterPos:        pos,
+		StatementEnd:    clauses.end,
+		IfExists:        ifExists,
+		RoleRenamePairs: roleRenamePairs,
+		KeyedBy:         clauses.keyedBy,
+		NotKeyed:        clauses.notKeyed,
+		Intervals:       clauses.intervals,
+		To:              clauses.to,
+	}, nil
+}
@@ -3,8 +3,14 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
+// alterTableOptionNames are the MySQL table options which can be changed by ALTER TABLE.
+var alterTableOptionNames = NewSet("ENGINE", "AUTO_INCREMENT", "COMMENT", "DEFAULT", "CHARSET", "CHARACTER",
+	"COLLATE", "ROW_FORMAT", "KEY_BLOCK_SIZE", "AVG_ROW_LENGTH", "MAX_ROWS", "MIN_ROWS", "PACK_KEYS",
+	"CHECKSUM", "STATS_PERSISTENT", "STATS_AUTO_RECALC", "STATS_SAMPLE_PAGES", "COMPRESSION", "ENCRYPTION")
+
 func (p *Parser) parseAlterTable(pos Pos) (*AlterTable, error) {
 	alterTable := &AlterTable{
 		AlterPos:   pos,
@@ -27,9 +33,14 @@ func (p *Parser) parseAlterTable(pos Pos) (*AlterTable, error) {
 
 	for !p.lexer.isEOF() {
 		var alter AlterTableClause
+		var alters []AlterTableClause
 		switch {
+		case p.matchKeyword(KeywordAdd) && p.peekAddColumnList():
+			alters, err = p.parseAlterTableAddColumns(p.Start())
 		case p.matchKeyword(KeywordAdd):
 			alter, err = p.parseAlterTableAdd(p.Start())
+		case p.matchKeyword(KeywordAlter):
+			alter, err = p.parseAlterTableAlterColumn(p.Start())
 		case p.matchKeyword(KeywordDrop):
 			alter, err = p.parseAlterTableDrop(p.Start())
 		case p.matchKeyword(KeywordAttach):
@@ -42,7 +53,7 @@ func (p *Parser) parseAlterTable(pos Pos) (*AlterTable, error) {
 		case p.matchKeyword(KeywordRemove):
 			alter, err = p.parseAlterTableRemoveTTL(p.Start())
 		case p.matchKeyword(KeywordRename):
-			alter, err = p.parseAlterTableRenameColumn(p.Start())
+			alter, err = p.parseAlterTableRename(p.Start())
 		case p.matchKeyword(KeywordClear):
 			alter, err = p.parseAlterTableClear(p.Start())
 		case p.matchKeyword(KeywordModify):
@@ -51,13 +62,46 @@ func (p *Parser) parseAlterTable(pos Pos) (*AlterTable, error) {
 			alter, err = p.parseAlterTableReplacePartition(p.Start())
 		case p.matchKeyword(KeywordMaterialize):
 			alter, err = p.parseAlterTableMaterialize(p.Start())
+		case p.matchKeyword(KeywordUpdate):
+			alter, err = p.parseAlterTableUpdate(p.Start())
+		case p.matchKeyword(KeywordDelete):
+			alter, err = p.parseAlterTableDelete(p.Start())
+		case p.matchKeyword(KeywordMove):
+			alter, err = p.parseAlterTableMovePartition(p.Start())
+		case p.matchKeyword(KeywordFetch):
+			alter, err = p.parseAlterTableFetchPartition(p.Start())
+		case p.matchKeyword(KeywordReset):
+			alter, err = p.parseAlterTableResetSetting(p.Start())
+		case p.matchKeyword(KeywordApply):
+			alter, err = p.parseAlterTableApplyDeletedMask(p.Start())
+		case p.matchKeyword(KeywordChange):
+			alter, err = p.parseAlterTableChangeColumn(p.Start())
+		case p.matchKeyword(KeywordConvert):
+			alter, err = p.parseAlterTableConvertCharset(p.Start())
+		case p.matchKeyword(KeywordAlgorithm):
+			alter, err = p.parseAlterTableAlgorithm(p.Start())
+		case p.matchKeyword(KeywordLock):
+			alter, err = p.parseAlterTableLock(p.Start())
+		case p.matchTokenKind(TokenKindIdent) && alterTableOptionNames.Contains(strings.ToUpper(p.last().String)):
+			alter, err = p.parseAlterTableOption(p.Start())
+		case p.matchTokenKind(TokenKindIdent) && (strings.EqualFold(p.last().String, "DISABLE") ||
+			strings.EqualFold(p.last().String, "ENABLE")):
+			alter, err = p.parseAlterTableKeys(p.Start())
 		default:
-			return nil, errors.New("expected token: ADD|DROP|ATTACH|DETACH|FREEZE|REMOVE|CLEAR")
+			return nil, fmt.Errorf("expected token: %s", alterTableKeywords[p.dialect])
 		}
 		if err != nil {
 			return nil, err
 		}
-		alterTable.AlterExprs = append(alterTable.AlterExprs, alter)
+		if alter != nil {
+			alters = append(alters, alter)
+		}
+		for _, alter := range alters {
+			if err := p.checkAlterTableClause(alter); err != nil {
+				return nil, err
+			}
+		}
+		alterTable.AlterExprs = append(alterTable.AlterExprs, alters...)
 		if p.tryConsumeTokenKind(TokenKindComma) == nil {
 			break
 		}
@@ -76,21 +120,81 @@ func (p *Parser) parseAlterTableAdd(pos Pos) (AlterTableClause, error) {
 	}
 
 	switch {
-	case p.matchKeyword(KeywordColumn):
-		return p.parseAlterTableAddColumn(pos)
-	case p.matchKeyword(KeywordIndex):
+	case p.matchKeyword(KeywordIndex), p.peekWords("FULLTEXT"), p.peekWords("SPATIAL"):
 		return p.parseAlterTableAddIndex(pos)
 	case p.matchKeyword(KeywordProjection):
 		return p.parseAlterTableAddProjection(pos)
+	case p.matchKeyword(KeywordConstraint):
+		return p.parseAlterTableAddConstraint(pos)
+	case p.peekWords("FOREIGN", KeywordKey):
+		return p.parseAlterTableAddForeignKey(pos, nil)
+	case p.matchKeyword(KeywordPrimary), p.matchKeyword(KeywordUnique), p.matchKeyword(KeywordKey):
+		return p.parseAlterTableAddKey(pos, nil)
+	case p.matchTokenKind(TokenKindIdent):
+		return p.parseAlterTableAddColumn(pos)
 	default:
-		return nil, errors.New("expected token: COLUMN|INDEX|PROJECTION")
+		return nil, errors.New("expected token: COLUMN|INDEX|PROJECTION|CONSTRAINT|PRIMARY|UNIQUE|KEY|FOREIGN|FULLTEXT|SPATIAL")
 	}
 }
 
-func (p *Parser) parseAlterTableAddColumn(pos Pos) (*AlterTableAddColumn, error) {
-	if err := p.expectKeyword(KeywordColumn); err != nil {
+// peekWords reports whether the next tokens are the given words, without consuming them.
+func (p *Parser) peekWords(words ...string) bool {
+	savedState := p.lexer.saveState()
+	defer p.lexer.restoreState(savedState)
+	return p.tryConsumeWords(words...) != 0
+}
+
+// peekAddColumnList reports whether the ADD clause is the MySQL `ADD [COLUMN] (...)` list.
+func (p *Parser) peekAddColumnList() bool {
+	savedState := p.lexer.saveState()
+	defer p.lexer.restoreState(savedState)
+	_ = p.lexer.consumeToken()
+	_ = p.tryConsumeKeywords(KeywordColumn)
+	return p.matchTokenKind(TokenKindLParen)
+}
+
+// Syntax: ADD [COLUMN] (tableColumnDfnt, ...)
+//
+// Each column is returned as its own ADD COLUMN clause.
+func (p *Parser) parseAlterTableAddColumns(pos Pos) ([]AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordAdd); err != nil {
+		return nil, err
+	}
+	if err := p.expectDialect("ADD COLUMN (...)", DialectMySQL); err != nil {
+		return nil, err
+	}
+	_ = p.tryConsumeKeywords(KeywordColumn)
+	if err := p.expectTokenKind(TokenKindLParen); err != nil {
 		return nil, err
 	}
+	var alters []AlterTableClause
+	var column *AlterTableAddColumn
+	for {
+		def, err := p.parseTableColumnExpr(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		column = &AlterTableAddColumn{
+			AddPos:       pos,
+			StatementEnd: def.End(),
+			Column:       def,
+		}
+		alters = append(alters, column)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			break
+		}
+	}
+	column.StatementEnd = p.End()
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, err
+	}
+	return alters, nil
+}
+
+// Syntax: ADD [COLUMN] (IF NOT EXISTS)? tableColumnDfnt (FIRST | AFTER nestedIdentifier)?
+func (p *Parser) parseAlterTableAddColumn(pos Pos) (*AlterTableAddColumn, error) {
+	// the COLUMN keyword is optional in MySQL
+	_ = p.tryConsumeKeywords(KeywordColumn)
 
 	ifNotExists, err := p.tryParseIfNotExists()
 	if err != nil {
@@ -103,11 +207,14 @@ func (p *Parser) parseAlterTableAddColumn(pos Pos) (*AlterTableAddColumn, error)
 	}
 	statementEnd := column.End()
 
-	after, err := p.tryParseAfterClause()
+	firstEnd := p.End()
+	first, after, err := p.tryParseColumnPosition()
 	if err != nil {
 		return nil, err
 	}
-	if after != nil {
+	if first {
+		statementEnd = firstEnd
+	} else if after != nil {
 		statementEnd = after.End()
 	}
 
@@ -116,13 +223,195 @@ func (p *Parser) parseAlterTableAddColumn(pos Pos) (*AlterTableAddColumn, error)
 		StatementEnd: statementEnd,
 		Column:       column,
 		IfNotExists:  ifNotExists,
+		First:        first,
 		After:        after,
 	}, nil
 }
 
+// Syntax: ADD CONSTRAINT name? (PRIMARY KEY ... | UNIQUE ... | FOREIGN KEY ...)
+func (p *Parser) parseAlterTableAddConstraint(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordConstraint); err != nil {
+		return nil, err
+	}
+	var constraint *Ident
+	if p.matchTokenKind(TokenKindIdent) && !p.matchKeyword(KeywordPrimary) && !p.matchKeyword(KeywordUnique) &&
+		!p.peekWords("FOREIGN", KeywordKey) {
+		var err error
+		if constraint, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case p.peekWords("FOREIGN", KeywordKey):
+		return p.parseAlterTableAddForeignKey(pos, constraint)
+	case p.matchKeyword(KeywordPrimary), p.matchKeyword(KeywordUnique):
+		if err := p.expectDialect("ADD CONSTRAINT", DialectMySQL, DialectPostgreSQL); err != nil {
+			return nil, err
+		}
+		return p.parseAlterTableAddKey(pos, constraint)
+	default:
+		return nil, fmt.Errorf("expected keyword: PRIMARY|UNIQUE|FOREIGN, but got %q", p.lastTokenKind())
+	}
+}
+
+// Syntax: ADD (PRIMARY KEY | UNIQUE (KEY|INDEX)? | KEY) name? (columnExprList)
+func (p *Parser) parseAlterTableAddKey(pos Pos, constraint *Ident) (*AlterTableAddKey, error) {
+	keyPos := p.Start()
+	var keyType string
+	switch {
+	case p.tryConsumeKeywords(KeywordPrimary):
+		if err := p.expectKeyword(KeywordKey); err != nil {
+			return nil, err
+		}
+		keyType = "PRIMARY KEY"
+	case p.tryConsumeKeywords(KeywordUnique):
+		if !p.tryConsumeKeywords(KeywordKey) {
+			_ = p.tryConsumeKeywords(KeywordIndex)
+		}
+		keyType = "UNIQUE KEY"
+	case p.tryConsumeKeywords(KeywordKey):
+		keyType = "KEY"
+	default:
+		return nil, fmt.Errorf("expected keyword: PRIMARY|UNIQUE|KEY, but got %q", p.lastTokenKind())
+	}
+
+	key, err := p.parseTableKey(keyPos, keyType)
+	if err != nil {
+		return nil, err
+	}
+	return &AlterTableAddKey{
+		AddPos:     pos,
+		Constraint: constraint,
+		KeyType:    keyType,
+		Key:        key,
+	}, nil
+}
+
+// Syntax: FOREIGN KEY name? (columnExprList) REFERENCES tableIdentifier (columnExprList)
+// (ON DELETE referenceOption)? (ON UPDATE referenceOption)?
+func (p *Parser) parseAlterTableAddForeignKey(pos Pos, constraint *Ident) (*AlterTableAddForeignKey, error) {
+	if err := p.expectDialect("ADD FOREIGN KEY", DialectMySQL, DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	_ = p.tryConsumeWords("FOREIGN", KeywordKey)
+	foreignKey := &AlterTableAddForeignKey{AddPos: pos, Constraint: constraint}
+	var err error
+	if p.matchTokenKind(TokenKindIdent) {
+		if foreignKey.Name, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+	if foreignKey.Columns, _, err = p.parseParenColumnList(); err != nil {
+		return nil, err
+	}
+	if p.tryConsumeWords("REFERENCES") == 0 {
+		return nil, fmt.Errorf("expected keyword: REFERENCES, but got %q", p.lastTokenKind())
+	}
+	if foreignKey.RefTable, err = p.parseTableIdentifier(p.Start()); err != nil {
+		return nil, err
+	}
+	if foreignKey.RefColumns, foreignKey.StatementEnd, err = p.parseParenColumnList(); err != nil {
+		return nil, err
+	}
+	for p.matchKeyword(KeywordOn) {
+		savedState := p.lexer.saveState()
+		_ = p.lexer.consumeToken()
+		var action *string
+		switch {
+		case p.tryConsumeKeywords(KeywordDelete) && foreignKey.OnDelete == "":
+			action = &foreignKey.OnDelete
+		case p.tryConsumeKeywords(KeywordUpdate) && foreignKey.OnUpdate == "":
+			action = &foreignKey.OnUpdate
+		default:
+			p.lexer.restoreState(savedState)
+			return foreignKey, nil
+		}
+		for _, words := range referenceOptions {
+			if end := p.tryConsumeWords(words...); end != 0 {
+				*action = strings.Join(words, " ")
+				foreignKey.StatementEnd = end
+				break
+			}
+		}
+		if *action == "" {
+			return nil, fmt.Errorf("expected keyword: RESTRICT|CASCADE|SET NULL|SET DEFAULT|NO ACTION, but got %q", p.lastTokenKind())
+		}
+	}
+	return foreignKey, nil
+}
+
+// referenceOptions are the actions of a foreign key ON DELETE and ON UPDATE.
+var referenceOptions = [][]string{{"RESTRICT"}, {"CASCADE"}, {"SET", "NULL"}, {"SET", "DEFAULT"}, {"NO", "ACTION"}}
+
+// parseParenColumnList parses `(columnExprList)` and returns the end of the closing parenthesis.
+func (p *Parser) parseParenColumnList() (*ColumnExprList, Pos, error) {
+	if err := p.expectTokenKind(TokenKindLParen); err != nil {
+		return nil, 0, err
+	}
+	columns, err := p.parseColumnExprList(p.Start())
+	if err != nil {
+		return nil, 0, err
+	}
+	end := p.End()
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, 0, err
+	}
+	return columns, end, nil
+}
+
+// Syntax: ALTER [COLUMN] nestedIdentifier (SET DEFAULT expr | DROP DEFAULT)
+func (p *Parser) parseAlterTableAlterColumn(pos Pos) (*AlterTableAlterColumn, error) {
+	if err := p.expectKeyword(KeywordAlter); err != nil {
+		return nil, err
+	}
+	if err := p.expectDialect("ALTER TABLE ALTER COLUMN", DialectMySQL, DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	_ = p.tryConsumeKeywords(KeywordColumn)
+	column, err := p.ParseNestedIdentifier(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	alterColumn := &AlterTableAlterColumn{AlterPos: pos, Column: column}
+	switch {
+	case p.tryConsumeKeywords(KeywordSet):
+		if err := p.expectKeyword(KeywordDefault); err != nil {
+			return nil, err
+		}
+		if alterColumn.Default, err = p.parseExpr(p.Start()); err != nil {
+			return nil, err
+		}
+		alterColumn.StatementEnd = alterColumn.Default.End()
+	case p.tryConsumeKeywords(KeywordDrop):
+		alterColumn.StatementEnd = p.End()
+		if err := p.expectKeyword(KeywordDefault); err != nil {
+			return nil, err
+		}
+		alterColumn.DropDefault = true
+	default:
+		return nil, fmt.Errorf("expected keyword: SET|DROP, but got %q", p.lastTokenKind())
+	}
+	return alterColumn, nil
+}
+
+// Syntax: ADD [FULLTEXT|SPATIAL] INDEX (IF NOT EXISTS)? tableIndex (AFTER nestedIdentifier)?
 func (p *Parser) parseAlterTableAddIndex(pos Pos) (*AlterTableAddIndex, error) {
 	indexPos := p.Start()
-	if err := p.expectKeyword(KeywordIndex); err != nil {
+	var kind string
+	for _, word := range []string{"FULLTEXT", "SPATIAL"} {
+		if p.tryConsumeWords(word) != 0 {
+			kind = word
+		}
+	}
+	if kind != "" {
+		if err := p.expectDialect(kind+" INDEX", DialectMySQL); err != nil {
+			return nil, err
+		}
+		// MySQL also writes FULLTEXT KEY or just FULLTEXT
+		if !p.tryConsumeKeywords(KeywordIndex) {
+			_ = p.tryConsumeKeywords(KeywordKey)
+		}
+	} else if err := p.expectKeyword(KeywordIndex); err != nil {
 		return nil, err
 	}
 
@@ -134,6 +423,7 @@ func (p *Parser) parseAlterTableAddIndex(pos Pos) (*AlterTableAddIndex, error) {
 	if err != nil {
 		return nil, err
 	}
+	index.Kind = kind
 	statementEnd := index.End()
 	after, err := p.tryParseAfterClause()
 	if err != nil {
@@ -258,10 +548,20 @@ func (p *Parser) parseTableIndex(pos Pos) (*TableIndex, error) {
 		return nil, err
 	}
 
+	// MySQL writes the index type USING BTREE|HASH before or after the columns
+	using, err := p.tryParseIndexUsing()
+	if err != nil {
+		return nil, err
+	}
 	columnExpr, err := p.parseColumnsExpr(p.Start())
 	if err != nil {
 		return nil, err
 	}
+	if using == nil {
+		if using, err = p.tryParseIndexUsing(); err != nil {
+			return nil, err
+		}
+	}
 
 	var columnType Expr
 	if p.tryConsumeKeywords(KeywordType) {
@@ -282,24 +582,65 @@ func (p *Parser) parseTableIndex(pos Pos) (*TableIndex, error) {
 	return &TableIndex{
 		IndexPos:    pos,
 		Name:        name,
+		Using:       using,
 		ColumnExpr:  columnExpr,
 		ColumnType:  columnType,
 		Granularity: granularity,
 	}, nil
 }
 
+func (p *Parser) tryParseIndexUsing() (*Ident, error) {
+	if !p.matchKeyword(KeywordUsing) {
+		return nil, nil // nolint
+	}
+	if err := p.expectDialect("index USING", DialectMySQL); err != nil {
+		return nil, err
+	}
+	_ = p.lexer.consumeToken()
+	return p.parseIdent()
+}
+
 func (p *Parser) parseAlterTableDrop(pos Pos) (AlterTableClause, error) {
 	if err := p.expectKeyword(KeywordDrop); err != nil {
 		return nil, err
 	}
 
 	switch {
-	case p.matchKeyword(KeywordColumn), p.matchKeyword(KeywordIndex), p.matchKeyword(KeywordProjection):
+	case p.matchKeyword(KeywordColumn), p.matchKeyword(KeywordIndex), p.matchKeyword(KeywordProjection),
+		p.matchKeyword(KeywordKey):
 		return p.parseAlterTableDropClause(pos)
 	case p.matchKeyword(KeywordDetached), p.matchKeyword(KeywordPartition):
 		return p.parseAlterTableDropPartition(pos)
+	case p.tryConsumeKeywords(KeywordPrimary):
+		keyEnd := p.End()
+		if err := p.expectKeyword(KeywordKey); err != nil {
+			return nil, err
+		}
+		return &AlterTableDropPrimaryKey{
+			DropPos: pos,
+			KeyEnd:  keyEnd,
+		}, nil
+	case p.tryConsumeWords("FOREIGN", KeywordKey) != 0:
+		name, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		return &AlterTableDropForeignKey{
+			DropPos: pos,
+			Name:    name,
+		}, nil
+	case p.matchTokenKind(TokenKindIdent):
+		// MySQL allows `DROP col` without the COLUMN keyword
+		name, err := p.ParseNestedIdentifier(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		return &AlterTableDropColumn{
+			DropPos:    pos,
+			ColumnName: name,
+		}, nil
 	default:
-		return nil, errors.New("expected keyword: COLUMN|INDEX|PROJECTION|DETACHED|PARTITION")
+		return nil, errors.New("expected keyword: COLUMN|INDEX|KEY|PRIMARY|FOREIGN|PROJECTION|DETACHED|PARTITION")
 	}
 }
 
@@ -337,6 +678,22 @@ func (p *Parser) tryParsePartitionClause(pos Pos) (*PartitionClause, error) {
 	return p.parsePartitionClause(pos)
 }
 
+// parsePartitionOrPart parses a partitionClause or PART 'name', a single data part of a partition.
+func (p *Parser) parsePartitionOrPart(pos Pos) (*PartitionClause, error) {
+	if p.tryConsumeWords("PART") == 0 {
+		return p.parsePartitionClause(pos)
+	}
+	name, err := p.parseString(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	return &PartitionClause{
+		PartitionPos: pos,
+		Expr:         name,
+		Part:         true,
+	}, nil
+}
+
 func (p *Parser) parsePartitionClause(pos Pos) (*PartitionClause, error) {
 	if err := p.expectKeyword(KeywordPartition); err != nil {
 		return nil, err
@@ -391,12 +748,12 @@ func (p *Parser) parseAlterTableDropClause(pos Pos) (AlterTableClause, error) {
 	switch {
 	case p.matchKeyword(KeywordColumn):
 		kind = KeywordColumn
-	case p.matchKeyword(KeywordIndex):
+	case p.matchKeyword(KeywordIndex), p.matchKeyword(KeywordKey):
 		kind = KeywordIndex
 	case p.matchKeyword(KeywordProjection):
 		kind = KeywordProjection
 	default:
-		return nil, fmt.Errorf("expected token: COLUMN|INDEX|PROJECTION, but got %s", p.lastTokenKind())
+		return nil, fmt.Errorf("expected token: COLUMN|INDEX|KEY|PROJECTION, but got %s", p.lastTokenKind())
 	}
 	_ = p.lexer.consumeToken()
 
@@ -440,6 +797,15 @@ func (p *Parser) tryParseAfterClause() (*NestedIdentifier, error) {
 	return p.ParseNestedIdentifier(p.Start())
 }
 
+// tryParseColumnPosition parses the MySQL column position: FIRST | AFTER nestedIdentifier
+func (p *Parser) tryParseColumnPosition() (bool, *NestedIdentifier, error) {
+	if p.tryConsumeKeywords(KeywordFirst) {
+		return true, nil, nil
+	}
+	after, err := p.tryParseAfterClause()
+	return false, after, err
+}
+
 // Syntax: ALTER TABLE DROP partitionClause
 func (p *Parser) parseAlterTableDropPartition(pos Pos) (AlterTableClause, error) {
 	var hasDetached bool
@@ -580,12 +946,32 @@ func (p *Parser) parseAlterTableClearClause(pos Pos) (AlterTableClause, error) {
 	}
 }
 
-// Syntax: ALTER TABLE RENAME COLUMN (IF EXISTS)? nestedIdentifier TO nestedIdentifier
-func (p *Parser) parseAlterTableRenameColumn(pos Pos) (AlterTableClause, error) {
+func (p *Parser) parseAlterTableRename(pos Pos) (AlterTableClause, error) {
 	if err := p.expectKeyword(KeywordRename); err != nil {
 		return nil, err
 	}
 
+	switch {
+	case p.matchKeyword(KeywordColumn):
+		return p.parseAlterTableRenameColumn(pos)
+	case p.matchKeyword(KeywordIndex), p.matchKeyword(KeywordKey):
+		return p.parseAlterTableRenameIndex(pos)
+	case p.tryConsumeKeywords(KeywordTo), p.tryConsumeKeywords(KeywordAs):
+		newName, err := p.parseTableIdentifier(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		return &AlterTableRenameTable{
+			RenamePos: pos,
+			NewName:   newName,
+		}, nil
+	default:
+		return nil, fmt.Errorf("expected keyword: COLUMN|INDEX|KEY|TO|AS, but got %q", p.lastTokenKind())
+	}
+}
+
+// Syntax: ALTER TABLE RENAME COLUMN (IF EXISTS)? nestedIdentifier TO nestedIdentifier
+func (p *Parser) parseAlterTableRenameColumn(pos Pos) (AlterTableClause, error) {
 	if err := p.expectKeyword(KeywordColumn); err != nil {
 		return nil, err
 	}
@@ -644,18 +1030,50 @@ func (p *Parser) parseAlterTableModify(pos Pos) (AlterTableClause, error) {
 			StatementEnd: selectQuery.End(),
 			SelectExpr:   selectQuery,
 		}, nil
+	case p.tryConsumeKeywords(KeywordSetting):
+		settings, err := p.parseSettingsClause(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		return &AlterTableModifySetting{
+			ModifyPos:    pos,
+			StatementEnd: settings.End(),
+			Settings:     settings.Items,
+		}, nil
+	case p.tryConsumeKeywords(KeywordOrder):
+		if err := p.expectKeyword(KeywordBy); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseExpr(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		return &AlterTableModifyOrderBy{
+			ModifyPos: pos,
+			Expr:      expr,
+		}, nil
+	case p.tryConsumeKeywords(KeywordComment):
+		comment, err := p.parseString(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		return &AlterTableModifyComment{
+			ModifyPos: pos,
+			Comment:   comment,
+		}, nil
+	case p.matchTokenKind(TokenKindIdent):
+		// MySQL allows `MODIFY col type` without the COLUMN keyword
+		return p.parseAlterTableModifyColumn(pos)
 	default:
-		return nil, fmt.Errorf("expected keyword: COLUMN|TTL|QUERY, but got %q",
-			p.last().String)
+		return nil, fmt.Errorf("expected keyword: COLUMN|TTL|QUERY|SETTING|ORDER|COMMENT, but got %q",
+			p.lastTokenKind())
 	}
 
 }
 
-// syntax: MODIFY COLUMN (IF EXISTS)? tableColumnDfnt
+// syntax: MODIFY [COLUMN] (IF EXISTS)? tableColumnDfnt (FIRST | AFTER nestedIdentifier)?
 func (p *Parser) parseAlterTableModifyColumn(pos Pos) (AlterTableClause, error) {
-	if err := p.expectKeyword(KeywordColumn); err != nil {
-		return nil, err
-	}
+	_ = p.tryConsumeKeywords(KeywordColumn)
 
 	ifExists, err := p.tryParseIfExists()
 	if err != nil {
@@ -681,6 +1099,22 @@ func (p *Parser) parseAlterTableModifyColumn(pos Pos) (AlterTableClause, error)
 		return nil, err
 	}
 	alterTableModifyColumn.RemovePropertyType = removePropertyType
+	if removePropertyType != nil {
+		alterTableModifyColumn.StatementEnd = removePropertyType.End()
+	}
+
+	firstEnd := p.End()
+	first, after, err := p.tryParseColumnPosition()
+	if err != nil {
+		return nil, err
+	}
+	if first {
+		alterTableModifyColumn.StatementEnd = firstEnd
+	} else if after != nil {
+		alterTableModifyColumn.StatementEnd = after.End()
+	}
+	alterTableModifyColumn.First = first
+	alterTableModifyColumn.After = after
 
 	return alterTableModifyColumn, nil
 }
@@ -780,3 +1214,364 @@ func (p *Parser) parseAlterTableMaterialize(pos Pos) (AlterTableClause, error) {
 		Partition:       partition,
 	}, nil
 }
+
+func (p *Parser) tryParseInPartitionClause() (*PartitionClause, error) {
+	if !p.matchKeyword(KeywordIn) || !p.peekKeyword(KeywordPartition) {
+		return nil, nil // nolint
+	}
+	_ = p.lexer.consumeToken()
+	return p.parsePartitionClause(p.Start())
+}
+
+func (p *Parser) parseAssignment(_ Pos) (*Assignment, error) {
+	column, err := p.ParseNestedIdentifier(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectTokenKind(TokenKindSingleEQ); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseExpr(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	return &Assignment{
+		Column: column,
+		Expr:   expr,
+	}, nil
+}
+
+// Syntax: ALTER TABLE UPDATE assignment (, assignment)* (IN partitionClause)? WHERE expr
+func (p *Parser) parseAlterTableUpdate(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordUpdate); err != nil {
+		return nil, err
+	}
+
+	assignments := make([]*Assignment, 0)
+	for {
+		assignment, err := p.parseAssignment(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			break
+		}
+	}
+
+	partition, err := p.tryParseInPartitionClause()
+	if err != nil {
+		return nil, err
+	}
+	where, err := p.parseWhereClause(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	return &AlterTableUpdate{
+		UpdatePos:   pos,
+		Assignments: assignments,
+		InPartition: partition,
+		Where:       where,
+	}, nil
+}
+
+// Syntax: ALTER TABLE DELETE (IN partitionClause)? WHERE expr
+func (p *Parser) parseAlterTableDelete(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordDelete); err != nil {
+		return nil, err
+	}
+
+	partition, err := p.tryParseInPartitionClause()
+	if err != nil {
+		return nil, err
+	}
+	where, err := p.parseWhereClause(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	return &AlterTableDelete{
+		DeletePos:   pos,
+		InPartition: partition,
+		Where:       where,
+	}, nil
+}
+
+// Syntax: ALTER TABLE MOVE (partitionClause | PART 'name') TO (DISK 'name' | VOLUME 'name' | TABLE tableIdentifier)
+func (p *Parser) parseAlterTableMovePartition(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordMove); err != nil {
+		return nil, err
+	}
+
+	partition, err := p.parsePartitionOrPart(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordTo); err != nil {
+		return nil, err
+	}
+
+	movePartition := &AlterTableMovePartition{
+		MovePos:   pos,
+		Partition: partition,
+	}
+	switch {
+	case p.tryConsumeKeywords(KeywordDisk):
+		movePartition.ToDisk, err = p.parseString(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		movePartition.StatementEnd = movePartition.ToDisk.End()
+	case p.tryConsumeKeywords(KeywordVolume):
+		movePartition.ToVolume, err = p.parseString(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		movePartition.StatementEnd = movePartition.ToVolume.End()
+	case p.tryConsumeKeywords(KeywordTable):
+		movePartition.ToTable, err = p.parseTableIdentifier(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		movePartition.StatementEnd = movePartition.ToTable.End()
+	default:
+		return nil, fmt.Errorf("expected keyword: DISK|VOLUME|TABLE, but got %q", p.lastTokenKind())
+	}
+	return movePartition, nil
+}
+
+// Syntax: ALTER TABLE FETCH (partitionClause | PART 'name') FROM 'path'
+func (p *Parser) parseAlterTableFetchPartition(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordFetch); err != nil {
+		return nil, err
+	}
+
+	partition, err := p.parsePartitionOrPart(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordFrom); err != nil {
+		return nil, err
+	}
+	from, err := p.parseString(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	return &AlterTableFetchPartition{
+		FetchPos:  pos,
+		Partition: partition,
+		From:      from,
+	}, nil
+}
+
+// Syntax: ALTER TABLE RESET SETTING ident (, ident)*
+func (p *Parser) parseAlterTableResetSetting(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordReset); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordSetting); err != nil {
+		return nil, err
+	}
+
+	settings := make([]*Ident, 0)
+	for {
+		name, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, name)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			break
+		}
+	}
+	return &AlterTableResetSetting{
+		ResetPos:     pos,
+		StatementEnd: settings[len(settings)-1].End(),
+		Settings:     settings,
+	}, nil
+}
+
+// Syntax: ALTER TABLE APPLY DELETED MASK (IN partitionClause)?
+func (p *Parser) parseAlterTableApplyDeletedMask(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordApply); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordDeleted); err != nil {
+		return nil, err
+	}
+	statementEnd := p.End()
+	if err := p.expectKeyword(KeywordMask); err != nil {
+		return nil, err
+	}
+
+	partition, err := p.tryParseInPartitionClause()
+	if err != nil {
+		return nil, err
+	}
+	if partition != nil {
+		statementEnd = partition.End()
+	}
+	return &AlterTableApplyDeletedMask{
+		ApplyPos:     pos,
+		StatementEnd: statementEnd,
+		InPartition:  partition,
+	}, nil
+}
+
+// Syntax: CHANGE [COLUMN] nestedIdentifier tableColumnDfnt (FIRST | AFTER nestedIdentifier)?
+func (p *Parser) parseAlterTableChangeColumn(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordChange); err != nil {
+		return nil, err
+	}
+	_ = p.tryConsumeKeywords(KeywordColumn)
+
+	oldColumnName, err := p.ParseNestedIdentifier(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	column, err := p.parseTableColumnExpr(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	statementEnd := column.End()
+
+	firstEnd := p.End()
+	first, after, err := p.tryParseColumnPosition()
+	if err != nil {
+		return nil, err
+	}
+	if first {
+		statementEnd = firstEnd
+	} else if after != nil {
+		statementEnd = after.End()
+	}
+
+	return &AlterTableChangeColumn{
+		ChangePos:     pos,
+		StatementEnd:  statementEnd,
+		OldColumnName: oldColumnName,
+		Column:        column,
+		First:         first,
+		After:         after,
+	}, nil
+}
+
+// Syntax: RENAME (INDEX|KEY) nestedIdentifier TO nestedIdentifier
+func (p *Parser) parseAlterTableRenameIndex(pos Pos) (AlterTableClause, error) {
+	if !p.tryConsumeKeywords(KeywordIndex) {
+		if err := p.expectKeyword(KeywordKey); err != nil {
+			return nil, err
+		}
+	}
+
+	oldIndexName, err := p.ParseNestedIdentifier(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordTo); err != nil {
+		return nil, err
+	}
+	newIndexName, err := p.ParseNestedIdentifier(p.Start())
+	if err != nil {
+		return nil, err
+	}
+
+	return &AlterTableRenameIndex{
+		RenamePos:    pos,
+		OldIndexName: oldIndexName,
+		NewIndexName: newIndexName,
+	}, nil
+}
+
+// Syntax: CONVERT TO (CHARACTER SET | CHARSET) ident (COLLATE ident)?
+func (p *Parser) parseAlterTableConvertCharset(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordConvert); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordTo); err != nil {
+		return nil, err
+	}
+	if !p.tryConsumeKeywords(KeywordCharset) && !p.tryConsumeKeywords(KeywordCharacter, KeywordSet) {
+		return nil, fmt.Errorf("expected keyword: CHARACTER SET|CHARSET, but got %q", p.lastTokenKind())
+	}
+
+	charset, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	statementEnd := charset.End()
+
+	var collate *Ident
+	if p.tryConsumeKeywords(KeywordCollate) {
+		collate, err = p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		statementEnd = collate.End()
+	}
+
+	return &AlterTableConvertCharset{
+		ConvertPos:   pos,
+		StatementEnd: statementEnd,
+		Charset:      charset,
+		Collate:      collate,
+	}, nil
+}
+
+// Syntax: ALGORITHM [=] (DEFAULT|INSTANT|INPLACE|COPY)
+func (p *Parser) parseAlterTableAlgorithm(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordAlgorithm); err != nil {
+		return nil, err
+	}
+	_ = p.tryConsumeTokenKind(TokenKindSingleEQ)
+
+	algorithm, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	return &AlterTableAlgorithm{
+		AlgorithmPos: pos,
+		Algorithm:    algorithm,
+	}, nil
+}
+
+// Syntax: LOCK [=] (DEFAULT|NONE|SHARED|EXCLUSIVE)
+func (p *Parser) parseAlterTableLock(pos Pos) (AlterTableClause, error) {
+	if err := p.expectKeyword(KeywordLock); err != nil {
+		return nil, err
+	}
+	_ = p.tryConsumeTokenKind(TokenKindSingleEQ)
+
+	lock, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	return &AlterTableLock{
+		LockPos: pos,
+		Lock:    lock,
+	}, nil
+}
+
+// Syntax: DISABLE KEYS|ENABLE KEYS
+func (p *Parser) parseAlterTableKeys(pos Pos) (AlterTableClause, error) {
+	enable := strings.EqualFold(p.last().String, "ENABLE")
+	_ = p.lexer.consumeToken()
+
+	statementEnd := p.tryConsumeWords("KEYS")
+	if statementEnd == 0 {
+		return nil, fmt.Errorf("expected keyword: KEYS, but got %q", p.lastTokenKind())
+	}
+	return &AlterTableKeys{
+		KeysPos:      pos,
+		StatementEnd: statementEnd,
+		Enable:       enable,
+	}, nil
+}
+
+// Syntax: tableOption, e.g. ENGINE = InnoDB, AUTO_INCREMENT = 100, DEFAULT CHARSET = utf8mb4
+func (p *Parser) parseAlterTableOption(pos Pos) (AlterTableClause, error) {
+	option, err := p.parseTableOption(pos)
+	if err != nil {
+		return nil, err
+	}
+	return &AlterTableOption{Option: option}, nil
+}
@@ -16,6 +16,7 @@ const (
 	PrecedenceCompare
 	PrecedenceBetweenLike
 	precedenceIn
+	PrecedenceConcat
 	PrecedenceAddSub
 	PrecedenceMulDivMod
 	PrecedenceBracket
@@ -35,6 +36,12 @@ func (p *Parser) getNextPrecedence() int {
 	switch {
 	case p.matchKeyword(KeywordOr):
 		return PrecedenceOr
+	case p.matchTokenKind(TokenKindConcat):
+		// MySQL reads `||` as a synonym of OR
+		if p.dialect == DialectMySQL {
+			return PrecedenceOr
+		}
+		return PrecedenceConcat
 	case p.matchKeyword(KeywordAnd):
 		return PrecedenceAnd
 	case p.matchKeyword(KeywordIs):
@@ -64,6 +71,10 @@ func (p *Parser) getNextPrecedence() int {
 	case p.matchKeyword(KeywordBetween), p.matchKeyword(KeywordLike), p.matchKeyword(KeywordIlike):
 		return PrecedenceBetweenLike
 	case p.matchKeyword(KeywordIn):
+		// `IN PARTITION` belongs to the enclosing ALTER TABLE mutation, not to the expression.
+		if p.peekKeyword(KeywordPartition) {
+			return PrecedenceUnknown
+		}
 		return precedenceIn
 	case p.matchKeyword(KeywordGlobal):
 		return PrecedenceGlobal
@@ -84,6 +95,16 @@ func (p *Parser) parseInfix(expr Expr, precedence int) (Expr, error) {
 		p.matchKeyword(KeywordIn), p.matchKeyword(KeywordLike),
 		p.matchKeyword(KeywordIlike), p.matchKeyword(KeywordAnd), p.matchKeyword(KeywordOr),
 		p.matchTokenKind(TokenKindArrow), p.matchTokenKind(TokenKindDoubleEQ):
+		if p.matchTokenKind(TokenKindArrow) {
+			if lambda, err := p.tryParseLambdaExpr(expr); lambda != nil || err != nil {
+				return lambda, err
+			}
+		}
+		if p.matchKeyword(KeywordIlike) {
+			if err := p.expectDialect("ILIKE", DialectClickHouse, DialectPostgreSQL); err != nil {
+				return nil, err
+			}
+		}
 		op := p.last().ToString()
 		_ = p.lexer.consumeToken()
 		rightExpr, err := p.parseSubExpr(p.Start(), precedence)
@@ -95,7 +116,25 @@ func (p *Parser) parseInfix(expr Expr, precedence int) (Expr, error) {
 			Operation: TokenKind(op),
 			RightExpr: rightExpr,
 		}, nil
+	case p.matchTokenKind(TokenKindConcat):
+		_ = p.lexer.consumeToken()
+		rightExpr, err := p.parseSubExpr(p.Start(), precedence)
+		if err != nil {
+			return nil, err
+		}
+		operation := TokenKindConcat
+		if p.dialect == DialectMySQL {
+			operation = KeywordOr
+		}
+		return &BinaryOperation{
+			LeftExpr:  expr,
+			Operation: operation,
+			RightExpr: rightExpr,
+		}, nil
 	case p.matchTokenKind(TokenKindDash):
+		if err := p.expectDialect("operator ::", DialectClickHouse, DialectPostgreSQL); err != nil {
+			return nil, err
+		}
 		_ = p.lexer.consumeToken()
 
 		if p.matchTokenKind(TokenKindIdent) && p.last().String == "Tuple" {
@@ -167,6 +206,9 @@ func (p *Parser) parseInfix(expr Expr, precedence int) (Expr, error) {
 		case p.matchKeyword(KeywordIn):
 		case p.matchKeyword(KeywordLike):
 		case p.matchKeyword(KeywordIlike):
+			if err := p.expectDialect("ILIKE", DialectClickHouse, DialectPostgreSQL); err != nil {
+				return nil, err
+			}
 		default:
 			return nil, fmt.Errorf("expected IN, LIKE or ILIKE after NOT, got %s", p.lastTokenKind())
 		}
@@ -239,6 +281,47 @@ func (p *Parser) parseSubExpr(pos Pos, precedence int) (Expr, error) {
 	return expr, nil
 }
 
+// tryParseLambdaExpr turns `params -> body` into a LambdaExpr when params is an
+// identifier or a parenthesized list of identifiers. MySQL reads `->` as the JSON
+// extraction operator, so it never has lambdas.
+func (p *Parser) tryParseLambdaExpr(params Expr) (*LambdaExpr, error) {
+	if p.dialect == DialectMySQL {
+		return nil, nil // nolint
+	}
+	lambda := &LambdaExpr{}
+	switch params := params.(type) {
+	case *Ident:
+		lambda.Params = []*Ident{params}
+	case *ParamExprList:
+		if params.ColumnArgList != nil || params.Items == nil {
+			return nil, nil // nolint
+		}
+		for _, item := range params.Items.Items {
+			if column, ok := item.(*ColumnExpr); ok && column.Alias == nil {
+				item = column.Expr
+			}
+			ident, ok := item.(*Ident)
+			if !ok {
+				return nil, nil // nolint
+			}
+			lambda.Params = append(lambda.Params, ident)
+		}
+		lambda.LeftParenPos = params.LeftParenPos
+		lambda.RightParenPos = params.RightParenPos
+	default:
+		return nil, nil // nolint
+	}
+	if err := p.expectTokenKind(TokenKindArrow); err != nil {
+		return nil, err
+	}
+	body, err := p.parseExpr(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	lambda.Body = body
+	return lambda, nil
+}
+
 func (p *Parser) parseTernaryExpr(condition Expr) (*TernaryOperation, error) {
 	if err := p.expectTokenKind(TokenKindQuestionMark); err != nil {
 		return nil, err
@@ -395,14 +478,52 @@ func (p *Parser) parseColumnExpr(pos Pos) (Expr, error) { //nolint:funlen
 		return p.parseMapLiteral(p.Start())
 	case p.matchTokenKind(TokenKindDot):
 		return p.parseNumber(p.Start())
+	case p.matchTokenKind(TokenKindParam):
+		// PostgreSQL positional parameter `$1`
+		if err := p.expectDialect("positional parameter", DialectPostgreSQL); err != nil {
+			return nil, err
+		}
+		param := p.last()
+		_ = p.lexer.consumeToken()
+		return &PlaceHolder{
+			PlaceholderPos: pos,
+			PlaceHolderEnd: param.End,
+			Type:           param.String,
+		}, nil
 	case p.matchTokenKind(TokenKindQuestionMark):
 		// Placeholder `?`
+		end := p.End()
 		_ = p.lexer.consumeToken()
 		return &PlaceHolder{
 			PlaceholderPos: pos,
-			PlaceHolderEnd: pos,
+			PlaceHolderEnd: end,
 			Type:           string(TokenKindQuestionMark),
 		}, nil
+	case p.matchTokenKind(TokenKindColon):
+		return p.parseNamedParam(pos)
+	case p.matchTokenKind(TokenKindAtSign):
+		variable, err := p.parseVariableExpr(pos)
+		if err != nil {
+			return nil, err
+		}
+		// MySQL assigns user variables inside expressions with `@a := 1`
+		if variable.System || !p.matchTokenKind(TokenKindColon) || !p.peekTokenKind(TokenKindSingleEQ) {
+			return variable, nil
+		}
+		if err := p.expectDialect(":=", DialectMySQL); err != nil {
+			return nil, err
+		}
+		_ = p.lexer.consumeToken()
+		_ = p.lexer.consumeToken()
+		value, err := p.parseExpr(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		return &VariableAssignment{
+			Name:     variable,
+			Operator: ":=",
+			Value:    value,
+		}, nil
 	default:
 		return nil, fmt.Errorf("unexpected token kind: %s", p.lastTokenKind())
 	}
@@ -666,10 +787,29 @@ func (p *Parser) parseMapLiteral(pos Pos) (*MapLiteral, error) {
 	}, nil
 }
 
+// Syntax: :name
+func (p *Parser) parseNamedParam(pos Pos) (*NamedParam, error) {
+	colonEnd := p.End()
+	if err := p.expectTokenKind(TokenKindColon); err != nil {
+		return nil, err
+	}
+	if p.last() == nil || p.Start() != colonEnd {
+		return nil, fmt.Errorf("expected a parameter name right after :")
+	}
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	return &NamedParam{ColonPos: pos, Name: name}, nil
+}
+
 func (p *Parser) parseQueryParam(pos Pos) (*QueryParam, error) {
 	if err := p.expectTokenKind(TokenKindLBrace); err != nil {
 		return nil, err
 	}
+	if err := p.expectDialect("query parameter", DialectClickHouse); err != nil {
+		return nil, err
+	}
 
 	ident, err := p.parseIdent()
 	if err != nil {
@@ -732,6 +872,30 @@ func (p *Parser) parseColumnsExpr(pos Pos) (*ColumnExpr, error) {
 	}, nil
 }
 
+// parseBareExceptModifier parses `EXCEPT column`, which is kept as `EXCEPT(column)`.
+func (p *Parser) parseBareExceptModifier() (*FunctionExpr, error) {
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	column, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	return &FunctionExpr{
+		Name: name,
+		Params: &ParamExprList{
+			LeftParenPos:  column.Start(),
+			RightParenPos: column.End(),
+			Items: &ColumnExprList{
+				ListPos: column.Start(),
+				ListEnd: column.End(),
+				Items:   []Expr{column},
+			},
+		},
+	}, nil
+}
+
 func (p *Parser) parseSelectItem() (*SelectItem, error) {
 	expr, err := p.parseExpr(p.Start())
 	if err != nil {
@@ -740,15 +904,22 @@ func (p *Parser) parseSelectItem() (*SelectItem, error) {
 
 	modifiers := make([]*FunctionExpr, 0)
 	for {
-		if p.matchKeyword(KeywordExcept) || p.matchKeyword(KeywordApply) || p.matchKeyword(KeywordReplace) {
-			modifier, err := p.parseFunctionExpr(p.Start())
-			if err != nil {
-				return nil, err
-			}
-			modifiers = append(modifiers, modifier)
-		} else {
+		var modifier *FunctionExpr
+		switch {
+		case p.matchKeyword(KeywordExcept) && p.peekTokenKind(TokenKindIdent):
+			modifier, err = p.parseBareExceptModifier()
+		case p.matchKeyword(KeywordExcept) && !p.peekTokenKind(TokenKindLParen):
+			// EXCEPT [ALL | DISTINCT] SELECT is a set operation
+		case p.matchKeyword(KeywordExcept), p.matchKeyword(KeywordApply), p.matchKeyword(KeywordReplace):
+			modifier, err = p.parseFunctionExpr(p.Start())
+		}
+		if err != nil {
+			return nil, err
+		}
+		if modifier == nil {
 			break
 		}
+		modifiers = append(modifiers, modifier)
 	}
 
 	var alias *Ident
@@ -830,7 +1001,34 @@ func (p *Parser) parseColumnCaseExpr(pos Pos) (*CaseExpr, error) {
 	return caseExpr, nil
 }
 
-func (p *Parser) parseColumnType(_ Pos) (ColumnType, error) { // nolint:funlen
+func (p *Parser) parseColumnType(pos Pos) (ColumnType, error) {
+	columnType, err := p.parseColumnTypeName(pos)
+	if err != nil {
+		return nil, err
+	}
+	// PostgreSQL array types, e.g. int[] or text[3][3]
+	for p.matchTokenKind(TokenKindLBracket) {
+		if err := p.expectDialect("array type suffix", DialectPostgreSQL); err != nil {
+			return nil, err
+		}
+		_ = p.lexer.consumeToken()
+		arrayType := &ArrayType{ElementType: columnType}
+		if p.matchTokenKind(TokenKindInt) {
+			arrayType.Size, err = p.parseDecimal(p.Start())
+			if err != nil {
+				return nil, err
+			}
+		}
+		arrayType.RightBracketPos = p.End()
+		if err := p.expectTokenKind(TokenKindRBracket); err != nil {
+			return nil, err
+		}
+		columnType = arrayType
+	}
+	return columnType, nil
+}
+
+func (p *Parser) parseColumnTypeName(_ Pos) (ColumnType, error) { // nolint:funlen
 	ident, err := p.parseIdent()
 	if err != nil {
 		return nil, err
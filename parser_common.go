@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 type Parser struct {
-	lexer *Lexer
+	lexer   *Lexer
+	dialect Dialect
 }
 
 func NewParser(buffer string) *Parser {
@@ -106,11 +108,20 @@ func (p *Parser) tryParseIdent() *Ident {
 	return &Ident{
 		start:     lastToken.Pos,
 		end:       lastToken.End,
-		Name:      lastToken.String,
+		Name:      p.identName(lastToken),
 		QuoteType: lastToken.QuoteType,
 	}
 }
 
+// identName returns the name of an identifier token, PostgreSQL folds unquoted identifiers to
+// lower case while quoted ones are case-sensitive.
+func (p *Parser) identName(token *Token) string {
+	if p.dialect == DialectPostgreSQL && token.QuoteType == Unquoted {
+		return strings.ToLower(token.String)
+	}
+	return token.String
+}
+
 func (p *Parser) parseIdent() (*Ident, error) {
 	lastToken := p.last()
 	if err := p.expectTokenKind(TokenKindIdent); err != nil {
@@ -119,7 +130,7 @@ func (p *Parser) parseIdent() (*Ident, error) {
 	ident := &Ident{
 		start:     lastToken.Pos,
 		end:       lastToken.End,
-		Name:      lastToken.String,
+		Name:      p.identName(lastToken),
 		QuoteType: lastToken.QuoteType,
 	}
 	return ident, nil
@@ -329,6 +340,9 @@ func (p *Parser) parseFormat(pos Pos) (*FormatClause, error) {
 	if err := p.expectKeyword(KeywordFormat); err != nil {
 		return nil, err
 	}
+	if err := p.expectDialect("FORMAT", DialectClickHouse); err != nil {
+		return nil, err
+	}
 	formatIdent, err := p.parseIdent()
 	if err != nil {
 		return nil, err
@@ -347,8 +361,9 @@ func (p *Parser) wrapError(err error) error {
 	lineNo := 0
 	column := 0
 
-	for i := 0; i < int(p.Start()); i++ {
-		if p.lexer.input[i] == '\n' {
+	// columns count runes, not bytes
+	for _, r := range p.lexer.input[:p.Start()] {
+		if r == '\n' {
 			lineNo++
 			column = 0
 		} else {
@@ -367,7 +382,7 @@ func (p *Parser) wrapError(err error) error {
 				buf.WriteByte(' ')
 			}
 			if p.last() != nil {
-				buf.WriteString(strings.Repeat("^", len(p.last().String)))
+				buf.WriteString(strings.Repeat("^", utf8.RuneCountInString(p.last().String)))
 			} else {
 				buf.WriteString("^")
 			}
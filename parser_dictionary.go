@@ -0,0 +1,300 @@
+package parser
+
+// parseCreateDictionary parses a CREATE DICTIONARY statement.
+//
+// The syntax is as follows:
+// CREATE [OR REPLACE] DICTIONARY [IF NOT EXISTS] [db.]dictionary_name [ON CLUSTER cluster]
+// (
+//
+//	key1 type1 [DEFAULT|EXPRESSION expr1] [IS_OBJECT_ID],
+//	attr1 type2 [DEFAULT|EXPRESSION expr2] [HIERARCHICAL|INJECTIVE],
+//
+// )
+// PRIMARY KEY key1, key2
+// SOURCE(SOURCE_TYPE(param1 value1 ... paramN valueN))
+// LAYOUT(LAYOUT_TYPE(param_name param_value))
+// LIFETIME({MIN min_value MAX max_value | MAX max_value MIN min_value | max_value})
+// [RANGE(MIN range_min MAX range_max)]
+// [SETTINGS(setting_name = setting_value, ...)]
+// [COMMENT 'comment']
+//
+// The clauses after the attribute list may come in any order.
+func (p *Parser) parseCreateDictionary(pos Pos, orReplace bool) (*CreateDictionary, error) {
+	if err := p.expectDialect("CREATE DICTIONARY", DialectClickHouse); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordDictionary); err != nil {
+		return nil, err
+	}
+
+	createDictionary := &CreateDictionary{CreatePos: pos, OrReplace: orReplace}
+	var err error
+	createDictionary.IfNotExists, err = p.tryParseIfNotExists()
+	if err != nil {
+		return nil, err
+	}
+	createDictionary.Name, err = p.parseTableIdentifier(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	createDictionary.OnCluster, err = p.tryParseClusterClause(p.Start())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectTokenKind(TokenKindLParen); err != nil {
+		return nil, err
+	}
+	for !p.lexer.isEOF() && !p.matchTokenKind(TokenKindRParen) {
+		attribute, err := p.parseDictionaryAttribute()
+		if err != nil {
+			return nil, err
+		}
+		createDictionary.Attributes = append(createDictionary.Attributes, attribute)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			break
+		}
+	}
+	createDictionary.StatementEnd = p.End()
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, err
+	}
+
+	for {
+		var end Pos
+		switch {
+		case p.matchKeyword(KeywordPrimary) && createDictionary.PrimaryKey == nil:
+			primaryPos := p.Start()
+			_ = p.lexer.consumeToken()
+			if err := p.expectKeyword(KeywordKey); err != nil {
+				return nil, err
+			}
+			keys, err := p.parseColumnExprList(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			createDictionary.PrimaryKey = &PrimaryKeyClause{PrimaryPos: primaryPos, Expr: keys}
+			end = keys.End()
+		case p.matchKeyword(KeywordSource) && createDictionary.Source == nil:
+			source := &DictionarySourceClause{SourcePos: p.Start()}
+			_ = p.lexer.consumeToken()
+			source.Source, source.Args, source.RParenPos, err = p.parseDictionaryArgs()
+			if err != nil {
+				return nil, err
+			}
+			createDictionary.Source = source
+			end = source.End()
+		case p.matchKeyword(KeywordLayout) && createDictionary.Layout == nil:
+			layout := &DictionaryLayoutClause{LayoutPos: p.Start()}
+			_ = p.lexer.consumeToken()
+			layout.Layout, layout.Args, layout.RParenPos, err = p.parseDictionaryArgs()
+			if err != nil {
+				return nil, err
+			}
+			createDictionary.Layout = layout
+			end = layout.End()
+		case p.matchKeyword(KeywordLifetime) && createDictionary.Lifetime == nil:
+			createDictionary.Lifetime, err = p.parseDictionaryLifetime(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			end = createDictionary.Lifetime.End()
+		case p.matchKeyword(KeywordRange) && createDictionary.Range == nil:
+			createDictionary.Range, err = p.parseDictionaryRange(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			end = createDictionary.Range.End()
+		case p.matchKeyword(KeywordSettings) && createDictionary.Settings == nil:
+			settingsPos := p.Start()
+			_ = p.lexer.consumeToken()
+			if err := p.expectTokenKind(TokenKindLParen); err != nil {
+				return nil, err
+			}
+			createDictionary.Settings, err = p.parseSettingsClause(settingsPos)
+			if err != nil {
+				return nil, err
+			}
+			end = p.End()
+			if err := p.expectTokenKind(TokenKindRParen); err != nil {
+				return nil, err
+			}
+		case p.matchKeyword(KeywordComment) && createDictionary.Comment == nil:
+			createDictionary.Comment, err = p.tryParseComment()
+			if err != nil {
+				return nil, err
+			}
+			end = createDictionary.Comment.End()
+		default:
+			return createDictionary, nil
+		}
+		createDictionary.StatementEnd = end
+	}
+}
+
+// Syntax: name type [DEFAULT expr] [EXPRESSION expr] [HIERARCHICAL] [INJECTIVE] [IS_OBJECT_ID]
+func (p *Parser) parseDictionaryAttribute() (*DictionaryAttribute, error) {
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	columnType, err := p.parseColumnType(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	attribute := &DictionaryAttribute{
+		Name:         name,
+		Type:         columnType,
+		AttributeEnd: columnType.End(),
+	}
+	for {
+		switch {
+		case p.tryConsumeKeywords(KeywordDefault):
+			if attribute.Default, err = p.parseExpr(p.Start()); err != nil {
+				return nil, err
+			}
+			attribute.AttributeEnd = attribute.Default.End()
+			continue
+		case p.tryConsumeKeywords(KeywordExpression):
+			if attribute.Expression, err = p.parseExpr(p.Start()); err != nil {
+				return nil, err
+			}
+			attribute.AttributeEnd = attribute.Expression.End()
+			continue
+		case p.matchKeyword(KeywordHierarchical):
+			attribute.Hierarchical = true
+		case p.matchKeyword(KeywordInjective):
+			attribute.Injective = true
+		case p.matchKeyword(KeywordIs_object_id):
+			attribute.IsObjectID = true
+		default:
+			return attribute, nil
+		}
+		attribute.AttributeEnd = p.End()
+		_ = p.lexer.consumeToken()
+	}
+}
+
+// parseDictionaryArgs parses the `(TYPE(name value ...))` part of SOURCE and LAYOUT.
+func (p *Parser) parseDictionaryArgs() (*Ident, []*DictionaryArg, Pos, error) {
+	if err := p.expectTokenKind(TokenKindLParen); err != nil {
+		return nil, nil, 0, err
+	}
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	args, _, err := p.parseDictionaryArgList()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	rightParenPos := p.End()
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, nil, 0, err
+	}
+	return name, args, rightParenPos, nil
+}
+
+// parseDictionaryArgList parses `(name value ...)`, a parameter may be a nested list such as
+// `replica(host 'a' priority 1)`. It returns the end of the closing parenthesis.
+func (p *Parser) parseDictionaryArgList() ([]*DictionaryArg, Pos, error) {
+	if err := p.expectTokenKind(TokenKindLParen); err != nil {
+		return nil, 0, err
+	}
+	var args []*DictionaryArg
+	for !p.lexer.isEOF() && !p.matchTokenKind(TokenKindRParen) {
+		argName, err := p.parseIdent()
+		if err != nil {
+			return nil, 0, err
+		}
+		arg := &DictionaryArg{Name: argName}
+		if p.matchTokenKind(TokenKindLParen) {
+			arg.Args, arg.RParenPos, err = p.parseDictionaryArgList()
+		} else {
+			arg.Value, err = p.parseExpr(p.Start())
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		args = append(args, arg)
+		// ClickHouse also accepts commas between the parameters
+		_ = p.tryConsumeTokenKind(TokenKindComma)
+	}
+	rightParenPos := p.End()
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, 0, err
+	}
+	return args, rightParenPos, nil
+}
+
+// Syntax: LIFETIME({MIN min_value MAX max_value | MAX max_value MIN min_value | max_value})
+func (p *Parser) parseDictionaryLifetime(pos Pos) (*DictionaryLifetimeClause, error) {
+	if err := p.expectKeyword(KeywordLifetime); err != nil {
+		return nil, err
+	}
+	if err := p.expectTokenKind(TokenKindLParen); err != nil {
+		return nil, err
+	}
+	lifetime := &DictionaryLifetimeClause{LifetimePos: pos}
+	var err error
+	switch {
+	case p.tryConsumeKeywords(KeywordMin):
+		if lifetime.Min, err = p.parseNumber(p.Start()); err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword(KeywordMax); err != nil {
+			return nil, err
+		}
+		if lifetime.Max, err = p.parseNumber(p.Start()); err != nil {
+			return nil, err
+		}
+	case p.tryConsumeKeywords(KeywordMax):
+		if lifetime.Max, err = p.parseNumber(p.Start()); err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword(KeywordMin); err != nil {
+			return nil, err
+		}
+		if lifetime.Min, err = p.parseNumber(p.Start()); err != nil {
+			return nil, err
+		}
+	default:
+		if lifetime.Max, err = p.parseNumber(p.Start()); err != nil {
+			return nil, err
+		}
+	}
+	lifetime.RParenPos = p.End()
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, err
+	}
+	return lifetime, nil
+}
+
+// Syntax: RANGE(MIN range_min MAX range_max)
+func (p *Parser) parseDictionaryRange(pos Pos) (*DictionaryRangeClause, error) {
+	if err := p.expectKeyword(KeywordRange); err != nil {
+		return nil, err
+	}
+	if err := p.expectTokenKind(TokenKindLParen); err != nil {
+		return nil, err
+	}
+	dictionaryRange := &DictionaryRangeClause{RangePos: pos}
+	var err error
+	if err := p.expectKeyword(KeywordMin); err != nil {
+		return nil, err
+	}
+	if dictionaryRange.Min, err = p.parseIdent(); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordMax); err != nil {
+		return nil, err
+	}
+	if dictionaryRange.Max, err = p.parseIdent(); err != nil {
+		return nil, err
+	}
+	dictionaryRange.RParenPos = p.End()
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, err
+	}
+	return dictionaryRange, nil
+}
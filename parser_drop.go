@@ -1,6 +1,6 @@
 package parser
 
-func (p *Parser) parseDropDatabase(pos Pos) (*DropDatabase, error) {
+func (p *Parser) parseDropDatabase(pos Pos, detach bool) (*DropDatabase, error) {
 	if err := p.expectKeyword(KeywordDatabase); err != nil {
 		return nil, err
 	}
@@ -31,10 +31,11 @@ func (p *Parser) parseDropDatabase(pos Pos) (*DropDatabase, error) {
 		IfExists:     isExists,
 		OnCluster:    onCluster,
 		StatementEnd: statementEnd,
+		IsDetach:     detach,
 	}, nil
 }
 
-func (p *Parser) parseDropStmt(pos Pos) (*DropStmt, error) {
+func (p *Parser) parseDropStmt(pos Pos, detach bool) (*DropStmt, error) {
 	var isTemporary bool
 	dropTarget := KeywordTable
 	switch {
@@ -78,6 +79,7 @@ func (p *Parser) parseDropStmt(pos Pos) (*DropStmt, error) {
 		IsTemporary:  isTemporary,
 		Modifier:     modifier,
 		StatementEnd: p.Start(),
+		IsDetach:     detach,
 	}, nil
 }
 
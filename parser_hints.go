@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tryParseOptimizerHints parses the hint comment between the keyword ending at keywordEnd
+// and the current token. Outside of MySQL a hint is a plain comment.
+func (p *Parser) tryParseOptimizerHints(keywordEnd Pos) (*OptimizerHints, error) {
+	if p.dialect != DialectDefault && p.dialect != DialectMySQL {
+		return nil, nil // nolint
+	}
+	end := len(p.lexer.input)
+	if p.last() != nil {
+		end = int(p.Start())
+	}
+	lexer := &Lexer{input: p.lexer.input[:end], dialect: p.dialect}
+	lexer.current = int(keywordEnd)
+	// an unterminated hint is reported by parseOptimizerHints
+	for trivia, _ := lexer.consumeTrivia(); trivia != nil; trivia, _ = lexer.consumeTrivia() {
+		if trivia.Kind == TokenKindWhitespace {
+			continue
+		}
+		if !strings.HasPrefix(trivia.String, "/*+") {
+			break
+		}
+		return parseOptimizerHints(p.lexer.input, trivia.Pos, trivia.End)
+	}
+	return nil, nil // nolint
+}
+
+// parseOptimizerHints parses the hint comment input[start:end].
+//
+// Syntax: /*+ hint_name([arg [, arg] ...]) [hint_name(...) ...] */
+func parseOptimizerHints(input string, start, end Pos) (*OptimizerHints, error) {
+	if !strings.HasSuffix(input[start:end], "*/") || end-start < 5 {
+		return nil, fmt.Errorf("unterminated optimizer hint at %d", start)
+	}
+	hints := &OptimizerHints{HintPos: start, HintEnd: end}
+	i, bodyEnd := int(start)+3, int(end)-2
+	skipSpace := func() {
+		for i < bodyEnd && strings.IndexByte(" \t\r\n", input[i]) >= 0 {
+			i++
+		}
+	}
+	for skipSpace(); i < bodyEnd; skipSpace() {
+		nameStart := i
+		for i < bodyEnd && IsIdentPart(input[i]) {
+			i++
+		}
+		if i == nameStart || !IsIdentStart(input[nameStart]) {
+			return nil, fmt.Errorf("expected optimizer hint name at %d", nameStart)
+		}
+		hint := &OptimizerHint{
+			Name: &Ident{Name: input[nameStart:i], QuoteType: Unquoted, start: Pos(nameStart), end: Pos(i)},
+		}
+		skipSpace()
+		if i >= bodyEnd || input[i] != '(' {
+			return nil, fmt.Errorf("expected ( after optimizer hint %s", hint.Name.Name)
+		}
+		i++
+
+		// the arguments are kept as written, only quotes and nested parentheses are tracked
+		depth, argStart := 0, i
+		for ; i < bodyEnd; i++ {
+			c := input[i]
+			switch {
+			case c == '\'' || c == '"' || c == '`':
+				closing := strings.IndexByte(input[i+1:bodyEnd], c)
+				if closing < 0 {
+					return nil, fmt.Errorf("unterminated quote in optimizer hint %s", hint.Name.Name)
+				}
+				i += closing + 1
+				continue
+			case c == '(':
+				depth++
+				continue
+			case c == ')' && depth > 0:
+				depth--
+				continue
+			case c != ',' && c != ')' || depth > 0:
+				continue
+			}
+			if arg := strings.TrimSpace(input[argStart:i]); arg != "" {
+				hint.Args = append(hint.Args, arg)
+			}
+			argStart = i + 1
+			if c == ')' {
+				break
+			}
+		}
+		if i >= bodyEnd {
+			return nil, fmt.Errorf("expected ) to close optimizer hint %s", hint.Name.Name)
+		}
+		hint.RightParen = Pos(i)
+		i++
+		hints.Hints = append(hints.Hints, hint)
+	}
+	return hints, nil
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 func (p *Parser) tryParseWithClause(pos Pos) (*WithClause, error) {
@@ -96,13 +97,19 @@ func (p *Parser) parseFromClause(pos Pos) (*FromClause, error) {
 func (p *Parser) tryParseJoinConstraints(pos Pos) (Expr, error) {
 	switch {
 	case p.tryConsumeKeywords(KeywordOn):
-		columnExprList, err := p.parseColumnExprList(p.Start())
+		// ON takes a single condition, a comma after it starts the next table
+		listPos := p.Start()
+		condition, err := p.parseColumnsExpr(listPos)
 		if err != nil {
 			return nil, err
 		}
 		return &OnClause{
 			OnPos: pos,
-			On:    columnExprList,
+			On: &ColumnExprList{
+				ListPos: listPos,
+				ListEnd: condition.End(),
+				Items:   []Expr{condition},
+			},
 		}, nil
 	case p.tryConsumeKeywords(KeywordUsing):
 		hasParen := p.tryConsumeTokenKind(TokenKindLParen) != nil
@@ -233,6 +240,13 @@ func (p *Parser) parseJoinRightExpr(pos Pos) (expr Expr, err error) {
 	if len(modifiers) != 0 && !p.matchKeyword(KeywordJoin) {
 		return nil, fmt.Errorf("expected JOIN, got %s", p.lastTokenKind())
 	}
+	for _, modifier := range modifiers {
+		if strings.EqualFold(modifier, KeywordArray) {
+			if err := p.expectDialect("ARRAY JOIN", DialectClickHouse); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if !p.tryConsumeKeywords(KeywordJoin) {
 		return nil, nil
 	}
@@ -479,7 +493,11 @@ func (p *Parser) parseLimitClause(pos Pos) (*LimitClause, error) {
 	var offset Expr
 	if p.tryConsumeKeywords(KeywordOffset) {
 		offset, err = p.parseExpr(p.Start())
-	} else if p.tryConsumeTokenKind(TokenKindComma) != nil {
+	} else if p.matchTokenKind(TokenKindComma) {
+		if err := p.expectDialect("LIMIT offset, count", DialectMySQL, DialectClickHouse); err != nil {
+			return nil, err
+		}
+		_ = p.lexer.consumeToken()
 		offset = limit
 		limit, err = p.parseExpr(p.Start())
 	}
@@ -487,13 +505,205 @@ func (p *Parser) parseLimitClause(pos Pos) (*LimitClause, error) {
 		return nil, err
 	}
 
-	return &LimitClause{
+	limitClause := &LimitClause{
 		LimitPos: pos,
 		Limit:    limit,
 		Offset:   offset,
+	}
+	if p.matchKeyword(KeywordWith) && p.peekKeyword(KeywordTies) {
+		if err := p.expectDialect("LIMIT WITH TIES", DialectClickHouse, DialectPostgreSQL); err != nil {
+			return nil, err
+		}
+		_ = p.lexer.consumeToken()
+		limitClause.WithTies = true
+		limitClause.TiesEnd = p.End()
+		_ = p.lexer.consumeToken()
+	}
+	return limitClause, nil
+}
+
+func (p *Parser) tryParseQualifyClause(pos Pos) (*QualifyClause, error) {
+	if !p.matchKeyword(KeywordQualify) {
+		return nil, nil // nolint
+	}
+	if err := p.expectDialect("QUALIFY", DialectClickHouse); err != nil {
+		return nil, err
+	}
+	_ = p.lexer.consumeToken()
+
+	expr, err := p.parseExpr(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	return &QualifyClause{
+		QualifyPos: pos,
+		Expr:       expr,
 	}, nil
 }
 
+// Syntax: OFFSET expr [ROW|ROWS]
+func (p *Parser) parseOffsetClause(pos Pos) (*OffsetClause, error) {
+	if err := p.expectKeyword(KeywordOffset); err != nil {
+		return nil, err
+	}
+	offset, err := p.parseExpr(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	offsetClause := &OffsetClause{
+		OffsetPos: pos,
+		OffsetEnd: offset.End(),
+		Offset:    offset,
+	}
+	if p.matchKeyword(KeywordRow) || p.matchKeyword(KeywordRows) {
+		offsetClause.Unit = strings.ToUpper(p.last().String)
+		offsetClause.OffsetEnd = p.End()
+		_ = p.lexer.consumeToken()
+	}
+	return offsetClause, nil
+}
+
+// Syntax: FETCH {FIRST|NEXT} [expr] {ROW|ROWS} {ONLY|WITH TIES}
+func (p *Parser) parseFetchClause(pos Pos) (*FetchClause, error) {
+	if err := p.expectDialect("FETCH", DialectClickHouse, DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordFetch); err != nil {
+		return nil, err
+	}
+	fetch := &FetchClause{FetchPos: pos}
+	switch {
+	case p.matchKeyword(KeywordFirst), p.matchKeyword(KeywordNext):
+		fetch.Position = strings.ToUpper(p.last().String)
+		_ = p.lexer.consumeToken()
+	default:
+		return nil, fmt.Errorf("expected keyword: FIRST|NEXT, but got %q", p.lastTokenKind())
+	}
+
+	if !p.matchKeyword(KeywordRow) && !p.matchKeyword(KeywordRows) {
+		count, err := p.parseExpr(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		fetch.Count = count
+	}
+	if !p.matchKeyword(KeywordRow) && !p.matchKeyword(KeywordRows) {
+		return nil, fmt.Errorf("expected keyword: ROW|ROWS, but got %q", p.lastTokenKind())
+	}
+	fetch.Unit = strings.ToUpper(p.last().String)
+	_ = p.lexer.consumeToken()
+
+	switch {
+	case p.matchKeyword(KeywordOnly):
+		fetch.FetchEnd = p.End()
+		_ = p.lexer.consumeToken()
+	case p.tryConsumeKeywords(KeywordWith):
+		fetch.FetchEnd = p.End()
+		if err := p.expectKeyword(KeywordTies); err != nil {
+			return nil, err
+		}
+		fetch.WithTies = true
+	default:
+		return nil, fmt.Errorf("expected keyword: ONLY|WITH TIES, but got %q", p.lastTokenKind())
+	}
+	return fetch, nil
+}
+
+// Syntax: FOR {UPDATE|SHARE} [OF table, ...] [NOWAIT|SKIP LOCKED] | LOCK IN SHARE MODE
+func (p *Parser) tryParseLockingClause(pos Pos) (*LockingClause, error) {
+	locking := &LockingClause{LockPos: pos}
+	switch {
+	case p.matchKeyword(KeywordFor) && (p.peekKeyword(KeywordUpdate) || p.peekKeyword(KeywordShare)):
+		if err := p.expectDialect("FOR UPDATE", DialectMySQL, DialectPostgreSQL); err != nil {
+			return nil, err
+		}
+		_ = p.lexer.consumeToken()
+		locking.Mode = LockModeForUpdate
+		if p.matchKeyword(KeywordShare) {
+			locking.Mode = LockModeForShare
+		}
+		locking.LockEnd = p.End()
+		_ = p.lexer.consumeToken()
+	case p.matchKeyword(KeywordLock) && p.peekKeyword(KeywordIn):
+		if err := p.expectDialect("LOCK IN SHARE MODE", DialectMySQL); err != nil {
+			return nil, err
+		}
+		_ = p.lexer.consumeToken()
+		_ = p.lexer.consumeToken()
+		if err := p.expectKeyword(KeywordShare); err != nil {
+			return nil, err
+		}
+		locking.Mode = LockModeLockInShareMode
+		locking.LockEnd = p.End()
+		if err := p.expectKeyword(KeywordMode); err != nil {
+			return nil, err
+		}
+		return locking, nil
+	default:
+		return nil, nil // nolint
+	}
+
+	if p.tryConsumeKeywords(KeywordOf) {
+		for {
+			table, err := p.parseTableIdentifier(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			locking.Tables = append(locking.Tables, table)
+			locking.LockEnd = table.End()
+			if p.tryConsumeTokenKind(TokenKindComma) == nil {
+				break
+			}
+		}
+	}
+	switch {
+	case p.matchKeyword(KeywordNowait):
+		locking.Wait = LockWaitNowait
+		locking.LockEnd = p.End()
+		_ = p.lexer.consumeToken()
+	case p.tryConsumeKeywords(KeywordSkip):
+		locking.Wait = LockWaitSkipLocked
+		locking.LockEnd = p.End()
+		if err := p.expectKeyword(KeywordLocked); err != nil {
+			return nil, err
+		}
+	}
+	return locking, nil
+}
+
+// Syntax: INTO OUTFILE 'file' [COMPRESSION 'method' [LEVEL n]]
+func (p *Parser) tryParseIntoOutfileClause(pos Pos) (*IntoOutfileClause, error) {
+	if !p.matchKeyword(KeywordInto) || !p.peekKeyword(KeywordOutfile) {
+		return nil, nil // nolint
+	}
+	if err := p.expectDialect("INTO OUTFILE", DialectClickHouse, DialectMySQL); err != nil {
+		return nil, err
+	}
+	_ = p.lexer.consumeToken()
+	_ = p.lexer.consumeToken()
+
+	file, err := p.parseString(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	intoOutfile := &IntoOutfileClause{IntoPos: pos, File: file}
+	if p.matchKeyword(KeywordCompression) {
+		if err := p.expectDialect("INTO OUTFILE COMPRESSION", DialectClickHouse); err != nil {
+			return nil, err
+		}
+		_ = p.lexer.consumeToken()
+		if intoOutfile.Compression, err = p.parseString(p.Start()); err != nil {
+			return nil, err
+		}
+		if p.tryConsumeKeywords(KeywordLevel) {
+			if intoOutfile.Level, err = p.parseNumber(p.Start()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return intoOutfile, nil
+}
+
 func (p *Parser) tryParseLimitByClause(pos Pos) (Expr, error) {
 	if !p.matchKeyword(KeywordLimit) {
 		return nil, nil
@@ -539,6 +749,9 @@ func (p *Parser) parseLimitByClause(pos Pos) (Expr, error) {
 	if !p.tryConsumeKeywords(KeywordBy) {
 		return limit, nil
 	}
+	if err := p.expectDialect("LIMIT BY", DialectClickHouse); err != nil {
+		return nil, err
+	}
 	if by, err = p.parseColumnExprListWithLParen(p.Start()); err != nil {
 		return nil, err
 	}
@@ -722,6 +935,9 @@ func (p *Parser) parseArrayJoinClause(_ Pos) (*ArrayJoinClause, error) {
 	if err := p.expectKeyword(KeywordArray); err != nil {
 		return nil, err
 	}
+	if err := p.expectDialect("ARRAY JOIN", DialectClickHouse); err != nil {
+		return nil, err
+	}
 
 	if err := p.expectKeyword(KeywordJoin); err != nil {
 		return nil, err
@@ -787,42 +1003,209 @@ func (p *Parser) parseSelectQuery(_ Pos) (*SelectQuery, error) {
 		return nil, fmt.Errorf("expected SELECT, WITH or (, got %s", p.lastTokenKind())
 	}
 
-	hasParen := p.tryConsumeTokenKind(TokenKindLParen) != nil
-	selectStmt, err := p.parseSelectStmt(p.Start())
+	expr, err := p.parseSetOperation(setOperatorPrecedenceUnion)
 	if err != nil {
 		return nil, err
 	}
-	switch {
-	case p.tryConsumeKeywords(KeywordUnion):
+	query, ok := expr.(*SelectQuery)
+	if !ok {
+		query = &SelectQuery{
+			SelectPos:    expr.Start(),
+			StatementEnd: expr.End(),
+			SetOperation: expr.(*SetOperation),
+		}
+	} else if query.HasParen {
+		// `(SELECT ...) ORDER BY ... LIMIT ...`
+		if err := p.parseParenthesizedTail(query); err != nil {
+			return nil, err
+		}
+		return query, nil
+	} else if p.operandTails() {
+		return query, nil
+	}
+	if err := p.parseSelectTail(query); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+// operandTails reports whether ORDER BY, LIMIT and the other clauses of parseSelectTail apply
+// to each operand of a set operation, as in ClickHouse, rather than to the whole compound query.
+func (p *Parser) operandTails() bool {
+	return p.dialect == DialectClickHouse || p.dialect == DialectDefault
+}
+
+// parseParenthesizedTail parses the clauses following a parenthesized query into it.
+func (p *Parser) parseParenthesizedTail(query *SelectQuery) error {
+	tail := &SelectQuery{}
+	if err := p.parseSelectTail(tail); err != nil {
+		return err
+	}
+	return mergeSelectTail(query, tail)
+}
+
+// mergeSelectTail moves the clauses of tail to query, a clause the query has already can not
+// be applied again.
+func mergeSelectTail(query, tail *SelectQuery) error {
+	clauses := []struct {
+		name        string
+		outer, tail bool
+	}{
+		{"ORDER BY", query.OrderBy != nil, tail.OrderBy != nil},
+		{"LIMIT BY", query.LimitBy != nil, tail.LimitBy != nil},
+		{"LIMIT", query.Limit != nil, tail.Limit != nil},
+		{"OFFSET", query.Offset != nil || query.Limit != nil && query.Limit.Offset != nil, tail.Offset != nil},
+		{"FETCH", query.Fetch != nil, tail.Fetch != nil},
+		{"FOR", query.Locking != nil, tail.Locking != nil},
+		{"SETTINGS", query.Settings != nil, tail.Settings != nil},
+		{"INTO OUTFILE", query.IntoOutfile != nil, tail.IntoOutfile != nil},
+		{"FORMAT", query.Format != nil, tail.Format != nil},
+	}
+	for _, clause := range clauses {
+		if clause.outer && clause.tail {
+			return fmt.Errorf("%s of a parenthesized query that has one is not supported", clause.name)
+		}
+	}
+	if tail.StatementEnd == 0 {
+		return nil
+	}
+	if tail.OrderBy != nil {
+		query.OrderBy = tail.OrderBy
+	}
+	if tail.LimitBy != nil {
+		query.LimitBy = tail.LimitBy
+	}
+	if tail.Limit != nil {
+		query.Limit = tail.Limit
+	}
+	if tail.Offset != nil {
+		query.Offset = tail.Offset
+	}
+	if tail.Fetch != nil {
+		query.Fetch = tail.Fetch
+	}
+	if tail.Locking != nil {
+		query.Locking = tail.Locking
+	}
+	if tail.Settings != nil {
+		query.Settings = tail.Settings
+	}
+	if tail.IntoOutfile != nil {
+		query.IntoOutfile = tail.IntoOutfile
+	}
+	if tail.Format != nil {
+		query.Format = tail.Format
+	}
+	query.StatementEnd = tail.StatementEnd
+	return nil
+}
+
+const (
+	setOperatorPrecedenceUnion = iota + 1
+	setOperatorPrecedenceIntersect
+)
+
+// parseSetOperation parses the set operations binding at least as tight as precedence,
+// INTERSECT binds tighter than UNION and EXCEPT.
+func (p *Parser) parseSetOperation(precedence int) (Expr, error) {
+	var left Expr
+	left, err := p.parseSetOperand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var operator SetOperator
+		nextPrecedence := setOperatorPrecedenceUnion
+		switch {
+		case p.matchKeyword(KeywordUnion):
+			operator = SetOperatorUnion
+		case p.matchKeyword(KeywordExcept):
+			operator = SetOperatorExcept
+		case p.matchKeyword(KeywordIntersect):
+			operator = SetOperatorIntersect
+			nextPrecedence = setOperatorPrecedenceIntersect
+		default:
+			return left, nil
+		}
+		if nextPrecedence < precedence {
+			return left, nil
+		}
+		operatorPos := p.Start()
+		_ = p.lexer.consumeToken()
+
+		quantifier := SetQuantifierNone
 		switch {
 		case p.tryConsumeKeywords(KeywordAll):
-			unionAllExpr, err := p.parseSelectQuery(p.Start())
-			if err != nil {
-				return nil, err
-			}
-			selectStmt.UnionAll = unionAllExpr
+			quantifier = SetQuantifierAll
 		case p.tryConsumeKeywords(KeywordDistinct):
-			unionDistinctExpr, err := p.parseSelectStmt(p.Start())
-			if err != nil {
-				return nil, err
-			}
-			selectStmt.UnionDistinct = unionDistinctExpr
-		default:
-			return nil, fmt.Errorf("expected ALL or DISTINCT, got %s", p.lastTokenKind())
+			quantifier = SetQuantifierDistinct
 		}
-	case p.tryConsumeKeywords(KeywordExcept):
-		exceptExpr, err := p.parseSelectStmt(p.Start())
+
+		right, err := p.parseSetOperation(nextPrecedence + 1)
 		if err != nil {
 			return nil, err
 		}
-		selectStmt.Except = exceptExpr
+		left = &SetOperation{
+			Left:        left,
+			OperatorPos: operatorPos,
+			Operator:    operator,
+			Quantifier:  quantifier,
+			Right:       right,
+		}
 	}
-	if hasParen {
-		if err := p.expectTokenKind(TokenKindRParen); err != nil {
+}
+
+// Syntax: select_stmt | '(' select_query ')'
+func (p *Parser) parseSetOperand() (*SelectQuery, error) {
+	if !p.matchTokenKind(TokenKindLParen) {
+		query, err := p.parseSelectStmt(p.Start())
+		if err != nil {
 			return nil, err
 		}
+		// ClickHouse applies ORDER BY and LIMIT to each operand, MySQL and PostgreSQL to
+		// the whole compound query, so the caller parses them after the last operand.
+		if p.operandTails() {
+			if err := p.parseSelectTail(query); err != nil {
+				return nil, err
+			}
+		}
+		return query, nil
 	}
-	return selectStmt, nil
+	leftParenPos := p.Start()
+	_ = p.lexer.consumeToken()
+	query, err := p.parseSelectQuery(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	rightParenEnd := p.End()
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, err
+	}
+	if query.HasParen {
+		// keep a single level of parentheses, `((SELECT 1))` prints as `(SELECT 1)`
+		return query, nil
+	}
+	query.HasParen = true
+	query.SelectPos = leftParenPos
+	query.StatementEnd = rightParenEnd
+	if !p.operandTails() {
+		return query, nil
+	}
+	// `(SELECT ...) LIMIT 1 UNION ...`, the clauses after the last operand are left to the
+	// compound query.
+	savedState := p.lexer.saveState()
+	tail := &SelectQuery{}
+	if err := p.parseSelectTail(tail); err != nil {
+		return nil, err
+	}
+	if !p.matchKeyword(KeywordUnion) && !p.matchKeyword(KeywordExcept) && !p.matchKeyword(KeywordIntersect) {
+		p.lexer.restoreState(savedState)
+		return query, nil
+	}
+	if err := mergeSelectTail(query, tail); err != nil {
+		return nil, err
+	}
+	return query, nil
 }
 
 func (p *Parser) parseSelectStmt(pos Pos) (*SelectQuery, error) { // nolint: funlen
@@ -830,9 +1213,14 @@ func (p *Parser) parseSelectStmt(pos Pos) (*SelectQuery, error) { // nolint: fun
 	if err != nil {
 		return nil, err
 	}
+	selectEnd := p.End()
 	if err := p.expectKeyword(KeywordSelect); err != nil {
 		return nil, err
 	}
+	hints, err := p.tryParseOptimizerHints(selectEnd)
+	if err != nil {
+		return nil, err
+	}
 	// DISTINCT?
 	hasDistinct := p.tryConsumeKeywords(KeywordDistinct)
 
@@ -908,74 +1296,120 @@ func (p *Parser) parseSelectStmt(pos Pos) (*SelectQuery, error) { // nolint: fun
 	if window != nil {
 		statementEnd = window.End()
 	}
-	orderBy, err := p.tryParseOrderByClause(p.Start())
+	qualify, err := p.tryParseQualifyClause(p.Start())
 	if err != nil {
 		return nil, err
 	}
+	if qualify != nil {
+		statementEnd = qualify.End()
+	}
+
+	return &SelectQuery{
+		With:         withClause,
+		SelectPos:    pos,
+		StatementEnd: statementEnd,
+		Hints:        hints,
+		Top:          top,
+		HasDistinct:  hasDistinct,
+		SelectItems:  selectItems,
+		From:         from,
+		ArrayJoin:    arrayJoin,
+		Window:       window,
+		Prewhere:     prewhere,
+		Where:        where,
+		GroupBy:      groupBy,
+		Having:       having,
+		Qualify:      qualify,
+		WithTotal:    withTotal,
+	}, nil
+}
+
+// parseSelectTail parses the ORDER BY, LIMIT, SETTINGS and FORMAT clauses of a
+// SELECT or of a compound query.
+func (p *Parser) parseSelectTail(query *SelectQuery) error {
+	orderBy, err := p.tryParseOrderByClause(p.Start())
+	if err != nil {
+		return err
+	}
 	if orderBy != nil {
-		statementEnd = orderBy.End()
+		query.OrderBy = orderBy
+		query.StatementEnd = orderBy.End()
 	}
 
-	var limitBy *LimitByClause
-	var limit *LimitClause
 	parsedLimitBy, err := p.tryParseLimitByClause(p.Start())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if parsedLimitBy != nil {
-		statementEnd = parsedLimitBy.End()
+		query.StatementEnd = parsedLimitBy.End()
 		switch e := parsedLimitBy.(type) {
 		case *LimitByClause:
-			limitBy = e
-			limit, err = p.tryParseLimitClause(p.Start())
+			query.LimitBy = e
+			query.Limit, err = p.tryParseLimitClause(p.Start())
 			if err != nil {
-				return nil, err
+				return err
 			}
-			if limit != nil {
-				statementEnd = limit.End()
+			if query.Limit != nil {
+				query.StatementEnd = query.Limit.End()
 			}
 		case *LimitClause:
-			limit = e
+			query.Limit = e
 		}
 	}
 
+	if query.Limit == nil && p.matchKeyword(KeywordOffset) {
+		offset, err := p.parseOffsetClause(p.Start())
+		if err != nil {
+			return err
+		}
+		query.Offset = offset
+		query.StatementEnd = offset.End()
+	}
+	if p.matchKeyword(KeywordFetch) {
+		fetch, err := p.parseFetchClause(p.Start())
+		if err != nil {
+			return err
+		}
+		query.Fetch = fetch
+		query.StatementEnd = fetch.End()
+	}
+
+	locking, err := p.tryParseLockingClause(p.Start())
+	if err != nil {
+		return err
+	}
+	if locking != nil {
+		query.Locking = locking
+		query.StatementEnd = locking.End()
+	}
+
 	settings, err := p.tryParseSettingsClause(p.Start())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if settings != nil {
-		statementEnd = settings.End()
+		query.Settings = settings
+		query.StatementEnd = settings.End()
+	}
+
+	intoOutfile, err := p.tryParseIntoOutfileClause(p.Start())
+	if err != nil {
+		return err
+	}
+	if intoOutfile != nil {
+		query.IntoOutfile = intoOutfile
+		query.StatementEnd = intoOutfile.End()
 	}
 
 	format, err := p.tryParseFormat(p.Start())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if format != nil {
-		statementEnd = format.End()
+		query.Format = format
+		query.StatementEnd = format.End()
 	}
-
-	return &SelectQuery{
-		With:         withClause,
-		SelectPos:    pos,
-		StatementEnd: statementEnd,
-		Top:          top,
-		HasDistinct:  hasDistinct,
-		SelectItems:  selectItems,
-		From:         from,
-		ArrayJoin:    arrayJoin,
-		Window:       window,
-		Prewhere:     prewhere,
-		Where:        where,
-		GroupBy:      groupBy,
-		Having:       having,
-		OrderBy:      orderBy,
-		LimitBy:      limitBy,
-		Limit:        limit,
-		Settings:     settings,
-		Format:       format,
-		WithTotal:    withTotal,
-	}, nil
+	return nil
 }
 
 func (p *Parser) parseCTEStmt(pos Pos) (*CTEStmt, error) {
@@ -0,0 +1,551 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// variableScopes are the keywords naming the scope of a system variable.
+var variableScopes = NewSet(KeywordGlobal, KeywordSession, KeywordLocal, KeywordPersist, KeywordPersistOnly)
+
+// Syntax: BEGIN [WORK | TRANSACTION]
+// or: START TRANSACTION [READ ONLY | READ WRITE | WITH CONSISTENT SNAPSHOT] [, ...]
+func (p *Parser) parseBeginStmt(pos Pos) (*BeginStmt, error) {
+	begin := &BeginStmt{BeginPos: pos, StatementEnd: p.End()}
+	if p.tryConsumeKeywords(KeywordBegin) {
+		if p.matchOneOfKeywords(KeywordWork, KeywordTransaction) {
+			begin.Keyword = strings.ToUpper(p.last().String)
+			begin.StatementEnd = p.End()
+			_ = p.lexer.consumeToken()
+		}
+		return begin, nil
+	}
+
+	if err := p.expectKeyword(KeywordStart); err != nil {
+		return nil, err
+	}
+	begin.StartTransaction = true
+	begin.StatementEnd = p.End()
+	if err := p.expectKeyword(KeywordTransaction); err != nil {
+		return nil, err
+	}
+	if err := p.expectDialect("START TRANSACTION", DialectMySQL, DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.matchKeyword(KeywordRead):
+			_ = p.lexer.consumeToken()
+			if !p.matchOneOfKeywords(KeywordOnly, KeywordWrite) {
+				return nil, fmt.Errorf("expected keyword: ONLY|WRITE, but got %q", p.lastTokenKind())
+			}
+			begin.Modes = append(begin.Modes, "READ "+strings.ToUpper(p.last().String))
+		case p.tryConsumeKeywords(KeywordWith, KeywordConsistent):
+			if !p.matchKeyword(KeywordSnapshot) {
+				return nil, fmt.Errorf("expected keyword: SNAPSHOT, but got %q", p.lastTokenKind())
+			}
+			begin.Modes = append(begin.Modes, "WITH CONSISTENT SNAPSHOT")
+		default:
+			return begin, nil
+		}
+		begin.StatementEnd = p.End()
+		_ = p.lexer.consumeToken()
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			return begin, nil
+		}
+	}
+}
+
+// tryParseTransactionKeyword consumes the optional WORK or TRANSACTION after COMMIT and ROLLBACK.
+func (p *Parser) tryParseTransactionKeyword() string {
+	if !p.matchOneOfKeywords(KeywordWork, KeywordTransaction) {
+		return ""
+	}
+	keyword := strings.ToUpper(p.last().String)
+	_ = p.lexer.consumeToken()
+	return keyword
+}
+
+// parseTransactionOptions parses the optional AND [NO] CHAIN and [NO] RELEASE of COMMIT and ROLLBACK,
+// and returns the end of the last one.
+func (p *Parser) parseTransactionOptions(statement string) ([]string, Pos, error) {
+	var options []string
+	var end Pos
+	if p.tryConsumeKeywords(KeywordAnd) {
+		option := "AND CHAIN"
+		if p.tryConsumeKeywords(KeywordNo) {
+			option = "AND NO CHAIN"
+		}
+		end = p.End()
+		if err := p.expectKeyword(KeywordChain); err != nil {
+			return nil, end, err
+		}
+		options = append(options, option)
+	}
+	if p.matchKeyword(KeywordNo) && p.peekKeyword(KeywordRelease) {
+		_ = p.lexer.consumeToken()
+		options = append(options, "NO RELEASE")
+		end = p.End()
+		_ = p.lexer.consumeToken()
+	} else if p.matchKeyword(KeywordRelease) {
+		options = append(options, KeywordRelease)
+		end = p.End()
+		_ = p.lexer.consumeToken()
+	}
+	if len(options) > 0 {
+		if err := p.expectDialect(statement+" "+options[0], DialectMySQL); err != nil {
+			return nil, end, err
+		}
+	}
+	return options, end, nil
+}
+
+// Syntax: COMMIT [WORK | TRANSACTION] [AND [NO] CHAIN] [[NO] RELEASE]
+func (p *Parser) parseCommitStmt(pos Pos) (*CommitStmt, error) {
+	statementEnd := p.End()
+	if err := p.expectKeyword(KeywordCommit); err != nil {
+		return nil, err
+	}
+	if p.matchOneOfKeywords(KeywordWork, KeywordTransaction) {
+		statementEnd = p.End()
+	}
+	keyword := p.tryParseTransactionKeyword()
+	options, end, err := p.parseTransactionOptions(KeywordCommit)
+	if err != nil {
+		return nil, err
+	}
+	if len(options) > 0 {
+		statementEnd = end
+	}
+	return &CommitStmt{
+		CommitPos:    pos,
+		StatementEnd: statementEnd,
+		Keyword:      keyword,
+		Options:      options,
+	}, nil
+}
+
+// Syntax: ROLLBACK [WORK | TRANSACTION] [TO [SAVEPOINT] name | [AND [NO] CHAIN] [[NO] RELEASE]]
+func (p *Parser) parseRollbackStmt(pos Pos) (*RollbackStmt, error) {
+	statementEnd := p.End()
+	if err := p.expectKeyword(KeywordRollback); err != nil {
+		return nil, err
+	}
+	if p.matchOneOfKeywords(KeywordWork, KeywordTransaction) {
+		statementEnd = p.End()
+	}
+	rollback := &RollbackStmt{RollbackPos: pos, StatementEnd: statementEnd, Keyword: p.tryParseTransactionKeyword()}
+	if p.tryConsumeKeywords(KeywordTo) {
+		if err := p.expectDialect("ROLLBACK TO SAVEPOINT", DialectMySQL, DialectPostgreSQL); err != nil {
+			return nil, err
+		}
+		_ = p.tryConsumeKeywords(KeywordSavepoint)
+		savepoint, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		rollback.Savepoint = savepoint
+		rollback.StatementEnd = savepoint.End()
+		return rollback, nil
+	}
+	options, end, err := p.parseTransactionOptions(KeywordRollback)
+	if err != nil {
+		return nil, err
+	}
+	if len(options) > 0 {
+		rollback.Options = options
+		rollback.StatementEnd = end
+	}
+	return rollback, nil
+}
+
+// Syntax: SAVEPOINT name
+// or: RELEASE SAVEPOINT name
+func (p *Parser) parseSavepointStmt(pos Pos) (*SavepointStmt, error) {
+	release := p.tryConsumeKeywords(KeywordRelease)
+	if err := p.expectKeyword(KeywordSavepoint); err != nil {
+		return nil, err
+	}
+	if err := p.expectDialect("SAVEPOINT", DialectMySQL, DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	return &SavepointStmt{
+		SavepointPos: pos,
+		Release:      release,
+		Name:         name,
+	}, nil
+}
+
+// Syntax: LOCK {TABLES | TABLE} table [[AS] alias] {READ [LOCAL] | [LOW_PRIORITY] WRITE} [, ...]
+func (p *Parser) parseLockTablesStmt(pos Pos) (*LockTablesStmt, error) {
+	if err := p.expectKeyword(KeywordLock); err != nil {
+		return nil, err
+	}
+	if !p.tryConsumeKeywords(KeywordTables) && !p.tryConsumeKeywords(KeywordTable) {
+		return nil, fmt.Errorf("expected keyword: TABLES, but got %q", p.lastTokenKind())
+	}
+	if err := p.expectDialect("LOCK TABLES", DialectMySQL); err != nil {
+		return nil, err
+	}
+	lock := &LockTablesStmt{LockPos: pos}
+	for {
+		tableLock, err := p.parseTableLock()
+		if err != nil {
+			return nil, err
+		}
+		lock.Locks = append(lock.Locks, tableLock)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			return lock, nil
+		}
+	}
+}
+
+func (p *Parser) parseTableLock() (*TableLock, error) {
+	table, err := p.parseTableIdentifier(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	tableLock := &TableLock{Table: table}
+	if p.tryConsumeKeywords(KeywordAs) || p.lastTokenKind() == TokenKindIdent {
+		tableLock.Alias, err = p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case p.matchKeyword(KeywordRead):
+		tableLock.LockType = KeywordRead
+		tableLock.LockEnd = p.End()
+		_ = p.lexer.consumeToken()
+		if p.matchKeyword(KeywordLocal) {
+			tableLock.LockType = "READ LOCAL"
+			tableLock.LockEnd = p.End()
+			_ = p.lexer.consumeToken()
+		}
+	case p.tryConsumeKeywords(KeywordLowPriority):
+		tableLock.LockType = "LOW_PRIORITY WRITE"
+		tableLock.LockEnd = p.End()
+		if err := p.expectKeyword(KeywordWrite); err != nil {
+			return nil, err
+		}
+	case p.matchKeyword(KeywordWrite):
+		tableLock.LockType = KeywordWrite
+		tableLock.LockEnd = p.End()
+		_ = p.lexer.consumeToken()
+	default:
+		return nil, fmt.Errorf("expected keyword: READ|WRITE, but got %q", p.lastTokenKind())
+	}
+	return tableLock, nil
+}
+
+// Syntax: UNLOCK {TABLES | TABLE}
+func (p *Parser) parseUnlockTablesStmt(pos Pos) (*UnlockTablesStmt, error) {
+	if err := p.expectKeyword(KeywordUnlock); err != nil {
+		return nil, err
+	}
+	statementEnd := p.End()
+	if !p.tryConsumeKeywords(KeywordTables) && !p.tryConsumeKeywords(KeywordTable) {
+		return nil, fmt.Errorf("expected keyword: TABLES, but got %q", p.lastTokenKind())
+	}
+	if err := p.expectDialect("UNLOCK TABLES", DialectMySQL); err != nil {
+		return nil, err
+	}
+	return &UnlockTablesStmt{UnlockPos: pos, StatementEnd: statementEnd}, nil
+}
+
+// parseSetStatement parses the SET statements other than SET ROLE. ClickHouse settings
+// `SET name = value` are kept as a SetStmt, MySQL and PostgreSQL variables as a SetVariableStmt.
+//
+// Syntax: SET NAMES {charset | DEFAULT} [COLLATE collation]
+// or: SET {CHARACTER SET | CHARSET} {charset | DEFAULT}
+// or: SET [GLOBAL | SESSION | LOCAL | PERSIST | PERSIST_ONLY] name {= | TO} value [, ...]
+// or: SET {@name | @@[scope.]name} {= | :=} value [, ...]
+// or: SET [GLOBAL | SESSION] TRANSACTION characteristic [, ...]
+func (p *Parser) parseSetStatement(pos Pos) (Expr, error) {
+	if p.dialect == DialectClickHouse {
+		return p.parseSetStmt(pos)
+	}
+	if err := p.expectKeyword(KeywordSet); err != nil {
+		return nil, err
+	}
+	if p.matchOneOfKeywords(KeywordNames, KeywordCharacter, KeywordCharset) {
+		return p.parseSetNames(pos)
+	}
+	if p.matchKeyword(KeywordTransaction) ||
+		p.matchOneOfKeywords(KeywordGlobal, KeywordSession) && p.peekKeyword(KeywordTransaction) {
+		return p.parseSetTransaction(pos)
+	}
+
+	stmt := &SetVariableStmt{SetPos: pos}
+	for {
+		assignment, err := p.parseVariableAssignment()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Assignments = append(stmt.Assignments, assignment)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			break
+		}
+	}
+	if p.dialect == DialectDefault {
+		if settings := settingsOf(stmt); settings != nil {
+			return &SetStmt{SetPos: pos, Settings: settings}, nil
+		}
+	}
+	return stmt, nil
+}
+
+// isolationLevels are the levels of SET TRANSACTION ISOLATION LEVEL.
+var isolationLevels = []string{"READ UNCOMMITTED", "READ COMMITTED", "REPEATABLE READ", "SERIALIZABLE"}
+
+// Syntax: SET [GLOBAL | SESSION] TRANSACTION characteristic [, ...]
+// characteristic: ISOLATION LEVEL level | READ {ONLY | WRITE} | [NOT] DEFERRABLE
+func (p *Parser) parseSetTransaction(pos Pos) (*SetTransactionStmt, error) {
+	stmt := &SetTransactionStmt{SetPos: pos}
+	if p.matchOneOfKeywords(KeywordGlobal, KeywordSession) {
+		stmt.Scope = strings.ToUpper(p.last().String)
+		if err := p.expectDialect("SET "+stmt.Scope+" TRANSACTION", DialectMySQL); err != nil {
+			return nil, err
+		}
+		_ = p.lexer.consumeToken()
+	}
+	if err := p.expectKeyword(KeywordTransaction); err != nil {
+		return nil, err
+	}
+	if err := p.expectDialect("SET TRANSACTION", DialectMySQL, DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	for {
+		characteristic, end, err := p.parseTransactionCharacteristic()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Characteristics = append(stmt.Characteristics, characteristic)
+		stmt.StatementEnd = end
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			return stmt, nil
+		}
+	}
+}
+
+func (p *Parser) parseTransactionCharacteristic() (string, Pos, error) {
+	if p.tryConsumeWords("ISOLATION") > 0 {
+		if err := p.expectKeyword(KeywordLevel); err != nil {
+			return "", 0, err
+		}
+		for _, level := range isolationLevels {
+			if end := p.tryConsumeWords(strings.Fields(level)...); end > 0 {
+				return "ISOLATION LEVEL " + level, end, nil
+			}
+		}
+		return "", 0, fmt.Errorf("expected isolation level: %s, but got %q",
+			strings.Join(isolationLevels, "|"), p.lastTokenKind())
+	}
+	for _, characteristic := range []string{"READ ONLY", "READ WRITE", "DEFERRABLE", "NOT DEFERRABLE"} {
+		end := p.tryConsumeWords(strings.Fields(characteristic)...)
+		if end == 0 {
+			continue
+		}
+		if strings.HasSuffix(characteristic, "DEFERRABLE") {
+			if err := p.expectDialect(characteristic, DialectPostgreSQL); err != nil {
+				return "", 0, err
+			}
+		}
+		return characteristic, end, nil
+	}
+	return "", 0, fmt.Errorf("expected ISOLATION LEVEL, READ ONLY or READ WRITE, but got %q", p.lastTokenKind())
+}
+
+// tryConsumeWords consumes words, unquoted keywords or identifiers as they need not be reserved,
+// and returns the end of the last one, 0 if they do not follow.
+func (p *Parser) tryConsumeWords(words ...string) Pos {
+	savedState := p.lexer.saveState()
+	var end Pos
+	for _, word := range words {
+		token := p.last()
+		if token == nil || token.Kind != TokenKindKeyword && token.Kind != TokenKindIdent ||
+			token.QuoteType != Unquoted || !strings.EqualFold(token.String, word) {
+			p.lexer.restoreState(savedState)
+			return 0
+		}
+		end = token.End
+		_ = p.lexer.consumeToken()
+	}
+	return end
+}
+
+// settingsOf returns the assignments of stmt as ClickHouse settings,
+// or nil when some of them can only be a MySQL or PostgreSQL variable.
+func settingsOf(stmt *SetVariableStmt) *SettingsClause {
+	settings := &SettingsClause{SettingsPos: stmt.Assignments[0].Start(), ListEnd: stmt.End()}
+	for _, assignment := range stmt.Assignments {
+		name, ok := assignment.Name.(*Ident)
+		if !ok || assignment.Scope != "" || assignment.Operator != string(TokenKindSingleEQ) {
+			return nil
+		}
+		switch assignment.Value.(type) {
+		case *NumberLiteral, *StringLiteral, *MapLiteral:
+		default:
+			return nil
+		}
+		settings.Items = append(settings.Items, &SettingExprList{
+			SettingsPos: name.Start(),
+			Name:        name,
+			Expr:        assignment.Value,
+		})
+	}
+	return settings
+}
+
+func (p *Parser) parseSetNames(pos Pos) (*SetNamesStmt, error) {
+	stmt := &SetNamesStmt{SetPos: pos}
+	switch {
+	case p.tryConsumeKeywords(KeywordNames):
+	case p.tryConsumeKeywords(KeywordCharacter):
+		if err := p.expectKeyword(KeywordSet); err != nil {
+			return nil, err
+		}
+		stmt.CharacterSet = true
+	default:
+		_ = p.lexer.consumeToken()
+		stmt.CharacterSet = true
+	}
+	if err := p.expectDialect("SET NAMES", DialectMySQL, DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+
+	charset, err := p.parseCharsetName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Charset = charset
+	stmt.StatementEnd = charset.End()
+	if !stmt.CharacterSet && p.tryConsumeKeywords(KeywordCollate) {
+		collate, err := p.parseCharsetName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Collate = collate
+		stmt.StatementEnd = collate.End()
+	}
+	return stmt, nil
+}
+
+// parseCharsetName parses a character set or collation name, an identifier, a string or DEFAULT.
+func (p *Parser) parseCharsetName() (Expr, error) {
+	if p.matchTokenKind(TokenKindString) {
+		return p.parseString(p.Start())
+	}
+	return p.parseIdent()
+}
+
+func (p *Parser) parseVariableAssignment() (*VariableAssignment, error) {
+	assignment := &VariableAssignment{}
+	if p.matchTokenKind(TokenKindAtSign) {
+		variable, err := p.parseVariableExpr(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		assignment.Name = variable
+	} else {
+		if p.matchTokenKind(TokenKindKeyword) && variableScopes.Contains(strings.ToUpper(p.last().String)) &&
+			!p.peekTokenKind(TokenKindSingleEQ) {
+			assignment.Scope = strings.ToUpper(p.last().String)
+			if err := p.expectScopeDialect(assignment.Scope); err != nil {
+				return nil, err
+			}
+			_ = p.lexer.consumeToken()
+		}
+		name, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		assignment.Name = name
+	}
+
+	switch {
+	case p.tryConsumeTokenKind(TokenKindSingleEQ) != nil:
+		assignment.Operator = string(TokenKindSingleEQ)
+	case p.matchTokenKind(TokenKindColon):
+		_ = p.lexer.consumeToken()
+		if err := p.expectTokenKind(TokenKindSingleEQ); err != nil {
+			return nil, err
+		}
+		if err := p.expectDialect(":=", DialectMySQL); err != nil {
+			return nil, err
+		}
+		assignment.Operator = ":="
+	case p.tryConsumeKeywords(KeywordTo):
+		if err := p.expectDialect("SET ... TO", DialectPostgreSQL); err != nil {
+			return nil, err
+		}
+		assignment.Operator = KeywordTo
+	default:
+		return nil, fmt.Errorf("expected = or :=, but got %q", p.lastTokenKind())
+	}
+
+	value, err := p.parseVariableValue()
+	if err != nil {
+		return nil, err
+	}
+	assignment.Value = value
+	return assignment, nil
+}
+
+func (p *Parser) expectScopeDialect(scope string) error {
+	if scope == KeywordSession || scope == KeywordLocal {
+		return p.expectDialect("SET "+scope, DialectMySQL, DialectPostgreSQL)
+	}
+	return p.expectDialect("SET "+scope, DialectMySQL)
+}
+
+// parseVariableValue parses the value of a SET assignment, ON, OFF, DEFAULT and
+// other bare words such as `utf8mb4` are kept as identifiers.
+func (p *Parser) parseVariableValue() (Expr, error) {
+	if p.matchOneOfKeywords(KeywordOn, KeywordDefault, KeywordAll) {
+		return p.parseIdent()
+	}
+	return p.parseExpr(p.Start())
+}
+
+// Syntax: @name | @'name' | @@[scope.]name
+func (p *Parser) parseVariableExpr(pos Pos) (*VariableExpr, error) {
+	if err := p.expectTokenKind(TokenKindAtSign); err != nil {
+		return nil, err
+	}
+	// drivers such as clickhouse-go bind @name, system variables only exist in MySQL
+	if err := p.expectDialect("variable", DialectMySQL, DialectClickHouse); err != nil {
+		return nil, err
+	}
+	variable := &VariableExpr{AtPos: pos}
+	if p.tryConsumeTokenKind(TokenKindAtSign) != nil {
+		if err := p.expectDialect("system variable", DialectMySQL); err != nil {
+			return nil, err
+		}
+		variable.System = true
+		if p.matchTokenKind(TokenKindKeyword) && variableScopes.Contains(strings.ToUpper(p.last().String)) &&
+			p.peekTokenKind(TokenKindDot) {
+			variable.Scope = strings.ToUpper(p.last().String)
+			_ = p.lexer.consumeToken()
+			_ = p.lexer.consumeToken()
+		}
+	}
+	if p.matchTokenKind(TokenKindString) {
+		name, err := p.parseString(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		variable.Name = &Ident{Name: name.Literal, QuoteType: BackTicks, start: name.Start(), end: name.End()}
+		return variable, nil
+	}
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	variable.Name = name
+	return variable, nil
+}
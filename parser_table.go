@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
 func (p *Parser) parseDDL(pos Pos) (DDL, error) {
@@ -10,8 +12,8 @@ func (p *Parser) parseDDL(pos Pos) (DDL, error) {
 		p.matchKeyword(KeywordAttach):
 		_ = p.lexer.consumeToken()
 		orReplace := p.tryConsumeKeywords(KeywordOr, KeywordReplace)
-		if orReplace && !p.matchOneOfKeywords(KeywordTemporary, KeywordTable, KeywordView, KeywordFunction) {
-			return nil, fmt.Errorf("expected keyword: TEMPORARY|TABLE|VIEW|FUNCTION, but got %q", p.last().String)
+		if orReplace && !p.matchOneOfKeywords(KeywordTemporary, KeywordTable, KeywordView, KeywordFunction, KeywordDictionary) {
+			return nil, fmt.Errorf("expected keyword: TEMPORARY|TABLE|VIEW|FUNCTION|DICTIONARY, but got %q", p.last().String)
 		}
 		switch {
 		case p.matchKeyword(KeywordDatabase):
@@ -31,8 +33,16 @@ func (p *Parser) parseDDL(pos Pos) (DDL, error) {
 			return p.parseCreateRole(pos)
 		case p.matchKeyword(KeywordUser):
 			return p.parseCreateUser(pos)
+		case p.matchKeyword(KeywordDictionary):
+			return p.parseCreateDictionary(pos, orReplace)
+		case p.matchOneOfKeywords(KeywordRow, KeywordPolicy):
+			return p.parseCreateRowPolicy(pos)
+		case p.matchOneOfKeywords(KeywordSettings, KeywordProfile):
+			return p.parseCreateSettingsProfile(pos)
+		case p.matchKeyword(KeywordQuota):
+			return p.parseCreateQuota(pos)
 		default:
-			return nil, fmt.Errorf("expected keyword: DATABASE|TABLE|VIEW|ROLE|USER|FUNCTION|MATERIALIZED, but got %q",
+			return nil, fmt.Errorf("expected keyword: DATABASE|TABLE|VIEW|ROLE|USER|FUNCTION|MATERIALIZED|DICTIONARY|ROW POLICY|SETTINGS PROFILE|QUOTA, but got %q",
 				p.lastTokenKind())
 		}
 	case p.matchKeyword(KeywordAlter):
@@ -40,24 +50,33 @@ func (p *Parser) parseDDL(pos Pos) (DDL, error) {
 		switch {
 		case p.matchKeyword(KeywordRole):
 			return p.parseAlterRole(pos)
+		case p.matchKeyword(KeywordUser):
+			return p.parseAlterUser(pos)
 		case p.matchKeyword(KeywordTable):
 			return p.parseAlterTable(pos)
+		case p.matchOneOfKeywords(KeywordRow, KeywordPolicy):
+			return p.parseAlterRowPolicy(pos)
+		case p.matchOneOfKeywords(KeywordSettings, KeywordProfile):
+			return p.parseAlterSettingsProfile(pos)
+		case p.matchKeyword(KeywordQuota):
+			return p.parseAlterQuota(pos)
 		default:
-			return nil, fmt.Errorf("expected keyword: TABLE|ROLE, but got %q", p.last().String)
+			return nil, fmt.Errorf("expected keyword: TABLE|ROLE|USER|ROW POLICY|SETTINGS PROFILE|QUOTA, but got %q", p.last().String)
 		}
 	case p.matchKeyword(KeywordDrop),
 		p.matchKeyword(KeywordDetach):
+		detach := p.matchKeyword(KeywordDetach)
 		_ = p.lexer.consumeToken()
 		switch {
 		case p.matchKeyword(KeywordDatabase):
-			return p.parseDropDatabase(pos)
+			return p.parseDropDatabase(pos, detach)
 		case p.matchKeyword(KeywordTemporary),
 			p.matchKeyword(KeywordView),
 			p.matchKeyword(KeywordDictionary),
 			p.matchKeyword(KeywordTable):
-			return p.parseDropStmt(pos)
-		case p.matchKeyword(KeywordUser),
-			p.matchKeyword(KeywordRole):
+			return p.parseDropStmt(pos, detach)
+		case p.matchOneOfKeywords(KeywordUser, KeywordRole, KeywordRow, KeywordPolicy,
+			KeywordSettings, KeywordProfile, KeywordQuota):
 			return p.parserDropUserOrRole(pos)
 		default:
 			return nil, fmt.Errorf("expected keyword: DATABASE|TABLE, but got %q", p.last().String)
@@ -120,13 +139,37 @@ func (p *Parser) parseTableOption(pos Pos) (*TableOption, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the clauses following a ClickHouse engine are read as options too
+	if name.QuoteType == Unquoted && engineClauses.Contains(strings.ToUpper(name.Name)) {
+		if err := p.expectDialect("table option "+strings.ToUpper(name.Name), DialectClickHouse); err != nil {
+			return nil, err
+		}
+	}
 
 	// some options like DEFAULT CHARSET are written as `DEFAULT CHARSET`
 	// so we should consume the second keyword if it exists
-	if name.Name == "DEFAULT" {
-		if p.tryConsumeKeywords(KeywordCharset) {
-			name.Name = "DEFAULT CHARSET"
-			name.end = p.last().End
+	if strings.EqualFold(name.Name, KeywordDefault) {
+		if err := p.expectDialect("table option DEFAULT", DialectMySQL); err != nil {
+			return nil, err
+		}
+		switch {
+		case p.matchKeyword(KeywordCharset), p.matchKeyword(KeywordCollate):
+			name.Name = "DEFAULT " + strings.ToUpper(p.last().String)
+			name.end = p.End()
+			_ = p.lexer.consumeToken()
+		case p.matchKeyword(KeywordCharacter):
+			_ = p.lexer.consumeToken()
+			name.Name = "DEFAULT CHARACTER SET"
+			name.end = p.End()
+			if err := p.expectKeyword(KeywordSet); err != nil {
+				return nil, err
+			}
+		}
+	} else if strings.EqualFold(name.Name, KeywordCharacter) {
+		name.Name = "CHARACTER SET"
+		name.end = p.End()
+		if err := p.expectKeyword(KeywordSet); err != nil {
+			return nil, err
 		}
 	}
 
@@ -135,7 +178,8 @@ func (p *Parser) parseTableOption(pos Pos) (*TableOption, error) {
 
 	// If there is an equals sign, or the next token is an identifier (not a keyword that starts another clause),
 	// we'll parse it as a value expression.
-	if hasEquals != nil || p.matchTokenKind(TokenKindIdent) {
+	if hasEquals != nil || p.matchTokenKind(TokenKindIdent) ||
+		p.matchTokenKind(TokenKindString) || p.matchTokenKind(TokenKindInt) {
 		var err error
 		value, err = p.parseExpr(pos)
 		if err != nil {
@@ -151,6 +195,9 @@ func (p *Parser) parseTableOption(pos Pos) (*TableOption, error) {
 	}, nil
 }
 
+// engineClauses start the MergeTree clauses of a ClickHouse table engine.
+var engineClauses = NewSet(KeywordOrder, KeywordPrimary, KeywordSample, KeywordTtl, KeywordSettings)
+
 func (p *Parser) parseCreateTable(pos Pos, orReplace bool) (*CreateTable, error) {
 	createTable := &CreateTable{CreatePos: pos, OrReplace: orReplace}
 	createTable.HasTemporary = p.tryConsumeKeywords(KeywordTemporary)
@@ -191,12 +238,41 @@ func (p *Parser) parseCreateTable(pos Pos, orReplace bool) (*CreateTable, error)
 	}
 	createTable.TableSchema = tableSchema
 
+	if p.matchKeyword(KeywordInherits) {
+		createTable.Inherits, err = p.parseInheritsClause()
+		if err != nil {
+			return nil, err
+		}
+		createTable.StatementEnd = p.End()
+	}
+	if p.dialect == DialectPostgreSQL && p.matchKeyword(KeywordPartition) {
+		partitionPos := p.Start()
+		_ = p.lexer.consumeToken()
+		if err := p.expectKeyword(KeywordBy); err != nil {
+			return nil, err
+		}
+		// RANGE|LIST|HASH (columns) is parsed as a function call
+		expr, err := p.parseExpr(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		createTable.PartitionBy = &PartitionByClause{
+			PartitionPos: partitionPos,
+			Expr:         expr,
+		}
+		createTable.StatementEnd = expr.End()
+	}
+
 	var options []*TableOption
 	for {
 		// try parse table options, e.g. ENGINE=..., COMMENT=..., etc.
-		if p.matchTokenKind(TokenKindSemicolon) || p.matchTokenKind(TokenKindEOF) {
+		// a trailing FORMAT belongs to the statement
+		if p.matchTokenKind(TokenKindSemicolon) || p.matchTokenKind(TokenKindEOF) || p.matchKeyword(KeywordFormat) {
 			break
 		}
+		if p.dialect == DialectPostgreSQL {
+			return nil, fmt.Errorf("table option %q is not supported in PostgreSQL", p.last().String)
+		}
 
 		option, err := p.parseTableOption(p.Start())
 		if err != nil {
@@ -491,7 +567,9 @@ func (p *Parser) parseTableKey(pos Pos, constraintType string) (*Key, error) {
 			return nil, err
 		}
 	}
-	key.Name = keyName.Name
+	if keyName != nil {
+		key.Name = keyName.Name
+	}
 	// This is a bit of a hack, but we need to store the constraint type somewhere.
 	// We'll prepend it to the name if a name exists, or just use the type as the name.
 	if key.Name != "" {
@@ -508,10 +586,10 @@ func (p *Parser) parseTableKey(pos Pos, constraintType string) (*Key, error) {
 		return nil, err
 	}
 	key.Columns = cols
+	key.end = p.End()
 	if err := p.expectTokenKind(TokenKindRParen); err != nil {
 		return nil, err
 	}
-	key.end = p.last().End
 	return key, nil
 }
 
@@ -565,6 +643,12 @@ func (p *Parser) parseTableColumnExpr(pos Pos) (*ColumnDef, error) {
 		case p.tryConsumeKeywords(KeywordAutoIncrement):
 			column.AutoIncrement = true
 			columnEnd = p.last().End
+		case p.matchKeyword(KeywordGenerated):
+			column.Generated, err = p.parseGeneratedColumn(p.Start())
+			if err != nil {
+				return nil, err
+			}
+			columnEnd = column.Generated.End()
 
 		case p.matchKeyword(KeywordNot), p.matchKeyword(KeywordNull):
 			notNull, err = p.tryParseNotNull(p.Start())
@@ -695,10 +779,11 @@ func (p *Parser) parseTableArgList(pos Pos) (*TableArgListExpr, error) {
 }
 
 func (p *Parser) tryParseClusterClause(pos Pos) (*ClusterClause, error) {
-	if !p.tryConsumeKeywords(KeywordOn) {
+	// ON without CLUSTER belongs to the caller, e.g. `DROP ROW POLICY p ON t`
+	if !p.tryConsumeKeywords(KeywordOn, KeywordCluster) {
 		return nil, nil // nolint
 	}
-	if err := p.expectKeyword(KeywordCluster); err != nil {
+	if err := p.expectDialect("ON CLUSTER", DialectClickHouse); err != nil {
 		return nil, err
 	}
 
@@ -793,6 +878,15 @@ func (p *Parser) parseOrderByClause(pos Pos) (*OrderByClause, error) {
 		orderByListExpr.ListEnd = items[len(items)-1].End()
 	}
 	orderByListExpr.Items = items
+
+	if p.matchKeyword(KeywordInterpolate) {
+		interpolate, err := p.parseInterpolateClause(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		orderByListExpr.Interpolate = interpolate
+		orderByListExpr.ListEnd = interpolate.End()
+	}
 	return orderByListExpr, nil
 }
 
@@ -825,21 +919,125 @@ func (p *Parser) parseOrderExpr(pos Pos) (*OrderExpr, error) {
 		}, nil
 	}
 
-	direction := OrderDirectionNone
+	orderExpr := &OrderExpr{
+		OrderPos:  pos,
+		Alias:     alias,
+		Expr:      columnExpr,
+		Direction: OrderDirectionNone,
+	}
 	switch {
 	case p.matchKeyword(KeywordAsc), p.matchKeyword(KeywordAscending):
-		direction = OrderDirectionAsc
+		orderExpr.Direction = OrderDirectionAsc
+		orderExpr.OrderEnd = p.End()
 		_ = p.lexer.consumeToken()
 	case p.matchKeyword(KeywordDesc), p.matchKeyword(KeywordDescending):
-		direction = OrderDirectionDesc
+		orderExpr.Direction = OrderDirectionDesc
+		orderExpr.OrderEnd = p.End()
 		_ = p.lexer.consumeToken()
 	}
-	return &OrderExpr{
-		OrderPos:  pos,
-		Alias:     alias,
-		Expr:      columnExpr,
-		Direction: direction,
-	}, nil
+
+	// NULLS FIRST|LAST
+	if p.tryConsumeKeywords(KeywordNulls) {
+		switch {
+		case p.matchKeyword(KeywordFirst):
+			orderExpr.Nulls = NullsOrderFirst
+		case p.matchKeyword(KeywordLast):
+			orderExpr.Nulls = NullsOrderLast
+		default:
+			return nil, fmt.Errorf("expected keyword: FIRST|LAST, but got %q", p.lastTokenKind())
+		}
+		orderExpr.OrderEnd = p.End()
+		_ = p.lexer.consumeToken()
+	}
+
+	// COLLATE 'locale'
+	if p.tryConsumeKeywords(KeywordCollate) {
+		collate, err := p.parseString(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		orderExpr.Collate = collate
+		orderExpr.OrderEnd = collate.End()
+	}
+
+	if p.matchKeyword(KeywordWith) && p.peekKeyword(KeywordFill) {
+		withFill, err := p.parseWithFillClause(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		orderExpr.WithFill = withFill
+		orderExpr.OrderEnd = withFill.End()
+	}
+	return orderExpr, nil
+}
+
+// Syntax: WITH FILL [FROM expr] [TO expr] [STEP expr]
+func (p *Parser) parseWithFillClause(pos Pos) (*WithFillClause, error) {
+	if err := p.expectDialect("WITH FILL", DialectClickHouse); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordWith); err != nil {
+		return nil, err
+	}
+	withFill := &WithFillClause{WithPos: pos, FillEnd: p.End()}
+	if err := p.expectKeyword(KeywordFill); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if p.tryConsumeKeywords(KeywordFrom) {
+		if withFill.From, err = p.parseExpr(p.Start()); err != nil {
+			return nil, err
+		}
+	}
+	if p.tryConsumeKeywords(KeywordTo) {
+		if withFill.To, err = p.parseExpr(p.Start()); err != nil {
+			return nil, err
+		}
+	}
+	if p.tryConsumeKeywords(KeywordStep) {
+		if withFill.Step, err = p.parseExpr(p.Start()); err != nil {
+			return nil, err
+		}
+	}
+	return withFill, nil
+}
+
+// Syntax: INTERPOLATE [(column [AS expr], ...)]
+func (p *Parser) parseInterpolateClause(pos Pos) (*InterpolateClause, error) {
+	if err := p.expectDialect("INTERPOLATE", DialectClickHouse); err != nil {
+		return nil, err
+	}
+	interpolate := &InterpolateClause{InterpolatePos: pos, ListEnd: p.End()}
+	if err := p.expectKeyword(KeywordInterpolate); err != nil {
+		return nil, err
+	}
+	if !p.matchTokenKind(TokenKindLParen) {
+		return interpolate, nil
+	}
+	_ = p.lexer.consumeToken()
+
+	for !p.matchTokenKind(TokenKindRParen) {
+		column, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		item := &InterpolateItem{Column: column}
+		if p.tryConsumeKeywords(KeywordAs) {
+			if item.Expr, err = p.parseExpr(p.Start()); err != nil {
+				return nil, err
+			}
+		}
+		interpolate.Items = append(interpolate.Items, item)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			break
+		}
+	}
+	interpolate.ListEnd = p.End()
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, err
+	}
+	return interpolate, nil
 }
 
 func (p *Parser) tryParseTTLClause(pos Pos, allowMultiValues bool) (*TTLClause, error) {
@@ -970,6 +1168,9 @@ func (p *Parser) tryParseSettingsClause(pos Pos) (*SettingsClause, error) {
 	if !p.tryConsumeKeywords(KeywordSettings) {
 		return nil, nil // nolint
 	}
+	if err := p.expectDialect("SETTINGS", DialectClickHouse); err != nil {
+		return nil, err
+	}
 	return p.parseSettingsClause(pos)
 }
 
@@ -1087,6 +1288,12 @@ func (p *Parser) parseEngineExpr(pos Pos) (*EngineExpr, error) {
 	}
 
 	for !p.lexer.isEOF() {
+		// MySQL only has the engine name
+		if p.matchOneOfKeywords(KeywordOrder, KeywordPrimary, KeywordSample, KeywordTtl) {
+			if err := p.expectDialect("ENGINE "+strings.ToUpper(p.last().String), DialectClickHouse); err != nil {
+				return nil, err
+			}
+		}
 		switch {
 		case p.matchKeyword(KeywordOrder):
 			orderBy, err := p.tryParseOrderByClause(p.Start())
@@ -1151,7 +1358,7 @@ func (p *Parser) parseStmt(pos Pos) (Expr, error) {
 		p.matchKeyword(KeywordTruncate),
 		p.matchKeyword(KeywordRename):
 		expr, err = p.parseDDL(pos)
-	case p.matchKeyword(KeywordSelect), p.matchKeyword(KeywordWith):
+	case p.matchKeyword(KeywordSelect), p.matchKeyword(KeywordWith), p.matchTokenKind(TokenKindLParen):
 		expr, err = p.parseSelectQuery(pos)
 	case p.matchKeyword(KeywordDelete):
 		expr, err = p.parseDeleteClause(pos)
@@ -1159,28 +1366,53 @@ func (p *Parser) parseStmt(pos Pos) (Expr, error) {
 		expr, err = p.parseInsertStmt(p.Start())
 	case p.matchKeyword(KeywordUse):
 		expr, err = p.parseUseStmt(pos)
+	case p.matchKeyword(KeywordSet) && (p.peekKeyword(KeywordRole) || p.peekKeyword(KeywordDefault)):
+		expr, err = p.parseSetRoleStmt(pos)
 	case p.matchKeyword(KeywordSet):
-		expr, err = p.parseSetStmt(pos)
+		expr, err = p.parseSetStatement(pos)
+	case p.matchOneOfKeywords(KeywordBegin, KeywordStart):
+		expr, err = p.parseBeginStmt(pos)
+	case p.matchKeyword(KeywordCommit):
+		expr, err = p.parseCommitStmt(pos)
+	case p.matchKeyword(KeywordRollback):
+		expr, err = p.parseRollbackStmt(pos)
+	case p.matchOneOfKeywords(KeywordSavepoint, KeywordRelease):
+		expr, err = p.parseSavepointStmt(pos)
+	case p.matchKeyword(KeywordLock):
+		expr, err = p.parseLockTablesStmt(pos)
+	case p.matchKeyword(KeywordUnlock):
+		expr, err = p.parseUnlockTablesStmt(pos)
 	case p.matchKeyword(KeywordSystem):
 		expr, err = p.parseSystemStmt(pos)
 	case p.matchKeyword(KeywordOptimize):
 		expr, err = p.parseOptimizeStmt(pos)
 	case p.matchKeyword(KeywordCheck):
 		expr, err = p.parseCheckStmt(pos)
+	case p.matchKeyword(KeywordKill):
+		expr, err = p.parseKillStmt(pos)
 	case p.matchKeyword(KeywordExplain):
 		expr, err = p.parseExplainStmt(pos)
 	case p.matchKeyword(KeywordGrant):
 		expr, err = p.parseGrantPrivilegeStmt(pos)
+	case p.matchKeyword(KeywordRevoke):
+		expr, err = p.parseRevokeStmt(pos)
 	default:
 		return nil, fmt.Errorf("unexpected token: %q", p.last().String)
 	}
 	if err != nil {
 		return nil, err
 	}
-	_, err = p.tryParseFormat(p.Start())
+	format, err := p.tryParseFormat(p.Start())
 	if err != nil {
 		return nil, err
 	}
+	if format != nil {
+		stmt, ok := expr.(FormatStmt)
+		if !ok || stmt.GetFormat() != nil {
+			return nil, errors.New("FORMAT is not allowed after this statement")
+		}
+		stmt.setFormat(format)
+	}
 
 	// Statement can be terminated by ';' or EOF
 	if p.last() != nil && !p.matchTokenKind(";") {
@@ -1190,17 +1422,28 @@ func (p *Parser) parseStmt(pos Pos) (Expr, error) {
 }
 
 func (p *Parser) Parse() ([]Expr, error) {
+	if !p.dialect.valid() {
+		return nil, fmt.Errorf("unknown dialect: %s", p.dialect)
+	}
 	var stmts []Expr
 	for {
-		_ = p.lexer.consumeToken()
-		if p.lexer.isEOF() {
+		lexErr := p.lexer.consumeToken()
+		// the lexer is at EOF once the last token is read, a statement may still be pending
+		if p.last() == nil {
+			if lexErr != nil {
+				return nil, p.wrapError(lexErr)
+			}
 			break
 		}
 		if p.matchTokenKind(";") {
 			continue
 		}
+		p.lexer.err = nil
 		stmt, err := p.parseStmt(p.Start())
 		if err != nil {
+			if p.lexer.err != nil {
+				err = p.lexer.err
+			}
 			return nil, p.wrapError(err)
 		}
 		stmts = append(stmts, stmt)
@@ -1208,6 +1451,25 @@ func (p *Parser) Parse() ([]Expr, error) {
 	return stmts, nil
 }
 
+// consumeInlineData returns the raw data following `INSERT ... FORMAT name`. As in
+// ClickHouse the data runs to the end of the input, so the lexer is moved to EOF.
+func (p *Parser) consumeInlineData(format *FormatClause) (string, Pos) {
+	start := int(format.End())
+	if format.Format.QuoteType != Unquoted {
+		start++
+	}
+	rest := p.lexer.input[start:]
+	data := strings.TrimLeft(rest, " \t\r\n")
+	if data == "" || data[0] == ';' {
+		return "", 0
+	}
+	// the lexer already tried to read the data as a token, forget about it
+	p.lexer.current = len(p.lexer.input)
+	p.lexer.lastToken = nil
+	p.lexer.err = nil
+	return strings.TrimRight(data, " \t\r\n"), Pos(start + len(rest) - len(data))
+}
+
 func (p *Parser) parseUseStmt(pos Pos) (*UseStmt, error) {
 	if err := p.expectKeyword(KeywordUse); err != nil {
 		return nil, err
@@ -1269,9 +1531,14 @@ func (p *Parser) parseTruncateTable(pos Pos) (*TruncateTable, error) {
 }
 
 func (p *Parser) parseDeleteClause(pos Pos) (*DeleteClause, error) {
+	deleteEnd := p.End()
 	if err := p.expectKeyword(KeywordDelete); err != nil {
 		return nil, err
 	}
+	hints, err := p.tryParseOptimizerHints(deleteEnd)
+	if err != nil {
+		return nil, err
+	}
 	if err := p.expectKeyword(KeywordFrom); err != nil {
 		return nil, err
 	}
@@ -1284,19 +1551,27 @@ func (p *Parser) parseDeleteClause(pos Pos) (*DeleteClause, error) {
 		return nil, err
 	}
 
-	if err := p.expectKeyword(KeywordWhere); err != nil {
+	// ClickHouse requires a WHERE, MySQL and PostgreSQL delete every row without one
+	var whereExpr Expr
+	if p.tryConsumeKeywords(KeywordWhere) {
+		if whereExpr, err = p.parseExpr(p.Start()); err != nil {
+			return nil, err
+		}
+	} else if err := p.expectDialect("DELETE without WHERE", DialectMySQL, DialectPostgreSQL); err != nil {
 		return nil, err
 	}
-	whereExpr, err := p.parseExpr(p.Start())
+	returning, err := p.tryParseReturningClause(p.Start())
 	if err != nil {
 		return nil, err
 	}
 
 	return &DeleteClause{
 		DeletePos: pos,
+		Hints:     hints,
 		Table:     tableIdentifier,
 		OnCluster: onCluster,
 		WhereExpr: whereExpr,
+		Returning: returning,
 	}, nil
 }
 
@@ -1395,18 +1670,22 @@ func (p *Parser) parseAssignmentValues(pos Pos) (*AssignmentValues, error) {
 }
 
 func (p *Parser) parseInsertStmt(pos Pos) (*InsertStmt, error) {
+	insertEnd := p.End()
 	if err := p.expectKeyword(KeywordInsert); err != nil {
 		return nil, err
 	}
+	hints, err := p.tryParseOptimizerHints(insertEnd)
+	if err != nil {
+		return nil, err
+	}
 	if err := p.expectKeyword(KeywordInto); err != nil {
 		return nil, err
 	}
 
-	insertExpr := &InsertStmt{InsertPos: pos}
+	insertExpr := &InsertStmt{InsertPos: pos, Hints: hints}
 	insertExpr.HasTableKeyword = p.tryConsumeKeywords(KeywordTable)
 
 	var table Expr
-	var err error
 	if p.tryConsumeKeywords(KeywordFunction) {
 		table, err = p.parseFunctionExpr(p.Start())
 	} else {
@@ -1428,6 +1707,13 @@ func (p *Parser) parseInsertStmt(pos Pos) (*InsertStmt, error) {
 	switch {
 	case p.matchKeyword(KeywordFormat):
 		insertExpr.Format, err = p.parseFormat(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		insertExpr.Data, insertExpr.DataPos = p.consumeInlineData(insertExpr.Format)
+		if insertExpr.Data != "" {
+			return insertExpr, nil
+		}
 	case p.matchKeyword(KeywordValues):
 		// consume VALUES keyword
 		_ = p.lexer.consumeToken()
@@ -1448,13 +1734,179 @@ func (p *Parser) parseInsertStmt(pos Pos) (*InsertStmt, error) {
 	default:
 		// do nothing
 	}
+	if err != nil {
+		return nil, err
+	}
 
+	if p.matchKeyword(KeywordOn) && p.peekKeyword(KeywordConflict) {
+		insertExpr.OnConflict, err = p.parseOnConflictClause(p.Start())
+		if err != nil {
+			return nil, err
+		}
+	}
+	insertExpr.Returning, err = p.tryParseReturningClause(p.Start())
 	if err != nil {
 		return nil, err
 	}
 	return insertExpr, nil
 }
 
+// Syntax: ON CONFLICT ((columns) | ON CONSTRAINT ident)? DO (NOTHING | UPDATE SET assignment (, assignment)* whereClause?)
+func (p *Parser) parseOnConflictClause(pos Pos) (*OnConflictClause, error) {
+	if err := p.expectDialect("ON CONFLICT", DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordOn); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordConflict); err != nil {
+		return nil, err
+	}
+
+	onConflict := &OnConflictClause{OnPos: pos}
+	var err error
+	switch {
+	case p.matchTokenKind(TokenKindLParen):
+		onConflict.Columns, err = p.parseColumnNamesExpr(p.Start())
+	case p.tryConsumeKeywords(KeywordOn, KeywordConstraint):
+		onConflict.Constraint, err = p.parseIdent()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectKeyword(KeywordDo); err != nil {
+		return nil, err
+	}
+	if p.matchKeyword(KeywordNothing) {
+		onConflict.StatementEnd = p.End()
+		_ = p.lexer.consumeToken()
+		onConflict.DoNothing = true
+		return onConflict, nil
+	}
+	if err := p.expectKeyword(KeywordUpdate); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordSet); err != nil {
+		return nil, err
+	}
+	for {
+		assignment, err := p.parseAssignment(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		onConflict.Assignments = append(onConflict.Assignments, assignment)
+		onConflict.StatementEnd = assignment.End()
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			break
+		}
+	}
+	onConflict.Where, err = p.tryParseWhereClause(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	if onConflict.Where != nil {
+		onConflict.StatementEnd = onConflict.Where.End()
+	}
+	return onConflict, nil
+}
+
+func (p *Parser) tryParseReturningClause(pos Pos) (*ReturningClause, error) {
+	if !p.matchKeyword(KeywordReturning) {
+		return nil, nil // nolint
+	}
+	if err := p.expectDialect("RETURNING", DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	_ = p.lexer.consumeToken()
+
+	items, err := p.parseSelectItems()
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("expected RETURNING list, but got %q", p.lastTokenKind())
+	}
+	return &ReturningClause{
+		ReturningPos: pos,
+		Items:        items,
+	}, nil
+}
+
+// Syntax: INHERITS (tableIdentifier (, tableIdentifier)*)
+func (p *Parser) parseInheritsClause() ([]*TableIdentifier, error) {
+	if err := p.expectDialect("INHERITS", DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordInherits); err != nil {
+		return nil, err
+	}
+	if err := p.expectTokenKind(TokenKindLParen); err != nil {
+		return nil, err
+	}
+	var parents []*TableIdentifier
+	for {
+		parent, err := p.parseTableIdentifier(p.Start())
+		if err != nil {
+			return nil, err
+		}
+		parents = append(parents, parent)
+		if p.tryConsumeTokenKind(TokenKindComma) == nil {
+			break
+		}
+	}
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, err
+	}
+	return parents, nil
+}
+
+// Syntax: GENERATED (ALWAYS | BY DEFAULT) AS (IDENTITY | (expr) STORED)
+func (p *Parser) parseGeneratedColumn(pos Pos) (*GeneratedColumn, error) {
+	if err := p.expectDialect("GENERATED column", DialectPostgreSQL); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword(KeywordGenerated); err != nil {
+		return nil, err
+	}
+	generated := &GeneratedColumn{GeneratedPos: pos}
+	switch {
+	case p.tryConsumeKeywords(KeywordAlways):
+		generated.Always = true
+	case p.tryConsumeKeywords(KeywordBy, KeywordDefault):
+	default:
+		return nil, fmt.Errorf("expected keyword: ALWAYS|BY DEFAULT, but got %q", p.lastTokenKind())
+	}
+	if err := p.expectKeyword(KeywordAs); err != nil {
+		return nil, err
+	}
+
+	if p.matchKeyword(KeywordIdentity) {
+		generated.GeneratedEnd = p.End()
+		_ = p.lexer.consumeToken()
+		return generated, nil
+	}
+	if !generated.Always {
+		return nil, errors.New("generated columns with an expression must be GENERATED ALWAYS")
+	}
+	if err := p.expectTokenKind(TokenKindLParen); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseExpr(p.Start())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectTokenKind(TokenKindRParen); err != nil {
+		return nil, err
+	}
+	generated.Expr = expr
+	generated.GeneratedEnd = p.End()
+	if err := p.expectKeyword(KeywordStored); err != nil {
+		return nil, err
+	}
+	return generated, nil
+}
+
 func (p *Parser) parseRenameStmt(pos Pos) (*RenameStmt, error) {
 	if err := p.expectKeyword(KeywordRename); err != nil {
 		return nil, err
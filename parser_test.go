@@ -2,8 +2,10 @@ package parser
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseCreateTableWithColumnComments(t *testing.T) {
@@ -403,3 +405,2272 @@ CREATE TABLE orders (
 	}
 
 }
+
+func TestParseAlterTableMutations(t *testing.T) {
+	tests := []struct {
+		sql       string
+		alterType string
+	}{
+		{"ALTER TABLE db.events UPDATE status = 1, retries = retries + 1 IN PARTITION 202401 WHERE id = 42", "UPDATE"},
+		{"ALTER TABLE events DELETE WHERE created_at < '2020-01-01'", "DELETE"},
+		{"ALTER TABLE events MOVE PARTITION 202401 TO DISK 'cold'", "MOVE_PARTITION"},
+		{"ALTER TABLE events MOVE PARTITION 202401 TO TABLE archive.events", "MOVE_PARTITION"},
+		{"ALTER TABLE events FETCH PARTITION 202401 FROM '/clickhouse/tables/01/events'", "FETCH_PARTITION"},
+		{"ALTER TABLE events MOVE PART 'all_1_1_0' TO DISK 'cold'", "MOVE_PARTITION"},
+		{"ALTER TABLE events FETCH PART 'all_1_1_0' FROM '/clickhouse/tables/01/events'", "FETCH_PARTITION"},
+		{"ALTER TABLE events MODIFY SETTING merge_with_ttl_timeout=3600", "MODIFY_SETTING"},
+		{"ALTER TABLE events RESET SETTING merge_with_ttl_timeout", "RESET_SETTING"},
+		{"ALTER TABLE events MODIFY ORDER BY (id, created_at)", "MODIFY_ORDER_BY"},
+		{"ALTER TABLE events MODIFY COMMENT 'raw events'", "MODIFY_COMMENT"},
+		{"ALTER TABLE events APPLY DELETED MASK IN PARTITION 202401", "APPLY_DELETED_MASK"},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		alterTable, ok := stmts[0].(*AlterTable)
+		if !ok {
+			t.Fatalf("Expected AlterTable statement, but got %T", stmts[0])
+		}
+		if len(alterTable.AlterExprs) != 1 {
+			t.Fatalf("Expected 1 alter clause, but got %d", len(alterTable.AlterExprs))
+		}
+		if got := alterTable.AlterExprs[0].AlterType(); got != tt.alterType {
+			t.Errorf("Expected alter type %s, but got %s", tt.alterType, got)
+		}
+		if alterTable.String() != tt.sql {
+			t.Errorf("Expected %q, but got %q", tt.sql, alterTable.String())
+		}
+	}
+}
+
+func TestParseAlterTableMySQL(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+		types    []string
+	}{
+		{
+			sql:      "ALTER TABLE users CHANGE email email_address VARCHAR(255) NOT NULL AFTER name",
+			expected: "ALTER TABLE users CHANGE COLUMN email email_address VARCHAR(255) NOT NULL AFTER name",
+			types:    []string{"CHANGE_COLUMN"},
+		},
+		{
+			sql:      "ALTER TABLE users MODIFY age BIGINT FIRST",
+			expected: "ALTER TABLE users MODIFY COLUMN age BIGINT FIRST",
+			types:    []string{"MODIFY_COLUMN"},
+		},
+		{
+			sql:      "ALTER TABLE users ADD nickname VARCHAR(32) AFTER name",
+			expected: "ALTER TABLE users ADD COLUMN nickname VARCHAR(32) AFTER name",
+			types:    []string{"ADD_COLUMN"},
+		},
+		{
+			sql:      "ALTER TABLE users ADD PRIMARY KEY (id), ADD UNIQUE INDEX uk_email (email), ADD KEY idx_name (name)",
+			expected: "ALTER TABLE users ADD PRIMARY KEY (id), ADD UNIQUE KEY uk_email (email), ADD KEY idx_name (name)",
+			types:    []string{"ADD_PRIMARY_KEY", "ADD_UNIQUE_KEY", "ADD_KEY"},
+		},
+		{
+			sql:      "ALTER TABLE users DROP PRIMARY KEY, DROP KEY idx_name",
+			expected: "ALTER TABLE users DROP PRIMARY KEY, DROP INDEX idx_name",
+			types:    []string{"DROP_PRIMARY_KEY", "DROP_INDEX"},
+		},
+		{
+			sql:      "ALTER TABLE users RENAME TO members, RENAME INDEX idx_a TO idx_b",
+			expected: "ALTER TABLE users RENAME TO members, RENAME INDEX idx_a TO idx_b",
+			types:    []string{"RENAME_TABLE", "RENAME_INDEX"},
+		},
+		{
+			sql:      "ALTER TABLE users ENGINE = InnoDB, AUTO_INCREMENT = 100, DEFAULT CHARSET = utf8mb4",
+			expected: "ALTER TABLE users ENGINE = InnoDB, AUTO_INCREMENT = 100, DEFAULT CHARSET = utf8mb4",
+			types:    []string{"TABLE_OPTION", "TABLE_OPTION", "TABLE_OPTION"},
+		},
+		{
+			sql:      "ALTER TABLE users CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci",
+			expected: "ALTER TABLE users CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci",
+			types:    []string{"CONVERT_CHARSET"},
+		},
+		{
+			sql:      "ALTER TABLE users ADD COLUMN score INT, ALGORITHM=INPLACE, LOCK=NONE",
+			expected: "ALTER TABLE users ADD COLUMN score INT, ALGORITHM = INPLACE, LOCK = NONE",
+			types:    []string{"ADD_COLUMN", "ALGORITHM", "LOCK"},
+		},
+		{
+			sql:      "ALTER TABLE users ADD CONSTRAINT pk PRIMARY KEY (id), ADD CONSTRAINT UNIQUE KEY uk (email)",
+			expected: "ALTER TABLE users ADD CONSTRAINT pk PRIMARY KEY (id), ADD UNIQUE KEY uk (email)",
+			types:    []string{"ADD_PRIMARY_KEY", "ADD_UNIQUE_KEY"},
+		},
+		{
+			sql:      "ALTER TABLE orders ADD CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE ON UPDATE SET NULL",
+			expected: "ALTER TABLE orders ADD CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE SET NULL",
+			types:    []string{"ADD_FOREIGN_KEY"},
+		},
+		{
+			sql:      "ALTER TABLE orders ADD FOREIGN KEY (user_id, shop_id) REFERENCES db.users (id, shop_id), DROP FOREIGN KEY fk_old",
+			expected: "ALTER TABLE orders ADD FOREIGN KEY (user_id, shop_id) REFERENCES db.users (id, shop_id), DROP FOREIGN KEY fk_old",
+			types:    []string{"ADD_FOREIGN_KEY", "DROP_FOREIGN_KEY"},
+		},
+		{
+			sql:      "ALTER TABLE posts ADD INDEX idx_user (user_id) USING BTREE, ADD INDEX idx_at USING HASH (created_at)",
+			expected: "ALTER TABLE posts ADD INDEX idx_user(user_id) USING BTREE, ADD INDEX idx_at(created_at) USING HASH",
+			types:    []string{"ADD_INDEX", "ADD_INDEX"},
+		},
+		{
+			sql:      "ALTER TABLE posts ADD FULLTEXT INDEX ft_body (body), ADD FULLTEXT ft_title (title)",
+			expected: "ALTER TABLE posts ADD FULLTEXT INDEX ft_body(body), ADD FULLTEXT INDEX ft_title(title)",
+			types:    []string{"ADD_INDEX", "ADD_INDEX"},
+		},
+		{
+			sql:      "ALTER TABLE users ALTER COLUMN score SET DEFAULT 1, ALTER name DROP DEFAULT",
+			expected: "ALTER TABLE users ALTER COLUMN score SET DEFAULT 1, ALTER COLUMN name DROP DEFAULT",
+			types:    []string{"ALTER_COLUMN", "ALTER_COLUMN"},
+		},
+		{
+			sql:      "ALTER TABLE users ADD COLUMN (c INT, d VARCHAR(10) NOT NULL), ADD (e INT)",
+			expected: "ALTER TABLE users ADD COLUMN c INT, ADD COLUMN d VARCHAR(10) NOT NULL, ADD COLUMN e INT",
+			types:    []string{"ADD_COLUMN", "ADD_COLUMN", "ADD_COLUMN"},
+		},
+		{
+			sql:      "ALTER TABLE users DISABLE KEYS",
+			expected: "ALTER TABLE users DISABLE KEYS",
+			types:    []string{"KEYS"},
+		},
+		{
+			sql:      "ALTER TABLE users ENABLE KEYS",
+			expected: "ALTER TABLE users ENABLE KEYS",
+			types:    []string{"KEYS"},
+		},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		alterTable, ok := stmts[0].(*AlterTable)
+		if !ok {
+			t.Fatalf("Expected AlterTable statement, but got %T", stmts[0])
+		}
+		if len(alterTable.AlterExprs) != len(tt.types) {
+			t.Fatalf("Expected %d alter clauses, but got %d", len(tt.types), len(alterTable.AlterExprs))
+		}
+		for i, alter := range alterTable.AlterExprs {
+			if got := alter.AlterType(); got != tt.types[i] {
+				t.Errorf("Expected alter type %s, but got %s", tt.types[i], got)
+			}
+		}
+		if alterTable.String() != tt.expected {
+			t.Errorf("Expected %q, but got %q", tt.expected, alterTable.String())
+		}
+	}
+}
+
+func TestParseWithDialect(t *testing.T) {
+	tests := []struct {
+		dialect  Dialect
+		sql      string
+		expected string
+		err      string
+	}{
+		{
+			dialect:  DialectMySQL,
+			sql:      `SELECT "name", ` + "`id`" + ` FROM users WHERE a || b LIMIT 10, 20`,
+			expected: "SELECT 'name', `id` FROM users WHERE a OR b LIMIT 20 OFFSET 10",
+		},
+		{
+			dialect:  DialectClickHouse,
+			sql:      `SELECT "name" || 'x', a::Int32 FROM users LIMIT 10, 20`,
+			expected: `SELECT "name" || 'x', a::Int32 FROM users LIMIT 20 OFFSET 10`,
+		},
+		{
+			dialect: DialectPostgreSQL,
+			sql:     `SELECT name FROM users LIMIT 10, 20`,
+			err:     "LIMIT offset, count is not supported in PostgreSQL",
+		},
+		{
+			dialect: DialectPostgreSQL,
+			sql:     "SELECT `name` FROM users",
+			err:     "backtick quoted identifiers are not supported in PostgreSQL",
+		},
+		{
+			dialect: DialectMySQL,
+			sql:     `SELECT a::INT FROM users`,
+			err:     "operator :: is not supported in MySQL",
+		},
+		{
+			dialect: DialectClickHouse,
+			sql:     `CREATE TABLE users (id Int32) ENGINE = Memory DEFAULT CHARSET = utf8mb4`,
+			err:     "table option DEFAULT is not supported in ClickHouse",
+		},
+		{
+			dialect: DialectMySQL,
+			sql:     `ALTER TABLE users DELETE WHERE id = 1`,
+			err:     "ALTER TABLE DELETE is not supported in MySQL",
+		},
+		{
+			dialect:  DialectMySQL,
+			sql:      "/*!40000 ALTER TABLE `users` DISABLE KEYS */;",
+			expected: "ALTER TABLE `users` DISABLE KEYS",
+		},
+		{
+			dialect: DialectClickHouse,
+			sql:     "ALTER TABLE users ADD FOREIGN KEY (a) REFERENCES u (id)",
+			err:     "ADD FOREIGN KEY is not supported in ClickHouse",
+		},
+		{
+			dialect: DialectPostgreSQL,
+			sql:     "ALTER TABLE users DROP FOREIGN KEY fk",
+			err:     "ALTER TABLE DROP_FOREIGN_KEY is not supported in PostgreSQL",
+		},
+		{
+			dialect: DialectClickHouse,
+			sql:     "ALTER TABLE users ALTER COLUMN a SET DEFAULT 1",
+			err:     "ALTER TABLE ALTER COLUMN is not supported in ClickHouse",
+		},
+		{
+			dialect: DialectClickHouse,
+			sql:     "ALTER TABLE users CHANGE a b Int32",
+			err:     "expected token: ADD|DROP|RENAME|MODIFY|ATTACH|DETACH|FREEZE|REMOVE|CLEAR|REPLACE|MATERIALIZE|UPDATE|DELETE|MOVE|FETCH|RESET|APPLY",
+		},
+		{
+			dialect: DialectClickHouse,
+			sql:     "ALTER TABLE users ENABLE KEYS",
+			err:     "ALTER TABLE KEYS is not supported in ClickHouse",
+		},
+		{
+			dialect:  DialectMySQL,
+			sql:      `DELETE FROM users`,
+			expected: `DELETE FROM users`,
+		},
+		{
+			dialect: DialectClickHouse,
+			sql:     `DELETE FROM users`,
+			err:     "DELETE without WHERE is not supported in ClickHouse",
+		},
+		{
+			dialect: Dialect(42),
+			sql:     `SELECT 1`,
+			err:     "unknown dialect",
+		},
+		{dialect: DialectMySQL, sql: `SELECT * FROM t ARRAY JOIN tags`, err: "ARRAY JOIN is not supported in MySQL"},
+		{dialect: DialectMySQL, sql: `SELECT * FROM t LEFT ARRAY JOIN tags`, err: "ARRAY JOIN is not supported in MySQL"},
+		{dialect: DialectMySQL, sql: `SELECT * FROM t LIMIT 1 BY a`, err: "LIMIT BY is not supported in MySQL"},
+		{dialect: DialectMySQL, sql: `SELECT * FROM t SETTINGS max_threads = 1`, err: "SETTINGS is not supported in MySQL"},
+		{dialect: DialectMySQL, sql: `SELECT {x: UInt8}`, err: "query parameter is not supported in MySQL"},
+		{
+			dialect: DialectMySQL,
+			sql:     `CREATE TABLE t (a INT) ENGINE = MergeTree ORDER BY a`,
+			err:     "table option ORDER is not supported in MySQL",
+		},
+		{dialect: DialectPostgreSQL, sql: `SELECT * FROM t SETTINGS max_threads = 1`, err: "SETTINGS is not supported in PostgreSQL"},
+		{dialect: DialectPostgreSQL, sql: `SELECT * FROM t LIMIT 1 BY a`, err: "LIMIT BY is not supported in PostgreSQL"},
+		{dialect: DialectPostgreSQL, sql: `SELECT * FROM t FORMAT JSON`, err: "FORMAT is not supported in PostgreSQL"},
+		{dialect: DialectPostgreSQL, sql: `SELECT {x: UInt8}`, err: "query parameter is not supported in PostgreSQL"},
+		{dialect: DialectClickHouse, sql: `SELECT $1`, err: "positional parameter is not supported in ClickHouse"},
+		{dialect: DialectMySQL, sql: `CREATE TABLE t (a INT) ENGINE = InnoDB`, expected: `CREATE TABLE t (a INT) ENGINE = InnoDB`},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParserWithOptions(tt.sql, Options{Dialect: tt.dialect}).Parse()
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected error %q for %q, but got %v", tt.err, tt.sql, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		if stmts[0].String() != tt.expected {
+			t.Errorf("Expected %q, but got %q", tt.expected, stmts[0].String())
+		}
+	}
+}
+
+func TestFormatDialect(t *testing.T) {
+	stmts, err := NewParserWithOptions("SELECT `user id`, \"name\" FROM `db`.users", Options{Dialect: DialectClickHouse}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	expected := map[Dialect]string{
+		DialectMySQL:      "SELECT `user id`, `name` FROM `db`.users",
+		DialectPostgreSQL: `SELECT "user id", "name" FROM "db".users`,
+		DialectClickHouse: "SELECT `user id`, \"name\" FROM `db`.users",
+	}
+	for dialect, sql := range expected {
+		got, err := Format(stmts[0], dialect)
+		if err != nil {
+			t.Fatalf("Failed to format as %s: %v", dialect, err)
+		}
+		if got != sql {
+			t.Errorf("Expected %s output %q, but got %q", dialect, sql, got)
+		}
+	}
+
+	stmts, err = NewParserWithOptions("SELECT a || b FROM t", Options{Dialect: DialectPostgreSQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	if _, err := Format(stmts[0], DialectMySQL); err == nil {
+		t.Errorf("Expected an error when printing || as MySQL")
+	}
+
+	for _, tt := range []struct {
+		sql     string
+		dialect Dialect
+	}{
+		{"SELECT * FROM t FINAL", DialectMySQL},
+		{"SELECT * FROM t SAMPLE 0.1", DialectPostgreSQL},
+		{"SELECT * FROM t PREWHERE a = 1", DialectMySQL},
+		{"SELECT * FROM t ARRAY JOIN tags", DialectPostgreSQL},
+		{"SELECT * FROM t LEFT ARRAY JOIN tags", DialectMySQL},
+		{"SELECT * FROM t LIMIT 1 BY a", DialectMySQL},
+		{"SELECT * FROM t SETTINGS max_threads = 1", DialectPostgreSQL},
+		{"SELECT * FROM t FORMAT JSON", DialectMySQL},
+		{"SELECT {x: UInt8}", DialectPostgreSQL},
+		{"SELECT x::Int32", DialectMySQL},
+	} {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		if _, err := Format(stmts[0], tt.dialect); err == nil || !strings.Contains(err.Error(), "can not be printed as") {
+			t.Errorf("Expected an error when printing %q as %s, but got %v", tt.sql, tt.dialect, err)
+		}
+	}
+
+	stmts, err = NewParserWithOptions(`SELECT 'a\', "b" FROM t`, Options{Dialect: DialectPostgreSQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	if got, err := Format(stmts[0], DialectPostgreSQL); err != nil || got != `SELECT 'a\', "b" FROM t` {
+		t.Errorf("Unexpected PostgreSQL output %q: %v", got, err)
+	}
+
+	stmts, err = NewParserWithOptions("SELECT `a\"b`, \"it's\", \"a\"\"b\" FROM t", Options{Dialect: DialectMySQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	expected = map[Dialect]string{
+		DialectMySQL:      "SELECT `a\"b`, 'it''s', 'a\"b' FROM t",
+		DialectPostgreSQL: `SELECT "a""b", 'it''s', 'a"b' FROM t`,
+	}
+	for dialect, sql := range expected {
+		got, err := Format(stmts[0], dialect)
+		if err != nil {
+			t.Fatalf("Failed to format as %s: %v", dialect, err)
+		}
+		if got != sql {
+			t.Errorf("Expected %s output %q, but got %q", dialect, sql, got)
+		}
+	}
+}
+
+func TestParsePostgreSQL(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{
+			sql:      `SELECT Id, "Name" FROM Users WHERE "Name" ILIKE $1 AND age > $2`,
+			expected: `SELECT id, "Name" FROM users WHERE "Name" ILIKE $1 AND age > $2`,
+		},
+		{
+			sql:      `SELECT $$it's$$, $fn$a $$ b$fn$, 'x''y'`,
+			expected: `SELECT 'it''s', 'a $$ b', 'x''y'`,
+		},
+		{
+			sql:      `SELECT price::numeric, tags::text[] FROM items`,
+			expected: `SELECT price::numeric, tags::text[] FROM items`,
+		},
+		{
+			sql: `CREATE TABLE orders (id SERIAL PRIMARY KEY, seq BIGINT GENERATED BY DEFAULT AS IDENTITY, ` +
+				`total NUMERIC GENERATED ALWAYS AS (price * qty) STORED, tags TEXT[]) ` +
+				`INHERITS (base_orders) PARTITION BY RANGE (created_at)`,
+			expected: `CREATE TABLE orders (id serial PRIMARY KEY, seq bigint GENERATED BY DEFAULT AS IDENTITY, ` +
+				`total numeric GENERATED ALWAYS AS (price * qty) STORED, tags text[]) ` +
+				`INHERITS (base_orders) PARTITION BY range(created_at)`,
+		},
+		{
+			sql: `INSERT INTO users (id, name) VALUES ($1, $2) ` +
+				`ON CONFLICT (id) DO UPDATE SET name = excluded.name WHERE users.active RETURNING id`,
+			expected: `INSERT INTO users (id, name) VALUES ($1, $2) ` +
+				`ON CONFLICT (id) DO UPDATE SET name = excluded.name WHERE users.active RETURNING id`,
+		},
+		{
+			sql:      `INSERT INTO users (id) VALUES (1) ON CONFLICT ON CONSTRAINT users_pkey DO NOTHING`,
+			expected: `INSERT INTO users (id) VALUES (1) ON CONFLICT ON CONSTRAINT users_pkey DO NOTHING`,
+		},
+		{
+			sql:      `DELETE FROM users WHERE id = $1 RETURNING id, name`,
+			expected: `DELETE FROM users WHERE id = $1 RETURNING id, name`,
+		},
+		{
+			sql:      `SELECT "a""b" FROM "T"`,
+			expected: `SELECT "a""b" FROM "T"`,
+		},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParserWithOptions(tt.sql, Options{Dialect: DialectPostgreSQL}).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		if stmts[0].String() != tt.expected {
+			t.Errorf("Expected %q, but got %q", tt.expected, stmts[0].String())
+		}
+	}
+
+	insert, err := NewParserWithOptions(`INSERT INTO t (id) VALUES ($1) RETURNING id`,
+		Options{Dialect: DialectPostgreSQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	param, ok := insert[0].(*InsertStmt).Values[0].Values[0].(*PlaceHolder)
+	if !ok || param.Type != "$1" {
+		t.Errorf("Expected positional parameter $1, but got %#v", insert[0].(*InsertStmt).Values[0].Values[0])
+	}
+
+	for _, sql := range []string{
+		`SELECT name FROM users WHERE name ILIKE 'a%'`,
+		`DELETE FROM users WHERE id = 1 RETURNING id`,
+	} {
+		if _, err := NewParserWithOptions(sql, Options{Dialect: DialectMySQL}).Parse(); err == nil {
+			t.Errorf("Expected MySQL to reject %q", sql)
+		}
+	}
+}
+
+func TestParseOrderByModifiers(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{
+			sql:      `SELECT day, x FROM t ORDER BY day WITH FILL FROM a TO b STEP INTERVAL 1 DAY INTERPOLATE (x AS x + 1, y)`,
+			expected: `SELECT day, x FROM t ORDER BY day WITH FILL FROM a TO b STEP INTERVAL 1 DAY INTERPOLATE (x AS x + 1, y)`,
+		},
+		{
+			sql:      `SELECT n FROM t ORDER BY n WITH FILL INTERPOLATE`,
+			expected: `SELECT n FROM t ORDER BY n WITH FILL INTERPOLATE`,
+		},
+		{
+			sql:      `SELECT a, b FROM t ORDER BY a DESC NULLS LAST, b COLLATE 'tr' WITH FILL STEP 2`,
+			expected: `SELECT a, b FROM t ORDER BY a DESC NULLS LAST, b COLLATE 'tr' WITH FILL STEP 2`,
+		},
+		{
+			sql:      `SELECT count() OVER (PARTITION BY k ORDER BY a ASC NULLS FIRST) FROM t`,
+			expected: `SELECT count() OVER (PARTITION BY k ORDER BY a ASC NULLS FIRST) FROM t`,
+		},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		if stmts[0].String() != tt.expected {
+			t.Errorf("Expected %q, but got %q", tt.expected, stmts[0].String())
+		}
+	}
+
+	stmts, err := NewParser(`SELECT a FROM t ORDER BY a DESC NULLS FIRST WITH FILL FROM 1 TO 10`).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	orderExpr := stmts[0].(*SelectQuery).OrderBy.Items[0].(*OrderExpr)
+	if orderExpr.Nulls != NullsOrderFirst || orderExpr.WithFill == nil || orderExpr.WithFill.To.String() != "10" {
+		t.Errorf("Unexpected order modifiers: %s", orderExpr.String())
+	}
+
+	var fills int
+	visitor := &DefaultASTVisitor{
+		Visit: func(expr Expr) error {
+			if _, ok := expr.(*WithFillClause); ok {
+				fills++
+			}
+			return nil
+		},
+	}
+	if err := stmts[0].Accept(visitor); err != nil {
+		t.Fatalf("Failed to visit statement: %v", err)
+	}
+	if fills != 1 {
+		t.Errorf("Expected 1 WITH FILL clause to be visited, but got %d", fills)
+	}
+
+	if _, err := NewParserWithOptions(`SELECT a FROM t ORDER BY a WITH FILL`, Options{Dialect: DialectMySQL}).Parse(); err == nil {
+		t.Errorf("Expected MySQL to reject WITH FILL")
+	}
+}
+
+func TestParseLambdaExpr(t *testing.T) {
+	sql := `SELECT arrayMap(x -> x * 2, arr), arrayFilter((k, v) -> v > 0 AND k != '', keys, vals) FROM t`
+	stmts, err := NewParser(sql).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	if stmts[0].String() != sql {
+		t.Errorf("Expected %q, but got %q", sql, stmts[0].String())
+	}
+
+	visitor := &lambdaScopeVisitor{}
+	visitor.Visit = func(expr Expr) error {
+		switch expr := expr.(type) {
+		case *LambdaExpr:
+			visitor.lambdas = append(visitor.lambdas, expr)
+		case *Ident:
+			for _, lambda := range visitor.scope {
+				if lambda.HasParam(expr.Name) {
+					return nil
+				}
+			}
+			visitor.columns = append(visitor.columns, expr.Name)
+		}
+		return nil
+	}
+	if err := stmts[0].Accept(visitor); err != nil {
+		t.Fatalf("Failed to visit statement: %v", err)
+	}
+	lambdas := visitor.lambdas
+	if got := strings.Join(visitor.columns, ","); got != "arrayMap,arr,arrayFilter,keys,vals,t" {
+		t.Errorf("Expected lambda params to be excluded from columns, but got %s", got)
+	}
+	if len(lambdas) != 2 {
+		t.Fatalf("Expected 2 lambdas, but got %d", len(lambdas))
+	}
+	if len(lambdas[1].Params) != 2 || lambdas[1].Params[1].Name != "v" {
+		t.Errorf("Unexpected lambda params: %s", lambdas[1].String())
+	}
+	if _, ok := lambdas[0].Body.(*BinaryOperation); !ok {
+		t.Errorf("Expected lambda body to be a BinaryOperation, but got %T", lambdas[0].Body)
+	}
+
+	stmts, err = NewParserWithOptions(`SELECT doc -> '$.name' FROM t`, Options{Dialect: DialectMySQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	item := stmts[0].(*SelectQuery).SelectItems[0].Expr
+	if _, ok := item.(*BinaryOperation); !ok {
+		t.Errorf("Expected MySQL -> to be a BinaryOperation, but got %T", item)
+	}
+}
+
+type lambdaScopeVisitor struct {
+	DefaultASTVisitor
+	scope   []*LambdaExpr
+	lambdas []*LambdaExpr
+	columns []string
+}
+
+func (v *lambdaScopeVisitor) Enter(expr Expr) {
+	if lambda, ok := expr.(*LambdaExpr); ok {
+		v.scope = append(v.scope, lambda)
+	}
+}
+
+func (v *lambdaScopeVisitor) Leave(expr Expr) {
+	if _, ok := expr.(*LambdaExpr); ok {
+		v.scope = v.scope[:len(v.scope)-1]
+	}
+}
+
+func TestParseSetOperations(t *testing.T) {
+	tests := []struct {
+		dialect  Dialect
+		sql      string
+		expected string
+	}{
+		{
+			sql:      `SELECT a FROM t UNION SELECT b FROM u INTERSECT SELECT c FROM v`,
+			expected: `(SELECT a FROM t) UNION ((SELECT b FROM u) INTERSECT (SELECT c FROM v))`,
+		},
+		{
+			sql:      `(SELECT a FROM t UNION ALL SELECT b FROM u) EXCEPT DISTINCT SELECT c FROM v`,
+			expected: `(((SELECT a FROM t) UNION ALL (SELECT b FROM u))) EXCEPT DISTINCT (SELECT c FROM v)`,
+		},
+		{
+			sql:      `SELECT a FROM t EXCEPT SELECT b FROM u UNION ALL SELECT c FROM v`,
+			expected: `((SELECT a FROM t) EXCEPT (SELECT b FROM u)) UNION ALL (SELECT c FROM v)`,
+		},
+		{
+			sql:      `SELECT 1 EXCEPT SELECT 2`,
+			expected: `(SELECT 1) EXCEPT (SELECT 2)`,
+		},
+		{
+			sql:      `SELECT 1 EXCEPT ALL SELECT 2`,
+			expected: `(SELECT 1) EXCEPT ALL (SELECT 2)`,
+		},
+		{
+			sql:      `SELECT 1 UNION ALL SELECT 2 EXCEPT SELECT 3`,
+			expected: `((SELECT 1) UNION ALL (SELECT 2)) EXCEPT (SELECT 3)`,
+		},
+	}
+	var describe func(expr Expr) string
+	describe = func(expr Expr) string {
+		switch expr := expr.(type) {
+		case *SetOperation:
+			op := string(expr.Operator)
+			if expr.Quantifier != SetQuantifierNone {
+				op += " " + string(expr.Quantifier)
+			}
+			return "(" + describe(expr.Left) + ") " + op + " (" + describe(expr.Right) + ")"
+		case *SelectQuery:
+			if expr.SetOperation != nil {
+				return "(" + describe(expr.SetOperation) + ")"
+			}
+		}
+		return expr.String()
+	}
+	for _, tt := range tests {
+		stmts, err := NewParserWithOptions(tt.sql, Options{Dialect: tt.dialect}).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		if stmts[0].String() != tt.sql {
+			t.Errorf("Expected %q, but got %q", tt.sql, stmts[0].String())
+		}
+		setOperation := stmts[0].(*SelectQuery).SetOperation
+		if setOperation == nil {
+			t.Fatalf("Expected a set operation in %q", tt.sql)
+		}
+		if got := describe(setOperation); got != tt.expected {
+			t.Errorf("Expected %q, but got %q", tt.expected, got)
+		}
+	}
+
+	// EXCEPT after a select item is the column transformer only before ( or a column name
+	for sql, expected := range map[string]string{
+		`CREATE VIEW v AS SELECT 1 UNION ALL SELECT 2 EXCEPT SELECT 3`: `CREATE VIEW v AS SELECT 1 UNION ALL SELECT 2 EXCEPT SELECT 3`,
+		`SELECT * EXCEPT (a, b) FROM t`:                                `SELECT * EXCEPT(a, b) FROM t`,
+		`SELECT * EXCEPT a, b FROM t`:                                  `SELECT * EXCEPT(a), b FROM t`,
+	} {
+		stmts, err := NewParser(sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", sql, err)
+		}
+		if stmts[0].String() != expected {
+			t.Errorf("Expected %q, but got %q", expected, stmts[0].String())
+		}
+	}
+
+	sql := `(SELECT a FROM t ORDER BY a LIMIT 1) UNION ALL SELECT b FROM u ORDER BY 1 LIMIT 5`
+	stmts, err := NewParserWithOptions(sql, Options{Dialect: DialectMySQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	compound := stmts[0].(*SelectQuery)
+	if compound.String() != sql {
+		t.Errorf("Expected %q, but got %q", sql, compound.String())
+	}
+	if compound.OrderBy == nil || compound.Limit == nil {
+		t.Fatalf("Expected ORDER BY and LIMIT on the compound query: %s", compound.String())
+	}
+	left := compound.SetOperation.Left.(*SelectQuery)
+	right := compound.SetOperation.Right.(*SelectQuery)
+	if !left.HasParen || left.Limit == nil || right.OrderBy != nil {
+		t.Errorf("Unexpected operands: %s | %s", left.String(), right.String())
+	}
+
+	// ClickHouse applies ORDER BY and LIMIT to the last SELECT
+	stmts, err = NewParserWithOptions(`SELECT a FROM t UNION ALL SELECT b FROM u ORDER BY b LIMIT 5`,
+		Options{Dialect: DialectClickHouse}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	compound = stmts[0].(*SelectQuery)
+	if compound.OrderBy != nil || compound.SetOperation.Right.(*SelectQuery).Limit == nil {
+		t.Errorf("Expected ORDER BY and LIMIT on the last SELECT: %s", compound.String())
+	}
+
+	// so does the default dialect, a parenthesized operand takes the clauses after it
+	sql = `SELECT a FROM t ORDER BY a LIMIT 1 UNION ALL (SELECT b FROM u) LIMIT 2 UNION ALL SELECT c FROM v`
+	stmts, err = NewParser(sql).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	compound = stmts[0].(*SelectQuery)
+	if compound.String() != `SELECT a FROM t ORDER BY a LIMIT 1 UNION ALL (SELECT b FROM u LIMIT 2) UNION ALL SELECT c FROM v` {
+		t.Errorf("Unexpected compound query %s", compound.String())
+	}
+	left = compound.SetOperation.Left.(*SetOperation).Left.(*SelectQuery)
+	right = compound.SetOperation.Left.(*SetOperation).Right.(*SelectQuery)
+	if left.Limit == nil || right.Limit == nil || compound.Limit != nil {
+		t.Errorf("Expected LIMIT on the first two operands: %s", compound.String())
+	}
+	if compound.UnionAll() == nil || compound.UnionAll().String() != "SELECT c FROM v" || compound.Except() != nil {
+		t.Errorf("Unexpected deprecated accessors of %s", compound.String())
+	}
+
+	// the clauses after a parenthesized compound query apply to all of it
+	sql = `(SELECT a FROM t UNION ALL SELECT b FROM u) ORDER BY a LIMIT 3`
+	for _, dialect := range []Dialect{DialectDefault, DialectMySQL, DialectClickHouse, DialectPostgreSQL} {
+		stmts, err = NewParserWithOptions(sql, Options{Dialect: dialect}).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL in dialect %d: %v", dialect, err)
+		}
+		compound = stmts[0].(*SelectQuery)
+		if compound.String() != sql || compound.OrderBy == nil || compound.Limit == nil {
+			t.Errorf("Expected ORDER BY and LIMIT on the compound query in dialect %d: %s", dialect, compound.String())
+		}
+		if compound.End() != Pos(len(sql)) {
+			t.Errorf("Expected the query to end at %d, got %d", len(sql), compound.End())
+		}
+	}
+	if _, err := NewParser(`(SELECT a FROM t LIMIT 1) LIMIT 2`).Parse(); err == nil {
+		t.Error("Expected an error for a second LIMIT")
+	}
+}
+
+func TestParseSelectTailClauses(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		sql     string
+		check   func(query *SelectQuery) bool
+	}{
+		{
+			dialect: DialectClickHouse,
+			sql:     `SELECT a, row_number() OVER (ORDER BY b) AS rn FROM t QUALIFY rn = 1`,
+			check:   func(query *SelectQuery) bool { return query.Qualify != nil },
+		},
+		{
+			dialect: DialectClickHouse,
+			sql:     `SELECT a FROM t ORDER BY a LIMIT 5 WITH TIES`,
+			check:   func(query *SelectQuery) bool { return query.Limit.WithTies },
+		},
+		{
+			dialect: DialectPostgreSQL,
+			sql:     `SELECT a FROM t ORDER BY a OFFSET 10 ROWS FETCH FIRST 5 ROWS ONLY`,
+			check: func(query *SelectQuery) bool {
+				return query.Offset.Offset.String() == "10" && query.Fetch.Count.String() == "5" && !query.Fetch.WithTies
+			},
+		},
+		{
+			dialect: DialectClickHouse,
+			sql:     `SELECT a FROM t INTO OUTFILE 'out.csv.gz' COMPRESSION 'gzip' LEVEL 3 FORMAT CSV`,
+			check: func(query *SelectQuery) bool {
+				return query.IntoOutfile.Compression.Literal == "gzip" && query.Format != nil
+			},
+		},
+		{
+			dialect: DialectMySQL,
+			sql:     `SELECT a FROM t WHERE id = 1 FOR UPDATE SKIP LOCKED`,
+			check: func(query *SelectQuery) bool {
+				return query.Locking.Mode == LockModeForUpdate && query.Locking.Wait == LockWaitSkipLocked
+			},
+		},
+		{
+			dialect: DialectPostgreSQL,
+			sql:     `SELECT a FROM t JOIN u ON t.id = u.id LIMIT 1 FOR SHARE OF t NOWAIT`,
+			check: func(query *SelectQuery) bool {
+				return query.Locking.Mode == LockModeForShare && len(query.Locking.Tables) == 1
+			},
+		},
+		{
+			dialect: DialectMySQL,
+			sql:     `SELECT a FROM t LOCK IN SHARE MODE`,
+			check:   func(query *SelectQuery) bool { return query.Locking.Mode == LockModeLockInShareMode },
+		},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParserWithOptions(tt.sql, Options{Dialect: tt.dialect}).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		query := stmts[0].(*SelectQuery)
+		if query.String() != tt.sql {
+			t.Errorf("Expected %q, but got %q", tt.sql, query.String())
+		}
+		if int(query.End()) != len(tt.sql) {
+			t.Errorf("Expected %q to end at %d, but got %d", tt.sql, len(tt.sql), query.End())
+		}
+		if !tt.check(query) {
+			t.Errorf("Unexpected clauses in %q", tt.sql)
+		}
+	}
+
+	for _, sql := range []string{
+		`SELECT a FROM t LOCK IN SHARE MODE`,
+		`SELECT a FROM t FOR UPDATE`,
+	} {
+		if _, err := NewParserWithOptions(sql, Options{Dialect: DialectClickHouse}).Parse(); err == nil {
+			t.Errorf("Expected ClickHouse to reject %q", sql)
+		}
+	}
+}
+
+func TestParseStatementFormat(t *testing.T) {
+	tests := []struct {
+		sql    string
+		format string
+	}{
+		{sql: `SELECT a FROM t FORMAT JSONEachRow`, format: "JSONEachRow"},
+		{sql: `SELECT a FROM t UNION ALL SELECT b FROM u FORMAT TSV`, format: "TSV"},
+		{sql: `(SELECT a FROM t) UNION ALL (SELECT b FROM u) FORMAT CSV`, format: "CSV"},
+		{sql: `EXPLAIN AST SELECT 1 FORMAT JSON`, format: "JSON"},
+		{sql: `CHECK TABLE t FORMAT PrettyCompact`, format: "PrettyCompact"},
+		{sql: `OPTIMIZE TABLE t FINAL FORMAT JSON`, format: "JSON"},
+		{sql: `INSERT INTO t (a, b) VALUES (1, 2) FORMAT Native`, format: "Native"},
+		{sql: `INSERT INTO t SELECT a FROM u`, format: ""},
+		{sql: `CREATE TABLE t (a Int32) ENGINE = Memory FORMAT JSON`, format: "JSON"},
+		{sql: `DROP TABLE t FORMAT JSON`, format: "JSON"},
+		{sql: `DROP DATABASE db FORMAT TSV`, format: "TSV"},
+		{sql: `SYSTEM FLUSH LOGS FORMAT JSON`, format: "JSON"},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		if got := EffectiveFormat(stmts[0]); got != tt.format {
+			t.Errorf("Expected format %q for %q, but got %q", tt.format, tt.sql, got)
+		}
+		if stmts[0].String() != tt.sql {
+			t.Errorf("Expected %q, but got %q", tt.sql, stmts[0].String())
+		}
+	}
+
+	sql := "INSERT INTO t (a, b) FORMAT CSV\n1,\"x\n2,y\n"
+	stmts, err := NewParser(sql).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	insert := stmts[0].(*InsertStmt)
+	if insert.Data != "1,\"x\n2,y" || sql[insert.DataPos:insert.End()] != insert.Data {
+		t.Errorf("Unexpected inline data %q at %d", insert.Data, insert.DataPos)
+	}
+	if EffectiveFormat(insert) != "CSV" {
+		t.Errorf("Expected format CSV, but got %q", EffectiveFormat(insert))
+	}
+
+	stmts, err = NewParser(`INSERT INTO t FORMAT CSV; SELECT 1`).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	if len(stmts) != 2 || stmts[0].(*InsertStmt).Data != "" {
+		t.Errorf("Expected an INSERT without data followed by a SELECT, but got %v", stmts)
+	}
+
+	_, err = NewParser(`TRUNCATE TABLE t FORMAT JSON`).Parse()
+	if err == nil || !strings.Contains(err.Error(), "FORMAT is not allowed after this statement") {
+		t.Errorf("Expected FORMAT to be rejected after TRUNCATE, but got %v", err)
+	}
+}
+
+func TestParseCreateDictionary(t *testing.T) {
+	sql := "CREATE OR REPLACE DICTIONARY IF NOT EXISTS db.rates ON CLUSTER c (" +
+		"id UInt64 IS_OBJECT_ID, parent UInt64 DEFAULT 0 HIERARCHICAL, start Date, stop Date, " +
+		"rate Float64 EXPRESSION rate * 2 INJECTIVE) PRIMARY KEY id " +
+		"SOURCE(CLICKHOUSE(TABLE 'rates' WHERE 'id > 0')) LAYOUT(RANGE_HASHED(range_lookup_strategy 'max')) " +
+		"LIFETIME(MIN 0 MAX 300) RANGE(MIN start MAX stop) SETTINGS(format_csv_allow_single_quotes=0) COMMENT 'rates'"
+	stmts, err := NewParser(sql).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	dictionary, ok := stmts[0].(*CreateDictionary)
+	if !ok {
+		t.Fatalf("Expected *CreateDictionary, but got %T", stmts[0])
+	}
+	if dictionary.String() != sql {
+		t.Errorf("Expected %q, but got %q", sql, dictionary.String())
+	}
+	if dictionary.Type() != "DICTIONARY" || !dictionary.OrReplace || !dictionary.IfNotExists {
+		t.Errorf("Unexpected dictionary header: %q", dictionary.String())
+	}
+	if len(dictionary.Attributes) != 5 || !dictionary.Attributes[0].IsObjectID ||
+		!dictionary.Attributes[1].Hierarchical || dictionary.Attributes[1].Default == nil ||
+		!dictionary.Attributes[4].Injective || dictionary.Attributes[4].Expression == nil {
+		t.Errorf("Unexpected dictionary attributes: %v", dictionary.Attributes)
+	}
+	if dictionary.Source.Source.Name != "CLICKHOUSE" || len(dictionary.Source.Args) != 2 ||
+		dictionary.Source.Args[1].Name.Name != "WHERE" {
+		t.Errorf("Unexpected dictionary source: %s", dictionary.Source)
+	}
+	if dictionary.Layout.Layout.Name != "RANGE_HASHED" || len(dictionary.Layout.Args) != 1 {
+		t.Errorf("Unexpected dictionary layout: %s", dictionary.Layout)
+	}
+	if dictionary.Lifetime.Min.Literal != "0" || dictionary.Lifetime.Max.Literal != "300" {
+		t.Errorf("Unexpected dictionary lifetime: %s", dictionary.Lifetime)
+	}
+	if dictionary.Range.Min.Name != "start" || dictionary.Range.Max.Name != "stop" {
+		t.Errorf("Unexpected dictionary range: %s", dictionary.Range)
+	}
+	if len(dictionary.Settings.Items) != 1 || dictionary.End() != dictionary.Comment.End() {
+		t.Errorf("Unexpected dictionary settings or end: %s, %d", dictionary.Settings, dictionary.End())
+	}
+
+	for _, sql := range []string{
+		"CREATE DICTIONARY d (k UInt64, v String DEFAULT '') PRIMARY KEY k SOURCE(MYSQL(host 'x' port 3306 user 'root' db 'test' table 'dict')) LAYOUT(HASHED()) LIFETIME(300)",
+		"CREATE DICTIONARY d (k UInt64) LAYOUT(FLAT()) SOURCE(NULL()) PRIMARY KEY k",
+	} {
+		stmts, err := NewParser(sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", sql, err)
+		}
+		if _, ok := stmts[0].(*CreateDictionary); !ok {
+			t.Errorf("Expected *CreateDictionary for %q, but got %T", sql, stmts[0])
+		}
+	}
+
+	// nested source parameters and a LIFETIME with MAX first
+	sql = "CREATE DICTIONARY d (k UInt64) PRIMARY KEY k " +
+		"SOURCE(MYSQL(port 3306 replica(host 'a' priority 1) replica(host 'b' priority 2) db 'test' table 'dict')) " +
+		"LAYOUT(HASHED()) LIFETIME(MAX 300 MIN 0)"
+	stmts, err = NewParser(sql).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	dictionary = stmts[0].(*CreateDictionary)
+	replica := dictionary.Source.Args[1]
+	if replica.Name.Name != "replica" || replica.Value != nil || len(replica.Args) != 2 || replica.Args[1].Name.Name != "priority" ||
+		sql[replica.Start():replica.End()] != "replica(host 'a' priority 1)" {
+		t.Errorf("Unexpected replica: %s", replica)
+	}
+	if dictionary.Lifetime.Min.Literal != "0" || dictionary.Lifetime.Max.Literal != "300" {
+		t.Errorf("Unexpected dictionary lifetime: %s", dictionary.Lifetime)
+	}
+	expected := strings.Replace(sql, "LIFETIME(MAX 300 MIN 0)", "LIFETIME(MIN 0 MAX 300)", 1)
+	if dictionary.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, dictionary.String())
+	}
+
+	sql = "CREATE DICTIONARY d (k UInt64) PRIMARY KEY k " +
+		"SOURCE(HTTP(url 'http://x/d.tsv' format 'TabSeparated' headers(header(name 'x' value 'y')))) LAYOUT(FLAT()) LIFETIME(0)"
+	stmts, err = NewParser(sql).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	if actual := stmts[0].String(); actual != sql {
+		t.Errorf("Expected %q, but got %q", sql, actual)
+	}
+
+	if _, err := NewParserWithOptions(`CREATE DICTIONARY d (k UInt64) PRIMARY KEY k`, Options{Dialect: DialectMySQL}).Parse(); err == nil {
+		t.Errorf("Expected CREATE DICTIONARY to be rejected in MySQL")
+	}
+}
+
+func TestParseAccessControl(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{sql: `REVOKE SELECT(x, y), INSERT ON db.t FROM alice, bob`},
+		{sql: `REVOKE ON CLUSTER c GRANT OPTION FOR ALL ON *.* FROM ALL EXCEPT admin`},
+		{sql: `REVOKE ADMIN OPTION FOR r1, r2 FROM CURRENT_USER`},
+		{sql: `REVOKE INSERT ON *.* FROM 'jeffrey'@'localhost'`},
+		{sql: `ALTER USER IF EXISTS u1 RENAME TO u2 IDENTIFIED WITH sha256_password BY 'x' HOST IP '10.0.0.0/8' DEFAULT ROLE r1`},
+		{sql: `ALTER USER 'u'@'localhost' IDENTIFIED BY 'pw'`},
+		{sql: `SET ROLE DEFAULT`},
+		{sql: `SET ROLE ALL EXCEPT r1, r2`},
+		{sql: `SET DEFAULT ROLE r1, r2 TO u1, CURRENT_USER`},
+		{sql: `ALTER USER alice DEFAULT ROLE NONE`},
+		{sql: `ALTER USER alice DEFAULT ROLE ALL EXCEPT r1, r2`},
+		{sql: `CREATE USER bob DEFAULT ROLE r1 EXCEPT r2 GRANTEES ANY`},
+		{sql: `CREATE USER carol GRANTEES NONE`},
+		{
+			sql:      `CREATE POLICY OR REPLACE p1 ON CLUSTER c ON db.t1, db.* IN local_directory FOR SELECT USING a = 1 AS RESTRICTIVE TO ALL EXCEPT admin`,
+			expected: `CREATE ROW POLICY OR REPLACE p1 ON CLUSTER c ON db.t1, db.* IN local_directory FOR SELECT USING a = 1 AS RESTRICTIVE TO ALL EXCEPT admin`,
+		},
+		{sql: `ALTER ROW POLICY p ON t RENAME TO p2 USING NONE AS PERMISSIVE TO NONE`},
+		{sql: `DROP ROW POLICY IF EXISTS p1, p2 ON db.t`},
+		{
+			sql:      `CREATE SETTINGS PROFILE IF NOT EXISTS prof SETTINGS max_memory_usage = 100 MIN 90 MAX 110 READONLY, INHERIT 'base' TO r1`,
+			expected: `CREATE SETTINGS PROFILE IF NOT EXISTS prof SETTINGS max_memory_usage=100 MIN 90 MAX 110 READONLY, INHERIT 'base' TO r1`,
+		},
+		{sql: `ALTER SETTINGS PROFILE prof RENAME TO prof2 SETTINGS max_threads=4 TO ALL`},
+		{sql: `DROP SETTINGS PROFILE prof`},
+		{sql: `CREATE QUOTA OR REPLACE q KEYED BY client_key, user_name FOR INTERVAL 30 minute MAX execution_time=0.5, FOR RANDOMIZED INTERVAL 5 quarter MAX queries=321, errors=10 TO default`},
+		{sql: `CREATE QUOTA q2 NOT KEYED FOR INTERVAL 1 hour NO LIMITS, FOR INTERVAL 1 day TRACKING ONLY`},
+		{sql: `ALTER QUOTA IF EXISTS q RENAME TO q3 FOR INTERVAL 1 hour MAX queries=10 TO ALL`},
+		{sql: `DROP QUOTA q`},
+		{sql: `GRANT r1, admin TO u1, u2 WITH ADMIN OPTION`},
+		{sql: `GRANT ALTER COLUMN, DROP, CREATE TABLE ON db.* TO r1 WITH GRANT OPTION`},
+		{sql: `GRANT SYSTEM FLUSH LOGS, SYSTEM RELOAD ON *.* TO r1`},
+		{sql: `GRANT ACCESS MANAGEMENT, URL, REMOTE ON *.* TO r1`},
+		{sql: `GRANT remote, url TO r1`},
+		{sql: `KILL QUERY ON CLUSTER c WHERE query_id = 'q' SYNC`},
+		{sql: `KILL MUTATION WHERE mutation_id = 'm' TEST`},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		expected := tt.expected
+		if expected == "" {
+			expected = tt.sql
+		}
+		if stmts[0].String() != expected {
+			t.Errorf("Expected %q, but got %q", expected, stmts[0].String())
+		}
+	}
+
+	stmts, err := NewParser(`ALTER USER alice DEFAULT ROLE NONE`).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	if end := stmts[0].(*AlterUser).DefaultRole.End(); end != 34 {
+		t.Errorf("Expected DEFAULT ROLE NONE to end at 34, but got %d", end)
+	}
+
+	stmts, err = NewParser(`REVOKE SELECT ON db.t FROM ALL EXCEPT admin`).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	revoke := stmts[0].(*RevokeStmt)
+	if revoke.Privileges[0].Keywords[0] != KeywordSelect || revoke.On.String() != "db.t" ||
+		revoke.From.Keyword != KeywordAll || revoke.From.Except[0].String() != "admin" {
+		t.Errorf("Unexpected REVOKE: %#v", revoke)
+	}
+
+	stmts, err = NewParser(`CREATE QUOTA q FOR INTERVAL 1 hour MAX queries = 10, errors = 5 TO r1`).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	quota := stmts[0].(*CreateQuota)
+	if len(quota.Intervals) != 1 || len(quota.Intervals[0].Limits) != 2 || quota.Intervals[0].Unit.Name != "hour" ||
+		quota.To.Roles[0].String() != "r1" {
+		t.Errorf("Unexpected CREATE QUOTA: %s", quota)
+	}
+
+	mysql := Options{Dialect: DialectMySQL}
+	if _, err := NewParserWithOptions(`REVOKE SELECT ON db.* FROM 'u'@'%'`, mysql).Parse(); err != nil {
+		t.Errorf("Expected REVOKE to parse in MySQL: %v", err)
+	}
+	if _, err := NewParserWithOptions(`CREATE ROW POLICY p ON t USING 1`, mysql).Parse(); err == nil {
+		t.Errorf("Expected CREATE ROW POLICY to be rejected in MySQL")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		sql         string
+		category    StatementCategory
+		readOnly    bool
+		destructive bool
+		reads       []string
+		writes      []string
+	}{
+		{
+			sql:      "WITH recent AS (SELECT * FROM db.events) SELECT * FROM recent JOIN users u ON recent.uid = u.id WHERE id IN (SELECT id FROM numbers(10))",
+			category: StatementCategoryDQL,
+			readOnly: true,
+			reads:    []string{"db.events", "users"},
+		},
+		{
+			sql:      "WITH secret AS (SELECT * FROM secret) SELECT * FROM secret",
+			category: StatementCategoryDQL,
+			readOnly: true,
+			reads:    []string{"secret"},
+		},
+		{
+			sql:      "SELECT * FROM (WITH x AS (SELECT 1) SELECT * FROM x) JOIN x ON 1",
+			category: StatementCategoryDQL,
+			readOnly: true,
+			reads:    []string{"x"},
+		},
+		{
+			sql:      "WITH a AS (SELECT * FROM b), b AS (SELECT 1) SELECT * FROM a, b WHERE 1 IN (SELECT 1 FROM a)",
+			category: StatementCategoryDQL,
+			readOnly: true,
+			reads:    []string{"b"},
+		},
+		{
+			sql:      "SELECT a FROM t1 UNION ALL SELECT a FROM t2",
+			category: StatementCategoryDQL,
+			readOnly: true,
+			reads:    []string{"t1", "t2"},
+		},
+		{
+			sql:      "SELECT * FROM t INTO OUTFILE 'out.csv'",
+			category: StatementCategoryDQL,
+			reads:    []string{"t"},
+		},
+		{
+			sql:      "DETACH TABLE db.t",
+			category: StatementCategoryDDL,
+			writes:   []string{"db.t"},
+		},
+		{
+			sql:      "DETACH DATABASE db",
+			category: StatementCategoryDDL,
+			writes:   []string{"db.*"},
+		},
+		{
+			sql:      "CREATE TABLE db.t2 AS db.t1",
+			category: StatementCategoryDDL,
+			reads:    []string{"db.t1"},
+			writes:   []string{"db.t2"},
+		},
+		{
+			sql:      "INSERT INTO FUNCTION remote('host:9000', db.t) SELECT a FROM s",
+			category: StatementCategoryDML,
+			reads:    []string{"s"},
+			writes:   []string{"remote('host:9000', db.t)"},
+		},
+		{
+			sql:      "EXPLAIN AST SELECT * FROM s",
+			category: StatementCategoryDQL,
+			readOnly: true,
+			reads:    []string{"s"},
+		},
+		{
+			sql:      "INSERT INTO db.t (a) SELECT a FROM db.s",
+			category: StatementCategoryDML,
+			reads:    []string{"db.s"},
+			writes:   []string{"db.t"},
+		},
+		{
+			sql:         "DELETE FROM t WHERE id IN (SELECT id FROM s)",
+			category:    StatementCategoryDML,
+			destructive: true,
+			reads:       []string{"s"},
+			writes:      []string{"t"},
+		},
+		{
+			sql:      "ALTER TABLE t UPDATE a = 1 WHERE b = 2",
+			category: StatementCategoryDML,
+			writes:   []string{"t"},
+		},
+		{
+			sql:         "ALTER TABLE t DROP PARTITION 202401",
+			category:    StatementCategoryDDL,
+			destructive: true,
+			writes:      []string{"t"},
+		},
+		{
+			sql:      "CREATE VIEW v AS SELECT * FROM t",
+			category: StatementCategoryDDL,
+			reads:    []string{"t"},
+			writes:   []string{"v"},
+		},
+		{
+			sql:         "DROP DATABASE db",
+			category:    StatementCategoryDDL,
+			destructive: true,
+			writes:      []string{"db.*"},
+		},
+		{
+			sql:         "TRUNCATE TABLE db.t",
+			category:    StatementCategoryDDL,
+			destructive: true,
+			writes:      []string{"db.t"},
+		},
+		{
+			sql:      "GRANT SELECT ON db.* TO r1",
+			category: StatementCategoryDCL,
+		},
+		{
+			sql:      "SET ROLE r1",
+			category: StatementCategoryDCL,
+			readOnly: true,
+		},
+		{
+			sql:      "SET max_threads = 4",
+			category: StatementCategoryAdmin,
+			readOnly: true,
+		},
+		{
+			sql:      "OPTIMIZE TABLE t FINAL",
+			category: StatementCategoryAdmin,
+			writes:   []string{"t"},
+		},
+		{
+			sql:      "SYSTEM FLUSH LOGS",
+			category: StatementCategoryAdmin,
+		},
+		{
+			sql:      "LOCK TABLES t READ, u WRITE",
+			category: StatementCategoryTCL,
+			reads:    []string{"t"},
+			writes:   []string{"u"},
+		},
+		{
+			sql:      "SET GLOBAL max_connections = 100",
+			category: StatementCategoryAdmin,
+		},
+	}
+	names := func(tables []*TableIdentifier) string {
+		var result []string
+		for _, table := range tables {
+			result = append(result, table.String())
+		}
+		return strings.Join(result, ", ")
+	}
+	for _, tt := range tests {
+		t.Run(tt.sql, func(t *testing.T) {
+			stmts, err := NewParser(tt.sql).Parse()
+			if err != nil {
+				t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+			}
+			info, err := Classify(stmts[0])
+			if err != nil {
+				t.Fatalf("Classify: %v", err)
+			}
+			if info.Category != tt.category || info.ReadOnly != tt.readOnly || info.Destructive != tt.destructive {
+				t.Fatalf("expected %s read-only=%v destructive=%v, got %s read-only=%v destructive=%v",
+					tt.category, tt.readOnly, tt.destructive, info.Category, info.ReadOnly, info.Destructive)
+			}
+			if names(info.Reads) != strings.Join(tt.reads, ", ") {
+				t.Fatalf("expected reads %v, got %v", tt.reads, names(info.Reads))
+			}
+			if names(info.Writes) != strings.Join(tt.writes, ", ") {
+				t.Fatalf("expected writes %v, got %v", tt.writes, names(info.Writes))
+			}
+		})
+	}
+
+	for sql, expected := range map[string]string{
+		"SELECT u.id, o.total FROM users u JOIN orders o USING (id) WHERE u.age > 18": "map[orders:[id total] users:[id age]]",
+		"SELECT a FROM t WHERE b IN (SELECT c FROM s WHERE s.d = t.e) ORDER BY a":     "map[s:[c d] t:[a b c e]]",
+		"WITH c AS (SELECT a, b FROM t) SELECT a FROM c":                              "map[t:[a b]]",
+		"SELECT a, sum(b) OVER w FROM t WINDOW w AS (PARTITION BY c)":                 "map[t:[a b c]]",
+		"SELECT a FROM t UNION ALL SELECT * FROM t":                                   "map[]",
+		"SELECT s.*, t.a FROM s, t":                                                   "map[t:[a]]",
+		"SELECT count() FROM t":                                                       "map[]",
+	} {
+		stmts, err := NewParser(sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", sql, err)
+		}
+		info, err := Classify(stmts[0])
+		if err != nil {
+			t.Fatalf("Classify: %v", err)
+		}
+		if actual := fmt.Sprint(info.ReadColumns); actual != expected {
+			t.Errorf("%s: expected read columns %s, got %s", sql, expected, actual)
+		}
+	}
+
+	if _, err := Classify(&Ident{Name: "a"}); err == nil {
+		t.Fatal("expected an error for an expression that is not a statement")
+	}
+}
+
+func TestParseSessionStatements(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{sql: "BEGIN"},
+		{sql: "BEGIN TRANSACTION"},
+		{sql: "START TRANSACTION READ ONLY, WITH CONSISTENT SNAPSHOT"},
+		{sql: "COMMIT"},
+		{sql: "COMMIT WORK AND NO CHAIN NO RELEASE"},
+		{sql: "ROLLBACK"},
+		{sql: "ROLLBACK TO sp1", expected: "ROLLBACK TO SAVEPOINT sp1"},
+		{sql: "SAVEPOINT sp1"},
+		{sql: "RELEASE SAVEPOINT sp1"},
+		{sql: "LOCK TABLES t WRITE, db.u AS x READ LOCAL, v LOW_PRIORITY WRITE"},
+		{sql: "LOCK TABLE t u READ", expected: "LOCK TABLES t AS u READ"},
+		{sql: "UNLOCK TABLES"},
+		{sql: "SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci"},
+		{sql: "SET CHARACTER SET utf8"},
+		{sql: "SET @a := 1, @b = @@session.sql_mode", expected: "SET @a := 1, @b = @@SESSION.sql_mode"},
+		{sql: "SET SESSION sql_mode = 'STRICT_TRANS_TABLES', GLOBAL max_connections = DEFAULT"},
+		{sql: "SET SQL_MODE=@OLD_SQL_MODE", expected: "SET SQL_MODE = @OLD_SQL_MODE"},
+		{sql: "SET @b = @a + 1"},
+		{sql: "SET @b := @a * 2, @c = 3"},
+		{sql: "SELECT @a := 1, @b"},
+		{sql: "SET FOREIGN_KEY_CHECKS=0"},
+		{sql: "SET TRANSACTION ISOLATION LEVEL READ COMMITTED"},
+		{sql: "SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY"},
+		{sql: "set global transaction isolation level serializable",
+			expected: "SET GLOBAL TRANSACTION ISOLATION LEVEL SERIALIZABLE"},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse SQL %q: %v", tt.sql, err)
+		}
+		if len(stmts) != 1 {
+			t.Fatalf("Expected 1 statement for %q, got %d", tt.sql, len(stmts))
+		}
+		expected := tt.expected
+		if expected == "" {
+			expected = tt.sql
+		}
+		if stmts[0].String() != expected {
+			t.Fatalf("Expected %q, got %q", expected, stmts[0].String())
+		}
+		if int(stmts[0].End()) != len(tt.sql) {
+			t.Fatalf("Expected %q to end at %d, got %d", tt.sql, len(tt.sql), stmts[0].End())
+		}
+	}
+
+	dump := "SET NAMES utf8mb4;\n" +
+		"SET @OLD_UNIQUE_CHECKS=@@UNIQUE_CHECKS, UNIQUE_CHECKS=0;\n" +
+		"SET FOREIGN_KEY_CHECKS=0;\n" +
+		"START TRANSACTION;\n" +
+		"LOCK TABLES `t` WRITE;\n" +
+		"INSERT INTO `t` VALUES (1),(2);\n" +
+		"UNLOCK TABLES;\n" +
+		"COMMIT;\n"
+	stmts, err := NewParserWithOptions(dump, Options{Dialect: DialectMySQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse dump: %v", err)
+	}
+	kinds := make([]string, 0, len(stmts))
+	for _, stmt := range stmts {
+		kinds = append(kinds, fmt.Sprintf("%T", stmt))
+	}
+	expected := "*parser.SetNamesStmt *parser.SetVariableStmt *parser.SetVariableStmt *parser.BeginStmt " +
+		"*parser.LockTablesStmt *parser.InsertStmt *parser.UnlockTablesStmt *parser.CommitStmt"
+	if strings.Join(kinds, " ") != expected {
+		t.Fatalf("Expected %s, got %s", expected, strings.Join(kinds, " "))
+	}
+
+	if _, err := NewParserWithOptions("LOCK TABLES t READ", Options{Dialect: DialectClickHouse}).Parse(); err == nil {
+		t.Fatal("Expected LOCK TABLES to be rejected in ClickHouse")
+	}
+	stmts, err = NewParserWithOptions("SET TRANSACTION READ WRITE, NOT DEFERRABLE",
+		Options{Dialect: DialectPostgreSQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SET TRANSACTION: %v", err)
+	}
+	if _, ok := stmts[0].(*SetTransactionStmt); !ok {
+		t.Fatalf("Expected a SetTransactionStmt, got %T", stmts[0])
+	}
+	if _, err := NewParserWithOptions("SET TRANSACTION DEFERRABLE", Options{Dialect: DialectMySQL}).Parse(); err == nil {
+		t.Fatal("Expected DEFERRABLE to be rejected in MySQL")
+	}
+
+	// statements ending early report an error
+	truncated := []string{
+		"SET x", "SET @a", "LOCK TABLES t", "LOCK", "UNLOCK", "START TRANSACTION READ",
+		"START TRANSACTION WITH CONSISTENT", "SET TRANSACTION", "SET TRANSACTION ISOLATION LEVEL",
+	}
+	for _, sql := range truncated {
+		for _, dialect := range []Dialect{DialectDefault, DialectMySQL} {
+			if _, err := NewParserWithOptions(sql, Options{Dialect: dialect}).Parse(); err == nil {
+				t.Errorf("Expected an error for %q in dialect %d", sql, dialect)
+			}
+		}
+	}
+	stmts, err = NewParserWithOptions("SET max_threads = 8", Options{Dialect: DialectClickHouse}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SET: %v", err)
+	}
+	if _, ok := stmts[0].(*SetStmt); !ok {
+		t.Fatalf("Expected a ClickHouse SetStmt, got %T", stmts[0])
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	sql := "SELECT `a`, 'it''s' -- note\r\n/* block */ FROM t\nWHERE x >= 1 -- end"
+	tokens, err := Tokenize(sql, TokenizeOptions{Whitespace: true, Comments: true})
+	if err != nil {
+		t.Fatalf("Failed to tokenize: %v", err)
+	}
+	var text strings.Builder
+	for _, token := range tokens {
+		text.WriteString(sql[token.Pos:token.End])
+	}
+	if text.String() != sql {
+		t.Fatalf("Expected tokens to cover the input, got %q", text.String())
+	}
+
+	tokens, err = Tokenize(sql, TokenizeOptions{})
+	if err != nil {
+		t.Fatalf("Failed to tokenize: %v", err)
+	}
+	expected := []struct {
+		kind         TokenKind
+		text         string
+		line, column int
+		quoteType    int
+	}{
+		{TokenKindKeyword, "SELECT", 1, 1, Unquoted},
+		{TokenKindIdent, "a", 1, 8, BackTicks},
+		{TokenKindComma, ",", 1, 11, 0},
+		{TokenKindString, "it''s", 1, 13, SingleQuote},
+		{TokenKindKeyword, "FROM", 2, 13, Unquoted},
+		{TokenKindIdent, "t", 2, 18, Unquoted},
+		{TokenKindKeyword, "WHERE", 3, 1, Unquoted},
+		{TokenKindIdent, "x", 3, 7, Unquoted},
+		{TokenKindGE, ">=", 3, 9, 0},
+		{TokenKindInt, "1", 3, 12, 0},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d", len(expected), len(tokens))
+	}
+	for i, token := range tokens {
+		e := expected[i]
+		if token.Kind != e.kind || token.String != e.text || token.Line != e.line ||
+			token.Column != e.column || token.QuoteType != e.quoteType {
+			t.Errorf("Unexpected token %d: %s %q at %d:%d quoted %d", i, token.Kind, token.String,
+				token.Line, token.Column, token.QuoteType)
+		}
+	}
+
+	tokens, err = Tokenize("SELECT\n  'abc", TokenizeOptions{})
+	if err == nil || err.Error() != "line 2:3 invalid string" {
+		t.Fatalf("Expected an invalid string error, got %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Fatalf("Expected the tokens before the error, got %d", len(tokens))
+	}
+
+	tokens, err = Tokenize("SELECT 1 /* open", TokenizeOptions{Comments: true})
+	if err == nil || err.Error() != "line 1:10 unterminated comment" || len(tokens) != 2 {
+		t.Fatalf("Expected an unterminated comment error, got %v %v", tokens, err)
+	}
+
+	tokens, err = Tokenize(`SELECT "a"`, TokenizeOptions{Dialect: DialectMySQL})
+	if err != nil || tokens[1].Kind != TokenKindString || tokens[1].QuoteType != DoubleQuote {
+		t.Fatalf("Expected a MySQL string, got %v %v", tokens, err)
+	}
+
+	// doubled quotes are escaped quotes, PostgreSQL keywords and identifiers keep their case
+	tokens, err = Tokenize(`SELECT "a""b", Foo FROM T`, TokenizeOptions{Dialect: DialectPostgreSQL})
+	if err != nil {
+		t.Fatalf("Failed to tokenize: %v", err)
+	}
+	var texts []string
+	for _, token := range tokens {
+		texts = append(texts, token.String)
+	}
+	if fmt.Sprint(texts) != `[SELECT a"b , Foo FROM T]` {
+		t.Errorf("Unexpected tokens %q", texts)
+	}
+	stmts, err := NewParserWithOptions("SELECT `a``b` c FROM t", Options{Dialect: DialectMySQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse SQL: %v", err)
+	}
+	item := stmts[0].(*SelectQuery).SelectItems[0]
+	if ident, ok := item.Expr.(*Ident); !ok || ident.Name != "a`b" || item.String() != "`a``b` AS c" {
+		t.Errorf("Expected the identifier a`b, got %s", item.String())
+	}
+}
+
+func TestParseMySQLComments(t *testing.T) {
+	hinted := []string{
+		"SELECT /*+ MAX_EXECUTION_TIME(1000) BKA(t1@qb1, `t 2`) */ DISTINCT a FROM t1",
+		"INSERT /*+ SET_VAR(foreign_key_checks = OFF) */ INTO t (a) VALUES (1)",
+		"DELETE /*+ NO_ICP() */ FROM t WHERE a = 1",
+	}
+	for _, sql := range hinted {
+		stmts, err := NewParserWithOptions(sql, Options{Dialect: DialectMySQL}).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", sql, err)
+		}
+		if stmts[0].String() != sql {
+			t.Fatalf("Expected %q, got %q", sql, stmts[0].String())
+		}
+	}
+	stmts, err := NewParser(hinted[0]).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse %q: %v", hinted[0], err)
+	}
+	hints := stmts[0].(*SelectQuery).Hints
+	if hints == nil || len(hints.Hints) != 2 || hints.Hints[1].Name.Name != "BKA" ||
+		strings.Join(hints.Hints[1].Args, "|") != "t1@qb1|`t 2`" {
+		t.Fatalf("Unexpected hints %v", hints)
+	}
+	if hinted[0][hints.Start():hints.End()] != hints.String() {
+		t.Fatalf("Unexpected hint position %d-%d", hints.Start(), hints.End())
+	}
+	if _, err := NewParser("SELECT /*+ MAX_EXECUTION_TIME */ 1").Parse(); err == nil {
+		t.Fatal("Expected an invalid hint to be rejected")
+	}
+	stmts, err = NewParserWithOptions("SELECT /*+ BKA(t1) */ 1", Options{Dialect: DialectClickHouse}).Parse()
+	if err != nil || stmts[0].(*SelectQuery).Hints != nil {
+		t.Fatalf("Expected a plain comment in ClickHouse, got %v", err)
+	}
+
+	dump := "# dump header\n" +
+		"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;\n" +
+		"/*!90000 SET x = 1 */;\n" +
+		"SELECT /*! 1 + */ 2; # trailing\n"
+	stmts, err = NewParserWithOptions(dump, Options{Dialect: DialectMySQL, MySQLVersion: 80032}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse dump: %v", err)
+	}
+	var texts []string
+	for _, stmt := range stmts {
+		texts = append(texts, stmt.String())
+	}
+	expected := "SET @OLD_CHARACTER_SET_CLIENT = @@CHARACTER_SET_CLIENT; SELECT 1 + 2"
+	if strings.Join(texts, "; ") != expected {
+		t.Fatalf("Expected %q, got %q", expected, strings.Join(texts, "; "))
+	}
+	if _, err := NewParserWithOptions("# comment\nSELECT 1", Options{Dialect: DialectClickHouse}).Parse(); err == nil {
+		t.Fatal("Expected # comments to be rejected outside of MySQL")
+	}
+
+	tokens, err := Tokenize("SELECT /*!40101 1 */ # x", TokenizeOptions{Dialect: DialectMySQL, Comments: true})
+	if err != nil {
+		t.Fatalf("Failed to tokenize: %v", err)
+	}
+	var kinds []string
+	for _, token := range tokens {
+		kinds = append(kinds, string(token.Kind)+" "+token.String)
+	}
+	expected = "<keyword> SELECT,<comment> /*!40101,<int> 1,<comment> */,<comment> # x"
+	if strings.Join(kinds, ",") != expected {
+		t.Fatalf("Expected %s, got %s", expected, strings.Join(kinds, ","))
+	}
+}
+
+func TestParseUnicodeIdentifiers(t *testing.T) {
+	sql := "SELECT 用户名, café, naïve_列1 FROM 订单 WHERE 金额 > 10"
+	stmts, err := NewParser(sql).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if stmts[0].String() != sql {
+		t.Fatalf("Expected %q, got %q", sql, stmts[0].String())
+	}
+	info, err := Classify(stmts[0])
+	if err != nil {
+		t.Fatalf("Failed to classify: %v", err)
+	}
+	from := info.Reads[0].Table
+	if from.Name != "订单" || sql[from.Start():from.End()] != "订单" {
+		t.Fatalf("Unexpected table %q at %d-%d", from.Name, from.Start(), from.End())
+	}
+
+	// full-width spaces separate tokens, a full-width comma is a token of its own
+	if _, err := NewParser("SELECT　a　FROM　t").Parse(); err != nil {
+		t.Fatalf("Failed to parse full-width spaces: %v", err)
+	}
+	_, err = NewParser("SELECT 名，b FROM t").Parse()
+	if err == nil || !strings.HasPrefix(err.Error(), "line 0:8 ") || !strings.Contains(err.Error(), `"，"`) {
+		t.Fatalf("Expected a rune-based column for the full-width comma, got %v", err)
+	}
+	if _, err := NewParser("SELECT 1中").Parse(); err == nil {
+		t.Fatal("Expected a number followed by a letter to be rejected")
+	}
+}
+
+func TestParameters(t *testing.T) {
+	ddl, err := NewParser(`CREATE TABLE db.events (id UInt64, name String, ts DateTime) ENGINE = MergeTree ORDER BY id;
+CREATE TABLE users (id UInt32, email String) ENGINE = Memory`).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	schema := NewSchema(ddl...)
+
+	tests := []struct {
+		dialect  Dialect
+		sql      string
+		expected string
+	}{
+		{
+			DialectDefault,
+			"SELECT * FROM db.events AS e JOIN users u ON e.id = u.id " +
+				"WHERE e.name = :name AND u.email IN (?, ?) AND ts BETWEEN ? AND CAST(? AS Date) LIMIT ?",
+			`:name named "name" 0 String, ? positional "" 1 String, ? positional "" 2 String, ` +
+				`? positional "" 3 DateTime, ? positional "" 4 Date, ? positional "" 5 UInt64`,
+		},
+		{
+			DialectPostgreSQL,
+			"SELECT * FROM users WHERE email = $2 AND id > $1 LIMIT $3",
+			`$2 numbered "" 2 String, $1 numbered "" 1 UInt32, $3 numbered "" 3 UInt64`,
+		},
+		{
+			DialectDefault,
+			"SELECT * FROM users WHERE id = @id AND email = {email: String} AND id = @@session.x",
+			`@id variable "id" 0 UInt32, {email: String} query "email" 0 String`,
+		},
+		{
+			DialectDefault,
+			"INSERT INTO db.events (name, id) VALUES (?, ?), (:n, id + ?)",
+			`? positional "" 1 String, ? positional "" 2 UInt64, :n named "n" 0 String, ? positional "" 3 UInt64`,
+		},
+		{
+			DialectDefault,
+			"SELECT * FROM db.events, users WHERE id = ? AND unknown = ?",
+			`? positional "" 1 <nil>, ? positional "" 2 <nil>`,
+		},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParserWithOptions(tt.sql, Options{Dialect: tt.dialect}).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.sql, err)
+		}
+		var described []string
+		for _, parameter := range Parameters(stmts[0], schema) {
+			if !strings.HasPrefix(tt.sql[parameter.Start():], parameter.Expr.String()) {
+				t.Errorf("Unexpected position %d-%d of %s", parameter.Start(), parameter.End(), parameter.Expr)
+			}
+			typeName := "<nil>"
+			if parameter.Type != nil {
+				typeName = parameter.Type.String()
+			}
+			described = append(described, fmt.Sprintf("%s %s %q %d %s", parameter.Expr, parameter.Kind,
+				parameter.Name, parameter.Index, typeName))
+		}
+		if strings.Join(described, ", ") != tt.expected {
+			t.Errorf("Parameters of %q:\nexpected %s\n     got %s", tt.sql, tt.expected, strings.Join(described, ", "))
+		}
+	}
+
+	if _, err := NewParserWithOptions("SELECT @@x", Options{Dialect: DialectClickHouse}).Parse(); err == nil {
+		t.Fatal("Expected system variables to be rejected in ClickHouse")
+	}
+}
+
+func TestBind(t *testing.T) {
+	at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	tests := []struct {
+		dialect  Dialect
+		sql      string
+		args     []any
+		expected string
+	}{
+		{
+			DialectDefault,
+			"SELECT * FROM t WHERE a = ? AND b IN ? AND c IN (?) AND d = ? AND e = ?",
+			[]any{"it's \\ ok", []int{1, 2}, []string{}, nil, []byte{0xca, 0xfe}},
+			`SELECT * FROM t WHERE a = 'it''s \\ ok' AND b IN (1, 2) AND c IN (NULL) AND d = NULL AND e = unhex('CAFE')`,
+		},
+		{
+			DialectMySQL,
+			"SELECT `a` FROM t WHERE ts > ? AND ok = ?",
+			[]any{at, true},
+			"SELECT `a` FROM t WHERE ts > '2024-05-06 07:08:09' AND ok = TRUE",
+		},
+		{
+			DialectClickHouse,
+			"SELECT a FROM t WHERE ts > ? AND tags = ? AND x = ?",
+			[]any{at, []string{"a"}, 1.5},
+			"SELECT a FROM t WHERE ts > toDateTime('2024-05-06 07:08:09', 'UTC') AND tags = ['a'] AND x = 1.5",
+		},
+		{
+			DialectPostgreSQL,
+			"SELECT a FROM t WHERE b = $1",
+			[]any{"a\\"},
+			`SELECT a FROM t WHERE b = 'a\'`,
+		},
+		{
+			DialectPostgreSQL,
+			"SELECT a FROM t WHERE b = $2 AND c = $1 AND d = $2",
+			[]any{"x\\y", int64(-3)},
+			`SELECT a FROM t WHERE b = -3 AND c = 'x\y' AND d = -3`,
+		},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParserWithOptions(tt.sql, Options{Dialect: tt.dialect}).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.sql, err)
+		}
+		bound, err := Binder{Dialect: tt.dialect}.Bind(stmts[0], tt.args...)
+		if err != nil {
+			t.Fatalf("Failed to bind %q: %v", tt.sql, err)
+		}
+		if bound != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, bound)
+		}
+		if stmts[0].String() == bound {
+			t.Errorf("Expected the statement to be unchanged after binding %q", tt.sql)
+		}
+		if _, err := NewParserWithOptions(bound, Options{Dialect: tt.dialect}).Parse(); err != nil {
+			t.Errorf("Failed to parse bound SQL %q: %v", bound, err)
+		}
+	}
+
+	stmts, err := NewParser("SELECT * FROM t WHERE a = ? AND b = ?").Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if _, err := Bind(stmts[0], 1); err == nil || err.Error() != "expected 2 arguments, got 1" {
+		t.Errorf("Expected an argument count error, got %v", err)
+	}
+	if _, err := Bind(stmts[0], 1, struct{}{}); err == nil {
+		t.Error("Expected an unsupported argument type to be rejected")
+	}
+}
+
+func TestBindNamed(t *testing.T) {
+	stmts, err := NewParser("SELECT * FROM t WHERE uid = {uid: UInt8} AND name = :name AND tag IN :tags AND x = @x AND y = @y").Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	bound, err := BindNamed(stmts[0], map[string]any{"uid": 7, "name": "bob", "tags": []string{"a", "b"}, "x": 1})
+	if err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+	expected := "SELECT * FROM t WHERE uid = 7 AND name = 'bob' AND tag IN ('a', 'b') AND x = 1 AND y = @y"
+	if bound != expected {
+		t.Fatalf("Expected %s, got %s", expected, bound)
+	}
+
+	for _, tt := range []struct {
+		args     map[string]any
+		expected string
+	}{
+		{map[string]any{"uid": 300, "name": "", "tags": nil}, "argument {uid: UInt8}: 300 (int) does not fit UInt8"},
+		{map[string]any{"uid": -1, "name": "", "tags": nil}, "argument {uid: UInt8}: -1 (int) does not fit UInt8"},
+		{map[string]any{"uid": nil, "name": "", "tags": nil}, "argument {uid: UInt8}: NULL does not fit UInt8"},
+		{map[string]any{"uid": 1, "tags": nil}, "missing argument for :name"},
+		{map[string]any{"uid": 1, "name": "", "tags": nil, "z": 1}, "arguments not used by any parameter: z"},
+	} {
+		if _, err := BindNamed(stmts[0], tt.args); err == nil || err.Error() != tt.expected {
+			t.Errorf("Expected %q, got %v", tt.expected, err)
+		}
+	}
+
+	stmts, err = NewParser("SELECT {ids: Array(Nullable(UInt32))}, {m: Map(String, Int8)}").Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	ok := map[string]any{"ids": []any{1, nil}, "m": map[string]int{"b": 2, "a": 1}}
+	if bound, err := BindNamed(stmts[0], ok); err != nil || bound != "SELECT [1, NULL], {'a': 1, 'b': 2}" {
+		t.Errorf("Unexpected binding %q: %v", bound, err)
+	}
+	if _, err := BindNamed(stmts[0], map[string]any{"ids": []string{"1"}, "m": map[string]int{}}); err == nil {
+		t.Error("Expected a string element to be rejected by Array(Nullable(UInt32))")
+	}
+
+	// the assigned variables are left as they are
+	stmts, err = NewParserWithOptions("SET @a = :x, @b = @a; SELECT @c := :x, @c", Options{Dialect: DialectMySQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if bound, err := BindNamed(stmts[0], map[string]any{"x": 1, "a": 2}); err != nil || bound != "SET @a = 1, @b = 2" {
+		t.Errorf("Unexpected binding %q: %v", bound, err)
+	}
+	if _, err := BindNamed(stmts[0], map[string]any{"x": 1, "b": 2}); err == nil || err.Error() != "arguments not used by any parameter: b" {
+		t.Errorf("Expected the assigned variable b to be unused, got %v", err)
+	}
+	if bound, err := BindNamed(stmts[1], map[string]any{"x": 1, "c": 2}); err != nil || bound != "SELECT @c := 1, 2" {
+		t.Errorf("Unexpected binding %q: %v", bound, err)
+	}
+}
+
+func TestEval(t *testing.T) {
+	now := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	env := &EvalEnv{
+		Vars: map[string]any{"x": int64(3), "n": 5, "t.name": "bob", "created": now},
+		Funcs: map[string]EvalFunc{
+			"double": func(args []any) (any, error) {
+				return args[0].(int64) * 2, nil
+			},
+		},
+		Now: func() time.Time { return now },
+	}
+	tests := []struct {
+		expr     string
+		expected any
+	}{
+		{"1 + 2 * 3", int64(7)},
+		{"(1 + 2) * x", int64(9)},
+		{"0x1F - 1", int64(30)},
+		{"017 + 1", int64(18)},
+		{"-9223372036854775808", int64(math.MinInt64)},
+		{"18446744073709551615", uint64(math.MaxUint64)},
+		{"7 / 2", 3.5},
+		{"-x % 2", int64(-1)},
+		{"'it''s' || '!'", "it's!"},
+		{"concat(t.name, '-', toString(x))", "bob-3"},
+		{"1 < 2 AND NOT (2 = 3)", true},
+		{"NULL = 1 OR 1 = 1", true},
+		{"NULL = 1 AND 1 = 1", nil},
+		{"x BETWEEN 1 AND 3", true},
+		{"x IN (1, 2)", false},
+		{"x NOT IN (1, 2)", true},
+		{"'Hello' LIKE 'He%o'", true},
+		{"'a_c' LIKE 'a\\_%'", true},
+		{"CASE x WHEN 1 THEN 'one' WHEN 3 THEN 'three' END", "three"},
+		{"CASE WHEN x > 5 THEN 'big' ELSE 'small' END", "small"},
+		{"if(x > 1, 'yes', 'no')", "yes"},
+		{"x > 1 ? 1 : 0", int64(1)},
+		{"CAST('42' AS Int32) + 1", int64(43)},
+		{"'1.5'::Float64", 1.5},
+		{"'7'::Nullable(Int8)", int64(7)},
+		{"toDate('2024-03-01 12:00:00')", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"now() + INTERVAL 1 MONTH", time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)},
+		{"today() - INTERVAL 2 DAY", time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC)},
+		{"double(x)", int64(6)},
+		{"n + 1", int64(6)},
+		{"NULL IS NULL", true},
+		{"CAST(300 AS UInt8)", int64(44)},
+		{"CAST(-1 AS UInt8)", int64(255)},
+		{"CAST(200 AS Int8)", int64(-56)},
+		{"CAST(-1 AS UInt64)", ^uint64(0)},
+		{"toDate(19000)", time.Date(2022, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{`'a\nb\\c'`, "a\nb\\c"},
+		{`'it\'s' || 'it''s'`, "it's" + "it's"},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParser("SELECT " + tt.expr).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.expr, err)
+		}
+		expr := stmts[0].(*SelectQuery).SelectItems[0].Expr
+		value, err := Eval(expr, env)
+		if err != nil {
+			t.Errorf("Failed to evaluate %q: %v", tt.expr, err)
+			continue
+		}
+		if value != tt.expected {
+			t.Errorf("Expected %q to be %#v, got %#v", tt.expr, tt.expected, value)
+		}
+	}
+
+	for _, tt := range []struct {
+		expr     string
+		expected string
+	}{
+		{"1 + y", "position 11: unknown identifier y"},
+		{"unknown(1)", "position 7: unknown function unknown"},
+		{"'a' + 1", "position 8: can not convert \"a\" to a number"},
+		{"9223372036854775807 + 1", "position 7: 9223372036854775807 + 1 overflows Int64"},
+		{"x * 4611686018427387904", "position 7: 3 * 4611686018427387904 overflows Int64"},
+	} {
+		stmts, err := NewParser("SELECT " + tt.expr).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.expr, err)
+		}
+		if _, err := Eval(stmts[0].(*SelectQuery).SelectItems[0].Expr, env); err == nil || err.Error() != tt.expected {
+			t.Errorf("Expected %q, got %v", tt.expected, err)
+		}
+	}
+
+	// PostgreSQL strings keep their backslashes
+	stmts, err := NewParserWithOptions(`SELECT 'a\nb'`, Options{Dialect: DialectPostgreSQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	value, err := Eval(stmts[0].(*SelectQuery).SelectItems[0].Expr, &EvalEnv{Dialect: DialectPostgreSQL})
+	if err != nil || value != `a\nb` {
+		t.Errorf("Expected a\\nb, got %#v %v", value, err)
+	}
+
+	// DEFAULT expressions and TTL deadlines are evaluated with the row's values bound
+	stmts, err = NewParser("CREATE TABLE t (created DateTime, d Date DEFAULT toDate(created)) ENGINE = MergeTree " +
+		"ORDER BY (created) TTL created + INTERVAL 1 DAY").Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	table := stmts[0].(*CreateTable)
+	column := NewSchema(table).Column(table, "d")
+	if value, err := Eval(column.DefaultExpr, env); err != nil || value != time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("Unexpected DEFAULT value %v: %v", value, err)
+	}
+	var ttl Expr
+	for _, option := range table.TableOptions {
+		if option.Name.Name == "TTL" {
+			ttl = option.Value
+		}
+	}
+	if ttl == nil {
+		t.Fatal("Expected a TTL option")
+	}
+	if value, err := Eval(ttl, env); err != nil || value != now.AddDate(0, 0, 1) {
+		t.Errorf("Unexpected TTL deadline %v: %v", value, err)
+	}
+}
+
+func TestCompileFilter(t *testing.T) {
+	compiler := FilterCompiler{Funcs: map[string]EvalFunc{
+		"lower": func(args []any) (any, error) {
+			return strings.ToLower(args[0].(string)), nil
+		},
+	}}
+	rows := []map[string]any{
+		{"id": int64(1), "name": "Alice", "level": "info", "score": 9.5, "tag": nil},
+		{"id": int64(2), "name": "bob", "level": "ERROR", "score": 3.0, "tag": "x"},
+		{"id": int64(3), "name": "carol", "level": "warn", "score": nil, "tag": "y"},
+	}
+	tests := []struct {
+		where    string
+		expected []int64
+	}{
+		{"id >= 2", []int64{2, 3}},
+		{"name LIKE 'a%' OR name ILIKE 'B%'", []int64{2}},
+		{"name NOT ILIKE '%o%'", []int64{1}},
+		{"lower(level) IN ('error', 'warn')", []int64{2, 3}},
+		{"id NOT IN (1, 3)", []int64{2}},
+		{"tag IS NULL", []int64{1}},
+		{"tag IS NOT NULL AND score BETWEEN 1 AND 5", []int64{2}},
+		{"NOT (score > 5)", []int64{2}},
+		{"e.id * 2 = 6", []int64{3}},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParser("SELECT * FROM events AS e WHERE " + tt.where).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.where, err)
+		}
+		filter, err := compiler.Compile(stmts[0].(*SelectQuery).Where)
+		if err != nil {
+			t.Fatalf("Failed to compile %q: %v", tt.where, err)
+		}
+		var matched []int64
+		for _, row := range rows {
+			ok, err := filter(row)
+			if err != nil {
+				t.Fatalf("Failed to filter %q: %v", tt.where, err)
+			}
+			if ok {
+				matched = append(matched, row["id"].(int64))
+			}
+		}
+		if fmt.Sprint(matched) != fmt.Sprint(tt.expected) {
+			t.Errorf("Expected %q to match %v, got %v", tt.where, tt.expected, matched)
+		}
+	}
+
+	for _, tt := range []struct {
+		where    string
+		expected string
+	}{
+		{"upper(name) = 'BOB'", "position 27: function upper is not allowed in a filter"},
+		{"id IN (SELECT id FROM banned)", "position 34: (SELECT id FROM banned) is not supported in a filter"},
+		{"name = :name", "position 34: :name is not supported in a filter"},
+		{"id = 1 AND CASE WHEN id THEN 1 END", "position 38: CASE WHEN id THEN 1 END is not supported in a filter"},
+	} {
+		stmts, err := NewParser("SELECT * FROM events WHERE " + tt.where).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.where, err)
+		}
+		if _, err := compiler.Compile(stmts[0].(*SelectQuery).Where); err == nil || err.Error() != tt.expected {
+			t.Errorf("Expected %q, got %v", tt.expected, err)
+		}
+	}
+
+	filter, err := CompileFilter(&BinaryOperation{
+		LeftExpr:  &Ident{Name: "missing"},
+		Operation: "=",
+		RightExpr: &NumberLiteral{Literal: "1", Base: 10},
+	})
+	if err != nil {
+		t.Fatalf("Failed to compile: %v", err)
+	}
+	if _, err := filter(rows[0]); err == nil || err.Error() != "position 0: unknown identifier missing" {
+		t.Errorf("Expected an unknown identifier error, got %v", err)
+	}
+}
+
+func TestExecutor(t *testing.T) {
+	executor := NewExecutor()
+	executor.Register("users", []string{"id", "name", "country"}, []map[string]any{
+		{"id": 1, "name": "alice", "country": "fr"},
+		{"id": 2, "name": "bob", "country": "de"},
+		{"id": 3, "name": "carol", "country": "fr"},
+		{"id": 4, "name": "dave"},
+	})
+	executor.Register("orders", nil, []map[string]any{
+		{"id": 10, "user_id": 1, "amount": 5.5},
+		{"id": 11, "user_id": 1, "amount": 4.5},
+		{"id": 12, "user_id": 2, "amount": 20},
+		{"id": 13, "user_id": 9, "amount": 1},
+	})
+	executor.Register("countries", nil, []map[string]any{
+		{"country": "fr", "label": "France"},
+		{"country": "de", "label": "Germany"},
+	})
+	executor.Register("scores", []string{"score"}, []map[string]any{
+		{"score": nil}, {"score": 5}, {"score": nil}, {"score": 1},
+	})
+
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{
+			"SELECT id, upper(name) AS n FROM users WHERE country = 'fr' ORDER BY id DESC",
+			"[id n] [[3 CAROL] [1 ALICE]]",
+		},
+		{
+			"SELECT * FROM users WHERE country IS NULL",
+			"[id name country] [[4 dave <nil>]]",
+		},
+		{
+			"SELECT u.name, o.amount FROM users AS u INNER JOIN orders AS o ON u.id = o.user_id ORDER BY o.id",
+			"[name amount] [[alice 5.5] [alice 4.5] [bob 20]]",
+		},
+		{
+			"SELECT u.name, count(o.id) AS orders, sum(o.amount) AS total FROM users u " +
+				"LEFT JOIN orders o ON o.user_id = u.id GROUP BY u.name ORDER BY total DESC, u.name",
+			"[name orders total] [[bob 1 20] [alice 2 10] [carol 0 <nil>] [dave 0 <nil>]]",
+		},
+		{
+			"SELECT name, label FROM users JOIN countries USING (country) ORDER BY 1",
+			"[name label] [[alice France] [bob Germany] [carol France]]",
+		},
+		{
+			"SELECT * FROM users JOIN countries USING country WHERE id = 2",
+			"[id name country label] [[2 bob de Germany]]",
+		},
+		{
+			"SELECT country, count(*) AS c, uniq(name), min(id), max(id), avg(id) FROM users " +
+				"GROUP BY country HAVING c > 1",
+			"[country c uniq(name) min(id) max(id) avg(id)] [[fr 2 2 1 3 2]]",
+		},
+		{
+			"SELECT count(), sum(amount) FROM orders WHERE amount > 100",
+			"[count() sum(amount)] [[0 <nil>]]",
+		},
+		{
+			"SELECT DISTINCT country FROM users ORDER BY country NULLS FIRST LIMIT 2",
+			"[country] [[<nil>] [de]]",
+		},
+		{
+			"SELECT score FROM scores ORDER BY score",
+			"[score] [[1] [5] [<nil>] [<nil>]]",
+		},
+		{
+			"SELECT score FROM scores ORDER BY score DESC",
+			"[score] [[5] [1] [<nil>] [<nil>]]",
+		},
+		{
+			"SELECT score FROM scores ORDER BY score NULLS FIRST",
+			"[score] [[<nil>] [<nil>] [1] [5]]",
+		},
+		{
+			"SELECT score FROM scores ORDER BY score DESC NULLS FIRST",
+			"[score] [[<nil>] [<nil>] [5] [1]]",
+		},
+		{
+			"SELECT id FROM users ORDER BY id LIMIT 1, 2",
+			"[id] [[2] [3]]",
+		},
+		{
+			"SELECT id FROM users WHERE id < 2 UNION ALL SELECT user_id FROM orders WHERE amount > 10",
+			"[id] [[1] [2]]",
+		},
+		{
+			"SELECT n FROM (SELECT name AS n FROM users) AS s WHERE s.n LIKE '%o%'",
+			"[n] [[bob] [carol]]",
+		},
+		{
+			"SELECT 1 + 1 AS two",
+			"[two] [[2]]",
+		},
+	}
+	for _, tt := range tests {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.sql, err)
+		}
+		result, err := executor.Execute(stmts[0])
+		if err != nil {
+			t.Errorf("Failed to execute %q: %v", tt.sql, err)
+			continue
+		}
+		if actual := fmt.Sprint(result.Columns, " ", result.Rows); actual != tt.expected {
+			t.Errorf("Unexpected result of %q:\nexpected %s\nactual   %s", tt.sql, tt.expected, actual)
+		}
+	}
+
+	for _, tt := range []struct {
+		sql      string
+		expected string
+	}{
+		{"SELECT id FROM missing", "position 15: unknown table missing"},
+		{"SELECT nope FROM users", "position 7: unknown column nope"},
+		{"SELECT id FROM users JOIN orders ON users.id = orders.user_id", "position 7: column id is ambiguous"},
+		{"SELECT name, count(*) FROM users GROUP BY country", "position 7: column name is neither grouped nor aggregated"},
+		{"SELECT id FROM users RIGHT JOIN orders ON users.id = orders.user_id", "position 32: RIGHT JOIN is not supported"},
+		{"SELECT id FROM users UNION DISTINCT SELECT id FROM orders", "position 21: UNION DISTINCT is not supported, only UNION ALL is"},
+		{"SELECT id FROM users WHERE id IN (SELECT user_id FROM orders)", "position 34: subqueries in expressions are not supported"},
+		{"SELECT id FROM users WHERE count(*) > 1", "position 27: aggregate function count(*) is not allowed in WHERE"},
+		{"SELECT id FROM users LIMIT 1 BY country", "position 21: LIMIT BY is not supported"},
+	} {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.sql, err)
+		}
+		if _, err := executor.Execute(stmts[0]); err == nil || err.Error() != tt.expected {
+			t.Errorf("Expected %q, got %v", tt.expected, err)
+		}
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	ddl := `CREATE TABLE events (id UInt64, user_id Nullable(UInt32), amount Decimal(10, 2), price Float32,
+		name LowCardinality(String), tags Array(String), attrs Map(String, UInt16), point Tuple(Float64, Float64),
+		ts DateTime, score Nullable(Int16)) ENGINE = MergeTree ORDER BY (id)`
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"id", "UInt64"},
+		{"e.id + 1", "UInt64"},
+		{"1 + 1", "UInt16"},
+		{"user_id * 2", "Nullable(UInt64)"},
+		{"score - 1", "Nullable(Int32)"},
+		{"amount * 2", "Decimal(10, 2)"},
+		{"price / 2", "Float64"},
+		{"-id", "Int64"},
+		{"CAST(id AS String)", "String"},
+		{"'1'::Int32", "Int32"},
+		{"'1'::Nullable(Int32)", "Nullable(Int32)"},
+		{"CAST(id, 'Nullable(String)')", "Nullable(String)"},
+		{"CASE WHEN id > 1 THEN 1 ELSE -1 END", "Int16"},
+		{"CASE WHEN id > 1 THEN name END", "Nullable(String)"},
+		{"tags[1]", "String"},
+		{"attrs['k']", "UInt16"},
+		{"point.1", "Float64"},
+		{"user_id = 1", "Nullable(UInt8)"},
+		{"user_id IS NULL", "UInt8"},
+		{"count()", "UInt64"},
+		{"sum(user_id)", "Nullable(UInt64)"},
+		{"sum(amount)", "Decimal(38, 2)"},
+		{"avg(price)", "Float64"},
+		{"max(ts)", "DateTime"},
+		{"countIf(id > 1)", "UInt64"},
+		{"sumIf(score, id > 1)", "Nullable(Int64)"},
+		{"ts + INTERVAL 1 DAY", "DateTime"},
+		{"toDate(ts)", "Date"},
+		{"if(id > 1, 'a', NULL)", "Nullable(String)"},
+		{"coalesce(user_id, 0)", "UInt32"},
+		{"[1, 2, 300]", "Array(UInt16)"},
+		{"(1, 'a')", "Tuple(UInt8, String)"},
+		{"concat(name, 'x')", "String"},
+		{"groupArray(user_id)", "Array(UInt32)"},
+		{"unknown(id)", "<nil>"},
+		{"missing + 1", "<nil>"},
+	}
+	schemaStmts, err := NewParser(ddl).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	schema := NewSchema(schemaStmts...)
+	for _, tt := range tests {
+		stmts, err := NewParser("SELECT " + tt.expr + " FROM events AS e").Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.expr, err)
+		}
+		query := stmts[0].(*SelectQuery)
+		if actual := fmt.Sprint(SelectTypes(query, NewTypeScope(schema, query))[0]); actual != tt.expected {
+			t.Errorf("Expected %q to be %s, got %s", tt.expr, tt.expected, actual)
+		}
+	}
+
+	schemaStmts, err = NewParserWithOptions("CREATE TABLE t (id INT, price DOUBLE, name VARCHAR(10))",
+		Options{Dialect: DialectMySQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	stmts, err := NewParserWithOptions("SELECT id + 1, id / 2, price * id, name = 'x', sum(id), count(*), "+
+		"if(id > 1, name, NULL) FROM t", Options{Dialect: DialectMySQL}).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	query := stmts[0].(*SelectQuery)
+	scope := NewTypeScope(NewSchema(schemaStmts...), query)
+	scope.Dialect = DialectMySQL
+	expected := "[BIGINT DECIMAL DOUBLE BIGINT DECIMAL BIGINT VARCHAR(10)]"
+	if actual := fmt.Sprint(SelectTypes(query, scope)); actual != expected {
+		t.Errorf("Expected %s, got %s", expected, actual)
+	}
+
+	// the tables of each query block are only seen by the block and its subqueries
+	schemaStmts, err = NewParser("CREATE TABLE t (id UInt64, b String) ENGINE = Memory; " +
+		"CREATE TABLE u (id Int8, b Int32) ENGINE = Memory").Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	schema = NewSchema(schemaStmts...)
+	for _, tt := range []struct {
+		sql      string
+		expected string
+	}{
+		{"SELECT id FROM t UNION ALL SELECT b FROM u", "[UInt64]"},
+		{"SELECT id, b FROM t WHERE id IN (SELECT id FROM u)", "[UInt64 String]"},
+		{"SELECT id FROM (SELECT b FROM u) AS s, t", "[UInt64]"},
+		{"SELECT x.id, t.id FROM t AS x", "[UInt64 <nil>]"},
+		{"SELECT id FROM t JOIN u ON t.id = u.id", "[<nil>]"},
+	} {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.sql, err)
+		}
+		query := stmts[0].(*SelectQuery)
+		if actual := fmt.Sprint(SelectTypes(query, NewTypeScope(schema, query))); actual != tt.expected {
+			t.Errorf("Expected %q to be %s, got %s", tt.sql, tt.expected, actual)
+		}
+	}
+
+	// a correlated subquery reads the columns of the outer query
+	stmts, err = NewParser("SELECT (SELECT b FROM u WHERE u.id = t.id LIMIT 1) FROM t").Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	scope = NewTypeScope(schema, stmts[0])
+	item := stmts[0].(*SelectQuery).SelectItems[0].Expr
+	var outer, inner Expr
+	_ = item.Accept(&DefaultASTVisitor{Visit: func(expr Expr) error {
+		if column, ok := expr.(*ColumnIdentifier); ok {
+			if column.Table.Name == "t" {
+				outer = column
+			} else if column.Table.Name == "u" {
+				inner = column
+			}
+		}
+		return nil
+	}})
+	if actual := fmt.Sprint(TypeOf(outer, scope), " ", TypeOf(inner, scope)); actual != "UInt64 Int8" {
+		t.Errorf("Expected the correlated columns to be UInt64 Int8, got %s", actual)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	ddl := `CREATE TABLE users (id UInt64, name String, created DateTime) ENGINE = MergeTree ORDER BY (id);
+		CREATE TABLE orders (id UInt64, user_id UInt64, amount Float64) ENGINE = MergeTree ORDER BY (id)`
+	tests := []struct {
+		sql      string
+		expected []string
+	}{
+		{"SELECT u.name, o.amount FROM users AS u JOIN orders AS o ON o.user_id = u.id", nil},
+		{"SELECT nope FROM users", []string{"unknown column nope"}},
+		{"SELECT id FROM missing", []string{"unknown table missing"}},
+		{"SELECT x.id FROM users AS u", []string{"unknown table x"}},
+		{"SELECT u.amount FROM users AS u", []string{"unknown column u.amount"}},
+		{"SELECT id FROM users, orders", []string{"column id is ambiguous"}},
+		{"SELECT id FROM users JOIN orders USING (id)", nil},
+		{"WITH recent AS (SELECT id, name FROM users) SELECT r.name, r.created FROM recent AS r",
+			[]string{"unknown column r.created"}},
+		{"SELECT t.total FROM (SELECT sum(amount) AS total FROM orders) AS t", nil},
+		{"SELECT name FROM users AS u WHERE id IN (SELECT user_id FROM orders WHERE user_id = u.id)", nil},
+		{"SELECT arrayMap(v -> v + id, [1, 2]) FROM users", nil},
+		{"SELECT lower(name, 1), if(id > 1, 1) FROM users",
+			[]string{"function lower takes 1 argument, got 2", "function if takes 3 arguments, got 2"}},
+		{"SELECT countIf(id > 1), count(DISTINCT id, name), sumIf(id) FROM users",
+			[]string{"function sumIf takes 2 arguments, got 1"}},
+		{"SELECT name, count() FROM users GROUP BY name", nil},
+		{"SELECT toDate(created) AS day, count() FROM users GROUP BY day", nil},
+		{"SELECT name, id, count() FROM users GROUP BY 1", []string{"column id is neither grouped nor aggregated"}},
+		{"SELECT name, count() FROM users", []string{"column name is neither grouped nor aggregated"}},
+		{"INSERT INTO users (id, name) VALUES (1, 'a'), (2)", []string{"INSERT has 2 columns but 1 values"}},
+		{"INSERT INTO users VALUES (1, 'a')", []string{"INSERT has 3 columns but 2 values"}},
+		{"INSERT INTO users (id, email) VALUES (1, 'a')", []string{"unknown column email"}},
+		{"INSERT INTO users SELECT id, user_id FROM orders", []string{"INSERT has 3 columns but the SELECT returns 2"}},
+		{"DELETE FROM users WHERE email = ''", []string{"unknown column email"}},
+		{"SELECT id FROM users WHERE id = '1' AND created > '2024-01-01'", nil},
+		{"SELECT id FROM users WHERE id = 'abc' OR name > id OR created = name", []string{
+			"cannot compare id (UInt64) with 'abc' (String)",
+			"cannot compare name (String) with id (UInt64)",
+			"cannot compare created (DateTime) with name (String)",
+		}},
+		{"SELECT number FROM numbers(10)", nil},
+		{"SELECT id FROM users UNION ALL SELECT id, name FROM users", []string{"UNION operands have 1 and 2 columns"}},
+	}
+	schemaStmts, err := NewParser(ddl).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	schema := NewSchema(schemaStmts...)
+	for _, tt := range tests {
+		stmts, err := NewParser(tt.sql).Parse()
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.sql, err)
+		}
+		var actual []string
+		for _, diagnostic := range Validate(stmts[0], schema) {
+			actual = append(actual, diagnostic.Message)
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+			t.Errorf("Expected %q to report %q, got %q", tt.sql, tt.expected, actual)
+		}
+	}
+
+	stmts, err := NewParser("SELECT id, missing FROM users").Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	diagnostics := Validate(stmts[0], schema)
+	if len(diagnostics) != 1 || diagnostics[0].Pos != 11 || diagnostics[0].End != 18 {
+		t.Errorf("Expected a diagnostic at 11-18, got %v", diagnostics)
+	}
+}
@@ -0,0 +1,215 @@
+package parser
+
+// Schema is a set of table definitions used to look up the type of columns.
+type Schema struct {
+	tables []*CreateTable
+}
+
+// NewSchema returns a Schema of the tables created by the CREATE TABLE statements in stmts,
+// other statements are ignored so a whole DDL script can be passed.
+func NewSchema(stmts ...Expr) *Schema {
+	schema := &Schema{}
+	for _, stmt := range stmts {
+		if table, ok := stmt.(*CreateTable); ok {
+			schema.tables = append(schema.tables, table)
+		}
+	}
+	return schema
+}
+
+// Table returns the definition of the table, a table without database matches any database.
+func (s *Schema) Table(table *TableIdentifier) *CreateTable {
+	if s == nil || table == nil {
+		return nil
+	}
+	for _, definition := range s.tables {
+		identifier := definition.Identifier
+		if identifier.Table.Name != table.Table.Name {
+			continue
+		}
+		if table.Schema == nil || identifier.Schema == nil || identifier.Schema.Name == table.Schema.Name {
+			return definition
+		}
+	}
+	return nil
+}
+
+// Columns returns the column definitions of a table in declaration order.
+func (s *Schema) Columns(table *CreateTable) []*ColumnDef {
+	if table == nil || table.TableSchema == nil {
+		return nil
+	}
+	var columns []*ColumnDef
+	for _, column := range table.TableSchema.Columns {
+		if column, ok := column.(*ColumnDef); ok {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// Column returns the definition of the named column of a table, or nil.
+func (s *Schema) Column(table *CreateTable, name string) *ColumnDef {
+	for _, column := range s.Columns(table) {
+		if column.Name.String() == name || columnDefName(column) == name {
+			return column
+		}
+	}
+	return nil
+}
+
+// columnDefName is the unquoted name of a column.
+func columnDefName(column *ColumnDef) string {
+	if column.Name.DotIdent != nil {
+		return column.Name.Ident.Name + "." + column.Name.DotIdent.Name
+	}
+	return column.Name.Ident.Name
+}
+
+// tableScope maps the names and aliases of the tables of a query block to their definitions,
+// the column references a scope does not resolve are looked up in its parent, the scope of
+// the outer query.
+type tableScope struct {
+	parent *tableScope
+	tables map[string]*CreateTable
+}
+
+func newTableScope(parent *tableScope) *tableScope {
+	return &tableScope{parent: parent, tables: make(map[string]*CreateTable)}
+}
+
+// statementTables are the table scopes of a statement and the scope of each column reference.
+type statementTables struct {
+	// root has the tables of the top-level query, or the target of an INSERT or DELETE
+	root *tableScope
+	refs map[Expr]*tableScope
+}
+
+// scope returns the scope expr is found in, the root scope if expr is not in the statement.
+func (s *statementTables) scope(expr Expr) *tableScope {
+	if s == nil {
+		return nil
+	}
+	if scope, ok := s.refs[expr]; ok {
+		return scope
+	}
+	return s.root
+}
+
+// statementTables returns the table scopes of stmt, one per query block like Validate uses.
+func (s *Schema) statementTables(stmt Expr) *statementTables {
+	visitor := &tableScopeVisitor{schema: s, stmt: stmt, scopes: []*tableScope{newTableScope(nil)}}
+	visitor.tables = &statementTables{root: visitor.scopes[0], refs: make(map[Expr]*tableScope)}
+	if s != nil {
+		_ = stmt.Accept(visitor)
+	}
+	return visitor.tables
+}
+
+// tableScopeVisitor builds the table scopes of a statement, a subquery sees the tables of the
+// queries around it but its own tables are not visible outside.
+type tableScopeVisitor struct {
+	DefaultASTVisitor
+	schema *Schema
+	stmt   Expr
+	// scopes are the scopes of the queries being visited, innermost last
+	scopes []*tableScope
+	tables *statementTables
+}
+
+func (v *tableScopeVisitor) Enter(expr Expr) {
+	scope := v.scopes[len(v.scopes)-1]
+	switch expr := expr.(type) {
+	case *SelectQuery:
+		// the tables of a top-level query are the root scope
+		if expr == v.stmt {
+			return
+		}
+		// the query of an INSERT does not see the table it inserts into
+		if _, ok := v.stmt.(*SelectQuery); !ok && len(v.scopes) == 1 {
+			scope = nil
+		}
+		v.scopes = append(v.scopes, newTableScope(scope))
+	case *Ident, *ColumnIdentifier:
+		v.tables.refs[expr] = scope
+	case *TableExpr:
+		switch table := expr.Expr.(type) {
+		case *TableIdentifier:
+			v.add(scope, table, nil)
+		case *AliasExpr:
+			if identifier, ok := table.Expr.(*TableIdentifier); ok {
+				v.add(scope, identifier, table.Alias)
+			}
+		}
+	case *InsertStmt:
+		if table, ok := expr.Table.(*TableIdentifier); ok {
+			v.add(scope, table, nil)
+		}
+	case *DeleteClause:
+		v.add(scope, expr.Table, nil)
+	}
+}
+
+func (v *tableScopeVisitor) Leave(expr Expr) {
+	if query, ok := expr.(*SelectQuery); ok && query != v.stmt {
+		v.scopes = v.scopes[:len(v.scopes)-1]
+	}
+}
+
+// add puts a table in scope under its alias, or its name if it has none.
+func (v *tableScopeVisitor) add(scope *tableScope, table *TableIdentifier, alias Expr) {
+	definition := v.schema.Table(table)
+	if definition == nil {
+		return
+	}
+	if alias, ok := alias.(*Ident); ok {
+		scope.tables[alias.Name] = definition
+		return
+	}
+	scope.tables[table.Table.Name] = definition
+}
+
+// resolveColumn returns the definition of an *Ident or *ColumnIdentifier column reference,
+// looking it up from the scope of the reference outwards. An unqualified name must be found
+// in exactly one of the tables of the first scope that has it.
+func (s *Schema) resolveColumn(tables *statementTables, expr Expr) *ColumnDef {
+	for scope := tables.scope(expr); scope != nil; scope = scope.parent {
+		if column, found := s.scopeColumn(scope, expr); found {
+			return column
+		}
+	}
+	return nil
+}
+
+// scopeColumn looks a column reference up in the tables of scope, found is set if the
+// reference is resolved by scope even though it is ambiguous.
+func (s *Schema) scopeColumn(scope *tableScope, expr Expr) (*ColumnDef, bool) {
+	switch expr := expr.(type) {
+	case *Ident:
+		var found *ColumnDef
+		seen := make(map[*CreateTable]bool)
+		for _, table := range scope.tables {
+			if seen[table] {
+				continue
+			}
+			seen[table] = true
+			if column := s.Column(table, expr.Name); column != nil {
+				if found != nil {
+					return nil, true
+				}
+				found = column
+			}
+		}
+		return found, found != nil
+	case *ColumnIdentifier:
+		if expr.Table == nil {
+			return s.scopeColumn(scope, expr.Column)
+		}
+		table, ok := scope.tables[expr.Table.Name]
+		if !ok {
+			return nil, false
+		}
+		return s.Column(table, expr.Column.Name), true
+	}
+	return nil, true
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// TokenizeOptions configures Tokenize and NewTokenizer.
+type TokenizeOptions struct {
+	Dialect Dialect
+	// MySQLVersion is the version of executable comments to tokenize as SQL, see Options.
+	MySQLVersion int
+	// Whitespace emits runs of whitespace as TokenKindWhitespace tokens.
+	Whitespace bool
+	// Comments emits comments as TokenKindComment tokens.
+	Comments bool
+}
+
+// Tokenizer splits SQL into tokens without parsing it, for syntax highlighting and tooling.
+type Tokenizer struct {
+	lexer *Lexer
+	opts  TokenizeOptions
+
+	// line and column are the 1-based position of offset
+	offset int
+	line   int
+	column int
+}
+
+// NewTokenizer returns a Tokenizer reading input.
+func NewTokenizer(input string, opts TokenizeOptions) *Tokenizer {
+	lexer := NewLexer(input)
+	lexer.dialect = opts.Dialect
+	lexer.mysqlVersion = opts.MySQLVersion
+	return &Tokenizer{
+		lexer:  lexer,
+		opts:   opts,
+		line:   1,
+		column: 1,
+	}
+}
+
+// Next returns the next token, a TokenKindEOF token is returned at the end of the input.
+//
+// Pos and End of a token span its source text, quotes included, while String is the
+// text inside the quotes of identifiers and strings, and the source text of other tokens.
+func (t *Tokenizer) Next() (Token, error) {
+	l := t.lexer
+	for {
+		trivia, err := l.consumeTrivia()
+		if err != nil {
+			token := t.locate(Token{Pos: trivia.Pos})
+			return Token{}, fmt.Errorf("line %d:%d %w", token.Line, token.Column, err)
+		}
+		if trivia == nil {
+			break
+		}
+		if trivia.Kind == TokenKindWhitespace && t.opts.Whitespace ||
+			trivia.Kind == TokenKindComment && t.opts.Comments {
+			return t.locate(*trivia), nil
+		}
+	}
+	start := l.current
+	if l.isEOF() {
+		return t.locate(Token{Pos: Pos(start), End: Pos(start), Kind: TokenKindEOF}), nil
+	}
+	if err := l.consumeToken(); err != nil {
+		token := t.locate(Token{Pos: Pos(start)})
+		return Token{}, fmt.Errorf("line %d:%d %w", token.Line, token.Column, err)
+	}
+	token := *l.lastToken
+	token.Pos = Pos(start)
+	token.End = Pos(l.current)
+	return t.locate(token), nil
+}
+
+// locate sets the line and column of the token, tokens are located in order.
+func (t *Tokenizer) locate(token Token) Token {
+	t.offset, t.line, t.column = advance(t.lexer.input, t.offset, t.line, t.column, int(token.Pos))
+	token.Line = t.line
+	token.Column = t.column
+	return token
+}
+
+// Locate returns the 1-based line and column of pos in input as Tokenize does: lines end with
+// \n, \r\n or \r, and columns count characters.
+func Locate(input string, pos Pos) (line, column int) {
+	_, line, column = advance(input, 0, 1, 1, int(pos))
+	return line, column
+}
+
+// advance moves the line and column of offset forward to pos.
+func advance(input string, offset, line, column, pos int) (int, int, int) {
+	pos = min(pos, len(input))
+	for offset < pos {
+		r, size := utf8.DecodeRuneInString(input[offset:])
+		offset += size
+		switch {
+		case r == '\n', r == '\r' && (offset >= len(input) || input[offset] != '\n'):
+			line++
+			column = 1
+		case r == '\r':
+			// the line ends with the \n of \r\n
+		default:
+			column++
+		}
+	}
+	return offset, line, column
+}
+
+// Tokenize returns the tokens of input, the final TokenKindEOF token is not included.
+// On error the tokens before the invalid one are returned with the error.
+func Tokenize(input string, opts TokenizeOptions) ([]Token, error) {
+	tokenizer := NewTokenizer(input, opts)
+	var tokens []Token
+	for {
+		token, err := tokenizer.Next()
+		if err != nil {
+			return tokens, err
+		}
+		if token.Kind == TokenKindEOF {
+			return tokens, nil
+		}
+		tokens = append(tokens, token)
+	}
+}
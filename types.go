@@ -0,0 +1,889 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TypeScope is what TypeOf resolves column references with, see NewTypeScope.
+type TypeScope struct {
+	// Dialect selects the typing rules, MySQL and PostgreSQL use MySQL's, the other dialects
+	// ClickHouse's.
+	Dialect Dialect
+	schema  *Schema
+	tables  *statementTables
+}
+
+// NewTypeScope returns the scope of the tables and aliases stmt uses, whose definitions are
+// looked up in schema.
+func NewTypeScope(schema *Schema, stmt Expr) *TypeScope {
+	return &TypeScope{schema: schema, tables: schema.statementTables(stmt)}
+}
+
+// SelectTypes returns the type of each select item of query, nil if it is unknown or the
+// item is a *. The items of the first query of a UNION are used.
+func SelectTypes(query *SelectQuery, scope *TypeScope) []ColumnType {
+	query = firstSelect(query)
+	if query == nil {
+		return nil
+	}
+	types := make([]ColumnType, len(query.SelectItems))
+	for i, item := range query.SelectItems {
+		types[i] = TypeOf(item.Expr, scope)
+	}
+	return types
+}
+
+// firstSelect returns the first SELECT of a query or of a set operation.
+func firstSelect(expr Expr) *SelectQuery {
+	switch expr := expr.(type) {
+	case *SelectQuery:
+		if expr.SetOperation != nil {
+			return firstSelect(expr.SetOperation)
+		}
+		return expr
+	case *SetOperation:
+		return firstSelect(expr.Left)
+	}
+	return nil
+}
+
+// TypeOf infers the type of expr, it returns nil if the type is unknown. Columns are looked up
+// in scope, which may be nil. The inference follows ClickHouse's rules, e.g. 1 + 1 is UInt16
+// and comparisons are UInt8, or MySQL's depending on the dialect of the scope. Nullable
+// operands make the result Nullable with the ClickHouse rules, except for the functions which
+// handle NULL such as isNull, coalesce and count.
+func TypeOf(expr Expr, scope *TypeScope) ColumnType {
+	if scope == nil {
+		scope = &TypeScope{}
+	}
+	return scope.typeOf(expr)
+}
+
+func (s *TypeScope) mysql() bool {
+	return s.Dialect == DialectMySQL || s.Dialect == DialectPostgreSQL
+}
+
+func (s *TypeScope) typeOf(expr Expr) ColumnType { // nolint: funlen
+	switch expr := expr.(type) {
+	case *ColumnExpr:
+		return s.typeOf(expr.Expr)
+	case *NumberLiteral:
+		return s.numberType(expr)
+	case *StringLiteral:
+		return s.stringType()
+	case *NullLiteral:
+		return s.nullType()
+	case *Ident:
+		if isLiteralIdent(expr) {
+			if strings.EqualFold(expr.Name, KeywordNull) {
+				return s.nullType()
+			}
+			return s.named("Bool", "BOOLEAN")
+		}
+		if column := s.schema.resolveColumn(s.tables, expr); column != nil {
+			return column.Type
+		}
+	case *ColumnIdentifier:
+		if column := s.schema.resolveColumn(s.tables, expr); column != nil {
+			return column.Type
+		}
+	case *ParamExprList:
+		types := s.listTypes(expr.Items)
+		if len(types) == 1 {
+			return types[0]
+		}
+		if types == nil || s.mysql() {
+			return nil
+		}
+		return complexType("Tuple", types...)
+	case *ArrayParamList:
+		types := s.listTypes(expr.Items)
+		if types == nil || s.mysql() {
+			return nil
+		}
+		element := s.supertype(types...)
+		if element == nil {
+			return nil
+		}
+		return complexType("Array", element)
+	case *UnaryExpr:
+		switch strings.ToUpper(string(expr.Kind)) {
+		case KeywordNot:
+			return s.predicate(s.typeOf(expr.Expr))
+		case string(TokenKindMinus):
+			return s.negate(s.typeOf(expr.Expr))
+		case string(TokenKindPlus):
+			return s.typeOf(expr.Expr)
+		}
+	case *NotExpr:
+		return s.predicate(s.typeOf(expr.Expr))
+	case *NegateExpr:
+		return s.negate(s.typeOf(expr.Expr))
+	case *BinaryOperation:
+		return s.binaryType(expr)
+	case *BetweenClause:
+		return s.predicate(s.typeOf(expr.Expr), s.typeOf(expr.Between), s.typeOf(expr.And))
+	case *IsNullExpr, *IsNotNullExpr:
+		return s.boolType()
+	case *TernaryOperation:
+		return s.supertype(s.typeOf(expr.TrueExpr), s.typeOf(expr.FalseExpr))
+	case *CaseExpr:
+		var types []ColumnType
+		for _, when := range expr.Whens {
+			types = append(types, s.typeOf(when.Then))
+		}
+		if expr.Else != nil {
+			types = append(types, s.typeOf(expr.Else))
+		} else {
+			types = append(types, s.nullType())
+		}
+		return s.supertype(types...)
+	case *CastExpr:
+		return s.castType(expr.AsType)
+	case *IntervalExpr:
+		if s.mysql() {
+			return nil
+		}
+		unit, err := intervalUnit(expr.Unit.Name)
+		if err != nil {
+			return nil
+		}
+		return scalarType("Interval" + unit[:1] + strings.ToLower(unit[1:]))
+	case *ObjectParams:
+		if expr.Params == nil || expr.Params.Items == nil || len(expr.Params.Items.Items) != 1 {
+			return nil
+		}
+		return s.elementType(s.typeOf(expr.Object), expr.Params.Items.Items[0])
+	case *IndexOperation:
+		return s.elementType(s.typeOf(expr.Object), expr.Index)
+	case *FunctionExpr:
+		return s.functionType(expr)
+	case *QueryParam:
+		return expr.Type
+	}
+	return nil
+}
+
+func (s *TypeScope) listTypes(list *ColumnExprList) []ColumnType {
+	if list == nil {
+		return nil
+	}
+	types := make([]ColumnType, len(list.Items))
+	for i, item := range list.Items {
+		if types[i] = s.typeOf(item); types[i] == nil {
+			return nil
+		}
+	}
+	return types
+}
+
+func (s *TypeScope) binaryType(expr *BinaryOperation) ColumnType {
+	operation := strings.ToUpper(string(expr.Operation))
+	if operation == string(TokenKindDash) {
+		return s.castType(expr.RightExpr)
+	}
+	left, right := s.typeOf(expr.LeftExpr), s.typeOf(expr.RightExpr)
+	switch operation {
+	case "+", "-", "*", "/", "%":
+		return s.arithmetic(operation, left, right)
+	case string(TokenKindConcat):
+		return s.nullableLike(s.stringType(), left, right)
+	case KeywordIn, "NOT " + KeywordIn, "GLOBAL " + KeywordIn:
+		// the right side is a list or a subquery, only the left side makes the result Nullable
+		return s.predicate(left)
+	}
+	return s.predicate(left, right)
+}
+
+// castType returns the type of CAST(x AS T), x::T or CAST(x, 'T').
+func (s *TypeScope) castType(target Expr) ColumnType {
+	return castTarget(target)
+}
+
+// castTarget returns the type of a cast, the type of x::T is parsed as an expression, e.g. an
+// *Ident or a *FunctionExpr, and the one of CAST(x, 'T') is a string.
+func castTarget(target Expr) ColumnType {
+	text := ""
+	switch target := target.(type) {
+	case ColumnType:
+		return target
+	case *StringLiteral:
+		text = target.Literal
+	default:
+		text = target.String()
+	}
+	parser := NewParser(text)
+	if err := parser.lexer.consumeToken(); err != nil {
+		return nil
+	}
+	columnType, err := parser.parseColumnType(parser.Start())
+	if err != nil || parser.last() != nil {
+		return nil
+	}
+	return columnType
+}
+
+// elementType returns the type of container[index] or container.index.
+func (s *TypeScope) elementType(container ColumnType, index Expr) ColumnType {
+	base, nullable := unwrapType(container)
+	var element ColumnType
+	switch base := base.(type) {
+	case *ArrayType:
+		element = base.ElementType
+	case *ComplexType:
+		switch base.Name.Name {
+		case "Array":
+			if len(base.Params) == 1 {
+				element = base.Params[0]
+			}
+		case "Map":
+			if len(base.Params) == 2 {
+				element = base.Params[1]
+			}
+		case "Tuple":
+			if number, ok := index.(*NumberLiteral); ok {
+				n, err := strconv.Atoi(number.Literal)
+				if err == nil && n >= 1 && n <= len(base.Params) {
+					element = base.Params[n-1]
+				}
+			}
+		}
+	}
+	if element == nil || !nullable {
+		return element
+	}
+	return s.nullable(element)
+}
+
+// numberType is the type of a literal, the smallest integer type holding it with ClickHouse.
+func (s *TypeScope) numberType(number *NumberLiteral) ColumnType {
+	value, err := parseNumberLiteral(number)
+	if err != nil {
+		return nil
+	}
+	switch value := value.(type) {
+	case float64:
+		return s.named("Float64", "DECIMAL")
+	case uint64:
+		return s.named("UInt64", "BIGINT")
+	case int64:
+		if s.mysql() {
+			return scalarType("BIGINT")
+		}
+		for _, bits := range []int{8, 16, 32, 64} {
+			if value >= 0 && value < 1<<bits {
+				return intType(bits, false)
+			}
+			if value < 0 && value >= -(1<<(bits-1)) {
+				return intType(bits, true)
+			}
+		}
+	}
+	return nil
+}
+
+// named returns a scalar type named after the rules of the scope.
+func (s *TypeScope) named(clickhouse, mysql string) ColumnType {
+	if s.mysql() {
+		return scalarType(mysql)
+	}
+	return scalarType(clickhouse)
+}
+
+func (s *TypeScope) stringType() ColumnType {
+	return s.named("String", "VARCHAR")
+}
+
+func (s *TypeScope) boolType() ColumnType {
+	return s.named("UInt8", "BIGINT")
+}
+
+// nullType is the type of NULL, which has no type with MySQL.
+func (s *TypeScope) nullType() ColumnType {
+	if s.mysql() {
+		return nil
+	}
+	return complexType("Nullable", scalarType("Nothing"))
+}
+
+// nullable wraps columnType in Nullable with the ClickHouse rules.
+func (s *TypeScope) nullable(columnType ColumnType) ColumnType {
+	if s.mysql() || columnType == nil {
+		return columnType
+	}
+	if _, nullable := unwrapType(columnType); nullable {
+		return columnType
+	}
+	if lowCardinality, ok := columnType.(*ComplexType); ok && lowCardinality.Name.Name == "LowCardinality" {
+		return complexType("LowCardinality", s.nullable(lowCardinality.Params[0]))
+	}
+	return complexType("Nullable", columnType)
+}
+
+// nullableLike makes result Nullable if one of the operands is.
+func (s *TypeScope) nullableLike(result ColumnType, operands ...ColumnType) ColumnType {
+	for _, operand := range operands {
+		if _, nullable := unwrapType(operand); nullable {
+			return s.nullable(result)
+		}
+	}
+	return result
+}
+
+// predicate is the type of a comparison or boolean operator on the operands.
+func (s *TypeScope) predicate(operands ...ColumnType) ColumnType {
+	return s.nullableLike(s.boolType(), operands...)
+}
+
+func (s *TypeScope) negate(operand ColumnType) ColumnType {
+	class := classifyType(operand)
+	var result ColumnType
+	switch class.kind {
+	case typeKindInt:
+		if s.mysql() {
+			return scalarType("BIGINT")
+		}
+		bits := class.bits
+		if !class.signed {
+			bits = min(bits*2, 64)
+		}
+		result = intType(bits, true)
+	case typeKindFloat, typeKindDecimal:
+		result, _ = unwrapType(operand)
+	case typeKindNothing:
+		return operand
+	default:
+		return nil
+	}
+	return s.nullableLike(result, operand)
+}
+
+// arithmetic is the type of left operation right, integers are widened to hold the result with
+// ClickHouse, MySQL computes them as BIGINT and divides them as DECIMAL.
+func (s *TypeScope) arithmetic(operation string, left, right ColumnType) ColumnType { // nolint: funlen
+	l, r := classifyType(left), classifyType(right)
+	if l.kind == typeKindNothing || r.kind == typeKindNothing {
+		return s.nullType()
+	}
+	var result ColumnType
+	switch {
+	case l.kind == typeKindInt && r.kind == typeKindInt:
+		switch {
+		case s.mysql() && operation == "/":
+			result = scalarType("DECIMAL")
+		case s.mysql():
+			result = scalarType("BIGINT")
+		case operation == "/":
+			result = scalarType("Float64")
+		case operation == "%":
+			result = intType(min(max(l.bits, r.bits), 64), l.signed)
+		case operation == "-":
+			result = intType(min(max(l.bits, r.bits)*2, 64), true)
+		default:
+			result = intType(min(max(l.bits, r.bits)*2, 64), l.signed || r.signed)
+		}
+	case (l.kind == typeKindFloat || r.kind == typeKindFloat) && l.numeric() && r.numeric():
+		result = s.named("Float64", "DOUBLE")
+	case l.kind == typeKindDecimal && r.numeric(), r.kind == typeKindDecimal && l.numeric():
+		if s.mysql() {
+			result = scalarType("DECIMAL")
+			break
+		}
+		decimal := left
+		if r.kind == typeKindDecimal && (l.kind != typeKindDecimal || r.scale > l.scale) {
+			decimal = right
+		}
+		result, _ = unwrapType(decimal)
+	case (l.kind == typeKindDate || l.kind == typeKindDateTime) && (operation == "+" || operation == "-") &&
+		(r.kind == typeKindInterval || r.kind == typeKindInt):
+		result, _ = unwrapType(left)
+	case (r.kind == typeKindDate || r.kind == typeKindDateTime) && operation == "+" &&
+		(l.kind == typeKindInterval || l.kind == typeKindInt):
+		result, _ = unwrapType(right)
+	default:
+		return nil
+	}
+	return s.nullableLike(result, left, right)
+}
+
+// supertype is the type the values of all the types convert to, for CASE, if() and arrays.
+func (s *TypeScope) supertype(types ...ColumnType) ColumnType { // nolint: funlen
+	var result ColumnType
+	var resultClass typeClass
+	nullable := false
+	for _, columnType := range types {
+		if columnType == nil {
+			if s.mysql() {
+				// NULL has no type in MySQL
+				continue
+			}
+			return nil
+		}
+		base, isNullable := unwrapType(columnType)
+		nullable = nullable || isNullable
+		class := classifyType(base)
+		if class.kind == typeKindNothing {
+			continue
+		}
+		switch {
+		case result == nil:
+			result, resultClass = base, class
+			continue
+		case base.String() == result.String():
+			continue
+		case class.kind == typeKindInt && resultClass.kind == typeKindInt:
+			if s.mysql() {
+				result = scalarType("BIGINT")
+				continue
+			}
+			bits, signed := max(class.bits, resultClass.bits), class.signed || resultClass.signed
+			if class.signed != resultClass.signed {
+				unsignedBits := class.bits
+				if class.signed {
+					unsignedBits = resultClass.bits
+				}
+				if unsignedBits >= bits {
+					bits = unsignedBits * 2
+				}
+				if bits > 64 {
+					return nil
+				}
+			}
+			result = intType(bits, signed)
+		case class.numeric() && resultClass.numeric():
+			if class.kind == typeKindDecimal || resultClass.kind == typeKindDecimal {
+				if class.kind == typeKindFloat || resultClass.kind == typeKindFloat {
+					return nil
+				}
+				if class.kind == typeKindDecimal && (resultClass.kind != typeKindDecimal || class.scale > resultClass.scale) {
+					result = base
+				}
+			} else {
+				result = s.named("Float64", "DOUBLE")
+			}
+		case class.kind == typeKindString && resultClass.kind == typeKindString:
+			result = s.stringType()
+		case (class.kind == typeKindDate || class.kind == typeKindDateTime) &&
+			(resultClass.kind == typeKindDate || resultClass.kind == typeKindDateTime):
+			if class.kind == typeKindDateTime {
+				result = base
+			}
+		default:
+			return nil
+		}
+		resultClass = classifyType(result)
+	}
+	if result == nil {
+		return s.nullType()
+	}
+	if nullable {
+		return s.nullable(result)
+	}
+	return result
+}
+
+// functionType is the type of a call of a built-in function, nil for unknown functions.
+func (s *TypeScope) functionType(call *FunctionExpr) ColumnType {
+	var args []Expr
+	if call.Params != nil && call.Params.Items != nil {
+		args = call.Params.Items.Items
+	}
+	types := make([]ColumnType, len(args))
+	for i, arg := range args {
+		if isStar(arg) {
+			continue
+		}
+		types[i] = s.typeOf(arg)
+	}
+	name := strings.ToLower(call.Name.Name)
+	signature, ok := functionSignatures[name]
+	// the -If combinator of aggregates takes the condition as the last argument
+	if base, isIf := strings.CutSuffix(name, "if"); !ok && isIf && aggregateSignatures.Contains(base) && len(args) > 0 {
+		signature, ok = functionSignatures[base], true
+		args, types = args[:len(args)-1], types[:len(types)-1]
+	}
+	if !ok {
+		return nil
+	}
+	return signature(s, args, types)
+}
+
+// functionSignature returns the result type of a function for its arguments and their types.
+type functionSignature func(s *TypeScope, args []Expr, types []ColumnType) ColumnType
+
+// returns is the signature of a function returning the ClickHouse or MySQL type whatever its
+// arguments, Nullable if one of them is.
+func returns(clickhouse, mysql string) functionSignature {
+	return func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		return s.nullableLike(s.named(clickhouse, mysql), types...)
+	}
+}
+
+// returnsNotNull is returns for the functions that are never NULL.
+func returnsNotNull(clickhouse, mysql string) functionSignature {
+	return func(s *TypeScope, _ []Expr, _ []ColumnType) ColumnType {
+		return s.named(clickhouse, mysql)
+	}
+}
+
+// returnsArg is the signature of a function returning the type of its first argument.
+func returnsArg(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+	if len(types) == 0 {
+		return nil
+	}
+	return types[0]
+}
+
+// returnsSupertype is the signature of a function returning one of its arguments.
+func returnsSupertype(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+	return s.supertype(types...)
+}
+
+// aggregateResult makes the result of an aggregate Nullable if its argument is.
+func aggregateResult(s *TypeScope, result ColumnType, types []ColumnType) ColumnType {
+	if result == nil {
+		return nil
+	}
+	return s.nullableLike(result, types...)
+}
+
+var aggregateSignatures = NewSet("count", "sum", "avg", "min", "max", "any", "anylast", "uniq", "uniqexact",
+	"grouparray", "groupuniqarray", "argmin", "argmax")
+
+// functionSignatures are the signatures of built-in functions by lower case name.
+var functionSignatures = map[string]functionSignature{
+	// aggregates
+	"count":     returnsNotNull("UInt64", "BIGINT"),
+	"uniq":      returnsNotNull("UInt64", "BIGINT"),
+	"uniqexact": returnsNotNull("UInt64", "BIGINT"),
+	"sum": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		if len(types) != 1 {
+			return nil
+		}
+		class := classifyType(types[0])
+		var result ColumnType
+		switch {
+		case class.kind == typeKindInt && s.mysql():
+			result = scalarType("DECIMAL")
+		case class.kind == typeKindInt:
+			result = intType(64, class.signed)
+		case class.kind == typeKindFloat:
+			result = s.named("Float64", "DOUBLE")
+		case class.kind == typeKindDecimal && s.mysql():
+			result = scalarType("DECIMAL")
+		case class.kind == typeKindDecimal:
+			result = decimalType(38, class.scale)
+		}
+		return aggregateResult(s, result, types)
+	},
+	"avg": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		if len(types) != 1 || !classifyType(types[0]).numeric() {
+			return nil
+		}
+		if s.mysql() && classifyType(types[0]).kind != typeKindFloat {
+			return scalarType("DECIMAL")
+		}
+		return aggregateResult(s, s.named("Float64", "DOUBLE"), types)
+	},
+	"min":     returnsArg,
+	"max":     returnsArg,
+	"any":     returnsArg,
+	"anylast": returnsArg,
+	"argmin":  returnsArg,
+	"argmax":  returnsArg,
+	"grouparray": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		if len(types) != 1 || types[0] == nil || s.mysql() {
+			return nil
+		}
+		// NULLs are skipped
+		element, _ := unwrapType(types[0])
+		return complexType("Array", element)
+	},
+
+	// dates
+	"now":               returnsNotNull("DateTime", "DATETIME"),
+	"current_timestamp": returnsNotNull("DateTime", "DATETIME"),
+	"today":             returnsNotNull("Date", "DATE"),
+	"curdate":           returnsNotNull("Date", "DATE"),
+	"current_date":      returnsNotNull("Date", "DATE"),
+	"yesterday":         returnsNotNull("Date", "DATE"),
+	"todate":            returns("Date", "DATE"),
+	"date":              returns("Date", "DATE"),
+	"todatetime":        returns("DateTime", "DATETIME"),
+	"tostartofday":      returns("DateTime", "DATETIME"),
+	"tostartofhour":     returns("DateTime", "DATETIME"),
+	"tostartofminute":   returns("DateTime", "DATETIME"),
+	"tostartofweek":     returns("Date", "DATE"),
+	"tostartofmonth":    returns("Date", "DATE"),
+	"tostartofyear":     returns("Date", "DATE"),
+	"toyear":            returns("UInt16", "INT"),
+	"year":              returns("UInt16", "INT"),
+	"tomonth":           returns("UInt8", "INT"),
+	"month":             returns("UInt8", "INT"),
+	"todayofmonth":      returns("UInt8", "INT"),
+	"tohour":            returns("UInt8", "INT"),
+	"tominute":          returns("UInt8", "INT"),
+	"toyyyymm":          returns("UInt32", "INT"),
+	"toyyyymmdd":        returns("UInt32", "INT"),
+	"tounixtimestamp":   returns("UInt32", "BIGINT"),
+
+	// strings
+	"tostring":   returns("String", "VARCHAR"),
+	"concat":     returns("String", "VARCHAR"),
+	"lower":      returns("String", "VARCHAR"),
+	"upper":      returns("String", "VARCHAR"),
+	"substring":  returns("String", "VARCHAR"),
+	"trim":       returns("String", "VARCHAR"),
+	"replaceall": returns("String", "VARCHAR"),
+	"format":     returns("String", "VARCHAR"),
+	"totypename": returnsNotNull("String", "VARCHAR"),
+	"length":     returns("UInt64", "BIGINT"),
+	"position":   returns("UInt64", "BIGINT"),
+	"empty":      returns("UInt8", "BIGINT"),
+	"notempty":   returns("UInt8", "BIGINT"),
+
+	// conversions
+	"toint8":    returns("Int8", "BIGINT"),
+	"toint16":   returns("Int16", "BIGINT"),
+	"toint32":   returns("Int32", "BIGINT"),
+	"toint64":   returns("Int64", "BIGINT"),
+	"touint8":   returns("UInt8", "BIGINT"),
+	"touint16":  returns("UInt16", "BIGINT"),
+	"touint32":  returns("UInt32", "BIGINT"),
+	"touint64":  returns("UInt64", "BIGINT"),
+	"tofloat32": returns("Float32", "DOUBLE"),
+	"tofloat64": returns("Float64", "DOUBLE"),
+	"touuid":    returns("UUID", "VARCHAR"),
+
+	// numbers
+	"abs":   returnsArg,
+	"round": returnsArg,
+	"floor": returnsArg,
+	"ceil":  returnsArg,
+
+	// conditions and NULL
+	"if": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		if len(types) != 3 {
+			return nil
+		}
+		return s.supertype(types[1], types[2])
+	},
+	"multiif": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		if len(types)%2 != 1 {
+			return nil
+		}
+		var results []ColumnType
+		for i := 1; i < len(types); i += 2 {
+			results = append(results, types[i])
+		}
+		return s.supertype(append(results, types[len(types)-1])...)
+	},
+	"coalesce": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		result := s.supertype(types...)
+		// the result is only NULL if the last argument can be
+		if len(types) > 0 && types[len(types)-1] != nil {
+			if _, nullable := unwrapType(types[len(types)-1]); !nullable {
+				result, _ = unwrapType(result)
+			}
+		}
+		return result
+	},
+	"nullif": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		if len(types) != 2 {
+			return nil
+		}
+		return s.nullable(types[0])
+	},
+	"assumenotnull": func(_ *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		if len(types) != 1 || types[0] == nil {
+			return nil
+		}
+		base, _ := unwrapType(types[0])
+		return base
+	},
+	"tonullable": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		if len(types) != 1 {
+			return nil
+		}
+		return s.nullable(types[0])
+	},
+	"isnull":    returnsNotNull("UInt8", "BIGINT"),
+	"isnotnull": returnsNotNull("UInt8", "BIGINT"),
+	"greatest":  returnsSupertype,
+	"least":     returnsSupertype,
+
+	// arrays, tuples and maps
+	"array": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		element := s.supertype(types...)
+		if element == nil || s.mysql() {
+			return nil
+		}
+		return complexType("Array", element)
+	},
+	"tuple": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		for _, columnType := range types {
+			if columnType == nil {
+				return nil
+			}
+		}
+		return complexType("Tuple", types...)
+	},
+	"map": func(s *TypeScope, _ []Expr, types []ColumnType) ColumnType {
+		if len(types)%2 != 0 || len(types) == 0 {
+			return nil
+		}
+		var keys, values []ColumnType
+		for i := 0; i < len(types); i += 2 {
+			keys, values = append(keys, types[i]), append(values, types[i+1])
+		}
+		key, value := s.supertype(keys...), s.supertype(values...)
+		if key == nil || value == nil {
+			return nil
+		}
+		return complexType("Map", key, value)
+	},
+	"arrayjoin": func(s *TypeScope, args []Expr, types []ColumnType) ColumnType {
+		if len(types) != 1 {
+			return nil
+		}
+		return s.elementType(types[0], nil)
+	},
+	"arrayelement": func(s *TypeScope, args []Expr, types []ColumnType) ColumnType {
+		if len(types) != 2 {
+			return nil
+		}
+		return s.elementType(types[0], args[1])
+	},
+	"tupleelement": func(s *TypeScope, args []Expr, types []ColumnType) ColumnType {
+		if len(types) != 2 {
+			return nil
+		}
+		return s.elementType(types[0], args[1])
+	},
+	"has": returnsNotNull("UInt8", "BIGINT"),
+}
+
+type typeKind int
+
+const (
+	typeKindOther typeKind = iota
+	typeKindNothing
+	typeKindInt
+	typeKindFloat
+	typeKindDecimal
+	typeKindString
+	typeKindDate
+	typeKindDateTime
+	typeKindInterval
+)
+
+// typeClass is what arithmetic and supertypes need to know of a type.
+type typeClass struct {
+	kind   typeKind
+	bits   int
+	signed bool
+	scale  int
+}
+
+func (c typeClass) numeric() bool {
+	return c.kind == typeKindInt || c.kind == typeKindFloat || c.kind == typeKindDecimal
+}
+
+var mysqlIntegerBits = map[string]int{
+	"TINYINT": 8, "SMALLINT": 16, "MEDIUMINT": 32, "INT": 32, "INTEGER": 32, "BIGINT": 64,
+	"INT2": 16, "INT4": 32, "INT8": 64, "SERIAL": 32, "BIGSERIAL": 64,
+}
+
+// classifyType classifies a ClickHouse, MySQL or PostgreSQL type, Nullable and LowCardinality
+// are ignored.
+func classifyType(columnType ColumnType) typeClass { // nolint: funlen
+	if columnType == nil {
+		return typeClass{}
+	}
+	base, _ := unwrapType(columnType)
+	name := base.Type()
+	upper := strings.ToUpper(name)
+	switch {
+	case name == "Nothing":
+		return typeClass{kind: typeKindNothing}
+	case strings.HasPrefix(name, "UInt") || strings.HasPrefix(name, "Int"):
+		prefix := "Int"
+		if strings.HasPrefix(name, "UInt") {
+			prefix = "UInt"
+		}
+		if bits, err := strconv.Atoi(strings.TrimPrefix(name, prefix)); err == nil {
+			return typeClass{kind: typeKindInt, bits: bits, signed: prefix == "Int"}
+		}
+		if strings.HasPrefix(name, "Interval") {
+			return typeClass{kind: typeKindInterval}
+		}
+	case mysqlIntegerBits[upper] != 0:
+		return typeClass{kind: typeKindInt, bits: mysqlIntegerBits[upper], signed: true}
+	case upper == "BOOL" || upper == "BOOLEAN":
+		return typeClass{kind: typeKindInt, bits: 8}
+	case strings.HasPrefix(name, "Float"), upper == "FLOAT", upper == "DOUBLE", upper == "REAL":
+		return typeClass{kind: typeKindFloat}
+	case strings.HasPrefix(upper, "DECIMAL"), upper == "NUMERIC":
+		class := typeClass{kind: typeKindDecimal}
+		if withParams, ok := base.(*TypeWithParams); ok && len(withParams.Params) > 0 {
+			// Decimal(P, S) or Decimal32(S)
+			scale := withParams.Params[len(withParams.Params)-1]
+			if len(withParams.Params) == 1 && upper == "DECIMAL" {
+				scale = nil
+			}
+			if number, ok := scale.(*NumberLiteral); ok {
+				class.scale, _ = strconv.Atoi(number.Literal)
+			}
+		}
+		return class
+	case name == "String", name == "FixedString", upper == "VARCHAR", upper == "CHAR", upper == "TEXT",
+		upper == "TINYTEXT", upper == "MEDIUMTEXT", upper == "LONGTEXT":
+		return typeClass{kind: typeKindString}
+	case upper == "DATE" || name == "Date32":
+		return typeClass{kind: typeKindDate}
+	case strings.HasPrefix(upper, "DATETIME"), upper == "TIMESTAMP":
+		return typeClass{kind: typeKindDateTime}
+	}
+	return typeClass{}
+}
+
+// unwrapType returns the type in Nullable and LowCardinality, and whether it is Nullable.
+func unwrapType(columnType ColumnType) (ColumnType, bool) {
+	nullable := false
+	for {
+		complexType, ok := columnType.(*ComplexType)
+		if !ok || len(complexType.Params) != 1 {
+			return columnType, nullable
+		}
+		switch complexType.Name.Name {
+		case "Nullable":
+			nullable = true
+		case "LowCardinality":
+		default:
+			return columnType, nullable
+		}
+		columnType = complexType.Params[0]
+	}
+}
+
+func intType(bits int, signed bool) ColumnType {
+	if signed {
+		return scalarType("Int" + strconv.Itoa(bits))
+	}
+	return scalarType("UInt" + strconv.Itoa(bits))
+}
+
+func decimalType(precision, scale int) ColumnType {
+	return &TypeWithParams{
+		Name: &Ident{Name: "Decimal", QuoteType: Unquoted},
+		Params: []Literal{
+			&NumberLiteral{Literal: strconv.Itoa(precision), Base: 10},
+			&NumberLiteral{Literal: strconv.Itoa(scale), Base: 10},
+		},
+	}
+}
+
+func complexType(name string, params ...ColumnType) *ComplexType {
+	return &ComplexType{Name: &Ident{Name: name, QuoteType: Unquoted}, Params: params}
+}
@@ -0,0 +1,883 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Diagnostic is a problem Validate found in a statement, positioned at the node it is about.
+type Diagnostic struct {
+	Pos     Pos
+	End     Pos
+	Message string
+}
+
+func (d *Diagnostic) String() string {
+	return fmt.Sprintf("position %d: %s", d.Pos, d.Message)
+}
+
+// Validate checks stmt against the tables of schema and returns its problems sorted by position:
+//   - unknown tables, unless they are CTEs, and unknown or ambiguous columns, resolved through
+//     table aliases, CTEs, subqueries and the outer queries of correlated subqueries;
+//   - calls of built-in functions with the wrong number of arguments;
+//   - select items neither aggregated nor in the GROUP BY clause of an aggregating query;
+//   - INSERT rows or SELECTs whose number of values is not the number of columns;
+//   - comparisons ClickHouse can not make, e.g. of a number with a string.
+//
+// Columns of table functions and of tables missing from a nil schema are not checked.
+func Validate(stmt Expr, schema *Schema) []*Diagnostic {
+	v := &validator{schema: schema, types: NewTypeScope(schema, stmt)}
+	v.statement(stmt)
+	sort.SliceStable(v.diagnostics, func(i, j int) bool {
+		return v.diagnostics[i].Pos < v.diagnostics[j].Pos
+	})
+	return v.diagnostics
+}
+
+type validator struct {
+	schema      *Schema
+	types       *TypeScope
+	diagnostics []*Diagnostic
+	// reads collects the columns read from each table, nil unless they are wanted, see readColumns.
+	reads *columnReads
+}
+
+// columnReads are the table sources of a statement, with the columns read from them.
+type columnReads struct {
+	sources []*validationSource
+	// unresolved is set when an expression has operands whose columns are not resolved.
+	unresolved bool
+}
+
+// validationScope is what the column references of a query resolve to, the references a scope
+// does not resolve are looked up in its parent, the scope of the outer query.
+type validationScope struct {
+	parent *validationScope
+	// ctes are the columns of the CTEs by name, nil while they are unknown.
+	ctes map[string][]string
+	// names are the select, WITH and ARRAY JOIN aliases and the lambda parameters.
+	names   map[string]bool
+	sources []*validationSource
+	// merged are the columns of USING clauses, which are not ambiguous.
+	merged map[string]bool
+}
+
+// validationSource is a table of a FROM clause, named by its alias.
+type validationSource struct {
+	name string
+	// columns are nil if they are unknown, any column is then accepted.
+	columns []string
+	// table is nil for CTEs, subqueries and table functions.
+	table *TableIdentifier
+	// read are the columns the statement reads from table, every column if whole is set.
+	read  []string
+	whole bool
+}
+
+func newValidationScope(parent *validationScope) *validationScope {
+	return &validationScope{
+		parent: parent,
+		ctes:   make(map[string][]string),
+		names:  make(map[string]bool),
+		merged: make(map[string]bool),
+	}
+}
+
+// cte returns the columns of the CTE name and whether it is one.
+func (s *validationScope) cte(name string) ([]string, bool) {
+	for ; s != nil; s = s.parent {
+		if columns, ok := s.ctes[name]; ok {
+			return columns, true
+		}
+	}
+	return nil, false
+}
+
+// lookup returns the sources which have the column name, and whether a source of unknown
+// columns may have it.
+func (s *validationScope) lookup(name string) ([]*validationSource, bool) {
+	var found []*validationSource
+	open := false
+	for _, source := range s.sources {
+		if source.columns == nil {
+			open = true
+			continue
+		}
+		for _, column := range source.columns {
+			if column == name {
+				found = append(found, source)
+				break
+			}
+		}
+	}
+	return found, open
+}
+
+// read records that the column name of source is read.
+func (v *validator) read(source *validationSource, name string) {
+	if v.reads != nil && source.table != nil && !containsString(source.read, name) {
+		source.read = append(source.read, name)
+	}
+}
+
+// readOpen records that the column name may be read from any source of scope whose columns
+// are unknown.
+func (v *validator) readOpen(scope *validationScope, name string) {
+	for _, source := range scope.sources {
+		if source.columns == nil {
+			v.read(source, name)
+		}
+	}
+}
+
+func (v *validator) report(expr Expr, format string, args ...any) {
+	v.diagnostics = append(v.diagnostics, &Diagnostic{
+		Pos:     expr.Start(),
+		End:     expr.End(),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) statement(stmt Expr) {
+	switch stmt := stmt.(type) {
+	case *SelectQuery, *SubQuery:
+		v.query(stmt, nil)
+	case *InsertStmt:
+		v.insert(stmt)
+	case *DeleteClause:
+		scope := newValidationScope(nil)
+		scope.sources = append(scope.sources, v.table(stmt.Table, nil, scope))
+		if stmt.WhereExpr != nil {
+			v.check(stmt.WhereExpr, scope)
+		}
+	case *CreateView:
+		if stmt.SubQuery != nil {
+			v.query(stmt.SubQuery, nil)
+		}
+	case *ExplainStmt:
+		v.statement(stmt.Statement)
+	}
+}
+
+// query validates a SELECT, a set operation or a subquery, and returns the names of its
+// columns, nil if they are unknown.
+func (v *validator) query(expr Expr, parent *validationScope) []string {
+	switch expr := expr.(type) {
+	case *SubQuery:
+		if expr.Select != nil {
+			return v.query(expr.Select, parent)
+		}
+	case *SetOperation:
+		left := v.query(expr.Left, parent)
+		right := v.query(expr.Right, parent)
+		if left != nil && right != nil && len(left) != len(right) {
+			v.report(expr.Right, "%s operands have %d and %d columns", expr.Operator, len(left), len(right))
+		}
+		return left
+	case *SelectQuery:
+		if expr.SetOperation != nil {
+			scope := newValidationScope(parent)
+			v.with(expr.With, scope)
+			return v.query(expr.SetOperation, scope)
+		}
+		return v.selectQuery(expr, parent)
+	}
+	return nil
+}
+
+func (v *validator) selectQuery(query *SelectQuery, parent *validationScope) []string { // nolint: funlen
+	scope := newValidationScope(parent)
+	v.with(query.With, scope)
+	var constraints []Expr
+	if query.From != nil {
+		v.from(query.From.Expr, scope, &constraints)
+	}
+	for _, constraint := range constraints {
+		v.check(constraint, scope)
+	}
+	if query.ArrayJoin != nil {
+		v.check(query.ArrayJoin.Expr, scope)
+		if list, ok := query.ArrayJoin.Expr.(*ColumnExprList); ok {
+			for _, item := range list.Items {
+				if item, ok := item.(*ColumnExpr); ok && item.Alias != nil {
+					scope.names[item.Alias.Name] = true
+					scope.sources = append(scope.sources, &validationSource{name: item.Alias.Name})
+				}
+			}
+		}
+	}
+	for _, item := range query.SelectItems {
+		if item.Alias != nil {
+			scope.names[item.Alias.Name] = true
+		}
+	}
+	for _, item := range query.SelectItems {
+		v.check(item.Expr, scope)
+	}
+	if query.Prewhere != nil {
+		v.check(query.Prewhere.Expr, scope)
+	}
+	if query.Where != nil {
+		v.check(query.Where.Expr, scope)
+	}
+	if query.Having != nil {
+		v.check(query.Having.Expr, scope)
+	}
+	if query.Qualify != nil {
+		v.check(query.Qualify.Expr, scope)
+	}
+	if query.GroupBy != nil {
+		v.check(query.GroupBy.Expr, scope)
+	}
+	if query.Window != nil && query.Window.WindowExpr != nil {
+		v.window(query.Window.WindowExpr, scope)
+	}
+	if query.OrderBy != nil {
+		for _, item := range query.OrderBy.Items {
+			v.check(item, scope)
+		}
+		if query.OrderBy.Interpolate != nil {
+			for _, item := range query.OrderBy.Interpolate.Items {
+				v.check(item.Column, scope)
+				if item.Expr != nil {
+					v.check(item.Expr, scope)
+				}
+			}
+		}
+	}
+	if query.LimitBy != nil && query.LimitBy.ByExpr != nil {
+		v.check(query.LimitBy.ByExpr, scope)
+	}
+	v.grouping(query, scope)
+	return v.outputs(query.SelectItems, scope)
+}
+
+// with adds the CTEs and the WITH aliases of a query to scope.
+func (v *validator) with(with *WithClause, scope *validationScope) {
+	if with == nil {
+		return
+	}
+	for _, cte := range with.CTEs {
+		alias, ok := cte.Alias.(*Ident)
+		if !ok {
+			v.check(cte.Expr, scope)
+			continue
+		}
+		switch cte.Expr.(type) {
+		case *SubQuery, *SelectQuery:
+			// registered first so a recursive CTE can use itself
+			scope.ctes[alias.Name] = nil
+			columns := v.query(cte.Expr, scope)
+			if len(cte.ColumnAliases) > 0 {
+				columns = make([]string, len(cte.ColumnAliases))
+				for i, columnAlias := range cte.ColumnAliases {
+					columns[i] = columnAlias.Name
+				}
+			}
+			scope.ctes[alias.Name] = columns
+		default:
+			v.check(cte.Expr, scope)
+		}
+		// (SELECT ...) AS name is also a scalar subquery in ClickHouse
+		scope.names[alias.Name] = true
+	}
+}
+
+// from adds the tables of a FROM clause to scope, and their join constraints to constraints
+// because they can use any of the tables.
+func (v *validator) from(expr Expr, scope *validationScope, constraints *[]Expr) {
+	switch expr := expr.(type) {
+	case *JoinExpr:
+		v.from(expr.Left, scope, constraints)
+		if expr.Right != nil {
+			v.from(expr.Right, scope, constraints)
+		}
+		switch constraint := expr.Constraints.(type) {
+		case *OnClause:
+			*constraints = append(*constraints, constraint.On)
+		case *UsingClause:
+			for _, item := range constraint.Using.Items {
+				if item, ok := item.(*ColumnExpr); ok {
+					item, ok := item.Expr.(*Ident)
+					if ok {
+						scope.merged[item.Name] = true
+						// read from the tables on both sides
+						for _, source := range scope.sources {
+							v.read(source, item.Name)
+						}
+					}
+				}
+			}
+		}
+	case *JoinTableExpr:
+		if expr.Table != nil {
+			v.from(expr.Table, scope, constraints)
+		}
+	case *TableExpr:
+		var alias Expr
+		if expr.Alias != nil {
+			alias = expr.Alias.Alias
+		}
+		table := expr.Expr
+		if aliasExpr, ok := table.(*AliasExpr); ok {
+			table, alias = aliasExpr.Expr, aliasExpr.Alias
+		}
+		switch table := table.(type) {
+		case *TableIdentifier:
+			scope.sources = append(scope.sources, v.table(table, alias, scope))
+		case *SubQuery, *SelectQuery:
+			scope.sources = append(scope.sources, &validationSource{
+				name: identName(alias),
+				// a subquery sees the CTEs and WITH aliases but not the other tables
+				columns: v.query(table, &validationScope{
+					parent: scope.parent,
+					ctes:   scope.ctes,
+					names:  scope.names,
+				}),
+			})
+		default:
+			scope.sources = append(scope.sources, &validationSource{name: identName(alias)})
+		}
+	}
+}
+
+// table returns the source of a table or CTE, reporting unknown tables.
+func (v *validator) table(table *TableIdentifier, alias Expr, scope *validationScope) *validationSource {
+	source := &validationSource{name: table.Table.Name}
+	if name := identName(alias); name != "" {
+		source.name = name
+	}
+	if table.Schema == nil {
+		if columns, ok := scope.cte(table.Table.Name); ok {
+			source.columns = columns
+			return source
+		}
+	}
+	source.table = table
+	if v.reads != nil {
+		v.reads.sources = append(v.reads.sources, source)
+	}
+	if v.schema == nil {
+		return source
+	}
+	definition := v.schema.Table(table)
+	if definition == nil {
+		v.report(table, "unknown table %s", table)
+		return source
+	}
+	for _, column := range v.schema.Columns(definition) {
+		source.columns = append(source.columns, columnDefName(column))
+	}
+	return source
+}
+
+func identName(expr Expr) string {
+	if ident, ok := expr.(*Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// outputs returns the names of the columns of the select items, nil if a * expands to
+// unknown columns.
+func (v *validator) outputs(items []*SelectItem, scope *validationScope) []string {
+	var columns []string
+	for _, item := range items {
+		star := ""
+		switch expr := item.Expr.(type) {
+		case *Ident:
+			if expr.Name == "*" {
+				star = "*"
+			}
+		case *NestedIdentifier:
+			if expr.DotIdent != nil && expr.DotIdent.Name == "*" {
+				star = expr.Ident.Name
+			}
+		case *ColumnIdentifier:
+			if expr.Column.Name == "*" && expr.Table != nil {
+				star = expr.Table.Name
+			}
+		}
+		if star != "" {
+			for _, source := range scope.sources {
+				if star == "*" || source.name == star {
+					source.whole = true
+				}
+			}
+			found := false
+			for _, source := range scope.sources {
+				if star != "*" && source.name != star {
+					continue
+				}
+				if source.columns == nil {
+					return nil
+				}
+				found = true
+				columns = append(columns, source.columns...)
+			}
+			if !found && star != "*" {
+				v.report(item.Expr, "unknown table %s", star)
+				return nil
+			}
+			continue
+		}
+
+		name := item.Expr.String()
+		switch expr := item.Expr.(type) {
+		case *Ident:
+			name = expr.Name
+		case *ColumnIdentifier:
+			name = expr.Column.Name
+		}
+		if item.Alias != nil {
+			name = item.Alias.Name
+		}
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// check validates the column references, calls and comparisons of an expression, its
+// subqueries are validated in a scope nested in scope. A nil scope does not resolve columns.
+func (v *validator) check(expr Expr, scope *validationScope) { // nolint: funlen
+	_ = walkEvalExpr(expr, func(expr Expr) (bool, error) {
+		switch expr := expr.(type) {
+		case *SubQuery, *SelectQuery:
+			v.query(expr, scope)
+			return false, nil
+		case *ColumnExprList:
+			for _, item := range expr.Items {
+				v.check(item, scope)
+			}
+			return false, nil
+		case *OrderExpr:
+			v.check(expr.Expr, scope)
+			return false, nil
+		case *LambdaExpr:
+			if scope == nil {
+				v.check(expr.Body, nil)
+				return false, nil
+			}
+			lambda := newValidationScope(scope)
+			for _, param := range expr.Params {
+				lambda.names[param.Name] = true
+			}
+			v.check(expr.Body, lambda)
+			return false, nil
+		case *ObjectParams:
+			v.check(expr.Object, scope)
+			if expr.Params != nil {
+				v.check(expr.Params, scope)
+			}
+			return false, nil
+		case *IndexOperation:
+			v.check(expr.Object, scope)
+			return false, nil
+		case *WindowFunctionExpr:
+			v.check(expr.Function, scope)
+			if window, ok := expr.OverExpr.(*WindowExpr); ok {
+				v.window(window, scope)
+			}
+			return false, nil
+		case *Ident:
+			if scope != nil && !isLiteralIdent(expr) && expr.Name != "*" {
+				v.resolve(expr, scope)
+			}
+			return false, nil
+		case *ColumnIdentifier:
+			if scope != nil && expr.Column.Name != "*" {
+				v.resolve(expr, scope)
+			}
+			return false, nil
+		case *NestedIdentifier:
+			// a table.* is expanded by outputs
+			if v.reads != nil && (expr.DotIdent == nil || expr.DotIdent.Name != "*") {
+				v.reads.unresolved = true
+			}
+			return false, nil
+		case *FunctionExpr:
+			v.arity(expr)
+			// COLUMNS('regexp') reads the columns matching the regexp
+			if scope != nil && strings.EqualFold(expr.Name.Name, "COLUMNS") {
+				for _, source := range scope.sources {
+					source.whole = true
+				}
+			}
+		case *BinaryOperation:
+			v.comparison(expr)
+		default:
+			if v.reads != nil && !walksOperands(expr) {
+				v.reads.unresolved = true
+			}
+		}
+		return true, nil
+	})
+}
+
+// window validates the PARTITION BY and ORDER BY clauses of a window.
+func (v *validator) window(window *WindowExpr, scope *validationScope) {
+	if window.PartitionBy != nil {
+		v.check(window.PartitionBy.Expr, scope)
+	}
+	if window.OrderBy != nil {
+		for _, item := range window.OrderBy.Items {
+			v.check(item, scope)
+		}
+	}
+}
+
+// walksOperands reports whether check reaches the column references in the operands of expr,
+// readColumns gives up on the other expressions.
+func walksOperands(expr Expr) bool {
+	switch expr := expr.(type) {
+	case *ParamExprList:
+		return expr.ColumnArgList == nil
+	case *ColumnExpr, *ArrayParamList, *UnaryExpr, *NotExpr, *NegateExpr, *BetweenClause,
+		*IsNullExpr, *IsNotNullExpr, *TernaryOperation, *CaseExpr, *CastExpr, *IntervalExpr,
+		*NumberLiteral, *StringLiteral, *NullLiteral, *NotNullLiteral, *PlaceHolder, *QueryParam:
+		return true
+	}
+	return false
+}
+
+// resolve returns the scope of a column reference and its name qualified by its table, which is
+// empty for aliases. It returns a nil scope and reports the reference if it is unknown or
+// ambiguous, or without reporting it if it may be a column of a table of unknown columns.
+func (v *validator) resolve(expr Expr, scope *validationScope) (*validationScope, string) {
+	if column, ok := expr.(*ColumnIdentifier); ok {
+		if column.Table == nil {
+			return v.resolve(column.Column, scope)
+		}
+		return v.resolveQualified(column, scope)
+	}
+	ident, ok := expr.(*Ident)
+	if !ok {
+		return nil, ""
+	}
+	for s := scope; s != nil; s = s.parent {
+		found, open := s.lookup(ident.Name)
+		switch {
+		case len(found) == 1, len(found) > 1 && s.merged[ident.Name]:
+			for _, source := range found {
+				v.read(source, ident.Name)
+			}
+			return s, found[0].name + "." + ident.Name
+		case len(found) > 1:
+			v.report(ident, "column %s is ambiguous", ident.Name)
+			return nil, ""
+		case s.names[ident.Name]:
+			return s, ""
+		case open:
+			// the column may be one of a table of an outer query as well
+			for ; s != nil; s = s.parent {
+				v.readOpen(s, ident.Name)
+			}
+			return nil, ""
+		}
+	}
+	v.report(ident, "unknown column %s", ident.Name)
+	return nil, ""
+}
+
+func (v *validator) resolveQualified(column *ColumnIdentifier, scope *validationScope) (*validationScope, string) {
+	table := column.Table.Name
+	for s := scope; s != nil; s = s.parent {
+		for _, source := range s.sources {
+			if source.name != table {
+				continue
+			}
+			if source.columns == nil {
+				v.read(source, column.Column.Name)
+				return nil, ""
+			}
+			for _, name := range source.columns {
+				if name == column.Column.Name {
+					v.read(source, name)
+					return s, source.name + "." + name
+				}
+			}
+			v.report(column, "unknown column %s", column)
+			return nil, ""
+		}
+		if s.names[table] {
+			return nil, ""
+		}
+	}
+	// a column of a Nested type, or an element of a named tuple
+	for s := scope; s != nil; s = s.parent {
+		for _, name := range []string{table + "." + column.Column.Name, table} {
+			if found, open := s.lookup(name); len(found) > 0 || open {
+				for _, source := range found {
+					v.read(source, name)
+				}
+				if open {
+					v.readOpen(s, name)
+				}
+				return nil, ""
+			}
+		}
+	}
+	v.report(column.Table, "unknown table %s", table)
+	return nil, ""
+}
+
+// grouping reports the columns of the select items of an aggregating query which are neither
+// grouped nor in aggregate functions.
+func (v *validator) grouping(query *SelectQuery, scope *validationScope) {
+	aggregating := query.GroupBy != nil
+	for _, item := range query.SelectItems {
+		_ = walkEvalExpr(item.Expr, func(expr Expr) (bool, error) {
+			aggregating = aggregating || isAggregateCall(expr)
+			return !aggregating, nil
+		})
+	}
+	if !aggregating {
+		return
+	}
+
+	grouped := make(map[string]bool)
+	if query.GroupBy != nil {
+		var keys []Expr
+		if list, ok := query.GroupBy.Expr.(*ColumnExprList); ok {
+			keys = list.Items
+		}
+		for _, key := range keys {
+			if columnExpr, ok := key.(*ColumnExpr); ok {
+				key = columnExpr.Expr
+			}
+			switch k := key.(type) {
+			case *NumberLiteral:
+				// GROUP BY position
+				if n, err := strconv.Atoi(k.Literal); err == nil && n >= 1 && n <= len(query.SelectItems) {
+					key = query.SelectItems[n-1].Expr
+				}
+			case *Ident:
+				for _, item := range query.SelectItems {
+					if item.Alias != nil && item.Alias.Name == k.Name {
+						key = item.Expr
+					}
+				}
+			}
+			grouped[key.String()] = true
+			switch key.(type) {
+			case *Ident, *ColumnIdentifier:
+				if s, name := v.quietResolve(key, scope); s == scope && name != "" {
+					grouped[name] = true
+				}
+			}
+		}
+	}
+
+	for _, item := range query.SelectItems {
+		if isStar(item.Expr) {
+			v.report(item.Expr, "* is neither grouped nor aggregated")
+			continue
+		}
+		_ = walkEvalExpr(item.Expr, func(expr Expr) (bool, error) {
+			if grouped[expr.String()] || isAggregateCall(expr) {
+				return false, nil
+			}
+			switch expr.(type) {
+			case *Ident, *ColumnIdentifier:
+			case *SubQuery, *SelectQuery, *LambdaExpr, *WindowFunctionExpr:
+				return false, nil
+			default:
+				return true, nil
+			}
+			// columns of outer queries are constant in a group
+			s, name := v.quietResolve(expr, scope)
+			if s == scope && name != "" && !grouped[name] {
+				v.report(expr, "column %s is neither grouped nor aggregated", expr)
+			}
+			return false, nil
+		})
+	}
+}
+
+// quietResolve is resolve without reporting, the references have been checked already.
+func (v *validator) quietResolve(expr Expr, scope *validationScope) (*validationScope, string) {
+	diagnostics := v.diagnostics
+	defer func() { v.diagnostics = diagnostics }()
+	if ident, ok := expr.(*Ident); ok && isLiteralIdent(ident) {
+		return nil, ""
+	}
+	return v.resolve(expr, scope)
+}
+
+// isAggregateCall reports whether expr is a call of an aggregate function, or of its -If
+// combinator.
+func isAggregateCall(expr Expr) bool {
+	call, ok := expr.(*FunctionExpr)
+	if !ok {
+		return false
+	}
+	name := strings.ToLower(call.Name.Name)
+	base, isIf := strings.CutSuffix(name, "if")
+	return aggregateSignatures.Contains(name) || isIf && aggregateSignatures.Contains(base)
+}
+
+// functionArity is the number of arguments of a function, max is -1 if it is variadic.
+type functionArity struct {
+	min, max int
+}
+
+// functionArities are the numbers of arguments of built-in functions by lower case name.
+var functionArities = map[string]functionArity{
+	// aggregates
+	"count": {0, 1}, "sum": {1, 1}, "avg": {1, 1}, "min": {1, 1}, "max": {1, 1}, "any": {1, 1},
+	"anylast": {1, 1}, "uniq": {1, -1}, "uniqexact": {1, -1}, "argmin": {2, 2}, "argmax": {2, 2},
+	"grouparray": {1, 1}, "groupuniqarray": {1, 1},
+	// dates
+	"now": {0, 1}, "today": {0, 0}, "curdate": {0, 0}, "yesterday": {0, 0}, "todate": {1, 2},
+	"date": {1, 1}, "todatetime": {1, 2}, "tostartofday": {1, 2}, "tostartofhour": {1, 2},
+	"tostartofminute": {1, 2}, "tostartofweek": {1, 3}, "tostartofmonth": {1, 2}, "tostartofyear": {1, 2},
+	"toyear": {1, 2}, "year": {1, 1}, "tomonth": {1, 2}, "month": {1, 1}, "todayofmonth": {1, 2},
+	"tohour": {1, 2}, "tominute": {1, 2}, "toyyyymm": {1, 2}, "toyyyymmdd": {1, 2},
+	"tounixtimestamp": {1, 2},
+	// strings
+	"tostring": {1, 2}, "concat": {1, -1}, "lower": {1, 1}, "upper": {1, 1}, "substring": {2, 3},
+	"trim": {1, 2}, "replaceall": {3, 3}, "format": {1, -1}, "totypename": {1, 1}, "length": {1, 1},
+	"position": {2, 3}, "empty": {1, 1}, "notempty": {1, 1}, "touuid": {1, 1},
+	// numbers
+	"abs": {1, 1}, "round": {1, 2}, "floor": {1, 2}, "ceil": {1, 2},
+	// conditions and NULL
+	"if": {3, 3}, "multiif": {3, -1}, "coalesce": {1, -1}, "ifnull": {2, 2}, "nullif": {2, 2},
+	"assumenotnull": {1, 1}, "tonullable": {1, 1}, "isnull": {1, 1}, "isnotnull": {1, 1},
+	"greatest": {1, -1}, "least": {1, -1},
+	// containers
+	"array": {0, -1}, "tuple": {0, -1}, "map": {0, -1}, "arrayjoin": {1, 1}, "arrayelement": {2, 2},
+	"tupleelement": {2, 3}, "has": {2, 2},
+}
+
+// arity reports the calls of built-in functions with the wrong number of arguments.
+func (v *validator) arity(call *FunctionExpr) {
+	name := strings.ToLower(call.Name.Name)
+	arity, ok := functionArities[name]
+	// the -If combinator of aggregates takes the condition as the last argument
+	if base, isIf := strings.CutSuffix(name, "if"); !ok && isIf && aggregateSignatures.Contains(base) {
+		arity, ok = functionArities[base]
+		arity.min++
+		if arity.max >= 0 {
+			arity.max++
+		}
+	}
+	if !ok {
+		return
+	}
+	args := 0
+	if call.Params != nil && call.Params.Items != nil {
+		if call.Params.Items.HasDistinct {
+			// count(DISTINCT a, b)
+			arity.max = -1
+		}
+		args = len(call.Params.Items.Items)
+	}
+	if args >= arity.min && (arity.max < 0 || args <= arity.max) {
+		return
+	}
+	var expected string
+	switch {
+	case arity.min == arity.max:
+		expected = strconv.Itoa(arity.min)
+	case arity.max < 0:
+		expected = fmt.Sprintf("at least %d", arity.min)
+	default:
+		expected = fmt.Sprintf("%d to %d", arity.min, arity.max)
+	}
+	unit := "arguments"
+	if expected == "1" || expected == "at least 1" {
+		unit = "argument"
+	}
+	v.report(call, "function %s takes %s %s, got %d", call.Name.Name, expected, unit, args)
+}
+
+// comparison reports the comparisons of a number with a string or of a date with a string,
+// except with the string literals ClickHouse parses as numbers or dates.
+func (v *validator) comparison(operation *BinaryOperation) {
+	switch operation.Operation {
+	case "=", "==", "!=", "<>", "<", "<=", ">", ">=":
+	default:
+		return
+	}
+	left, right := TypeOf(operation.LeftExpr, v.types), TypeOf(operation.RightExpr, v.types)
+	if comparableWith(operation.LeftExpr, left, right) && comparableWith(operation.RightExpr, right, left) {
+		return
+	}
+	v.report(operation, "cannot compare %s (%s) with %s (%s)",
+		operation.LeftExpr, left.Type(), operation.RightExpr, right.Type())
+}
+
+// comparableWith reports whether expr of type typ can be compared with an operand of type other.
+func comparableWith(expr Expr, typ, other ColumnType) bool {
+	if classifyType(typ).kind != typeKindString {
+		return true
+	}
+	if columnExpr, ok := expr.(*ColumnExpr); ok {
+		expr = columnExpr.Expr
+	}
+	literal, isLiteral := expr.(*StringLiteral)
+	switch class := classifyType(other); {
+	case class.numeric():
+		if isLiteral {
+			_, err := strconv.ParseFloat(literal.Literal, 64)
+			return err == nil
+		}
+		return false
+	case class.kind == typeKindDate, class.kind == typeKindDateTime:
+		return isLiteral
+	}
+	return true
+}
+
+// insert validates the table and columns of an INSERT and its number of values per row.
+func (v *validator) insert(insert *InsertStmt) {
+	var definition *CreateTable
+	if table, ok := insert.Table.(*TableIdentifier); ok && v.schema != nil {
+		if definition = v.schema.Table(table); definition == nil {
+			v.report(table, "unknown table %s", table)
+		}
+	}
+	expected := -1
+	if definition != nil {
+		expected = len(v.schema.Columns(definition))
+	}
+	if insert.ColumnNames != nil {
+		names := insert.ColumnNames.ColumnNames
+		expected = len(names)
+		for i := range names {
+			name := &names[i]
+			column := name.Ident.Name
+			if name.DotIdent != nil {
+				column += "." + name.DotIdent.Name
+			}
+			if definition != nil && v.schema.Column(definition, column) == nil {
+				v.report(name, "unknown column %s", name)
+			}
+		}
+	}
+	for _, row := range insert.Values {
+		for _, value := range row.Values {
+			v.check(value, nil)
+		}
+		if expected >= 0 && len(row.Values) != expected {
+			v.report(row, "INSERT has %d columns but %d values", expected, len(row.Values))
+		}
+	}
+	if insert.SelectExpr != nil {
+		columns := v.query(insert.SelectExpr, nil)
+		if expected >= 0 && columns != nil && len(columns) != expected {
+			v.report(insert.SelectExpr, "INSERT has %d columns but the SELECT returns %d", expected, len(columns))
+		}
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}